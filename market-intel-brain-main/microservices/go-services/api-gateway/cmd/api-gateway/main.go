@@ -2,19 +2,35 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"golang.org/x/sync/errgroup"
+
 	"github.com/market-intel/api-gateway/internal/config"
+	"github.com/market-intel/api-gateway/internal/config/reload"
 	"github.com/market-intel/api-gateway/internal/server"
 	"github.com/market-intel/api-gateway/internal/services"
 	"github.com/market-intel/api-gateway/pkg/logger"
 	"github.com/market-intel/api-gateway/pkg/otel"
+	"github.com/market-intel/api-gateway/pkg/shutdown"
+)
+
+// Shutdown priorities: listeners stop accepting new work first, then the
+// clients/connections that work was using, then cross-cutting
+// infrastructure that later components' teardown can still generate
+// telemetry for.
+const (
+	priorityListeners = iota
+	priorityClients
+	priorityTelemetry
 )
 
 var (
@@ -25,6 +41,17 @@ var (
 const Version = "0.1.0"
 
 func main() {
+	// "tokengen" and "validate" are subcommands rather than flags since they
+	// each do one thing and exit instead of starting the gateway
+	if len(os.Args) > 1 && os.Args[1] == "tokengen" {
+		runTokenGen(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "validate" {
+		runValidate(os.Args[2:])
+		return
+	}
+
 	flag.Parse()
 
 	if *version {
@@ -36,14 +63,10 @@ func main() {
 	logger.Init()
 
 	// Initialize OpenTelemetry
-	if err := otel.InitOpenTelemetry(); err != nil {
+	otel.InitPropagators()
+	if err := otel.InitOpenTelemetry(otel.LoadOTelConfigFromEnv()); err != nil {
 		logger.Fatalf("Failed to initialize OpenTelemetry: %v", err)
 	}
-	defer func() {
-		if err := otel.Shutdown(context.Background()); err != nil {
-			logger.Errorf("Failed to shutdown OpenTelemetry: %v", err)
-		}
-	}()
 
 	logger.Info("Starting Market Intel Brain API Gateway")
 
@@ -53,59 +76,192 @@ func main() {
 		logger.Fatalf("Failed to load configuration: %v", err)
 	}
 
-	logger.Info(fmt.Sprintf("Starting Market Intel Brain API Gateway - Version: %s, Environment: %s, HTTP Port: %d, gRPC Port: %d", 
+	logger.Info(fmt.Sprintf("Starting Market Intel Brain API Gateway - Version: %s, Environment: %s, HTTP Port: %d, gRPC Port: %d",
 		Version, cfg.Environment, cfg.Server.HTTPPort, cfg.Server.GRPCPort))
 
+	// loadConfig re-reads configuration the same way Load did at startup,
+	// used by both the SIGHUP handler below and the /admin/reload endpoint
+	// to produce a reload candidate.
+	loadConfig := func() (*config.Config, error) {
+		return config.Load(*configFile)
+	}
+
+	reloadManager := reload.NewManager(cfg)
+	reloadManager.Subscribe(reload.NewLoggingLevelSubscriber())
+
+	reloadCtx, stopReloadWatch := context.WithCancel(context.Background())
+	defer stopReloadWatch()
+	if err := reloadManager.Watch(reloadCtx, *configFile, loadConfig); err != nil {
+		logger.Errorf("failed to start config file watcher: %v", err)
+	}
+
 	// Create core engine client
 	coreEngineClient, err := services.NewCoreEngineClient(cfg.Services.CoreEngine)
 	if err != nil {
 		logger.Errorf("Failed to create Core Engine client: %v", err)
 		// Continue without Core Engine connection for now
 		coreEngineClient = nil
-	} else {
-		defer coreEngineClient.Close()
 	}
 
 	// Create HTTP server
-	httpServer := server.NewHTTPServer(cfg, coreEngineClient)
+	httpServer := server.NewHTTPServer(cfg, coreEngineClient, reloadManager, loadConfig)
+
+	// Create gRPC server, sharing the HTTP server's ingestion handler so its
+	// health service can fold in MQTT buffer liveness. It starts before the
+	// HTTP server below since GatewayServer needs its *grpc.Server to wrap,
+	// and the HTTP server's /readyz needs to know about the gateway's ready
+	// gate before it starts.
+	grpcServer := server.NewGRPCServer(cfg, coreEngineClient, httpServer.DataIngestionHandler())
+	logger.Infof("Starting gRPC server on port %d", cfg.Server.GRPCPort)
+	if err := grpcServer.Start(); err != nil {
+		logger.Fatalf("gRPC server failed to start: %v", err)
+	}
+	httpServer.AddReadyGate(grpcServer.Ready())
 
-	// Create gRPC server
-	grpcServer := server.NewGRPCServer(cfg)
+	// The gateway exposes grpcServer's registered services to gRPC-Web
+	// (browser) and HTTP/JSON-transcoding clients. It either binds its own
+	// port or, with Gateway.MultiplexHTTP, shares the HTTP server's port
+	// via a cmux split on Content-Type.
+	var gatewayServer *server.GatewayServer
+	var muxServe func() error
+	var muxClose func() error
+	if cfg.Gateway.Enabled {
+		gatewayServer = server.NewGatewayServer(cfg, grpcServer)
+		httpServer.AddReadyGate(gatewayServer.Ready())
+	}
 
-	// Start servers in goroutines
-	go func() {
-		logger.Infof("Starting HTTP server on port %d", cfg.Server.HTTPPort)
-		if err := httpServer.Start(fmt.Sprintf(":%d", cfg.Server.HTTPPort)); err != nil && err != http.ErrServerClosed {
-			logger.Fatalf("HTTP server failed to start: %v", err)
+	var httpLis, gatewayLis net.Listener
+	if cfg.Gateway.Enabled && cfg.Gateway.MultiplexHTTP {
+		var err error
+		httpLis, gatewayLis, muxServe, muxClose, err = server.NewMultiplexedListeners(cfg)
+		if err != nil {
+			logger.Fatalf("failed to set up gateway multiplexer: %v", err)
 		}
-	}()
+	}
+
+	logger.Infof("Starting HTTP server on port %d", cfg.Server.HTTPPort)
+	startErr := httpServer.Start
+	if httpLis != nil {
+		startErr = func() error { return httpServer.StartOn(httpLis) }
+	}
+	if err := startErr(); err != nil {
+		logger.Fatalf("HTTP server failed to start: %v", err)
+	}
 
-	go func() {
-		logger.Infof("Starting gRPC server on port %d", cfg.Server.GRPCPort)
-		if err := grpcServer.Start(); err != nil {
-			logger.Errorf("gRPC server failed to start: %v", err)
+	if gatewayServer != nil {
+		logger.Infof("Starting gateway server (gRPC-Web + JSON transcoding)")
+		startGateway := gatewayServer.Start
+		if gatewayLis != nil {
+			startGateway = func() error { return gatewayServer.StartOn(gatewayLis) }
+		}
+		if err := startGateway(); err != nil {
+			logger.Fatalf("gateway server failed to start: %v", err)
 		}
-	}()
+	}
+
+	if muxServe != nil {
+		go func() {
+			if err := muxServe(); err != nil {
+				logger.Errorf("gateway multiplexer stopped: %v", err)
+			}
+		}()
+	}
+
+	// shutdownHandler closes every component in priority order once asked to,
+	// bounding each one's close with its own timeout in addition to the
+	// overall cfg.Server.GracefulTimeout deadline below -- so a panic or a
+	// wedged close in one component can't skip the rest, the way a chain of
+	// deferred calls in main would.
+	shutdownHandler := shutdown.New()
+	shutdownHandler.Add("http_server", func(ctx context.Context) error {
+		return httpServer.Shutdown(ctx)
+	}, priorityListeners, cfg.Server.GracefulTimeout)
+	shutdownHandler.Add("grpc_server", func(ctx context.Context) error {
+		return grpcServer.Stop()
+	}, priorityListeners, cfg.GRPC.DrainTimeout)
+	if gatewayServer != nil {
+		shutdownHandler.Add("gateway_server", func(ctx context.Context) error {
+			return gatewayServer.Stop()
+		}, priorityListeners, cfg.Gateway.DrainTimeout)
+	}
+	if muxClose != nil {
+		shutdownHandler.Add("gateway_multiplexer", func(ctx context.Context) error {
+			return muxClose()
+		}, priorityListeners, 5*time.Second)
+	}
+	if coreEngineClient != nil {
+		shutdownHandler.Add("core_engine_client", func(ctx context.Context) error {
+			return coreEngineClient.Close()
+		}, priorityClients, 5*time.Second)
+	}
+	shutdownHandler.Add("otel", func(ctx context.Context) error {
+		return otel.Shutdown(ctx)
+	}, priorityTelemetry, 10*time.Second)
 
 	// Wait for interrupt signal
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
 
-	logger.Info("Shutting down servers...")
+	// g coordinates the two servers: if either one's Serve loop exits with a
+	// real error, the other's goroutine observes g's context being canceled
+	// and returns too, so a crashed server takes the whole process down
+	// instead of limping along half-up.
+	g, gctx := errgroup.WithContext(context.Background())
+	g.Go(func() error {
+		select {
+		case err := <-httpServer.Err():
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return fmt.Errorf("HTTP server: %w", err)
+			}
+			return nil
+		case <-gctx.Done():
+			return nil
+		}
+	})
+	g.Go(func() error {
+		select {
+		case err := <-grpcServer.Err():
+			if err != nil {
+				return fmt.Errorf("gRPC server: %w", err)
+			}
+			return nil
+		case <-gctx.Done():
+			return nil
+		}
+	})
+	if gatewayServer != nil {
+		g.Go(func() error {
+			select {
+			case err := <-gatewayServer.Err():
+				if err != nil && !errors.Is(err, http.ErrServerClosed) {
+					return fmt.Errorf("gateway server: %w", err)
+				}
+				return nil
+			case <-gctx.Done():
+				return nil
+			}
+		})
+	}
+
+	select {
+	case <-quit:
+		logger.Info("Shutting down servers...")
+	case <-gctx.Done():
+		logger.Error("a server exited unexpectedly, shutting down")
+	}
 
-	// Create a deadline for shutdown
-	// Graceful shutdown with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.GracefulTimeout)
 	defer cancel()
 
-	// Shutdown HTTP server
-	if err := httpServer.Shutdown(ctx); err != nil {
-		logger.Errorf("HTTP server shutdown error: %v", err)
+	for _, result := range shutdownHandler.Shutdown(shutdownCtx) {
+		if result.Err != nil {
+			logger.Errorf("shutdown: %s reported an error: %v", result.Name, result.Err)
+		}
 	}
 
-	// Shutdown gRPC server
-	grpcServer.Stop()
+	if err := g.Wait(); err != nil {
+		logger.Errorf("server error: %v", err)
+	}
 
 	logger.Info("Servers stopped successfully")
-}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/market-intel/api-gateway/internal/config"
+)
+
+// runValidate implements the "validate" subcommand: consul-style, it loads
+// configuration the same way the gateway does at startup and reports every
+// validation failure at once, instead of starting the server. On success it
+// prints the effective, secret-redacted configuration as JSON so an operator
+// can confirm what would actually be loaded.
+func runValidate(args []string) {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	configFile := fs.String("config", "", "Path to configuration file")
+	fs.Parse(args)
+
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "validate: %v\n", err)
+		os.Exit(1)
+	}
+
+	encoded, err := json.MarshalIndent(cfg.Redacted(), "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "validate: failed to encode configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println(string(encoded))
+}
@@ -0,0 +1,43 @@
+// JWT Access Claims
+// Defines the rights structure embedded in access tokens: a map of HTTP
+// method to allowed path patterns, plus an optional symbol scope used to
+// restrict market-data access to specific tickers.
+
+package auth
+
+import (
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Rights maps an HTTP method to the set of path patterns it may be used
+// against. Patterns may use a single "*" wildcard per path segment (e.g.
+// "/api/v1/*/buffer" matches "/api/v1/market-data/buffer" and
+// "/api/v1/news/buffer") via the same semantics as path.Match.
+type Rights map[string][]string
+
+// Allows reports whether method+path is covered by any pattern in r
+func (r Rights) Allows(method, requestPath string) bool {
+	patterns, ok := r[method]
+	if !ok {
+		return false
+	}
+
+	for _, pattern := range patterns {
+		if pathMatches(pattern, requestPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// Claims is the JWT payload minted by tokengen and verified by Middleware
+type Claims struct {
+	Subject string `json:"sub"`
+	Rights  Rights `json:"rights"`
+
+	// AllowedSymbols, when non-empty, restricts the "symbol"/"symbols" query
+	// parameters a request may reference (e.g. a bot scoped to AAPL only)
+	AllowedSymbols []string `json:"allowed_symbols,omitempty"`
+
+	jwt.RegisteredClaims
+}
@@ -0,0 +1,33 @@
+package auth
+
+import "testing"
+
+func TestRightsAllows(t *testing.T) {
+	rights := Rights{
+		"GET":  {"/api/v1/*/buffer", "/api/v1/ingestion/stats"},
+		"POST": {"/api/v1/market-data/fetch"},
+	}
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		want   bool
+	}{
+		{"exact match", "POST", "/api/v1/market-data/fetch", true},
+		{"wildcard match", "GET", "/api/v1/news/buffer", true},
+		{"wildcard match other segment", "GET", "/api/v1/market-data/buffer", true},
+		{"exact match without wildcard", "GET", "/api/v1/ingestion/stats", true},
+		{"method not granted", "DELETE", "/api/v1/market-data/fetch", false},
+		{"path not covered", "POST", "/api/v1/data-sources/connect", false},
+		{"wildcard does not cross segments", "GET", "/api/v1/market-data/extra/buffer", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := rights.Allows(tc.method, tc.path); got != tc.want {
+				t.Errorf("Allows(%q, %q) = %v, want %v", tc.method, tc.path, got, tc.want)
+			}
+		})
+	}
+}
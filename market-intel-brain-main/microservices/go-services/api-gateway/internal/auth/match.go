@@ -0,0 +1,14 @@
+package auth
+
+import "path"
+
+// pathMatches reports whether requestPath satisfies pattern, where pattern
+// may contain a single "*" wildcard per segment (matching any run of
+// characters other than "/", identical to path.Match semantics)
+func pathMatches(pattern, requestPath string) bool {
+	matched, err := path.Match(pattern, requestPath)
+	if err != nil {
+		return false
+	}
+	return matched
+}
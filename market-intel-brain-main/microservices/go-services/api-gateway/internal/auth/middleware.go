@@ -0,0 +1,129 @@
+// JWT Access Middleware
+// Parses the bearer token on each request, validates its signature and
+// expiry, and rejects any request whose method+path is not covered by the
+// token's rights map (and, when present, whose symbol query scope is
+// exceeded).
+
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/market-intel/api-gateway/pkg/logger"
+)
+
+// DeniedResponse is the structured body returned for unauthenticated or
+// out-of-scope requests
+type DeniedResponse struct {
+	Success   bool   `json:"success"`
+	Error     string `json:"error"`
+	Message   string `json:"message"`
+	Reason    string `json:"reason"`
+	Timestamp string `json:"timestamp"`
+}
+
+// AuthMiddleware enforces the rights map embedded in bearer tokens
+type AuthMiddleware struct {
+	secret string
+}
+
+// NewAuthMiddleware creates an AuthMiddleware that validates tokens signed
+// with secret
+func NewAuthMiddleware(secret string) *AuthMiddleware {
+	return &AuthMiddleware{secret: secret}
+}
+
+// Middleware returns the Gin middleware function
+func (m *AuthMiddleware) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString, err := bearerToken(c.GetHeader("Authorization"))
+		if err != nil {
+			deny(c, http.StatusUnauthorized, "UNAUTHORIZED", "Missing or malformed Authorization header", err.Error())
+			return
+		}
+
+		claims, err := ParseToken(m.secret, tokenString)
+		if err != nil {
+			deny(c, http.StatusUnauthorized, "UNAUTHORIZED", "Invalid or expired token", err.Error())
+			return
+		}
+
+		if !claims.Rights.Allows(c.Request.Method, c.Request.URL.Path) {
+			deny(c, http.StatusForbidden, "FORBIDDEN",
+				"Token does not grant access to this endpoint",
+				fmt.Sprintf("%s %s not covered by token rights", c.Request.Method, c.Request.URL.Path))
+			return
+		}
+
+		if reason, ok := m.checkSymbolScope(c, claims); !ok {
+			deny(c, http.StatusForbidden, "FORBIDDEN", "Token does not grant access to the requested symbol(s)", reason)
+			return
+		}
+
+		c.Set("auth_subject", claims.Subject)
+		c.Next()
+	}
+}
+
+// checkSymbolScope enforces AllowedSymbols against the request's
+// symbol/symbols query parameters, when the token carries a scope
+func (m *AuthMiddleware) checkSymbolScope(c *gin.Context, claims *Claims) (string, bool) {
+	if len(claims.AllowedSymbols) == 0 {
+		return "", true
+	}
+
+	requested := c.QueryArray("symbols")
+	if symbol := c.Query("symbol"); symbol != "" {
+		requested = append(requested, symbol)
+	}
+
+	for _, symbol := range requested {
+		if !containsSymbol(claims.AllowedSymbols, symbol) {
+			return fmt.Sprintf("symbol %q is outside the token's allowed scope", symbol), false
+		}
+	}
+
+	return "", true
+}
+
+func containsSymbol(allowed []string, symbol string) bool {
+	for _, a := range allowed {
+		if strings.EqualFold(a, symbol) {
+			return true
+		}
+	}
+	return false
+}
+
+// bearerToken extracts the token from a "Bearer <token>" Authorization header
+func bearerToken(header string) (string, error) {
+	const prefix = "Bearer "
+	if !strings.HasPrefix(header, prefix) {
+		return "", fmt.Errorf("authorization header must use the Bearer scheme")
+	}
+
+	token := strings.TrimSpace(strings.TrimPrefix(header, prefix))
+	if token == "" {
+		return "", fmt.Errorf("bearer token is empty")
+	}
+
+	return token, nil
+}
+
+// deny writes a structured denial response and logs the reason
+func deny(c *gin.Context, statusCode int, errType, message, reason string) {
+	logger.Warnf("Access denied (%s): %s", errType, reason)
+
+	c.AbortWithStatusJSON(statusCode, DeniedResponse{
+		Success:   false,
+		Error:     errType,
+		Message:   message,
+		Reason:    reason,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	})
+}
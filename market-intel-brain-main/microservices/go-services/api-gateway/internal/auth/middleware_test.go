@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newTestRouter(secret string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	v1 := router.Group("/api/v1")
+	v1.Use(NewAuthMiddleware(secret).Middleware())
+	{
+		v1.POST("/market-data/fetch", func(c *gin.Context) { c.Status(http.StatusOK) })
+		v1.POST("/data-sources/connect", func(c *gin.Context) { c.Status(http.StatusOK) })
+		v1.GET("/market-data/buffer", func(c *gin.Context) { c.Status(http.StatusOK) })
+		v1.GET("/ingestion/stats", func(c *gin.Context) { c.Status(http.StatusOK) })
+	}
+
+	return router
+}
+
+func TestMiddlewareAllowsCoveredEndpoint(t *testing.T) {
+	secret := testSecret
+	router := newTestRouter(secret)
+
+	token, err := GenerateToken(secret, "analytics-dashboard", Rights{
+		"GET": {"/api/v1/*/buffer", "/api/v1/ingestion/stats"},
+	}, nil, time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/api/v1/market-data/buffer", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+}
+
+func TestMiddlewareDeniesUncoveredEndpoint(t *testing.T) {
+	secret := testSecret
+	router := newTestRouter(secret)
+
+	// A read-only analytics token cannot invoke ConnectDataSource.
+	token, err := GenerateToken(secret, "analytics-dashboard", Rights{
+		"GET": {"/api/v1/*/buffer"},
+	}, nil, time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	req := httptest.NewRequest("POST", "/api/v1/data-sources/connect", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+}
+
+func TestMiddlewareDeniesMissingToken(t *testing.T) {
+	router := newTestRouter(testSecret)
+
+	req := httptest.NewRequest("GET", "/api/v1/ingestion/stats", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestMiddlewareDeniesSymbolOutsideScope(t *testing.T) {
+	secret := testSecret
+	router := newTestRouter(secret)
+
+	// A bot restricted to AAPL cannot fetch other symbols.
+	token, err := GenerateToken(secret, "aapl-bot", Rights{
+		"POST": {"/api/v1/market-data/fetch"},
+	}, []string{"AAPL"}, time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	req := httptest.NewRequest("POST", "/api/v1/market-data/fetch?symbols=MSFT", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+}
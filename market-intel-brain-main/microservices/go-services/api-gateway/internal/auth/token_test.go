@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+const testSecret = "test-secret-at-least-32-bytes-long!"
+
+func TestGenerateAndParseToken(t *testing.T) {
+	rights := Rights{"GET": {"/api/v1/ingestion/stats"}}
+
+	signed, err := GenerateToken(testSecret, "backtest-worker", rights, []string{"AAPL"}, time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	claims, err := ParseToken(testSecret, signed)
+	if err != nil {
+		t.Fatalf("ParseToken() error = %v", err)
+	}
+
+	if claims.Subject != "backtest-worker" {
+		t.Errorf("Subject = %q, want %q", claims.Subject, "backtest-worker")
+	}
+	if !claims.Rights.Allows("GET", "/api/v1/ingestion/stats") {
+		t.Errorf("expected rights to allow GET /api/v1/ingestion/stats")
+	}
+	if len(claims.AllowedSymbols) != 1 || claims.AllowedSymbols[0] != "AAPL" {
+		t.Errorf("AllowedSymbols = %v, want [AAPL]", claims.AllowedSymbols)
+	}
+}
+
+func TestParseTokenExpired(t *testing.T) {
+	signed, err := GenerateToken(testSecret, "bot", Rights{"GET": {"/api/v1/health"}}, nil, -time.Minute)
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	if _, err := ParseToken(testSecret, signed); err == nil {
+		t.Error("ParseToken() on expired token: expected error, got nil")
+	}
+}
+
+func TestParseTokenWrongSecret(t *testing.T) {
+	signed, err := GenerateToken(testSecret, "bot", Rights{"GET": {"/api/v1/health"}}, nil, time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	if _, err := ParseToken("a-completely-different-secret!!", signed); err == nil {
+		t.Error("ParseToken() with wrong secret: expected error, got nil")
+	}
+}
@@ -0,0 +1,29 @@
+// Response cache and request coalescing for the data ingestion handlers.
+// Cache sits in front of coreEngineClient calls so that many HTTP requests
+// for the same (symbols, source_id) tuple within a short TTL window share a
+// single upstream fetch instead of hammering the Rust core engine.
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned by Cache.Get when key has no value, whether
+// because it was never set, it expired, or it was purged
+var ErrNotFound = errors.New("cache: key not found")
+
+// Cache is implemented by every response-cache backend (LRUCache,
+// RedisCache) so handlers can be wired to whichever is configured without
+// knowing the storage details
+type Cache interface {
+	// Get returns the cached value for key, or ErrNotFound if absent
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Set stores value under key for ttl
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Purge deletes every key matching pattern (a glob, e.g. "market_data:AAPL:*")
+	// and returns the number of keys removed
+	Purge(ctx context.Context, pattern string) (int, error)
+}
@@ -0,0 +1,50 @@
+package cache
+
+import "sync"
+
+// call represents an in-flight or just-completed fn invocation shared by
+// every caller that asked for the same key while it was running
+type call struct {
+	wg    sync.WaitGroup
+	value []byte
+	err   error
+}
+
+// Coalescer collapses concurrent callers asking for the same key into a
+// single execution of fn, the way golang.org/x/sync/singleflight does;
+// hand-rolled here rather than taking the dependency for one pattern.
+type Coalescer struct {
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+// NewCoalescer returns an empty Coalescer
+func NewCoalescer() *Coalescer {
+	return &Coalescer{calls: make(map[string]*call)}
+}
+
+// Do runs fn for key, or waits for an identical in-flight call and shares
+// its result. shared reports whether the caller got someone else's result
+// rather than running fn itself.
+func (co *Coalescer) Do(key string, fn func() ([]byte, error)) (value []byte, err error, shared bool) {
+	co.mu.Lock()
+	if c, ok := co.calls[key]; ok {
+		co.mu.Unlock()
+		c.wg.Wait()
+		return c.value, c.err, true
+	}
+
+	c := &call{}
+	c.wg.Add(1)
+	co.calls[key] = c
+	co.mu.Unlock()
+
+	c.value, c.err = fn()
+	c.wg.Done()
+
+	co.mu.Lock()
+	delete(co.calls, key)
+	co.mu.Unlock()
+
+	return c.value, c.err, false
+}
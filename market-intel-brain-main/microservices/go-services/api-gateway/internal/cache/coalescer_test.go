@@ -0,0 +1,125 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCoalescerDoConcurrentSameKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		concurrent int
+	}{
+		{name: "two concurrent callers", concurrent: 2},
+		{name: "ten concurrent callers", concurrent: 10},
+		{name: "fifty concurrent callers", concurrent: 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			co := NewCoalescer()
+
+			var upstreamCalls int64
+			started := make(chan struct{})
+			release := make(chan struct{})
+			var wg sync.WaitGroup
+			results := make([][]byte, tt.concurrent)
+			errs := make([]error, tt.concurrent)
+
+			// Start the first caller alone and wait for its fn to actually
+			// begin executing before launching the rest, so every other
+			// caller is guaranteed to find the key already in flight
+			// instead of racing to register it themselves.
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				value, err, _ := co.Do("AAPL:yahoo_finance", func() ([]byte, error) {
+					atomic.AddInt64(&upstreamCalls, 1)
+					close(started)
+					<-release
+					return []byte("quote"), nil
+				})
+				results[0] = value
+				errs[0] = err
+			}()
+			<-started
+
+			for i := 1; i < tt.concurrent; i++ {
+				i := i
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					value, err, _ := co.Do("AAPL:yahoo_finance", func() ([]byte, error) {
+						atomic.AddInt64(&upstreamCalls, 1)
+						return []byte("quote"), nil
+					})
+					results[i] = value
+					errs[i] = err
+				}()
+			}
+
+			// Give the stragglers a moment to reach Do and register as
+			// waiters on the in-flight call before letting it complete.
+			time.Sleep(20 * time.Millisecond)
+			close(release)
+			wg.Wait()
+
+			if got := atomic.LoadInt64(&upstreamCalls); got != 1 {
+				t.Fatalf("expected exactly one upstream call for %d concurrent requests, got %d", tt.concurrent, got)
+			}
+			for i, err := range errs {
+				if err != nil {
+					t.Fatalf("caller %d: unexpected error: %v", i, err)
+				}
+				if string(results[i]) != "quote" {
+					t.Fatalf("caller %d: expected shared result %q, got %q", i, "quote", results[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCoalescerDoDistinctKeysRunIndependently(t *testing.T) {
+	co := NewCoalescer()
+	var calls int64
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, _ = co.Do(key, func() ([]byte, error) {
+				atomic.AddInt64(&calls, 1)
+				return []byte(key), nil
+			})
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt64(&calls); got != 5 {
+		t.Fatalf("expected one upstream call per distinct key (5), got %d", got)
+	}
+}
+
+func TestCoalescerDoSequentialCallsAfterCompletion(t *testing.T) {
+	co := NewCoalescer()
+	var calls int64
+
+	for i := 0; i < 3; i++ {
+		_, _, shared := co.Do("AAPL:yahoo_finance", func() ([]byte, error) {
+			atomic.AddInt64(&calls, 1)
+			return []byte("quote"), nil
+		})
+		if shared {
+			t.Fatalf("call %d: expected shared=false once the prior call has completed", i)
+		}
+	}
+
+	if got := atomic.LoadInt64(&calls); got != 3 {
+		t.Fatalf("expected one upstream call per sequential request (3), got %d", got)
+	}
+}
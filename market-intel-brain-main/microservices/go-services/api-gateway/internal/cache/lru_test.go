@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLRUCacheGetSet(t *testing.T) {
+	c := NewLRUCache(2)
+	ctx := context.Background()
+
+	if _, err := c.Get(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for missing key, got %v", err)
+	}
+
+	if err := c.Set(ctx, "AAPL", []byte("150.00"), time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	value, err := c.Get(ctx, "AAPL")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if string(value) != "150.00" {
+		t.Fatalf("expected %q, got %q", "150.00", value)
+	}
+}
+
+func TestLRUCacheExpiresByTTL(t *testing.T) {
+	c := NewLRUCache(10)
+	ctx := context.Background()
+
+	if err := c.Set(ctx, "AAPL", []byte("150.00"), -time.Second); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	if _, err := c.Get(ctx, "AAPL"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for expired key, got %v", err)
+	}
+}
+
+func TestLRUCacheEvictsOldestOverCapacity(t *testing.T) {
+	c := NewLRUCache(2)
+	ctx := context.Background()
+
+	_ = c.Set(ctx, "AAPL", []byte("a"), time.Minute)
+	_ = c.Set(ctx, "MSFT", []byte("m"), time.Minute)
+	_ = c.Set(ctx, "GOOG", []byte("g"), time.Minute)
+
+	if _, err := c.Get(ctx, "AAPL"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected AAPL to be evicted once capacity was exceeded")
+	}
+	if _, err := c.Get(ctx, "GOOG"); err != nil {
+		t.Fatalf("expected GOOG to still be cached, got %v", err)
+	}
+}
+
+func TestLRUCachePurgeByPattern(t *testing.T) {
+	c := NewLRUCache(10)
+	ctx := context.Background()
+
+	_ = c.Set(ctx, "market_data:AAPL:yahoo_finance", []byte("a"), time.Minute)
+	_ = c.Set(ctx, "market_data:MSFT:yahoo_finance", []byte("m"), time.Minute)
+	_ = c.Set(ctx, "news:AAPL:news_api", []byte("n"), time.Minute)
+
+	removed, err := c.Purge(ctx, "market_data:AAPL:*")
+	if err != nil {
+		t.Fatalf("Purge failed: %v", err)
+	}
+	if removed != 1 {
+		t.Fatalf("expected 1 key removed, got %d", removed)
+	}
+
+	if _, err := c.Get(ctx, "market_data:AAPL:yahoo_finance"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected purged key to be gone")
+	}
+	if _, err := c.Get(ctx, "market_data:MSFT:yahoo_finance"); err != nil {
+		t.Fatalf("expected unrelated key to survive purge, got %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package cache
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Metrics holds the Prometheus counters for the response cache, constructed
+// once and registered a single time, mirroring pkg/otel.MetricsMiddleware's
+// collector lifecycle.
+type Metrics struct {
+	hits      *prometheus.CounterVec
+	misses    *prometheus.CounterVec
+	coalesced *prometheus.CounterVec
+}
+
+// NewMetrics builds and registers the cache counters against registry
+func NewMetrics(registry *prometheus.Registry) *Metrics {
+	m := &Metrics{
+		hits: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "api_gateway_response_cache_hits_total",
+				Help: "Total number of response cache hits, by route",
+			},
+			[]string{"route"},
+		),
+		misses: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "api_gateway_response_cache_misses_total",
+				Help: "Total number of response cache misses, by route",
+			},
+			[]string{"route"},
+		),
+		coalesced: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "api_gateway_response_cache_coalesced_total",
+				Help: "Total number of requests that shared an in-flight upstream call instead of making their own, by route",
+			},
+			[]string{"route"},
+		),
+	}
+
+	registry.MustRegister(m.hits, m.misses, m.coalesced)
+	return m
+}
+
+func (m *Metrics) RecordHit(route string)       { m.hits.WithLabelValues(route).Inc() }
+func (m *Metrics) RecordMiss(route string)      { m.misses.WithLabelValues(route).Inc() }
+func (m *Metrics) RecordCoalesced(route string) { m.coalesced.WithLabelValues(route).Inc() }
@@ -0,0 +1,263 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RedisCache is a Cache backed by Redis, speaking RESP directly over a
+// single pooled TCP connection rather than pulling in a client library --
+// the same hand-rolled-over-SDK tradeoff internal/sources' VaultCredentialStore
+// makes for talking to Vault over raw HTTP.
+type RedisCache struct {
+	addr        string
+	password    string
+	db          int
+	dialTimeout time.Duration
+
+	mu   sync.Mutex
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// NewRedisCache returns a RedisCache that dials addr lazily on first use
+func NewRedisCache(addr, password string, db int) *RedisCache {
+	return &RedisCache{addr: addr, password: password, db: db, dialTimeout: 5 * time.Second}
+}
+
+func (c *RedisCache) ensureConn() (net.Conn, *bufio.Reader, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn != nil {
+		return c.conn, c.r, nil
+	}
+
+	conn, err := net.DialTimeout("tcp", c.addr, c.dialTimeout)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cache: failed to dial redis at %s: %w", c.addr, err)
+	}
+	r := bufio.NewReader(conn)
+
+	if c.password != "" {
+		if _, err := doCommand(conn, r, "AUTH", c.password); err != nil {
+			conn.Close()
+			return nil, nil, fmt.Errorf("cache: redis auth failed: %w", err)
+		}
+	}
+	if c.db != 0 {
+		if _, err := doCommand(conn, r, "SELECT", strconv.Itoa(c.db)); err != nil {
+			conn.Close()
+			return nil, nil, fmt.Errorf("cache: redis select db failed: %w", err)
+		}
+	}
+
+	c.conn = conn
+	c.r = r
+	return conn, r, nil
+}
+
+// invalidate drops the pooled connection so the next call redials; RESP
+// framing can't be resynchronized mid-stream after an I/O error
+func (c *RedisCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn != nil {
+		c.conn.Close()
+		c.conn = nil
+		c.r = nil
+	}
+}
+
+// Ping confirms the pooled connection (dialing it if not already connected)
+// is alive by round-tripping a RESP PING; used by internal/health's redis
+// dependency check.
+func (c *RedisCache) Ping(_ context.Context) error {
+	conn, r, err := c.ensureConn()
+	if err != nil {
+		return err
+	}
+
+	if _, err := doCommand(conn, r, "PING"); err != nil {
+		c.invalidate()
+		return fmt.Errorf("cache: redis PING failed: %w", err)
+	}
+	return nil
+}
+
+func (c *RedisCache) Get(_ context.Context, key string) ([]byte, error) {
+	conn, r, err := c.ensureConn()
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := doCommand(conn, r, "GET", key)
+	if err != nil {
+		c.invalidate()
+		return nil, fmt.Errorf("cache: redis GET failed: %w", err)
+	}
+	if reply == nil {
+		return nil, ErrNotFound
+	}
+	buf, ok := reply.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("cache: unexpected redis GET reply type %T", reply)
+	}
+	return buf, nil
+}
+
+func (c *RedisCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	conn, r, err := c.ensureConn()
+	if err != nil {
+		return err
+	}
+
+	ms := ttl.Milliseconds()
+	if ms <= 0 {
+		ms = 1
+	}
+
+	if _, err := doCommand(conn, r, "SET", key, string(value), "PX", strconv.FormatInt(ms, 10)); err != nil {
+		c.invalidate()
+		return fmt.Errorf("cache: redis SET failed: %w", err)
+	}
+	return nil
+}
+
+// Purge deletes every key matching pattern via SCAN (so it doesn't block
+// the Redis event loop the way KEYS would on a large keyspace) followed by
+// DEL, since Redis has no atomic "delete by pattern" command
+func (c *RedisCache) Purge(_ context.Context, pattern string) (int, error) {
+	conn, r, err := c.ensureConn()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	cursor := "0"
+	for {
+		reply, err := doCommand(conn, r, "SCAN", cursor, "MATCH", pattern, "COUNT", "100")
+		if err != nil {
+			c.invalidate()
+			return removed, fmt.Errorf("cache: redis SCAN failed: %w", err)
+		}
+
+		arr, ok := reply.([]interface{})
+		if !ok || len(arr) != 2 {
+			return removed, fmt.Errorf("cache: unexpected redis SCAN reply shape")
+		}
+		cursorBytes, ok := arr[0].([]byte)
+		if !ok {
+			return removed, fmt.Errorf("cache: unexpected redis SCAN cursor type %T", arr[0])
+		}
+		cursor = string(cursorBytes)
+
+		keys, ok := arr[1].([]interface{})
+		if !ok {
+			return removed, fmt.Errorf("cache: unexpected redis SCAN keys type %T", arr[1])
+		}
+		for _, k := range keys {
+			keyBytes, ok := k.([]byte)
+			if !ok {
+				continue
+			}
+			if _, err := doCommand(conn, r, "DEL", string(keyBytes)); err != nil {
+				c.invalidate()
+				return removed, fmt.Errorf("cache: redis DEL failed: %w", err)
+			}
+			removed++
+		}
+
+		if cursor == "0" {
+			break
+		}
+	}
+	return removed, nil
+}
+
+// writeCommand encodes args as a RESP array of bulk strings, the wire
+// format every Redis command request uses regardless of arity
+func writeCommand(w io.Writer, args ...string) error {
+	buf := make([]byte, 0, 64)
+	buf = append(buf, '*')
+	buf = strconv.AppendInt(buf, int64(len(args)), 10)
+	buf = append(buf, '\r', '\n')
+	for _, a := range args {
+		buf = append(buf, '$')
+		buf = strconv.AppendInt(buf, int64(len(a)), 10)
+		buf = append(buf, '\r', '\n')
+		buf = append(buf, a...)
+		buf = append(buf, '\r', '\n')
+	}
+	_, err := w.Write(buf)
+	return err
+}
+
+// readReply parses one RESP value: a simple string, error, integer, bulk
+// string ([]byte, or nil for a missing key), or array of any of those
+// (nested one level deep, as SCAN's [cursor, keys] reply requires)
+func readReply(r *bufio.Reader) (interface{}, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = line[:len(line)-2] // strip trailing \r\n
+	if len(line) == 0 {
+		return nil, fmt.Errorf("cache: empty redis reply line")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, errors.New(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		return buf[:n], nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		arr := make([]interface{}, n)
+		for i := 0; i < n; i++ {
+			v, err := readReply(r)
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = v
+		}
+		return arr, nil
+	default:
+		return nil, fmt.Errorf("cache: unrecognized redis reply prefix %q", line[0])
+	}
+}
+
+func doCommand(conn net.Conn, r *bufio.Reader, args ...string) (interface{}, error) {
+	if err := writeCommand(conn, args...); err != nil {
+		return nil, err
+	}
+	return readReply(r)
+}
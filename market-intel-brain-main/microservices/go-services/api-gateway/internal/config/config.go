@@ -1,516 +1,1061 @@
-package config
-
-import (
-	"fmt"
-	"os"
-	"strconv"
-	"time"
-	"errors"
-	"strings"
-
-
-)
-
-// ConfigError represents a configuration error
-type ConfigError struct {
-	Key     string
-	Value   string
-	Message string
-}
-
-func (e *ConfigError) Error() string {
-	return fmt.Sprintf("configuration error for %s: %s (value: %s)", e.Key, e.Message, e.Value)
-}
-
-// Config holds the application configuration
-type Config struct {
-	// Server configuration
-	Server ServerConfig `mapstructure:"server"`
-	
-	// Database configuration
-	Database DatabaseConfig `mapstructure:"database"`
-	
-	// Redis configuration
-	Redis RedisConfig `mapstructure:"redis"`
-	
-	// Kafka configuration
-	Kafka KafkaConfig `mapstructure:"kafka"`
-	
-	// Service URLs
-	Services ServiceURLs `mapstructure:"services"`
-	
-	// Security configuration
-	Security SecurityConfig `mapstructure:"security"`
-	
-	// Logging configuration
-	Logging LoggingConfig `mapstructure:"logging"`
-	
-	// Metrics configuration
-	Metrics MetricsConfig `mapstructure:"metrics"`
-	
-	// Environment
-	Environment string `mapstructure:"environment"`
-}
-
-// ServerConfig holds server-related configuration
-type ServerConfig struct {
-	HTTPPort           int           `mapstructure:"http_port"`
-	GRPCPort           int           `mapstructure:"grpc_port"`
-	Host               string        `mapstructure:"host"`
-	ReadTimeout        time.Duration `mapstructure:"read_timeout"`
-	WriteTimeout       time.Duration `mapstructure:"write_timeout"`
-	IdleTimeout        time.Duration `mapstructure:"idle_timeout"`
-	MaxHeaderBytes     int           `mapstructure:"max_header_bytes"`
-	MaxBodyBytes       int64         `mapstructure:"max_body_bytes"`
-	EnableHTTPS        bool          `mapstructure:"enable_https"`
-	EnableCORS         bool          `mapstructure:"enable_cors"`
-	EnableMetrics      bool          `mapstructure:"enable_metrics"`
-	EnablePprof        bool          `mapstructure:"enable_pprof"`
-	GracefulTimeout    time.Duration `mapstructure:"graceful_timeout"`
-	MaxConnections     int           `mapstructure:"max_connections"`
-}
-
-// DatabaseConfig holds database configuration
-type DatabaseConfig struct {
-	Host            string        `mapstructure:"host"`
-	Port            int           `mapstructure:"port"`
-	Database        string        `mapstructure:"database"`
-	Username        string        `mapstructure:"username"`
-	Password        string        `mapstructure:"password"`
-	SSLMode         string        `mapstructure:"ssl_mode"`
-	MaxConnections  int           `mapstructure:"max_connections"`
-	MinConnections  int           `mapstructure:"min_connections"`
-	MaxIdleTime     time.Duration `mapstructure:"max_idle_time"`
-	MaxLifetime     time.Duration `mapstructure:"max_lifetime"`
-	ConnectTimeout  time.Duration `mapstructure:"connect_timeout"`
-	QueryTimeout    time.Duration `mapstructure:"query_timeout"`
-}
-
-// RedisConfig holds Redis configuration
-type RedisConfig struct {
-	Host         string        `mapstructure:"host"`
-	Port         int           `mapstructure:"port"`
-	Password     string        `mapstructure:"password"`
-	Database     int           `mapstructure:"database"`
-	MaxRetries   int           `mapstructure:"max_retries"`
-	DialTimeout  time.Duration `mapstructure:"dial_timeout"`
-	ReadTimeout  time.Duration `mapstructure:"read_timeout"`
-	WriteTimeout time.Duration `mapstructure:"write_timeout"`
-	PoolSize     int           `mapstructure:"pool_size"`
-	MinIdleConns int           `mapstructure:"min_idle_conns"`
-	MaxConnAge   time.Duration `mapstructure:"max_conn_age"`
-}
-
-// KafkaConfig holds Kafka configuration
-type KafkaConfig struct {
-	Brokers              []string      `mapstructure:"brokers"`
-	ConsumerGroup        string        `mapstructure:"consumer_group"`
-	TopicPrefix          string        `mapstructure:"topic_prefix"`
-	CompressionType      string        `mapstructure:"compression_type"`
-	BatchSize            int           `mapstructure:"batch_size"`
-	BatchTimeout         time.Duration `mapstructure:"batch_timeout"`
-	CompressionLevel     int           `mapstructure:"compression_level"`
-	MaxMessageBytes      int           `mapstructure:"max_message_bytes"`
-	ConsumerFetchMin     int           `mapstructure:"consumer_fetch_min"`
-	ConsumerFetchDefault int           `mapstructure:"consumer_fetch_default"`
-	ConsumerFetchMax     int           `mapstructure:"consumer_fetch_max"`
-}
-
-// ServiceURLs holds service endpoint URLs
-type ServiceURLs struct {
-	CoreEngine  string `mapstructure:"core_engine"`
-	AuthService string `mapstructure:"auth_service"`
-	Analytics   string `mapstructure:"analytics"`
-	VectorStore string `mapstructure:"vector_store"`
-}
-
-// SecurityConfig holds security-related configuration
-type SecurityConfig struct {
-	JWTSecret           string        `mapstructure:"jwt_secret"`
-	JWTExpiration       time.Duration `mapstructure:"jwt_expiration"`
-	RefreshExpiration   time.Duration `mapstructure:"refresh_expiration"`
-	BcryptCost          int           `mapstructure:"bcrypt_cost"`
-	RateLimitEnabled    bool          `mapstructure:"rate_limit_enabled"`
-	RateLimitRPS        int           `mapstructure:"rate_limit_rps"`
-	RateLimitBurst      int           `mapstructure:"rate_limit_burst"`
-	CORSAllowedOrigins  []string      `mapstructure:"cors_allowed_origins"`
-	CORSAllowedMethods  []string      `mapstructure:"cors_allowed_methods"`
-	CORSAllowedHeaders  []string      `mapstructure:"cors_allowed_headers"`
-	EnableHTTPSRedirect bool          `mapstructure:"enable_https_redirect"`
-	TrustedProxies      []string      `mapstructure:"trusted_proxies"`
-}
-
-// LoggingConfig holds logging configuration
-type LoggingConfig struct {
-	Level         string `mapstructure:"level"`
-	Format        string `mapstructure:"format"`
-	Output        string `mapstructure:"output"`
-	EnableConsole bool   `mapstructure:"enable_console"`
-	EnableFile    bool   `mapstructure:"enable_file"`
-	FilePath      string `mapstructure:"file_path"`
-	MaxSize       int    `mapstructure:"max_size"`
-	MaxBackups    int    `mapstructure:"max_backups"`
-	MaxAge        int    `mapstructure:"max_age"`
-	Compress      bool   `mapstructure:"compress"`
-}
-
-// MetricsConfig holds metrics configuration
-type MetricsConfig struct {
-	Enabled    bool   `mapstructure:"enabled"`
-	Path       string `mapstructure:"path"`
-	Port       int    `mapstructure:"port"`
-	Namespace  string `mapstructure:"namespace"`
-	Subsystem  string `mapstructure:"subsystem"`
-}
-
-// Load loads configuration from environment variables with validation
-func Load(configFile string) (*Config, error) {
-	config := &Config{}
-	
-	// Load server configuration
-	serverConfig, err := loadServerConfig()
-	if err != nil {
-		return nil, fmt.Errorf("failed to load server config: %w", err)
-	}
-	config.Server = serverConfig
-	
-	// Load database configuration
-	databaseConfig, err := loadDatabaseConfig()
-	if err != nil {
-		return nil, fmt.Errorf("failed to load database config: %w", err)
-	}
-	config.Database = databaseConfig
-	
-	// Load Redis configuration
-	redisConfig, err := loadRedisConfig()
-	if err != nil {
-		return nil, fmt.Errorf("failed to load redis config: %w", err)
-	}
-	config.Redis = redisConfig
-	
-	// Load Kafka configuration
-	kafkaConfig, err := loadKafkaConfig()
-	if err != nil {
-		return nil, fmt.Errorf("failed to load kafka config: %w", err)
-	}
-	config.Kafka = kafkaConfig
-	
-	// Load service URLs
-	serviceURLs, err := loadServiceURLs()
-	if err != nil {
-		return nil, fmt.Errorf("failed to load service URLs: %w", err)
-	}
-	config.Services = serviceURLs
-	
-	// Load security configuration
-	securityConfig, err := loadSecurityConfig()
-	if err != nil {
-		return nil, fmt.Errorf("failed to load security config: %w", err)
-	}
-	config.Security = securityConfig
-	
-	// Load logging configuration
-	loggingConfig, err := loadLoggingConfig()
-	if err != nil {
-		return nil, fmt.Errorf("failed to load logging config: %w", err)
-	}
-	config.Logging = loggingConfig
-	
-	// Load metrics configuration
-	metricsConfig, err := loadMetricsConfig()
-	if err != nil {
-		return nil, fmt.Errorf("failed to load metrics config: %w", err)
-	}
-	config.Metrics = metricsConfig
-	
-	// Load environment
-	config.Environment = getEnv("ENVIRONMENT", "development")
-	
-	// Validate entire configuration
-	if err := config.Validate(); err != nil {
-		return nil, fmt.Errorf("configuration validation failed: %w", err)
-	}
-	
-	return config, nil
-}
-
-// Validate validates the entire configuration
-func (c *Config) Validate() error {
-	if err := c.Server.Validate(); err != nil {
-		return fmt.Errorf("server config validation failed: %w", err)
-	}
-	
-	if err := c.Database.Validate(); err != nil {
-		return fmt.Errorf("database config validation failed: %w", err)
-	}
-	
-	if err := c.Redis.Validate(); err != nil {
-		return fmt.Errorf("redis config validation failed: %w", err)
-	}
-	
-	if err := c.Kafka.Validate(); err != nil {
-		return fmt.Errorf("kafka config validation failed: %w", err)
-	}
-	
-	if err := c.Services.Validate(); err != nil {
-		return fmt.Errorf("services config validation failed: %w", err)
-	}
-	
-	if err := c.Security.Validate(); err != nil {
-		return fmt.Errorf("security config validation failed: %w", err)
-	}
-	
-	if err := c.Logging.Validate(); err != nil {
-		return fmt.Errorf("logging config validation failed: %w", err)
-	}
-	
-	if err := c.Metrics.Validate(); err != nil {
-		return fmt.Errorf("metrics config validation failed: %w", err)
-	}
-	
-	// Cross-component validation
-	if c.Server.HTTPPort == c.Server.GRPCPort {
-		return errors.New("HTTP and gRPC ports cannot be the same")
-	}
-	
-	return nil
-}
-
-// loadServerConfig loads server configuration from environment variables
-func loadServerConfig() (ServerConfig, error) {
-	config := ServerConfig{
-		HTTPPort:           getEnvInt("SERVER_HTTP_PORT", 8080),
-		GRPCPort:           getEnvInt("SERVER_GRPC_PORT", 8081),
-		Host:               getEnv("SERVER_HOST", "0.0.0.0"),
-		ReadTimeout:        getEnvDuration("SERVER_READ_TIMEOUT", 30*time.Second),
-		WriteTimeout:       getEnvDuration("SERVER_WRITE_TIMEOUT", 30*time.Second),
-		IdleTimeout:        getEnvDuration("SERVER_IDLE_TIMEOUT", 60*time.Second),
-		MaxHeaderBytes:     getEnvInt("SERVER_MAX_HEADER_BYTES", 1<<20), // 1MB
-		MaxBodyBytes:       getEnvInt64("SERVER_MAX_BODY_BYTES", 10<<20), // 10MB
-		EnableHTTPS:        getEnvBool("SERVER_ENABLE_HTTPS", false),
-		EnableCORS:         getEnvBool("SERVER_ENABLE_CORS", true),
-		EnableMetrics:      getEnvBool("SERVER_ENABLE_METRICS", true),
-		EnablePprof:        getEnvBool("SERVER_ENABLE_PPROF", false),
-		GracefulTimeout:    getEnvDuration("SERVER_GRACEFUL_TIMEOUT", 30*time.Second),
-		MaxConnections:     getEnvInt("SERVER_MAX_CONNECTIONS", 10000),
-	}
-	
-	return config, config.Validate()
-}
-
-// loadDatabaseConfig loads database configuration from environment variables
-func loadDatabaseConfig() (DatabaseConfig, error) {
-	config := DatabaseConfig{
-		Host:           getEnv("DB_HOST", "localhost"),
-		Port:           getEnvInt("DB_PORT", 5432),
-		Database:       getEnv("DB_NAME", "market_intel"),
-		Username:       getEnv("DB_USERNAME", "postgres"),
-		Password:       getEnvRequired("DB_PASSWORD"),
-		SSLMode:        getEnv("DB_SSL_MODE", "prefer"),
-		MaxConnections: getEnvInt("DB_MAX_CONNECTIONS", 20),
-		MinConnections: getEnvInt("DB_MIN_CONNECTIONS", 5),
-		MaxIdleTime:    getEnvDuration("DB_MAX_IDLE_TIME", 10*time.Minute),
-		MaxLifetime:    getEnvDuration("DB_MAX_LIFETIME", 30*time.Minute),
-		ConnectTimeout: getEnvDuration("DB_CONNECT_TIMEOUT", 30*time.Second),
-		QueryTimeout:   getEnvDuration("DB_QUERY_TIMEOUT", 30*time.Second),
-	}
-	
-	return config, config.Validate()
-}
-
-// loadRedisConfig loads Redis configuration from environment variables
-func loadRedisConfig() (RedisConfig, error) {
-	config := RedisConfig{
-		Host:         getEnv("REDIS_HOST", "localhost"),
-		Port:         getEnvInt("REDIS_PORT", 6379),
-		Password:     getEnv("REDIS_PASSWORD", ""),
-		Database:     getEnvInt("REDIS_DATABASE", 0),
-		MaxRetries:   getEnvInt("REDIS_MAX_RETRIES", 3),
-		DialTimeout:  getEnvDuration("REDIS_DIAL_TIMEOUT", 5*time.Second),
-		ReadTimeout:  getEnvDuration("REDIS_READ_TIMEOUT", 3*time.Second),
-		WriteTimeout: getEnvDuration("REDIS_WRITE_TIMEOUT", 3*time.Second),
-		PoolSize:     getEnvInt("REDIS_POOL_SIZE", 10),
-		MinIdleConns: getEnvInt("REDIS_MIN_IDLE_CONNS", 5),
-		MaxConnAge:   getEnvDuration("REDIS_MAX_CONN_AGE", 30*time.Minute),
-	}
-	
-	return config, config.Validate()
-}
-
-// loadKafkaConfig loads Kafka configuration from environment variables
-func loadKafkaConfig() (KafkaConfig, error) {
-	brokers := strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ",")
-	for i, broker := range brokers {
-		brokers[i] = strings.TrimSpace(broker)
-	}
-	
-	config := KafkaConfig{
-		Brokers:              brokers,
-		ConsumerGroup:        getEnv("KAFKA_CONSUMER_GROUP", "api-gateway"),
-		TopicPrefix:          getEnv("KAFKA_TOPIC_PREFIX", ""),
-		CompressionType:      getEnv("KAFKA_COMPRESSION_TYPE", "gzip"),
-		BatchSize:            getEnvInt("KAFKA_BATCH_SIZE", 100),
-		BatchTimeout:         getEnvDuration("KAFKA_BATCH_TIMEOUT", 10*time.Millisecond),
-		CompressionLevel:     getEnvInt("KAFKA_COMPRESSION_LEVEL", 6),
-		MaxMessageBytes:      getEnvInt("KAFKA_MAX_MESSAGE_BYTES", 1000000),
-		ConsumerFetchMin:     getEnvInt("KAFKA_CONSUMER_FETCH_MIN", 1),
-		ConsumerFetchDefault: getEnvInt("KAFKA_CONSUMER_FETCH_DEFAULT", 1024),
-		ConsumerFetchMax:     getEnvInt("KAFKA_CONSUMER_FETCH_MAX", 1048576),
-	}
-	
-	return config, config.Validate()
-}
-
-// loadServiceURLs loads service URLs from environment variables
-func loadServiceURLs() (ServiceURLs, error) {
-	config := ServiceURLs{
-		CoreEngine:  getEnv("CORE_ENGINE_URL", "localhost:50052"),
-		AuthService: getEnv("AUTH_SERVICE_URL", "localhost:50051"),
-		Analytics:   getEnv("ANALYTICS_SERVICE_URL", "localhost:50053"),
-		VectorStore: getEnv("VECTOR_STORE_URL", "localhost:50054"),
-	}
-	
-	return config, config.Validate()
-}
-
-// loadSecurityConfig loads security configuration from environment variables
-func loadSecurityConfig() (SecurityConfig, error) {
-	config := SecurityConfig{
-		JWTSecret:           getEnvRequired("JWT_SECRET"),
-		JWTExpiration:       getEnvDuration("JWT_EXPIRATION", 24*time.Hour),
-		RefreshExpiration:   getEnvDuration("REFRESH_TOKEN_EXPIRATION", 7*24*time.Hour),
-		BcryptCost:          getEnvInt("BCRYPT_COST", 12),
-		RateLimitEnabled:    getEnvBool("RATE_LIMIT_ENABLED", true),
-		RateLimitRPS:        getEnvInt("RATE_LIMIT_RPS", 100),
-		RateLimitBurst:      getEnvInt("RATE_LIMIT_BURST", 200),
-		CORSAllowedOrigins:  strings.Split(getEnv("CORS_ALLOWED_ORIGINS", "*"), ","),
-		CORSAllowedMethods:  strings.Split(getEnv("CORS_ALLOWED_METHODS", "GET,POST,PUT,DELETE,OPTIONS"), ","),
-		CORSAllowedHeaders:  strings.Split(getEnv("CORS_ALLOWED_HEADERS", "Content-Type,Authorization"), ","),
-		EnableHTTPSRedirect: getEnvBool("ENABLE_HTTPS_REDIRECT", false),
-		TrustedProxies:      strings.Split(getEnv("TRUSTED_PROXIES", ""), ","),
-	}
-	
-	return config, config.Validate()
-}
-
-// loadLoggingConfig loads logging configuration from environment variables
-func loadLoggingConfig() (LoggingConfig, error) {
-	config := LoggingConfig{
-		Level:         getEnv("LOG_LEVEL", "info"),
-		Format:        getEnv("LOG_FORMAT", "json"),
-		Output:        getEnv("LOG_OUTPUT", "stdout"),
-		EnableConsole: getEnvBool("LOG_ENABLE_CONSOLE", true),
-		EnableFile:    getEnvBool("LOG_ENABLE_FILE", false),
-		FilePath:      getEnv("LOG_FILE_PATH", "/var/log/api-gateway.log"),
-		MaxSize:       getEnvInt("LOG_MAX_SIZE", 100),
-		MaxBackups:    getEnvInt("LOG_MAX_BACKUPS", 3),
-		MaxAge:        getEnvInt("LOG_MAX_AGE", 28),
-		Compress:      getEnvBool("LOG_COMPRESS", true),
-	}
-	
-	return config, config.Validate()
-}
-
-// loadMetricsConfig loads metrics configuration from environment variables
-func loadMetricsConfig() (MetricsConfig, error) {
-	config := MetricsConfig{
-		Enabled:   getEnvBool("METRICS_ENABLED", true),
-		Path:      getEnv("METRICS_PATH", "/metrics"),
-		Port:      getEnvInt("METRICS_PORT", 9090),
-		Namespace: getEnv("METRICS_NAMESPACE", "market_intel"),
-		Subsystem: getEnv("METRICS_SUBSYSTEM", "api_gateway"),
-	}
-	
-	return config, config.Validate()
-}
-
-// Helper functions for environment variable parsing with validation
-
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
-}
-
-func getEnvRequired(key string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	panic(fmt.Sprintf("required environment variable %s is not set", key))
-}
-
-func getEnvInt(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
-		if intValue, err := strconv.Atoi(value); err == nil {
-			return intValue
-		}
-	}
-	return defaultValue
-}
-
-func getEnvInt64(key string, defaultValue int64) int64 {
-	if value := os.Getenv(key); value != "" {
-		if intValue, err := strconv.ParseInt(value, 10, 64); err == nil {
-			return intValue
-		}
-	}
-	return defaultValue
-}
-
-func getEnvBool(key string, defaultValue bool) bool {
-	if value := os.Getenv(key); value != "" {
-		if boolValue, err := strconv.ParseBool(value); err == nil {
-			return boolValue
-		}
-	}
-	return defaultValue
-}
-
-func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
-	if value := os.Getenv(key); value != "" {
-		if duration, err := time.ParseDuration(value); err == nil {
-			return duration
-		}
-	}
-	return defaultValue
-}
-
-// GetHTTPPort returns the HTTP port as a string
-func (c *Config) GetHTTPPort() string {
-	return fmt.Sprintf(":%d", c.Server.HTTPPort)
-}
-
-// GetGRPCPort returns the gRPC port as a string
-func (c *Config) GetGRPCPort() string {
-	return fmt.Sprintf(":%d", c.Server.GRPCPort)
-}
-
-// GetDatabaseURL returns the database connection URL
-func (c *Config) GetDatabaseURL() string {
-	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s",
-		c.Database.Username,
-		c.Database.Password,
-		c.Database.Host,
-		c.Database.Port,
-		c.Database.Database,
-		c.Database.SSLMode,
-	)
-}
-
-// GetRedisURL returns the Redis connection URL
-func (c *Config) GetRedisURL() string {
-	if c.Redis.Password != "" {
-		return fmt.Sprintf("redis://%s@%s:%d/%d",
-			c.Redis.Password,
-			c.Redis.Host,
-			c.Redis.Port,
-			c.Redis.Database,
-		)
-	}
-	return fmt.Sprintf("redis://%s:%d/%d",
-		c.Redis.Host,
-		c.Redis.Port,
-		c.Redis.Database,
-	)
-}
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/market-intel/api-gateway/internal/config/validate"
+	"github.com/market-intel/api-gateway/internal/secrets"
+)
+
+// ConfigError represents a configuration error
+type ConfigError struct {
+	Key     string
+	Value   string
+	Message string
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("configuration error for %s: %s (value: %s)", e.Key, e.Message, e.Value)
+}
+
+// asConfigError adapts a *validate.Error returned by validate.Struct into
+// the package's own ConfigError type, so every Validate() method -
+// tag-driven or hand-rolled - returns the same error shape.
+func asConfigError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if ve, ok := err.(*validate.Error); ok {
+		return &ConfigError{Key: ve.Key, Value: ve.Value, Message: ve.Message}
+	}
+	return err
+}
+
+// Config holds the application configuration
+type Config struct {
+	// Server configuration
+	Server ServerConfig `mapstructure:"server"`
+
+	// Database configuration
+	Database DatabaseConfig `mapstructure:"database"`
+
+	// Redis configuration
+	Redis RedisConfig `mapstructure:"redis"`
+
+	// Kafka configuration
+	Kafka KafkaConfig `mapstructure:"kafka"`
+
+	// Service URLs
+	Services ServiceURLs `mapstructure:"services"`
+
+	// Security configuration
+	Security SecurityConfig `mapstructure:"security"`
+
+	// Rate limit configuration for the per-route/per-API-key limiter
+	// (internal/middleware), layered on Security's global toggle
+	RateLimit RateLimitConfig `mapstructure:"rate_limit"`
+
+	// Data source adapter configuration
+	DataSources DataSourcesConfig `mapstructure:"data_sources"`
+
+	// Response cache and request coalescing configuration
+	ResponseCache ResponseCacheConfig `mapstructure:"response_cache"`
+
+	// gRPC server configuration
+	GRPC GRPCServerConfig `mapstructure:"grpc"`
+
+	// gRPC-Web / HTTP-JSON transcoding gateway configuration
+	Gateway GatewayConfig `mapstructure:"gateway"`
+
+	// Logging configuration
+	Logging LoggingConfig `mapstructure:"logging"`
+
+	// Metrics configuration
+	Metrics MetricsConfig `mapstructure:"metrics"`
+
+	// Health check configuration for internal/health's dependency registry
+	Health HealthConfig `mapstructure:"health"`
+
+	// Environment
+	Environment string `mapstructure:"environment"`
+}
+
+// ServerConfig holds server-related configuration
+type ServerConfig struct {
+	HTTPPort        int           `mapstructure:"http_port" validate:"name=SERVER_HTTP_PORT,min=1,max=65535" reload:"restart"`
+	GRPCPort        int           `mapstructure:"grpc_port" validate:"name=SERVER_GRPC_PORT,min=1,max=65535" reload:"restart"`
+	Host            string        `mapstructure:"host" validate:"name=SERVER_HOST,required" reload:"restart"`
+	ReadTimeout     time.Duration `mapstructure:"read_timeout" validate:"name=SERVER_READ_TIMEOUT,min=1"`
+	WriteTimeout    time.Duration `mapstructure:"write_timeout" validate:"name=SERVER_WRITE_TIMEOUT,min=1"`
+	IdleTimeout     time.Duration `mapstructure:"idle_timeout" validate:"name=SERVER_IDLE_TIMEOUT,min=1"`
+	MaxHeaderBytes  int           `mapstructure:"max_header_bytes" validate:"name=SERVER_MAX_HEADER_BYTES,min=1"`
+	MaxBodyBytes    int64         `mapstructure:"max_body_bytes" validate:"name=SERVER_MAX_BODY_BYTES,min=1"`
+	EnableHTTPS     bool          `mapstructure:"enable_https"`
+	EnableCORS      bool          `mapstructure:"enable_cors"`
+	EnableMetrics   bool          `mapstructure:"enable_metrics"`
+	EnablePprof     bool          `mapstructure:"enable_pprof"`
+	GracefulTimeout time.Duration `mapstructure:"graceful_timeout" validate:"name=SERVER_GRACEFUL_TIMEOUT,min=1"`
+	MaxConnections  int           `mapstructure:"max_connections" validate:"name=SERVER_MAX_CONNECTIONS,min=1"`
+
+	// ListenAddr, if set, overrides HTTPPort as "unix:///path/to.sock" or
+	// "tcp://host:port" -- e.g. for a sidecar (envoy/nginx) terminating
+	// TLS and proxying to the gateway over a Unix domain socket. Empty
+	// keeps the plain ":<HTTPPort>" TCP listener.
+	ListenAddr string `mapstructure:"listen_addr" reload:"restart"`
+}
+
+// DatabaseConfig holds database configuration
+type DatabaseConfig struct {
+	Host           string        `mapstructure:"host" validate:"name=DB_HOST,required" reload:"restart"`
+	Port           int           `mapstructure:"port" validate:"name=DB_PORT,min=1,max=65535" reload:"restart"`
+	Database       string        `mapstructure:"database" validate:"name=DB_NAME,required" reload:"restart"`
+	Username       string        `mapstructure:"username" validate:"name=DB_USERNAME,required" reload:"restart"`
+	Password       string        `mapstructure:"password" validate:"name=DB_PASSWORD,required,secret" reload:"restart"`
+	SSLMode        string        `mapstructure:"ssl_mode" validate:"name=DB_SSL_MODE,required,enum=disable|allow|prefer|require|verify-ca|verify-full" reload:"restart"`
+	MaxConnections int           `mapstructure:"max_connections" validate:"name=DB_MAX_CONNECTIONS,min=1,gte=MinConnections"`
+	MinConnections int           `mapstructure:"min_connections" validate:"name=DB_MIN_CONNECTIONS,min=0"`
+	MaxIdleTime    time.Duration `mapstructure:"max_idle_time" validate:"name=DB_MAX_IDLE_TIME,min=1"`
+	MaxLifetime    time.Duration `mapstructure:"max_lifetime" validate:"name=DB_MAX_LIFETIME,min=1"`
+	ConnectTimeout time.Duration `mapstructure:"connect_timeout" validate:"name=DB_CONNECT_TIMEOUT,min=1"`
+	QueryTimeout   time.Duration `mapstructure:"query_timeout" validate:"name=DB_QUERY_TIMEOUT,min=1"`
+}
+
+// RedisConfig holds Redis configuration
+type RedisConfig struct {
+	Host         string        `mapstructure:"host" validate:"name=REDIS_HOST,required"`
+	Port         int           `mapstructure:"port" validate:"name=REDIS_PORT,min=1,max=65535"`
+	Password     string        `mapstructure:"password"`
+	Database     int           `mapstructure:"database" validate:"name=REDIS_DATABASE,min=0,max=15"`
+	MaxRetries   int           `mapstructure:"max_retries" validate:"name=REDIS_MAX_RETRIES,min=0"`
+	DialTimeout  time.Duration `mapstructure:"dial_timeout" validate:"name=REDIS_DIAL_TIMEOUT,min=1"`
+	ReadTimeout  time.Duration `mapstructure:"read_timeout" validate:"name=REDIS_READ_TIMEOUT,min=1"`
+	WriteTimeout time.Duration `mapstructure:"write_timeout" validate:"name=REDIS_WRITE_TIMEOUT,min=1"`
+	PoolSize     int           `mapstructure:"pool_size" validate:"name=REDIS_POOL_SIZE,min=1,gte=MinIdleConns"`
+	MinIdleConns int           `mapstructure:"min_idle_conns" validate:"name=REDIS_MIN_IDLE_CONNS,min=0"`
+	MaxConnAge   time.Duration `mapstructure:"max_conn_age" validate:"name=REDIS_MAX_CONN_AGE,min=1"`
+}
+
+// KafkaConfig holds Kafka configuration
+type KafkaConfig struct {
+	Brokers              []string      `mapstructure:"brokers" validate:"name=KAFKA_BROKERS,required,dive=broker"`
+	ConsumerGroup        string        `mapstructure:"consumer_group" validate:"name=KAFKA_CONSUMER_GROUP,required"`
+	ClientID             string        `mapstructure:"client_id"`
+	Version              string        `mapstructure:"version" validate:"name=KAFKA_VERSION"`
+	TopicPrefix          string        `mapstructure:"topic_prefix"`
+	CompressionType      string        `mapstructure:"compression_type" validate:"name=KAFKA_COMPRESSION_TYPE,required,enum=none|gzip|snappy|lz4|zstd"`
+	BatchSize            int           `mapstructure:"batch_size" validate:"name=KAFKA_BATCH_SIZE,min=1"`
+	BatchTimeout         time.Duration `mapstructure:"batch_timeout" validate:"name=KAFKA_BATCH_TIMEOUT,min=0"`
+	CompressionLevel     int           `mapstructure:"compression_level" validate:"name=KAFKA_COMPRESSION_LEVEL,min=0"`
+	MaxMessageBytes      int           `mapstructure:"max_message_bytes" validate:"name=KAFKA_MAX_MESSAGE_BYTES,min=1"`
+	ConsumerFetchMin     int           `mapstructure:"consumer_fetch_min" validate:"name=KAFKA_CONSUMER_FETCH_MIN,min=1"`
+	ConsumerFetchDefault int           `mapstructure:"consumer_fetch_default" validate:"name=KAFKA_CONSUMER_FETCH_DEFAULT,min=1,gte=ConsumerFetchMin"`
+	ConsumerFetchMax     int           `mapstructure:"consumer_fetch_max" validate:"name=KAFKA_CONSUMER_FETCH_MAX,min=1,gte=ConsumerFetchDefault"`
+
+	// Security
+	SecurityProtocol string `mapstructure:"security_protocol" validate:"name=KAFKA_SECURITY_PROTOCOL,required,enum=plaintext|ssl|sasl_plaintext|sasl_ssl"`
+	SASLMechanism    string `mapstructure:"sasl_mechanism" validate:"name=KAFKA_SASL_MECHANISM,enum=PLAIN|SCRAM-SHA-256|SCRAM-SHA-512|OAUTHBEARER|GSSAPI"`
+	SASLUsername     string `mapstructure:"sasl_username" validate:"name=KAFKA_SASL_USERNAME"`
+	SASLPassword     string `mapstructure:"sasl_password" validate:"name=KAFKA_SASL_PASSWORD,secret"`
+	SASLTokenURL     string `mapstructure:"sasl_token_url" validate:"name=KAFKA_SASL_TOKEN_URL"`
+
+	TLSCAFile             string `mapstructure:"tls_ca_file" validate:"name=KAFKA_TLS_CA_FILE"`
+	TLSCertFile           string `mapstructure:"tls_cert_file" validate:"name=KAFKA_TLS_CERT_FILE"`
+	TLSKeyFile            string `mapstructure:"tls_key_file" validate:"name=KAFKA_TLS_KEY_FILE"`
+	TLSInsecureSkipVerify bool   `mapstructure:"tls_insecure_skip_verify"`
+
+	// Reliability
+	EnableIdempotence                bool          `mapstructure:"enable_idempotence"`
+	Acks                             string        `mapstructure:"acks" validate:"name=KAFKA_ACKS,required,enum=0|1|all"`
+	MaxInFlightRequestsPerConnection int           `mapstructure:"max_in_flight_requests_per_connection" validate:"name=KAFKA_MAX_IN_FLIGHT_REQUESTS_PER_CONNECTION,min=1"`
+	RebalanceStrategy                string        `mapstructure:"rebalance_strategy" validate:"name=KAFKA_REBALANCE_STRATEGY,required,enum=range|roundrobin|sticky"`
+	IsolationLevel                   string        `mapstructure:"isolation_level" validate:"name=KAFKA_ISOLATION_LEVEL,required,enum=read_committed|read_uncommitted"`
+	SessionTimeout                   time.Duration `mapstructure:"session_timeout" validate:"name=KAFKA_SESSION_TIMEOUT,min=1"`
+	HeartbeatInterval                time.Duration `mapstructure:"heartbeat_interval" validate:"name=KAFKA_HEARTBEAT_INTERVAL,min=1"`
+}
+
+// ServiceEndpoint describes how to reach one gRPC dependency: where
+// (a plain host:port, a DNS name resolved via grpc's builtin "dns"
+// resolver, or a discovery target like "consul://core-engine?tag=grpc" or
+// "etcd://market-intel/core-engine" resolved via pkg/discovery), how to
+// secure the connection, and which client-side load-balancing policy to
+// spread calls across resolved addresses with.
+type ServiceEndpoint struct {
+	Target        string `mapstructure:"target"`
+	TLSEnabled    bool   `mapstructure:"tls_enabled"`
+	MTLSEnabled   bool   `mapstructure:"mtls_enabled"`
+	LoadBalancing string `mapstructure:"load_balancing"`
+}
+
+// ServiceURLs holds the gateway's service dependencies. CoreEngineStream is
+// a plain ws(s):// URL consumed directly by pkg/stream rather than dialed
+// over gRPC, so it stays a bare string; everything else goes through
+// services.ClientFactory.
+type ServiceURLs struct {
+	CoreEngine       ServiceEndpoint `mapstructure:"core_engine"`
+	CoreEngineStream string          `mapstructure:"core_engine_stream"`
+	AuthService      ServiceEndpoint `mapstructure:"auth_service"`
+	Analytics        ServiceEndpoint `mapstructure:"analytics"`
+	VectorStore      ServiceEndpoint `mapstructure:"vector_store"`
+}
+
+// SecurityConfig holds security-related configuration
+type SecurityConfig struct {
+	JWTSecret           string        `mapstructure:"jwt_secret" validate:"name=JWT_SECRET,required,secret,min=32"`
+	JWTExpiration       time.Duration `mapstructure:"jwt_expiration" validate:"name=JWT_EXPIRATION,min=1"`
+	RefreshExpiration   time.Duration `mapstructure:"refresh_expiration" validate:"name=REFRESH_TOKEN_EXPIRATION,min=1,gte=JWTExpiration"`
+	BcryptCost          int           `mapstructure:"bcrypt_cost" validate:"name=BCRYPT_COST,min=4,max=31"`
+	RateLimitEnabled    bool          `mapstructure:"rate_limit_enabled"`
+	RateLimitRPS        int           `mapstructure:"rate_limit_rps" validate:"name=RATE_LIMIT_RPS,min=1"`
+	RateLimitBurst      int           `mapstructure:"rate_limit_burst" validate:"name=RATE_LIMIT_BURST,min=1,gte=RateLimitRPS"`
+	CORSAllowedOrigins  []string      `mapstructure:"cors_allowed_origins"`
+	CORSAllowedMethods  []string      `mapstructure:"cors_allowed_methods"`
+	CORSAllowedHeaders  []string      `mapstructure:"cors_allowed_headers"`
+	EnableHTTPSRedirect bool          `mapstructure:"enable_https_redirect"`
+	TrustedProxies      []string      `mapstructure:"trusted_proxies"`
+}
+
+// RateLimitConfig holds configuration for the per-route, per-API-key,
+// per-user-agent, and per-origin rate limiter (internal/middleware),
+// layered on top of SecurityConfig's global RateLimitEnabled/RateLimitRPS/
+// RateLimitBurst. Backend "redis" shares the connection fields in
+// RedisConfig the same way ResponseCacheConfig does.
+type RateLimitConfig struct {
+	Backend          string                `mapstructure:"backend" validate:"name=RATE_LIMIT_BACKEND,required,enum=memory|redis" reload:"restart"`
+	PerAPIKeyRPS     int                   `mapstructure:"per_api_key_rps" validate:"name=RATE_LIMIT_PER_API_KEY_RPS,min=0"`
+	PerAPIKeyBurst   int                   `mapstructure:"per_api_key_burst" validate:"name=RATE_LIMIT_PER_API_KEY_BURST,min=0"`
+	Routes           map[string]RouteLimit `mapstructure:"routes"`
+	ExemptUserAgents []string              `mapstructure:"exempt_user_agents"`
+	ExemptOrigins    []string              `mapstructure:"exempt_origins"`
+	ExemptCIDRs      []string              `mapstructure:"exempt_cidrs" validate:"name=RATE_LIMIT_EXEMPT_CIDRS,dive=cidr"`
+}
+
+// RouteLimit overrides the default rate limit for one route, set via
+// RATE_LIMIT_ROUTES (see parseRouteLimits).
+type RouteLimit struct {
+	RPS   int
+	Burst int
+}
+
+// LoggingConfig holds logging configuration
+type LoggingConfig struct {
+	Level         string `mapstructure:"level" validate:"name=LOG_LEVEL,required,enum=trace|debug|info|warn|error|fatal|panic"`
+	Format        string `mapstructure:"format" validate:"name=LOG_FORMAT,required,enum=json|text"`
+	Output        string `mapstructure:"output" validate:"name=LOG_OUTPUT,required,enum=stdout|stderr|file"`
+	EnableConsole bool   `mapstructure:"enable_console"`
+	EnableFile    bool   `mapstructure:"enable_file"`
+	FilePath      string `mapstructure:"file_path"`
+	MaxSize       int    `mapstructure:"max_size" validate:"name=LOG_MAX_SIZE,min=1"`
+	MaxBackups    int    `mapstructure:"max_backups" validate:"name=LOG_MAX_BACKUPS,min=0"`
+	MaxAge        int    `mapstructure:"max_age" validate:"name=LOG_MAX_AGE,min=0"`
+	Compress      bool   `mapstructure:"compress"`
+}
+
+// MetricsConfig holds metrics configuration
+type MetricsConfig struct {
+	Enabled   bool   `mapstructure:"enabled"`
+	Path      string `mapstructure:"path" validate:"name=METRICS_PATH,required,prefix=/"`
+	Port      int    `mapstructure:"port" validate:"name=METRICS_PORT,min=1,max=65535" reload:"restart"`
+	Namespace string `mapstructure:"namespace" validate:"name=METRICS_NAMESPACE,required,regex=^[a-zA-Z_][a-zA-Z0-9_]*$"`
+	Subsystem string `mapstructure:"subsystem" validate:"name=METRICS_SUBSYSTEM,required,regex=^[a-zA-Z_][a-zA-Z0-9_]*$"`
+}
+
+// DataSourcesConfig holds configuration for the pluggable data-source
+// adapter registry (internal/sources), including which CredentialStore
+// backend adapters resolve their API keys through
+type DataSourcesConfig struct {
+	Enabled            bool   `mapstructure:"enabled"`
+	CredentialProvider string `mapstructure:"credential_provider"`
+	CredentialFilePath string `mapstructure:"credential_file_path"`
+	VaultAddr          string `mapstructure:"vault_addr"`
+	VaultToken         string `mapstructure:"vault_token"`
+	VaultMountPath     string `mapstructure:"vault_mount_path"`
+}
+
+// ResponseCacheConfig holds configuration for the per-endpoint response
+// cache and request coalescer (internal/cache) sitting in front of
+// coreEngineClient calls. Backend "redis" reuses the Redis connection
+// fields in RedisConfig rather than duplicating them here.
+type ResponseCacheConfig struct {
+	Enabled       bool          `mapstructure:"enabled"`
+	Backend       string        `mapstructure:"backend"`
+	MaxEntries    int           `mapstructure:"max_entries"`
+	MarketDataTTL time.Duration `mapstructure:"market_data_ttl"`
+	NewsTTL       time.Duration `mapstructure:"news_ttl"`
+}
+
+// GRPCServerConfig holds configuration for the internal/server.GRPCServer,
+// covering TLS, keepalive enforcement, graceful drain, and the load-shedding
+// concurrency limit applied via pkg/resilience
+type GRPCServerConfig struct {
+	TLSCertFile string `mapstructure:"tls_cert_file" reload:"restart"`
+	TLSKeyFile  string `mapstructure:"tls_key_file" reload:"restart"`
+
+	KeepaliveTime                  time.Duration `mapstructure:"keepalive_time"`
+	KeepaliveTimeout               time.Duration `mapstructure:"keepalive_timeout"`
+	MaxConnectionIdle              time.Duration `mapstructure:"max_connection_idle"`
+	MaxConnectionAge               time.Duration `mapstructure:"max_connection_age"`
+	MaxConnectionAgeGrace          time.Duration `mapstructure:"max_connection_age_grace"`
+	EnforcementMinTime             time.Duration `mapstructure:"enforcement_min_time"`
+	EnforcementPermitWithoutStream bool          `mapstructure:"enforcement_permit_without_stream"`
+
+	DrainTimeout          time.Duration `mapstructure:"drain_timeout"`
+	MaxConcurrentRequests int64         `mapstructure:"max_concurrent_requests"`
+	EnableReflection      bool          `mapstructure:"enable_reflection"`
+
+	// ListenAddr, if set, overrides Server.GRPCPort the same way
+	// Server.ListenAddr overrides Server.HTTPPort.
+	ListenAddr string `mapstructure:"listen_addr" reload:"restart"`
+}
+
+// GatewayConfig controls server.GatewayServer, which exposes GRPC's
+// registered services to gRPC-Web (browser) and HTTP/JSON-transcoding
+// clients without duplicating handler code.
+type GatewayConfig struct {
+	Enabled bool `mapstructure:"enabled" reload:"restart"`
+
+	// Port the gateway listens on when MultiplexHTTP is false. Ignored
+	// otherwise, since the gateway then shares Server.HTTPPort instead of
+	// binding a port of its own.
+	Port int `mapstructure:"port" reload:"restart"`
+
+	// ListenAddr, if set, overrides Port the same way Server.ListenAddr
+	// overrides Server.HTTPPort. Ignored when MultiplexHTTP is true.
+	ListenAddr string `mapstructure:"listen_addr" reload:"restart"`
+
+	// MultiplexHTTP, if true, serves the gateway on Server.HTTPPort itself
+	// -- a cmux splitter in front of the HTTP listener routes gRPC-Web
+	// requests (identified by their Content-Type) to the gateway and
+	// everything else to the HTTP server's router, so browser clients
+	// don't need a second port.
+	MultiplexHTTP bool `mapstructure:"multiplex_http" reload:"restart"`
+
+	// AllowedOrigins is the gRPC-Web CORS allowlist; "*" allows any origin.
+	AllowedOrigins []string `mapstructure:"allowed_origins"`
+
+	// MethodAllowlist restricts gRPC-Web access to specific fully
+	// qualified RPC methods (e.g. "market.v1.MarketDataService/Stream").
+	// Empty allows every method registered on the wrapped gRPC server.
+	MethodAllowlist []string `mapstructure:"method_allowlist"`
+
+	// EnableWebSocketStreaming upgrades gRPC-Web requests for
+	// server-streaming methods to a WebSocket connection instead of
+	// relying on chunked HTTP responses, which some browser and proxy
+	// combinations buffer instead of streaming.
+	EnableWebSocketStreaming bool `mapstructure:"enable_websocket_streaming"`
+
+	// DrainTimeout bounds how long Stop waits for in-flight gRPC-Web calls
+	// to finish before force-closing, the same role GRPC.DrainTimeout
+	// plays for the native gRPC server.
+	DrainTimeout time.Duration `mapstructure:"drain_timeout"`
+}
+
+// HealthConfig holds configuration for the internal/health dependency
+// registry HealthHandler builds its /healthz, /readyz, and /health
+// endpoints on
+type HealthConfig struct {
+	CacheFor     time.Duration `mapstructure:"cache_for" validate:"name=HEALTH_CACHE_FOR,min=0"`
+	CheckTimeout time.Duration `mapstructure:"check_timeout" validate:"name=HEALTH_CHECK_TIMEOUT,min=1"`
+}
+
+// Load loads configuration from environment variables with validation
+func Load(configFile string) (*Config, error) {
+	config := &Config{}
+
+	// Load server configuration
+	serverConfig, err := loadServerConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server config: %w", err)
+	}
+	config.Server = serverConfig
+
+	// Load database configuration
+	databaseConfig, err := loadDatabaseConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load database config: %w", err)
+	}
+	config.Database = databaseConfig
+
+	// Load Redis configuration
+	redisConfig, err := loadRedisConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load redis config: %w", err)
+	}
+	config.Redis = redisConfig
+
+	// Load Kafka configuration
+	kafkaConfig, err := loadKafkaConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kafka config: %w", err)
+	}
+	config.Kafka = kafkaConfig
+
+	// Load service URLs
+	serviceURLs, err := loadServiceURLs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load service URLs: %w", err)
+	}
+	config.Services = serviceURLs
+
+	// Load security configuration
+	securityConfig, err := loadSecurityConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load security config: %w", err)
+	}
+	config.Security = securityConfig
+
+	// Load rate limit configuration
+	rateLimitConfig, err := loadRateLimitConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rate limit config: %w", err)
+	}
+	config.RateLimit = rateLimitConfig
+
+	// Load data source adapter configuration
+	dataSourcesConfig, err := loadDataSourcesConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load data sources config: %w", err)
+	}
+	config.DataSources = dataSourcesConfig
+
+	// Load response cache configuration
+	responseCacheConfig, err := loadResponseCacheConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load response cache config: %w", err)
+	}
+	config.ResponseCache = responseCacheConfig
+
+	// Load gRPC server configuration
+	grpcConfig, err := loadGRPCServerConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load grpc config: %w", err)
+	}
+	config.GRPC = grpcConfig
+
+	// Load gateway configuration
+	gatewayConfig, err := loadGatewayConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load gateway config: %w", err)
+	}
+	config.Gateway = gatewayConfig
+
+	// Load logging configuration
+	loggingConfig, err := loadLoggingConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load logging config: %w", err)
+	}
+	config.Logging = loggingConfig
+
+	// Load metrics configuration
+	metricsConfig, err := loadMetricsConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load metrics config: %w", err)
+	}
+	config.Metrics = metricsConfig
+
+	// Load health check configuration
+	healthConfig, err := loadHealthConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load health config: %w", err)
+	}
+	config.Health = healthConfig
+
+	// Load environment
+	config.Environment = getEnv("ENVIRONMENT", "development")
+
+	// Overlay an optional config file on top of the environment, for the
+	// handful of fields reload.Manager knows how to apply without a
+	// restart (see applyFileOverlay)
+	if err := applyFileOverlay(configFile, config); err != nil {
+		return nil, fmt.Errorf("failed to apply config file: %w", err)
+	}
+
+	// Validate entire configuration
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	return config, nil
+}
+
+// Validate validates the entire configuration, collecting every section's
+// and every cross-component invariant's failure into a single
+// *multierror.Error instead of stopping at the first one, so a misconfigured
+// deployment sees its full list of problems in one pass (e.g. from the
+// "validate" CLI subcommand) rather than fixing and re-running one error at
+// a time.
+func (c *Config) Validate() error {
+	var result *multierror.Error
+
+	sections := []struct {
+		name string
+		fn   func() error
+	}{
+		{"server", c.Server.Validate},
+		{"database", c.Database.Validate},
+		{"redis", c.Redis.Validate},
+		{"kafka", c.Kafka.Validate},
+		{"services", c.Services.Validate},
+		{"security", c.Security.Validate},
+		{"rate limit", c.RateLimit.Validate},
+		{"data sources", c.DataSources.Validate},
+		{"response cache", c.ResponseCache.Validate},
+		{"grpc", c.GRPC.Validate},
+		{"gateway", c.Gateway.Validate},
+		{"logging", c.Logging.Validate},
+		{"metrics", c.Metrics.Validate},
+		{"health", c.Health.Validate},
+	}
+	for _, section := range sections {
+		if err := section.fn(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("%s config validation failed: %w", section.name, err))
+		}
+	}
+
+	// Cross-component validation
+	if c.Server.HTTPPort == c.Server.GRPCPort {
+		result = multierror.Append(result, errors.New("HTTP and gRPC ports cannot be the same"))
+	}
+
+	if c.Metrics.Port == c.Server.HTTPPort || c.Metrics.Port == c.Server.GRPCPort {
+		result = multierror.Append(result, errors.New("METRICS_PORT must not collide with the HTTP or gRPC server port"))
+	}
+
+	if c.Gateway.Enabled && !c.Gateway.MultiplexHTTP {
+		if c.Gateway.Port == c.Server.HTTPPort || c.Gateway.Port == c.Server.GRPCPort || c.Gateway.Port == c.Metrics.Port {
+			result = multierror.Append(result, errors.New("GATEWAY_PORT must not collide with the HTTP, gRPC, or metrics port"))
+		}
+	}
+
+	if c.Environment == "production" {
+		for _, origin := range c.Security.CORSAllowedOrigins {
+			if origin != "*" {
+				continue
+			}
+			if len(c.Security.JWTSecret) < 32 {
+				result = multierror.Append(result, errors.New("CORS_ALLOWED_ORIGINS cannot include \"*\" in production with a short JWT_SECRET"))
+			}
+			if !c.Security.EnableHTTPSRedirect {
+				result = multierror.Append(result, errors.New("CORS_ALLOWED_ORIGINS cannot include \"*\" in production unless ENABLE_HTTPS_REDIRECT is set"))
+			}
+			break
+		}
+
+		if c.Gateway.Enabled {
+			for _, origin := range c.Gateway.AllowedOrigins {
+				if origin != "*" {
+					continue
+				}
+				result = multierror.Append(result, errors.New("GATEWAY_ALLOWED_ORIGINS cannot include \"*\" in production"))
+				break
+			}
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// loadServerConfig loads server configuration from environment variables
+func loadServerConfig() (ServerConfig, error) {
+	config := ServerConfig{
+		HTTPPort:        getEnvInt("SERVER_HTTP_PORT", 8080),
+		GRPCPort:        getEnvInt("SERVER_GRPC_PORT", 8081),
+		Host:            getEnv("SERVER_HOST", "0.0.0.0"),
+		ReadTimeout:     getEnvDuration("SERVER_READ_TIMEOUT", 30*time.Second),
+		WriteTimeout:    getEnvDuration("SERVER_WRITE_TIMEOUT", 30*time.Second),
+		IdleTimeout:     getEnvDuration("SERVER_IDLE_TIMEOUT", 60*time.Second),
+		MaxHeaderBytes:  getEnvInt("SERVER_MAX_HEADER_BYTES", 1<<20),  // 1MB
+		MaxBodyBytes:    getEnvInt64("SERVER_MAX_BODY_BYTES", 10<<20), // 10MB
+		EnableHTTPS:     getEnvBool("SERVER_ENABLE_HTTPS", false),
+		EnableCORS:      getEnvBool("SERVER_ENABLE_CORS", true),
+		EnableMetrics:   getEnvBool("SERVER_ENABLE_METRICS", true),
+		EnablePprof:     getEnvBool("SERVER_ENABLE_PPROF", false),
+		GracefulTimeout: getEnvDuration("SERVER_GRACEFUL_TIMEOUT", 30*time.Second),
+		MaxConnections:  getEnvInt("SERVER_MAX_CONNECTIONS", 10000),
+
+		ListenAddr: getEnv("SERVER_HTTP_LISTEN_ADDR", ""),
+	}
+
+	return config, config.Validate()
+}
+
+// loadDatabaseConfig loads database configuration from environment variables
+func loadDatabaseConfig() (DatabaseConfig, error) {
+	password, err := resolveSecretEnv("DB_PASSWORD")
+	if err != nil {
+		return DatabaseConfig{}, fmt.Errorf("failed to resolve DB_PASSWORD: %w", err)
+	}
+
+	config := DatabaseConfig{
+		Host:           getEnv("DB_HOST", "localhost"),
+		Port:           getEnvInt("DB_PORT", 5432),
+		Database:       getEnv("DB_NAME", "market_intel"),
+		Username:       getEnv("DB_USERNAME", "postgres"),
+		Password:       password,
+		SSLMode:        getEnv("DB_SSL_MODE", "prefer"),
+		MaxConnections: getEnvInt("DB_MAX_CONNECTIONS", 20),
+		MinConnections: getEnvInt("DB_MIN_CONNECTIONS", 5),
+		MaxIdleTime:    getEnvDuration("DB_MAX_IDLE_TIME", 10*time.Minute),
+		MaxLifetime:    getEnvDuration("DB_MAX_LIFETIME", 30*time.Minute),
+		ConnectTimeout: getEnvDuration("DB_CONNECT_TIMEOUT", 30*time.Second),
+		QueryTimeout:   getEnvDuration("DB_QUERY_TIMEOUT", 30*time.Second),
+	}
+
+	return config, config.Validate()
+}
+
+// loadRedisConfig loads Redis configuration from environment variables
+func loadRedisConfig() (RedisConfig, error) {
+	config := RedisConfig{
+		Host:         getEnv("REDIS_HOST", "localhost"),
+		Port:         getEnvInt("REDIS_PORT", 6379),
+		Password:     getEnv("REDIS_PASSWORD", ""),
+		Database:     getEnvInt("REDIS_DATABASE", 0),
+		MaxRetries:   getEnvInt("REDIS_MAX_RETRIES", 3),
+		DialTimeout:  getEnvDuration("REDIS_DIAL_TIMEOUT", 5*time.Second),
+		ReadTimeout:  getEnvDuration("REDIS_READ_TIMEOUT", 3*time.Second),
+		WriteTimeout: getEnvDuration("REDIS_WRITE_TIMEOUT", 3*time.Second),
+		PoolSize:     getEnvInt("REDIS_POOL_SIZE", 10),
+		MinIdleConns: getEnvInt("REDIS_MIN_IDLE_CONNS", 5),
+		MaxConnAge:   getEnvDuration("REDIS_MAX_CONN_AGE", 30*time.Minute),
+	}
+
+	return config, config.Validate()
+}
+
+// loadKafkaConfig loads Kafka configuration from environment variables
+func loadKafkaConfig() (KafkaConfig, error) {
+	brokers := strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ",")
+	for i, broker := range brokers {
+		brokers[i] = strings.TrimSpace(broker)
+	}
+
+	config := KafkaConfig{
+		Brokers:              brokers,
+		ConsumerGroup:        getEnv("KAFKA_CONSUMER_GROUP", "api-gateway"),
+		ClientID:             getEnv("KAFKA_CLIENT_ID", ""),
+		Version:              getEnv("KAFKA_VERSION", ""),
+		TopicPrefix:          getEnv("KAFKA_TOPIC_PREFIX", ""),
+		CompressionType:      getEnv("KAFKA_COMPRESSION_TYPE", "gzip"),
+		BatchSize:            getEnvInt("KAFKA_BATCH_SIZE", 100),
+		BatchTimeout:         getEnvDuration("KAFKA_BATCH_TIMEOUT", 10*time.Millisecond),
+		CompressionLevel:     getEnvInt("KAFKA_COMPRESSION_LEVEL", 6),
+		MaxMessageBytes:      getEnvInt("KAFKA_MAX_MESSAGE_BYTES", 1000000),
+		ConsumerFetchMin:     getEnvInt("KAFKA_CONSUMER_FETCH_MIN", 1),
+		ConsumerFetchDefault: getEnvInt("KAFKA_CONSUMER_FETCH_DEFAULT", 1024),
+		ConsumerFetchMax:     getEnvInt("KAFKA_CONSUMER_FETCH_MAX", 1048576),
+
+		SecurityProtocol: getEnv("KAFKA_SECURITY_PROTOCOL", "plaintext"),
+		SASLMechanism:    getEnv("KAFKA_SASL_MECHANISM", ""),
+		SASLUsername:     getEnv("KAFKA_SASL_USERNAME", ""),
+		SASLPassword:     getEnv("KAFKA_SASL_PASSWORD", ""),
+
+		TLSCAFile:             getEnv("KAFKA_TLS_CA_FILE", ""),
+		TLSCertFile:           getEnv("KAFKA_TLS_CERT_FILE", ""),
+		TLSKeyFile:            getEnv("KAFKA_TLS_KEY_FILE", ""),
+		TLSInsecureSkipVerify: getEnvBool("KAFKA_TLS_INSECURE_SKIP_VERIFY", false),
+
+		EnableIdempotence:                getEnvBool("KAFKA_ENABLE_IDEMPOTENCE", true),
+		Acks:                             getEnv("KAFKA_ACKS", "all"),
+		MaxInFlightRequestsPerConnection: getEnvInt("KAFKA_MAX_IN_FLIGHT_REQUESTS_PER_CONNECTION", 5),
+		RebalanceStrategy:                getEnv("KAFKA_REBALANCE_STRATEGY", "sticky"),
+		IsolationLevel:                   getEnv("KAFKA_ISOLATION_LEVEL", "read_committed"),
+		SessionTimeout:                   getEnvDuration("KAFKA_SESSION_TIMEOUT", 10*time.Second),
+		HeartbeatInterval:                getEnvDuration("KAFKA_HEARTBEAT_INTERVAL", 3*time.Second),
+	}
+
+	return config, config.Validate()
+}
+
+// loadServiceURLs loads service URLs from environment variables
+func loadServiceURLs() (ServiceURLs, error) {
+	config := ServiceURLs{
+		CoreEngine:       loadServiceEndpoint("CORE_ENGINE", "localhost:50052"),
+		CoreEngineStream: getEnv("CORE_ENGINE_STREAM_URL", "ws://localhost:50052/stream/market-data"),
+		AuthService:      loadServiceEndpoint("AUTH_SERVICE", "localhost:50051"),
+		Analytics:        loadServiceEndpoint("ANALYTICS", "localhost:50053"),
+		VectorStore:      loadServiceEndpoint("VECTOR_STORE", "localhost:50054"),
+	}
+
+	return config, config.Validate()
+}
+
+// loadServiceEndpoint reads a ServiceEndpoint from the <prefix>_URL,
+// <prefix>_TLS_ENABLED, <prefix>_MTLS_ENABLED and <prefix>_LOAD_BALANCING
+// environment variables, e.g. prefix "CORE_ENGINE" reads CORE_ENGINE_URL
+// etc. Defaulting mTLS off and load balancing to round_robin matches the
+// pre-ServiceEndpoint behavior, where every dependency was dialed as a
+// single plaintext-or-TLS target with no client-side balancing policy.
+func loadServiceEndpoint(prefix, defaultTarget string) ServiceEndpoint {
+	return ServiceEndpoint{
+		Target:        getEnv(prefix+"_URL", defaultTarget),
+		TLSEnabled:    getEnvBool(prefix+"_TLS_ENABLED", false),
+		MTLSEnabled:   getEnvBool(prefix+"_MTLS_ENABLED", false),
+		LoadBalancing: getEnv(prefix+"_LOAD_BALANCING", "round_robin"),
+	}
+}
+
+// loadSecurityConfig loads security configuration from environment variables
+func loadSecurityConfig() (SecurityConfig, error) {
+	jwtSecret, err := resolveSecretEnv("JWT_SECRET")
+	if err != nil {
+		return SecurityConfig{}, fmt.Errorf("failed to resolve JWT_SECRET: %w", err)
+	}
+
+	config := SecurityConfig{
+		JWTSecret:           jwtSecret,
+		JWTExpiration:       getEnvDuration("JWT_EXPIRATION", 24*time.Hour),
+		RefreshExpiration:   getEnvDuration("REFRESH_TOKEN_EXPIRATION", 7*24*time.Hour),
+		BcryptCost:          getEnvInt("BCRYPT_COST", 12),
+		RateLimitEnabled:    getEnvBool("RATE_LIMIT_ENABLED", true),
+		RateLimitRPS:        getEnvInt("RATE_LIMIT_RPS", 100),
+		RateLimitBurst:      getEnvInt("RATE_LIMIT_BURST", 200),
+		CORSAllowedOrigins:  strings.Split(getEnv("CORS_ALLOWED_ORIGINS", "*"), ","),
+		CORSAllowedMethods:  strings.Split(getEnv("CORS_ALLOWED_METHODS", "GET,POST,PUT,DELETE,OPTIONS"), ","),
+		CORSAllowedHeaders:  strings.Split(getEnv("CORS_ALLOWED_HEADERS", "Content-Type,Authorization"), ","),
+		EnableHTTPSRedirect: getEnvBool("ENABLE_HTTPS_REDIRECT", false),
+		TrustedProxies:      strings.Split(getEnv("TRUSTED_PROXIES", ""), ","),
+	}
+
+	return config, config.Validate()
+}
+
+// loadRateLimitConfig loads rate limiter configuration from environment
+// variables
+func loadRateLimitConfig() (RateLimitConfig, error) {
+	routes, err := parseRouteLimits(getEnv("RATE_LIMIT_ROUTES", ""))
+	if err != nil {
+		return RateLimitConfig{}, err
+	}
+
+	config := RateLimitConfig{
+		Backend:          getEnv("RATE_LIMIT_BACKEND", "memory"),
+		PerAPIKeyRPS:     getEnvInt("RATE_LIMIT_PER_API_KEY_RPS", 0),
+		PerAPIKeyBurst:   getEnvInt("RATE_LIMIT_PER_API_KEY_BURST", 0),
+		Routes:           routes,
+		ExemptUserAgents: splitCSV(getEnv("RATE_LIMIT_EXEMPT_USER_AGENTS", "")),
+		ExemptOrigins:    splitCSV(getEnv("RATE_LIMIT_EXEMPT_ORIGINS", "")),
+		ExemptCIDRs:      splitCSV(getEnv("RATE_LIMIT_EXEMPT_CIDRS", "")),
+	}
+
+	return config, config.Validate()
+}
+
+// parseRouteLimits parses RATE_LIMIT_ROUTES, a comma-separated list of
+// "path:rps:burst" triples, into the per-route override map RateLimitConfig
+// keys on the route's Gin c.FullPath().
+func parseRouteLimits(raw string) (map[string]RouteLimit, error) {
+	routes := make(map[string]RouteLimit)
+
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return routes, nil
+	}
+
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 {
+			return nil, &ConfigError{Key: "RATE_LIMIT_ROUTES", Value: entry, Message: "must be in format path:rps:burst"}
+		}
+
+		rps, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, &ConfigError{Key: "RATE_LIMIT_ROUTES", Value: entry, Message: "rps must be an integer"}
+		}
+		burst, err := strconv.Atoi(parts[2])
+		if err != nil {
+			return nil, &ConfigError{Key: "RATE_LIMIT_ROUTES", Value: entry, Message: "burst must be an integer"}
+		}
+
+		routes[parts[0]] = RouteLimit{RPS: rps, Burst: burst}
+	}
+
+	return routes, nil
+}
+
+// splitCSV splits raw on commas, trimming whitespace and dropping empty
+// entries, and returns nil for a blank raw -- unlike the bare
+// strings.Split(getEnv(...), ",") used for CORSAllowedOrigins and
+// TrustedProxies, it never yields a slice holding one empty string.
+func splitCSV(raw string) []string {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+
+	parts := strings.Split(raw, ",")
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// loadDataSourcesConfig loads data source adapter configuration from
+// environment variables
+func loadDataSourcesConfig() (DataSourcesConfig, error) {
+	config := DataSourcesConfig{
+		Enabled:            getEnvBool("DATA_SOURCES_ENABLED", true),
+		CredentialProvider: getEnv("DATA_SOURCES_CREDENTIAL_PROVIDER", "env"),
+		CredentialFilePath: getEnv("DATA_SOURCES_CREDENTIAL_FILE_PATH", ""),
+		VaultAddr:          getEnv("DATA_SOURCES_VAULT_ADDR", ""),
+		VaultToken:         getEnv("DATA_SOURCES_VAULT_TOKEN", ""),
+		VaultMountPath:     getEnv("DATA_SOURCES_VAULT_MOUNT_PATH", "secret"),
+	}
+
+	return config, config.Validate()
+}
+
+// loadResponseCacheConfig loads response cache configuration from
+// environment variables
+func loadResponseCacheConfig() (ResponseCacheConfig, error) {
+	config := ResponseCacheConfig{
+		Enabled:       getEnvBool("RESPONSE_CACHE_ENABLED", true),
+		Backend:       getEnv("RESPONSE_CACHE_BACKEND", "memory"),
+		MaxEntries:    getEnvInt("RESPONSE_CACHE_MAX_ENTRIES", 1000),
+		MarketDataTTL: getEnvDuration("RESPONSE_CACHE_MARKET_DATA_TTL", 2*time.Second),
+		NewsTTL:       getEnvDuration("RESPONSE_CACHE_NEWS_TTL", 60*time.Second),
+	}
+
+	return config, config.Validate()
+}
+
+// loadHealthConfig loads health check configuration from environment
+// variables
+func loadHealthConfig() (HealthConfig, error) {
+	config := HealthConfig{
+		CacheFor:     getEnvDuration("HEALTH_CACHE_FOR", 5*time.Second),
+		CheckTimeout: getEnvDuration("HEALTH_CHECK_TIMEOUT", 3*time.Second),
+	}
+
+	return config, config.Validate()
+}
+
+// loadGRPCServerConfig loads gRPC server configuration from environment
+// variables
+func loadGRPCServerConfig() (GRPCServerConfig, error) {
+	config := GRPCServerConfig{
+		TLSCertFile: getEnv("GRPC_TLS_CERT_FILE", ""),
+		TLSKeyFile:  getEnv("GRPC_TLS_KEY_FILE", ""),
+
+		KeepaliveTime:                  getEnvDuration("GRPC_KEEPALIVE_TIME", 2*time.Minute),
+		KeepaliveTimeout:               getEnvDuration("GRPC_KEEPALIVE_TIMEOUT", 20*time.Second),
+		MaxConnectionIdle:              getEnvDuration("GRPC_MAX_CONNECTION_IDLE", 5*time.Minute),
+		MaxConnectionAge:               getEnvDuration("GRPC_MAX_CONNECTION_AGE", 30*time.Minute),
+		MaxConnectionAgeGrace:          getEnvDuration("GRPC_MAX_CONNECTION_AGE_GRACE", 10*time.Second),
+		EnforcementMinTime:             getEnvDuration("GRPC_ENFORCEMENT_MIN_TIME", time.Minute),
+		EnforcementPermitWithoutStream: getEnvBool("GRPC_ENFORCEMENT_PERMIT_WITHOUT_STREAM", true),
+
+		DrainTimeout:          getEnvDuration("GRPC_DRAIN_TIMEOUT", 30*time.Second),
+		MaxConcurrentRequests: getEnvInt64("GRPC_MAX_CONCURRENT_REQUESTS", 500),
+		EnableReflection:      getEnvBool("GRPC_ENABLE_REFLECTION", true),
+
+		ListenAddr: getEnv("SERVER_GRPC_LISTEN_ADDR", ""),
+	}
+
+	return config, config.Validate()
+}
+
+// loadGatewayConfig loads the gRPC-Web/HTTP-JSON gateway configuration from
+// environment variables
+func loadGatewayConfig() (GatewayConfig, error) {
+	config := GatewayConfig{
+		Enabled:       getEnvBool("GATEWAY_ENABLED", false),
+		Port:          getEnvInt("GATEWAY_PORT", 8082),
+		ListenAddr:    getEnv("GATEWAY_LISTEN_ADDR", ""),
+		MultiplexHTTP: getEnvBool("GATEWAY_MULTIPLEX_HTTP", false),
+
+		AllowedOrigins:  strings.Split(getEnv("GATEWAY_ALLOWED_ORIGINS", "*"), ","),
+		MethodAllowlist: splitCSV(getEnv("GATEWAY_METHOD_ALLOWLIST", "")),
+
+		EnableWebSocketStreaming: getEnvBool("GATEWAY_ENABLE_WEBSOCKET_STREAMING", true),
+		DrainTimeout:             getEnvDuration("GATEWAY_DRAIN_TIMEOUT", 30*time.Second),
+	}
+
+	return config, config.Validate()
+}
+
+// loadLoggingConfig loads logging configuration from environment variables
+func loadLoggingConfig() (LoggingConfig, error) {
+	config := LoggingConfig{
+		Level:         getEnv("LOG_LEVEL", "info"),
+		Format:        getEnv("LOG_FORMAT", "json"),
+		Output:        getEnv("LOG_OUTPUT", "stdout"),
+		EnableConsole: getEnvBool("LOG_ENABLE_CONSOLE", true),
+		EnableFile:    getEnvBool("LOG_ENABLE_FILE", false),
+		FilePath:      getEnv("LOG_FILE_PATH", "/var/log/api-gateway.log"),
+		MaxSize:       getEnvInt("LOG_MAX_SIZE", 100),
+		MaxBackups:    getEnvInt("LOG_MAX_BACKUPS", 3),
+		MaxAge:        getEnvInt("LOG_MAX_AGE", 28),
+		Compress:      getEnvBool("LOG_COMPRESS", true),
+	}
+
+	return config, config.Validate()
+}
+
+// loadMetricsConfig loads metrics configuration from environment variables
+func loadMetricsConfig() (MetricsConfig, error) {
+	config := MetricsConfig{
+		Enabled:   getEnvBool("METRICS_ENABLED", true),
+		Path:      getEnv("METRICS_PATH", "/metrics"),
+		Port:      getEnvInt("METRICS_PORT", 9090),
+		Namespace: getEnv("METRICS_NAMESPACE", "market_intel"),
+		Subsystem: getEnv("METRICS_SUBSYSTEM", "api_gateway"),
+	}
+
+	return config, config.Validate()
+}
+
+// Helper functions for environment variable parsing with validation
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvRequired(key string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	panic(fmt.Sprintf("required environment variable %s is not set", key))
+}
+
+var (
+	secretResolverOnce sync.Once
+	secretResolver     *secrets.Resolver
+)
+
+// resolveSecretEnv reads key as a required environment variable (panicking
+// if unset, like getEnvRequired) and, if its value is a secret reference
+// such as "vault://secret/data/db#password", resolves it through
+// secretResolver. A plain literal value passes through unchanged, so
+// existing deployments that set JWT_SECRET/DB_PASSWORD directly keep
+// working. The resolver is shared process-wide so its cache and any Vault
+// lease-renewal loops survive across repeated Load calls on hot-reload.
+func resolveSecretEnv(key string) (string, error) {
+	secretResolverOnce.Do(func() {
+		secretResolver = secrets.NewResolver(os.Getenv("VAULT_ADDR"), os.Getenv("VAULT_TOKEN"))
+	})
+
+	raw := getEnvRequired(key)
+	value, err := secretResolver.Resolve(context.Background(), raw)
+	if err != nil {
+		return "", fmt.Errorf("resolving %s: %w", key, err)
+	}
+	return value, nil
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if intValue, err := strconv.Atoi(value); err == nil {
+			return intValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvInt64(key string, defaultValue int64) int64 {
+	if value := os.Getenv(key); value != "" {
+		if intValue, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return intValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if duration, err := time.ParseDuration(value); err == nil {
+			return duration
+		}
+	}
+	return defaultValue
+}
+
+// GetHTTPPort returns the HTTP port as a string
+func (c *Config) GetHTTPPort() string {
+	return fmt.Sprintf(":%d", c.Server.HTTPPort)
+}
+
+// GetGRPCPort returns the gRPC port as a string
+func (c *Config) GetGRPCPort() string {
+	return fmt.Sprintf(":%d", c.Server.GRPCPort)
+}
+
+// GetGatewayPort returns the gateway's own port as a string. It's unused
+// when Gateway.MultiplexHTTP is true, since the gateway shares the HTTP
+// server's port instead.
+func (c *Config) GetGatewayPort() string {
+	return fmt.Sprintf(":%d", c.Gateway.Port)
+}
+
+// GetDatabaseURL returns the database connection URL
+func (c *Config) GetDatabaseURL() string {
+	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s",
+		c.Database.Username,
+		c.Database.Password,
+		c.Database.Host,
+		c.Database.Port,
+		c.Database.Database,
+		c.Database.SSLMode,
+	)
+}
+
+// GetRedisURL returns the Redis connection URL
+func (c *Config) GetRedisURL() string {
+	if c.Redis.Password != "" {
+		return fmt.Sprintf("redis://%s@%s:%d/%d",
+			c.Redis.Password,
+			c.Redis.Host,
+			c.Redis.Port,
+			c.Redis.Database,
+		)
+	}
+	return fmt.Sprintf("redis://%s:%d/%d",
+		c.Redis.Host,
+		c.Redis.Port,
+		c.Redis.Database,
+	)
+}
+
+// Redacted returns a shallow copy of c with every credential field replaced
+// by "***" (the same masking convention ConfigError uses for secret tag
+// values), safe to log or print in full - e.g. from the "validate" CLI
+// subcommand - without leaking what it validated.
+func (c *Config) Redacted() *Config {
+	redacted := *c
+	redacted.Database.Password = redactSecret(c.Database.Password)
+	redacted.Redis.Password = redactSecret(c.Redis.Password)
+	redacted.Kafka.SASLPassword = redactSecret(c.Kafka.SASLPassword)
+	redacted.Security.JWTSecret = redactSecret(c.Security.JWTSecret)
+	redacted.DataSources.VaultToken = redactSecret(c.DataSources.VaultToken)
+	return &redacted
+}
+
+func redactSecret(value string) string {
+	if value == "" {
+		return ""
+	}
+	return "***"
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v2"
+)
+
+// fileOverlay is the subset of Config that an optional YAML file passed
+// via -config may override on top of the environment. It's deliberately
+// limited to Logging.Level and Security's rate limiter and CORS fields
+// rather than mirroring Config in full. Logging.Level
+// (reload.LoggingLevelSubscriber) and the rate limiter fields
+// (middleware.RateLimit) are applied by reload.Manager without a restart;
+// the CORS fields are overlaid onto Config, but no Subscriber applies them
+// to the running HTTP server.
+type fileOverlay struct {
+	Logging *struct {
+		Level string `yaml:"level"`
+	} `yaml:"logging"`
+	Security *struct {
+		RateLimitEnabled   *bool    `yaml:"rate_limit_enabled"`
+		RateLimitRPS       *int     `yaml:"rate_limit_rps"`
+		RateLimitBurst     *int     `yaml:"rate_limit_burst"`
+		CORSAllowedOrigins []string `yaml:"cors_allowed_origins"`
+		CORSAllowedMethods []string `yaml:"cors_allowed_methods"`
+		CORSAllowedHeaders []string `yaml:"cors_allowed_headers"`
+	} `yaml:"security"`
+}
+
+// applyFileOverlay reads path as YAML and overlays onto cfg whichever
+// fileOverlay fields it sets, leaving everything the file omits at its
+// environment-derived value. path == "" is not an error - the file is
+// optional - and neither is a path that doesn't exist, so a deployment can
+// point -config at a file that only shows up once an operator drops it in
+// to override defaults.
+func applyFileOverlay(path string, cfg *Config) error {
+	if path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var overlay fileOverlay
+	if err := yaml.Unmarshal(data, &overlay); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	if overlay.Logging != nil && overlay.Logging.Level != "" {
+		cfg.Logging.Level = overlay.Logging.Level
+	}
+	if s := overlay.Security; s != nil {
+		if s.RateLimitEnabled != nil {
+			cfg.Security.RateLimitEnabled = *s.RateLimitEnabled
+		}
+		if s.RateLimitRPS != nil {
+			cfg.Security.RateLimitRPS = *s.RateLimitRPS
+		}
+		if s.RateLimitBurst != nil {
+			cfg.Security.RateLimitBurst = *s.RateLimitBurst
+		}
+		if s.CORSAllowedOrigins != nil {
+			cfg.Security.CORSAllowedOrigins = s.CORSAllowedOrigins
+		}
+		if s.CORSAllowedMethods != nil {
+			cfg.Security.CORSAllowedMethods = s.CORSAllowedMethods
+		}
+		if s.CORSAllowedHeaders != nil {
+			cfg.Security.CORSAllowedHeaders = s.CORSAllowedHeaders
+		}
+	}
+
+	return nil
+}
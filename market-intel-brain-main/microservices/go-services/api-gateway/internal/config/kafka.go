@@ -0,0 +1,270 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/IBM/sarama"
+)
+
+// SaramaConfig materializes a *sarama.Config from the validated
+// KafkaConfig, so ingestion services stop constructing one ad hoc and
+// instead share a single source of truth for broker security, batching,
+// and consumer group behavior. Callers should run Validate() first;
+// SaramaConfig does not re-check consistency rules.
+func (k *KafkaConfig) SaramaConfig() (*sarama.Config, error) {
+	cfg := sarama.NewConfig()
+
+	cfg.ClientID = k.ConsumerGroup
+	if k.ClientID != "" {
+		cfg.ClientID = k.ClientID
+	}
+	if k.Version != "" {
+		version, err := sarama.ParseKafkaVersion(k.Version)
+		if err != nil {
+			return nil, fmt.Errorf("kafka: invalid version %q: %w", k.Version, err)
+		}
+		cfg.Version = version
+	}
+	cfg.Metadata.Full = true
+
+	cfg.Producer.Return.Successes = true
+	cfg.Producer.Return.Errors = true
+	cfg.Producer.MaxMessageBytes = k.MaxMessageBytes
+	cfg.Producer.Idempotent = k.EnableIdempotence
+	cfg.Net.MaxOpenRequests = k.MaxInFlightRequestsPerConnection
+
+	compression, err := kafkaCompressionCodec(k.CompressionType)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Producer.Compression = compression
+	cfg.Producer.CompressionLevel = k.CompressionLevel
+
+	acks, err := kafkaRequiredAcks(k.Acks)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Producer.RequiredAcks = acks
+
+	cfg.Consumer.Fetch.Min = int32(k.ConsumerFetchMin)
+	cfg.Consumer.Fetch.Default = int32(k.ConsumerFetchDefault)
+	cfg.Consumer.Fetch.Max = int32(k.ConsumerFetchMax)
+	cfg.Consumer.Group.Session.Timeout = k.SessionTimeout
+	cfg.Consumer.Group.Heartbeat.Interval = k.HeartbeatInterval
+
+	isolationLevel, err := kafkaIsolationLevel(k.IsolationLevel)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Consumer.IsolationLevel = isolationLevel
+
+	strategy, err := kafkaRebalanceStrategy(k.RebalanceStrategy)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Consumer.Group.Rebalance.GroupStrategies = []sarama.BalanceStrategy{strategy}
+
+	if err := k.configureSecurity(cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// configureSecurity wires SecurityProtocol/SASLMechanism/TLS material into
+// cfg's Net.SASL and Net.TLS settings.
+func (k *KafkaConfig) configureSecurity(cfg *sarama.Config) error {
+	switch k.SecurityProtocol {
+	case "plaintext":
+		return nil
+	case "ssl":
+		tlsConfig, err := k.tlsConfig()
+		if err != nil {
+			return err
+		}
+		cfg.Net.TLS.Enable = true
+		cfg.Net.TLS.Config = tlsConfig
+		return nil
+	case "sasl_plaintext", "sasl_ssl":
+		cfg.Net.SASL.Enable = true
+		cfg.Net.SASL.User = k.SASLUsername
+		cfg.Net.SASL.Password = k.SASLPassword
+
+		mechanism, err := kafkaSASLMechanism(k.SASLMechanism)
+		if err != nil {
+			return err
+		}
+		cfg.Net.SASL.Mechanism = mechanism
+
+		if mechanism == sarama.SASLTypeOAuth {
+			cfg.Net.SASL.TokenProvider = &oauthTokenProvider{
+				tokenURL: k.SASLTokenURL,
+				username: k.SASLUsername,
+				password: k.SASLPassword,
+			}
+		}
+
+		if k.SecurityProtocol == "sasl_ssl" {
+			tlsConfig, err := k.tlsConfig()
+			if err != nil {
+				return err
+			}
+			cfg.Net.TLS.Enable = true
+			cfg.Net.TLS.Config = tlsConfig
+		}
+		return nil
+	default:
+		return fmt.Errorf("kafka: unsupported security protocol %q", k.SecurityProtocol)
+	}
+}
+
+// tlsConfig builds a *tls.Config from the configured CA/cert/key files.
+func (k *KafkaConfig) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: k.TLSInsecureSkipVerify}
+
+	if k.TLSCAFile != "" {
+		caCert, err := os.ReadFile(k.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("kafka: failed to read TLS CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("kafka: failed to parse TLS CA file %s", k.TLSCAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if k.TLSCertFile != "" && k.TLSKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(k.TLSCertFile, k.TLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("kafka: failed to load TLS client cert/key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+func kafkaCompressionCodec(compressionType string) (sarama.CompressionCodec, error) {
+	switch compressionType {
+	case "none":
+		return sarama.CompressionNone, nil
+	case "gzip":
+		return sarama.CompressionGZIP, nil
+	case "snappy":
+		return sarama.CompressionSnappy, nil
+	case "lz4":
+		return sarama.CompressionLZ4, nil
+	case "zstd":
+		return sarama.CompressionZSTD, nil
+	default:
+		return 0, fmt.Errorf("kafka: unsupported compression type %q", compressionType)
+	}
+}
+
+func kafkaRequiredAcks(acks string) (sarama.RequiredAcks, error) {
+	switch acks {
+	case "0":
+		return sarama.NoResponse, nil
+	case "1":
+		return sarama.WaitForLocal, nil
+	case "all":
+		return sarama.WaitForAll, nil
+	default:
+		return 0, fmt.Errorf("kafka: unsupported acks value %q", acks)
+	}
+}
+
+func kafkaIsolationLevel(isolationLevel string) (sarama.IsolationLevel, error) {
+	switch isolationLevel {
+	case "read_committed":
+		return sarama.ReadCommitted, nil
+	case "read_uncommitted":
+		return sarama.ReadUncommitted, nil
+	default:
+		return 0, fmt.Errorf("kafka: unsupported isolation level %q", isolationLevel)
+	}
+}
+
+func kafkaRebalanceStrategy(rebalanceStrategy string) (sarama.BalanceStrategy, error) {
+	switch rebalanceStrategy {
+	case "range":
+		return sarama.NewBalanceStrategyRange(), nil
+	case "roundrobin":
+		return sarama.NewBalanceStrategyRoundRobin(), nil
+	case "sticky":
+		return sarama.NewBalanceStrategySticky(), nil
+	default:
+		return nil, fmt.Errorf("kafka: unsupported rebalance strategy %q", rebalanceStrategy)
+	}
+}
+
+func kafkaSASLMechanism(mechanism string) (sarama.SASLMechanism, error) {
+	switch mechanism {
+	case "PLAIN":
+		return sarama.SASLTypePlaintext, nil
+	case "SCRAM-SHA-256":
+		return sarama.SASLTypeSCRAMSHA256, nil
+	case "SCRAM-SHA-512":
+		return sarama.SASLTypeSCRAMSHA512, nil
+	case "OAUTHBEARER":
+		return sarama.SASLTypeOAuth, nil
+	case "GSSAPI":
+		return sarama.SASLTypeGSSAPI, nil
+	default:
+		return "", fmt.Errorf("kafka: unsupported SASL mechanism %q", mechanism)
+	}
+}
+
+// oauthTokenProvider implements sarama.AccessTokenProvider for the
+// OAUTHBEARER mechanism, fetching a token via the OAuth2 client
+// credentials grant from tokenURL on every call. Sarama re-invokes Token
+// on each new connection, so there is no caching here beyond whatever the
+// token endpoint itself does.
+type oauthTokenProvider struct {
+	tokenURL string
+	username string
+	password string
+}
+
+// Token implements sarama.AccessTokenProvider.
+func (p *oauthTokenProvider) Token() (*sarama.AccessToken, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	req, err := http.NewRequest(http.MethodPost, p.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("kafka: failed to build OAuth token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if p.username != "" {
+		req.SetBasicAuth(p.username, p.password)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("kafka: failed to fetch OAuth token from %s: %w", p.tokenURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("kafka: OAuth token endpoint %s returned status %d", p.tokenURL, resp.StatusCode)
+	}
+
+	var payload struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("kafka: failed to decode OAuth token response: %w", err)
+	}
+	if payload.AccessToken == "" {
+		return nil, fmt.Errorf("kafka: OAuth token endpoint %s did not return an access_token", p.tokenURL)
+	}
+
+	return &sarama.AccessToken{Token: payload.AccessToken}, nil
+}
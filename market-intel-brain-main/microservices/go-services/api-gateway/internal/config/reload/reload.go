@@ -0,0 +1,188 @@
+// Package reload implements config hot-reload for the API Gateway: a
+// Manager holds the active *config.Config and applies candidate configs
+// produced by SIGHUP or the /admin/reload HTTP endpoint. A candidate is
+// validated, diffed field-by-field against the active config, and rejected
+// outright if any changed field is tagged `reload:"restart"` (ports, DB
+// DSN components, and other values baked into a connection or listener at
+// startup). Otherwise every registered Subscriber gets a chance to apply
+// the change to the live component it owns; if any Subscriber rejects it,
+// the Subscribers applied so far are rolled back by re-invoking Apply with
+// old and new swapped, and the active config is left untouched.
+package reload
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/market-intel/api-gateway/internal/config"
+	"github.com/market-intel/api-gateway/pkg/logger"
+)
+
+// Subscriber is notified of a config reload before it takes effect and can
+// veto it by returning an error. Apply must be safe to call a second time
+// with old and new swapped, since a later Subscriber's rejection rolls
+// back every Subscriber that already applied the change.
+type Subscriber interface {
+	Name() string
+	Apply(old, new *config.Config) error
+}
+
+// RestartRequiredError is returned by Reload when the candidate config
+// changes one or more fields tagged `reload:"restart"`, which cannot take
+// effect without restarting the process.
+type RestartRequiredError struct {
+	Fields []string
+}
+
+func (e *RestartRequiredError) Error() string {
+	return fmt.Sprintf("config reload requires a process restart to apply: %s", strings.Join(e.Fields, ", "))
+}
+
+// Result summarizes a successfully applied reload.
+type Result struct {
+	// Changed lists every "Section.Field" path whose value differed
+	// between the old and new config.
+	Changed []string
+}
+
+// Manager holds the active *config.Config and mediates reloads against it.
+type Manager struct {
+	current atomic.Pointer[config.Config]
+
+	mu          sync.Mutex
+	subscribers []Subscriber
+}
+
+// NewManager builds a Manager whose active config is initial.
+func NewManager(initial *config.Config) *Manager {
+	m := &Manager{}
+	m.current.Store(initial)
+	return m
+}
+
+// Current returns the active config.
+func (m *Manager) Current() *config.Config {
+	return m.current.Load()
+}
+
+// Subscribe registers s to be notified of every future reload. Subscribers
+// are applied in registration order and rolled back in reverse order.
+func (m *Manager) Subscribe(s Subscriber) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.subscribers = append(m.subscribers, s)
+}
+
+// OnChange registers fn as a Subscriber named name, for callers that don't
+// need a dedicated type - it behaves exactly like Subscribe(s) with s.Name()
+// returning name and s.Apply(old, new) calling fn, rollback included.
+func (m *Manager) OnChange(name string, fn func(old, new *config.Config) error) {
+	m.Subscribe(&funcSubscriber{name: name, fn: fn})
+}
+
+// funcSubscriber adapts a plain func(old, new *config.Config) error to the
+// Subscriber interface, for OnChange.
+type funcSubscriber struct {
+	name string
+	fn   func(old, new *config.Config) error
+}
+
+func (s *funcSubscriber) Name() string { return s.name }
+
+func (s *funcSubscriber) Apply(old, new *config.Config) error { return s.fn(old, new) }
+
+// Reload loads a candidate config via loadFn, validates it, and - if
+// nothing tagged reload:"restart" changed - applies it: every Subscriber's
+// Apply is called in turn, and only once all of them succeed is the
+// candidate swapped in as the active config. If a Subscriber rejects the
+// change, Subscribers that already applied it are rolled back and the
+// active config is left unchanged.
+func (m *Manager) Reload(loadFn func() (*config.Config, error)) (*Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	candidate, err := loadFn()
+	if err != nil {
+		return nil, fmt.Errorf("reload: failed to load candidate config: %w", err)
+	}
+
+	if err := candidate.Validate(); err != nil {
+		return nil, fmt.Errorf("reload: candidate config failed validation: %w", err)
+	}
+
+	old := m.current.Load()
+	changed, restartFields := diff(old, candidate)
+	if len(restartFields) > 0 {
+		return nil, &RestartRequiredError{Fields: restartFields}
+	}
+	if len(changed) == 0 {
+		return &Result{}, nil
+	}
+
+	applied := make([]Subscriber, 0, len(m.subscribers))
+	for _, sub := range m.subscribers {
+		if err := sub.Apply(old, candidate); err != nil {
+			rollback(applied, candidate, old)
+			return nil, fmt.Errorf("reload: subscriber %s rejected config reload: %w", sub.Name(), err)
+		}
+		applied = append(applied, sub)
+	}
+
+	m.current.Store(candidate)
+	logger.Infof("config reload applied, %d field(s) changed: %s", len(changed), strings.Join(changed, ", "))
+
+	return &Result{Changed: changed}, nil
+}
+
+// rollback unwinds the Subscribers in applied, most-recently-applied
+// first, by calling Apply with old and new swapped. A rollback failure is
+// logged rather than returned, since the caller is already propagating the
+// original error that triggered the rollback.
+func rollback(applied []Subscriber, new, old *config.Config) {
+	for i := len(applied) - 1; i >= 0; i-- {
+		if err := applied[i].Apply(new, old); err != nil {
+			logger.Errorf("reload: rollback of subscriber %s failed, live state may be inconsistent: %v", applied[i].Name(), err)
+		}
+	}
+}
+
+// diff walks old and new's nested section structs (e.g. ServerConfig,
+// KafkaConfig) field by field, returning every field whose value differs
+// as "Section.Field", plus the subset tagged reload:"restart".
+func diff(old, new *config.Config) (changed, restartFields []string) {
+	ov := reflect.ValueOf(old).Elem()
+	nv := reflect.ValueOf(new).Elem()
+	t := ov.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		sectionName := t.Field(i).Name
+		oSection := ov.Field(i)
+		nSection := nv.Field(i)
+
+		if oSection.Kind() != reflect.Struct {
+			if !reflect.DeepEqual(oSection.Interface(), nSection.Interface()) {
+				changed = append(changed, sectionName)
+			}
+			continue
+		}
+
+		sectionType := oSection.Type()
+		for j := 0; j < sectionType.NumField(); j++ {
+			if reflect.DeepEqual(oSection.Field(j).Interface(), nSection.Field(j).Interface()) {
+				continue
+			}
+
+			field := sectionType.Field(j)
+			path := sectionName + "." + field.Name
+			changed = append(changed, path)
+			if field.Tag.Get("reload") == "restart" {
+				restartFields = append(restartFields, path)
+			}
+		}
+	}
+
+	return changed, restartFields
+}
@@ -0,0 +1,266 @@
+package reload
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/market-intel/api-gateway/internal/config"
+)
+
+// baseConfig returns a config that passes config.Config.Validate() in
+// full, modeled on the defaults config.Load() would produce from an empty
+// environment (see config.go's load*Config functions) so Manager.Reload's
+// pre-diff validation doesn't reject every candidate built from it. It is
+// not identical to them: the server read/write timeouts are 15s rather
+// than 30s, and Metrics.Port is 9100 rather than 9090.
+func baseConfig() *config.Config {
+	return &config.Config{
+		Server: config.ServerConfig{
+			HTTPPort:        8080,
+			GRPCPort:        8081,
+			Host:            "0.0.0.0",
+			ReadTimeout:     15 * time.Second,
+			WriteTimeout:    15 * time.Second,
+			IdleTimeout:     60 * time.Second,
+			MaxHeaderBytes:  1 << 20,
+			MaxBodyBytes:    10 << 20,
+			GracefulTimeout: 30 * time.Second,
+			MaxConnections:  10000,
+		},
+		Database: config.DatabaseConfig{
+			Host:           "localhost",
+			Port:           5432,
+			Database:       "market_intel",
+			Username:       "postgres",
+			Password:       "test-password",
+			SSLMode:        "prefer",
+			MaxConnections: 20,
+			MinConnections: 5,
+			MaxIdleTime:    10 * time.Minute,
+			MaxLifetime:    30 * time.Minute,
+			ConnectTimeout: 30 * time.Second,
+			QueryTimeout:   30 * time.Second,
+		},
+		Redis: config.RedisConfig{
+			Host:         "localhost",
+			Port:         6379,
+			MaxRetries:   3,
+			DialTimeout:  5 * time.Second,
+			ReadTimeout:  3 * time.Second,
+			WriteTimeout: 3 * time.Second,
+			PoolSize:     10,
+			MinIdleConns: 5,
+			MaxConnAge:   30 * time.Minute,
+		},
+		Kafka: config.KafkaConfig{
+			Brokers:                          []string{"localhost:9092"},
+			ConsumerGroup:                    "api-gateway",
+			CompressionType:                  "gzip",
+			BatchSize:                        100,
+			CompressionLevel:                 6,
+			MaxMessageBytes:                  1000000,
+			ConsumerFetchMin:                 1,
+			ConsumerFetchDefault:             1024,
+			ConsumerFetchMax:                 1048576,
+			SecurityProtocol:                 "plaintext",
+			EnableIdempotence:                true,
+			Acks:                             "all",
+			MaxInFlightRequestsPerConnection: 5,
+			RebalanceStrategy:                "sticky",
+			IsolationLevel:                   "read_committed",
+			SessionTimeout:                   10 * time.Second,
+			HeartbeatInterval:                3 * time.Second,
+		},
+		Services: config.ServiceURLs{
+			CoreEngine:       config.ServiceEndpoint{Target: "localhost:50052", LoadBalancing: "round_robin"},
+			CoreEngineStream: "ws://localhost:50052/stream/market-data",
+			AuthService:      config.ServiceEndpoint{Target: "localhost:50051", LoadBalancing: "round_robin"},
+			Analytics:        config.ServiceEndpoint{Target: "localhost:50053", LoadBalancing: "round_robin"},
+			VectorStore:      config.ServiceEndpoint{Target: "localhost:50054", LoadBalancing: "round_robin"},
+		},
+		Security: config.SecurityConfig{
+			JWTSecret:          "test-jwt-secret-at-least-32-bytes-long",
+			JWTExpiration:      24 * time.Hour,
+			RefreshExpiration:  7 * 24 * time.Hour,
+			BcryptCost:         12,
+			RateLimitRPS:       100,
+			RateLimitBurst:     200,
+			CORSAllowedOrigins: []string{"*"},
+			CORSAllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+			CORSAllowedHeaders: []string{"Content-Type", "Authorization"},
+		},
+		RateLimit: config.RateLimitConfig{
+			Backend: "memory",
+		},
+		DataSources: config.DataSourcesConfig{
+			CredentialProvider: "env",
+			VaultMountPath:     "secret",
+		},
+		ResponseCache: config.ResponseCacheConfig{
+			Backend:       "memory",
+			MaxEntries:    1000,
+			MarketDataTTL: 2 * time.Second,
+			NewsTTL:       60 * time.Second,
+		},
+		GRPC: config.GRPCServerConfig{
+			KeepaliveTime:                  2 * time.Minute,
+			KeepaliveTimeout:               20 * time.Second,
+			MaxConnectionIdle:              5 * time.Minute,
+			MaxConnectionAge:               30 * time.Minute,
+			MaxConnectionAgeGrace:          10 * time.Second,
+			EnforcementMinTime:             time.Minute,
+			EnforcementPermitWithoutStream: true,
+			DrainTimeout:                   30 * time.Second,
+			MaxConcurrentRequests:          500,
+			EnableReflection:               true,
+		},
+		Gateway: config.GatewayConfig{
+			Port:                     8082,
+			AllowedOrigins:           []string{"*"},
+			EnableWebSocketStreaming: true,
+			DrainTimeout:             30 * time.Second,
+		},
+		Logging: config.LoggingConfig{
+			Level:      "info",
+			Format:     "json",
+			Output:     "stdout",
+			MaxSize:    100,
+			MaxBackups: 3,
+			MaxAge:     28,
+		},
+		Metrics: config.MetricsConfig{
+			Path:      "/metrics",
+			Port:      9100,
+			Namespace: "market_intel",
+			Subsystem: "api_gateway",
+		},
+		Health: config.HealthConfig{
+			CacheFor:     5 * time.Second,
+			CheckTimeout: 3 * time.Second,
+		},
+		Environment: "development",
+	}
+}
+
+func TestReloadAppliesNonRestartFieldChanges(t *testing.T) {
+	m := NewManager(baseConfig())
+
+	candidate := baseConfig()
+	candidate.Logging.Level = "debug"
+
+	result, err := m.Reload(func() (*config.Config, error) { return candidate, nil })
+	if err != nil {
+		t.Fatalf("Reload returned error: %v", err)
+	}
+
+	if len(result.Changed) != 1 || result.Changed[0] != "Logging.Level" {
+		t.Errorf("expected Changed = [Logging.Level], got %v", result.Changed)
+	}
+	if m.Current().Logging.Level != "debug" {
+		t.Errorf("expected active config to be swapped in, got level %q", m.Current().Logging.Level)
+	}
+}
+
+func TestReloadRejectsRestartTaggedFieldChanges(t *testing.T) {
+	m := NewManager(baseConfig())
+
+	candidate := baseConfig()
+	candidate.Server.HTTPPort = 9090
+
+	_, err := m.Reload(func() (*config.Config, error) { return candidate, nil })
+
+	var restartErr *RestartRequiredError
+	if !errors.As(err, &restartErr) {
+		t.Fatalf("expected RestartRequiredError, got %v", err)
+	}
+	if len(restartErr.Fields) != 1 || restartErr.Fields[0] != "Server.HTTPPort" {
+		t.Errorf("expected Fields = [Server.HTTPPort], got %v", restartErr.Fields)
+	}
+	if m.Current().Server.HTTPPort != 8080 {
+		t.Errorf("expected active config to be left unchanged, got HTTPPort %d", m.Current().Server.HTTPPort)
+	}
+}
+
+func TestReloadNoopWhenNothingChanged(t *testing.T) {
+	m := NewManager(baseConfig())
+
+	result, err := m.Reload(func() (*config.Config, error) { return baseConfig(), nil })
+	if err != nil {
+		t.Fatalf("Reload returned error: %v", err)
+	}
+	if len(result.Changed) != 0 {
+		t.Errorf("expected no changed fields, got %v", result.Changed)
+	}
+}
+
+// recordingSubscriber tracks every Apply call it receives and optionally
+// fails the first one, letting tests assert both the rejection and the
+// rollback call that follows it.
+type recordingSubscriber struct {
+	name  string
+	fail  bool
+	calls []string
+}
+
+func (s *recordingSubscriber) Name() string { return s.name }
+
+func (s *recordingSubscriber) Apply(old, new *config.Config) error {
+	s.calls = append(s.calls, fmt.Sprintf("%s->%s", old.Logging.Level, new.Logging.Level))
+	if s.fail {
+		return fmt.Errorf("%s refused the change", s.name)
+	}
+	return nil
+}
+
+func TestReloadRollsBackAppliedSubscribersOnRejection(t *testing.T) {
+	m := NewManager(baseConfig())
+
+	first := &recordingSubscriber{name: "first"}
+	second := &recordingSubscriber{name: "second", fail: true}
+	m.Subscribe(first)
+	m.Subscribe(second)
+
+	candidate := baseConfig()
+	candidate.Logging.Level = "debug"
+
+	_, err := m.Reload(func() (*config.Config, error) { return candidate, nil })
+	if err == nil {
+		t.Fatal("expected error from rejecting subscriber, got nil")
+	}
+
+	if len(first.calls) != 2 {
+		t.Fatalf("expected first subscriber to be applied then rolled back, got calls %v", first.calls)
+	}
+	if first.calls[0] != "info->debug" || first.calls[1] != "debug->info" {
+		t.Errorf("unexpected rollback call sequence: %v", first.calls)
+	}
+	if m.Current().Logging.Level != "info" {
+		t.Errorf("expected active config to be left unchanged after rollback, got level %q", m.Current().Logging.Level)
+	}
+}
+
+func TestLoggingLevelSubscriberAppliesOnChange(t *testing.T) {
+	sub := NewLoggingLevelSubscriber()
+
+	old := baseConfig()
+	new := baseConfig()
+	new.Logging.Level = "warn"
+
+	if err := sub.Apply(old, new); err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+}
+
+func TestLoggingLevelSubscriberRejectsInvalidLevel(t *testing.T) {
+	sub := NewLoggingLevelSubscriber()
+
+	old := baseConfig()
+	new := baseConfig()
+	new.Logging.Level = "not-a-level"
+
+	if err := sub.Apply(old, new); err == nil {
+		t.Fatal("expected error for invalid log level, got nil")
+	}
+}
@@ -0,0 +1,26 @@
+package reload
+
+import (
+	"github.com/market-intel/api-gateway/internal/config"
+	"github.com/market-intel/api-gateway/pkg/logger"
+)
+
+// LoggingLevelSubscriber applies a reloaded LoggingConfig.Level to the
+// shared logger, so an operator can turn up verbosity to debug an incident
+// without restarting the gateway.
+type LoggingLevelSubscriber struct{}
+
+// NewLoggingLevelSubscriber returns a Subscriber that keeps the shared
+// logger's level in sync with LoggingConfig.Level across reloads.
+func NewLoggingLevelSubscriber() *LoggingLevelSubscriber {
+	return &LoggingLevelSubscriber{}
+}
+
+func (s *LoggingLevelSubscriber) Name() string { return "logging-level" }
+
+func (s *LoggingLevelSubscriber) Apply(old, new *config.Config) error {
+	if old.Logging.Level == new.Logging.Level {
+		return nil
+	}
+	return logger.SetLevel(new.Logging.Level)
+}
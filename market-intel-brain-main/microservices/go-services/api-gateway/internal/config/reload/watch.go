@@ -0,0 +1,96 @@
+package reload
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/market-intel/api-gateway/internal/config"
+	"github.com/market-intel/api-gateway/pkg/logger"
+)
+
+// Watch triggers Reload(loadFn) whenever the process receives SIGHUP or
+// configFile changes on disk, the same way pkg/tls.TLSConfig.Watch keeps
+// TLS material current: it watches configFile's containing directory
+// rather than the file itself, since config management tools typically
+// replace a file via atomic rename rather than writing in place. configFile
+// == "" watches nothing file-wise but SIGHUP reloading from the
+// environment still applies. Reload failures are logged rather than
+// returned, since a bad candidate must never take down a watcher that's
+// still waiting for the next, hopefully-valid, one. The watcher runs until
+// ctx is cancelled.
+func (m *Manager) Watch(ctx context.Context, configFile string, loadFn func() (*config.Config, error)) error {
+	var watcher *fsnotify.Watcher
+	if configFile != "" {
+		w, err := fsnotify.NewWatcher()
+		if err != nil {
+			return fmt.Errorf("failed to create fsnotify watcher: %w", err)
+		}
+		if err := w.Add(filepath.Dir(configFile)); err != nil {
+			w.Close()
+			return fmt.Errorf("failed to watch %s: %w", configFile, err)
+		}
+		watcher = w
+	}
+
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(sighup)
+		if watcher != nil {
+			defer watcher.Close()
+		}
+
+		var events <-chan fsnotify.Event
+		var errs <-chan error
+		if watcher != nil {
+			events = watcher.Events
+			errs = watcher.Errors
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-sighup:
+				logger.Info("received SIGHUP, reloading configuration")
+				m.reloadAndLog(loadFn)
+
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != filepath.Clean(configFile) {
+					continue
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+					continue
+				}
+				logger.Infof("detected change to %s, reloading configuration", event.Name)
+				m.reloadAndLog(loadFn)
+
+			case err, ok := <-errs:
+				if !ok {
+					return
+				}
+				logger.Errorf("config file watcher error: %v", err)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// reloadAndLog calls Reload(loadFn) and logs rather than propagates the
+// error, since Watch's triggers have no caller to return it to.
+func (m *Manager) reloadAndLog(loadFn func() (*config.Config, error)) {
+	if _, err := m.Reload(loadFn); err != nil {
+		logger.Errorf("config reload failed: %v", err)
+	}
+}
@@ -0,0 +1,287 @@
+// Package validate provides a struct-tag driven replacement for the
+// hand-rolled Validate() methods in internal/config: instead of a wall of
+// `if field == 0 { return &ConfigError{...} }` checks, a field declares its
+// constraints once as a `validate:"..."` tag and Struct walks the struct via
+// reflection to enforce them. The approach mirrors KEDA's
+// scalersconfig/typed_config.
+//
+// Tag syntax is a comma-separated list of tokens:
+//
+//	name=KEY           the env var key reported in Error.Key (defaults to the Go field name)
+//	required           string/slice must be non-empty, numeric must be non-zero
+//	min=N / max=N      numeric range (inclusive) or string/slice length bounds
+//	enum=a|b|c         value must equal one of the given alternatives
+//	regex=PATTERN      string must match the pattern
+//	prefix=P           string must start with P
+//	secret             mask the reported value as "***"
+//	gte=OtherField      value must be >= the named sibling field's value
+//	dive=validator     apply a registered custom validator to every slice element
+//	<name>             apply a registered custom validator to the whole field
+//
+// Unknown custom validator names are resolved via Register.
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Error is the config validation error Struct returns, matching the
+// shape config.ConfigError already used across internal/config so callers
+// don't need to change their error handling.
+type Error struct {
+	Key     string
+	Value   string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("configuration error for %s: %s (value: %s)", e.Key, e.Message, e.Value)
+}
+
+// CustomFunc validates a single formatted field value, returning a
+// human-readable failure message, or "" if the value is valid.
+type CustomFunc func(value string) string
+
+var customValidators = map[string]CustomFunc{}
+
+// Register adds a named custom validator usable as a bare `validate:"name"`
+// or `validate:"dive=name"` tag token. Intended to be called from an init()
+// in the package that owns the domain-specific check.
+func Register(name string, fn CustomFunc) {
+	customValidators[name] = fn
+}
+
+type rule struct {
+	name     string
+	required bool
+	secret   bool
+	min      *int64
+	max      *int64
+	enum     []string
+	regex    string
+	prefix   string
+	gte      string
+	dive     string
+	custom   []string
+}
+
+// Struct walks the exported fields of cfg (a pointer to a struct) and
+// validates each one tagged with `validate:"..."`, in declaration order,
+// returning the first failure as an *Error.
+func Struct(cfg interface{}) error {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("validate: Struct expects a pointer to a struct, got %T", cfg)
+	}
+	v = v.Elem()
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, ok := field.Tag.Lookup("validate")
+		if !ok {
+			continue
+		}
+
+		r, err := parseTag(tag)
+		if err != nil {
+			return fmt.Errorf("validate: invalid tag on %s.%s: %w", t.Name(), field.Name, err)
+		}
+		if r.name == "" {
+			r.name = field.Name
+		}
+
+		if err := checkField(v.Field(i), v, field.Name, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseTag(tag string) (rule, error) {
+	var r rule
+	for _, part := range strings.Split(tag, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, value, hasValue := strings.Cut(part, "=")
+		switch key {
+		case "required":
+			r.required = true
+		case "secret":
+			r.secret = true
+		case "name":
+			r.name = value
+		case "min":
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return r, fmt.Errorf("invalid min %q: %w", value, err)
+			}
+			r.min = &n
+		case "max":
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return r, fmt.Errorf("invalid max %q: %w", value, err)
+			}
+			r.max = &n
+		case "enum":
+			r.enum = strings.Split(value, "|")
+		case "regex":
+			r.regex = value
+		case "prefix":
+			r.prefix = value
+		case "gte":
+			r.gte = value
+		case "dive":
+			r.dive = value
+		default:
+			if hasValue {
+				return r, fmt.Errorf("unrecognized validate tag token %q", part)
+			}
+			r.custom = append(r.custom, key)
+		}
+	}
+	return r, nil
+}
+
+func checkField(fv reflect.Value, parent reflect.Value, fieldName string, r rule) error {
+	switch fv.Kind() {
+	case reflect.String:
+		return checkString(fv.String(), r)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return checkInt(fv, parent, r)
+	case reflect.Slice:
+		if fv.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("validate: unsupported slice element type for field %s", fieldName)
+		}
+		return checkStringSlice(fv, r)
+	default:
+		return fmt.Errorf("validate: unsupported field kind %s for field %s", fv.Kind(), fieldName)
+	}
+}
+
+func checkString(value string, r rule) error {
+	if r.required && value == "" {
+		return fail(r, value, fmt.Sprintf("%s is required", r.name))
+	}
+	if r.min != nil && int64(len(value)) < *r.min {
+		return fail(r, value, fmt.Sprintf("%s must be at least %d characters long", r.name, *r.min))
+	}
+	if r.max != nil && int64(len(value)) > *r.max {
+		return fail(r, value, fmt.Sprintf("%s must be at most %d characters long", r.name, *r.max))
+	}
+	if len(r.enum) > 0 && value != "" && !contains(r.enum, value) {
+		return fail(r, value, fmt.Sprintf("%s must be one of: %s", r.name, strings.Join(r.enum, ", ")))
+	}
+	if r.prefix != "" && !strings.HasPrefix(value, r.prefix) {
+		return fail(r, value, fmt.Sprintf("%s must start with %q", r.name, r.prefix))
+	}
+	if r.regex != "" {
+		matched, err := regexp.MatchString(r.regex, value)
+		if err != nil {
+			return fmt.Errorf("validate: invalid regex %q for %s: %w", r.regex, r.name, err)
+		}
+		if !matched {
+			return fail(r, value, fmt.Sprintf("%s must match pattern %s", r.name, r.regex))
+		}
+	}
+	for _, name := range r.custom {
+		if msg := runCustom(name, value); msg != "" {
+			return fail(r, value, fmt.Sprintf("%s %s", r.name, msg))
+		}
+	}
+	return nil
+}
+
+func checkInt(fv reflect.Value, parent reflect.Value, r rule) error {
+	n := fv.Int()
+	display := displayInt(fv, n)
+
+	if r.required && n == 0 {
+		return fail(r, display, fmt.Sprintf("%s is required", r.name))
+	}
+	if r.min != nil && n < *r.min {
+		return fail(r, display, fmt.Sprintf("%s must be >= %d", r.name, *r.min))
+	}
+	if r.max != nil && n > *r.max {
+		return fail(r, display, fmt.Sprintf("%s must be <= %d", r.name, *r.max))
+	}
+	if r.gte != "" {
+		sibling := parent.FieldByName(r.gte)
+		if !sibling.IsValid() {
+			return fmt.Errorf("validate: gte references unknown field %q", r.gte)
+		}
+		if n < sibling.Int() {
+			return fail(r, display, fmt.Sprintf("%s must be greater than or equal to %s", r.name, r.gte))
+		}
+	}
+	return nil
+}
+
+func checkStringSlice(fv reflect.Value, r rule) error {
+	length := fv.Len()
+	display := strings.Join(toStringSlice(fv), ",")
+
+	if r.required && length == 0 {
+		return fail(r, display, fmt.Sprintf("%s must contain at least one entry", r.name))
+	}
+	if r.min != nil && int64(length) < *r.min {
+		return fail(r, display, fmt.Sprintf("%s must contain at least %d entries", r.name, *r.min))
+	}
+	if r.max != nil && int64(length) > *r.max {
+		return fail(r, display, fmt.Sprintf("%s must contain at most %d entries", r.name, *r.max))
+	}
+	if r.dive != "" {
+		for _, elem := range toStringSlice(fv) {
+			if msg := runCustom(r.dive, elem); msg != "" {
+				return fail(r, elem, fmt.Sprintf("%s: %s", r.name, msg))
+			}
+		}
+	}
+	return nil
+}
+
+func runCustom(name string, value string) string {
+	fn, ok := customValidators[name]
+	if !ok {
+		return fmt.Sprintf("has no registered %q validator", name)
+	}
+	return fn(value)
+}
+
+func fail(r rule, value, message string) error {
+	if r.secret {
+		value = "***"
+	}
+	return &Error{Key: r.name, Value: value, Message: message}
+}
+
+func displayInt(fv reflect.Value, n int64) string {
+	if fv.Type() == reflect.TypeOf(time.Duration(0)) {
+		return time.Duration(n).String()
+	}
+	return strconv.FormatInt(n, 10)
+}
+
+func toStringSlice(fv reflect.Value) []string {
+	out := make([]string, fv.Len())
+	for i := range out {
+		out[i] = fv.Index(i).String()
+	}
+	return out
+}
+
+func contains(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
@@ -1,831 +1,647 @@
-package config
-
-import (
-	"fmt"
-	"net/url"
-	"regexp"
-	"strings"
-
-)
-
-// Validation methods for each configuration struct
-
-// Validate validates ServerConfig
-func (s *ServerConfig) Validate() error {
-	if s.HTTPPort == 0 {
-		return &ConfigError{
-			Key:     "SERVER_HTTP_PORT",
-			Value:   fmt.Sprintf("%d", s.HTTPPort),
-			Message: "HTTP port cannot be 0",
-		}
-	}
-	
-	if s.GRPCPort == 0 {
-		return &ConfigError{
-			Key:     "SERVER_GRPC_PORT",
-			Value:   fmt.Sprintf("%d", s.GRPCPort),
-			Message: "gRPC port cannot be 0",
-		}
-	}
-	
-	if s.HTTPPort == s.GRPCPort {
-		return &ConfigError{
-			Key:     "SERVER_PORTS",
-			Value:   fmt.Sprintf("%d/%d", s.HTTPPort, s.GRPCPort),
-			Message: "HTTP and gRPC ports cannot be the same",
-		}
-	}
-	
-	if s.HTTPPort < 1 || s.HTTPPort > 65535 {
-		return &ConfigError{
-			Key:     "SERVER_HTTP_PORT",
-			Value:   fmt.Sprintf("%d", s.HTTPPort),
-			Message: "HTTP port must be between 1 and 65535",
-		}
-	}
-	
-	if s.GRPCPort < 1 || s.GRPCPort > 65535 {
-		return &ConfigError{
-			Key:     "SERVER_GRPC_PORT",
-			Value:   fmt.Sprintf("%d", s.GRPCPort),
-			Message: "gRPC port must be between 1 and 65535",
-		}
-	}
-	
-	if s.Host == "" {
-		return &ConfigError{
-			Key:     "SERVER_HOST",
-			Value:   s.Host,
-			Message: "Server host cannot be empty",
-		}
-	}
-	
-	if s.ReadTimeout <= 0 {
-		return &ConfigError{
-			Key:     "SERVER_READ_TIMEOUT",
-			Value:   s.ReadTimeout.String(),
-			Message: "Read timeout must be positive",
-		}
-	}
-	
-	if s.WriteTimeout <= 0 {
-		return &ConfigError{
-			Key:     "SERVER_WRITE_TIMEOUT",
-			Value:   s.WriteTimeout.String(),
-			Message: "Write timeout must be positive",
-		}
-	}
-	
-	if s.IdleTimeout <= 0 {
-		return &ConfigError{
-			Key:     "SERVER_IDLE_TIMEOUT",
-			Value:   s.IdleTimeout.String(),
-			Message: "Idle timeout must be positive",
-		}
-	}
-	
-	if s.MaxHeaderBytes <= 0 {
-		return &ConfigError{
-			Key:     "SERVER_MAX_HEADER_BYTES",
-			Value:   fmt.Sprintf("%d", s.MaxHeaderBytes),
-			Message: "Max header bytes must be positive",
-		}
-	}
-	
-	if s.MaxBodyBytes <= 0 {
-		return &ConfigError{
-			Key:     "SERVER_MAX_BODY_BYTES",
-			Value:   fmt.Sprintf("%d", s.MaxBodyBytes),
-			Message: "Max body bytes must be positive",
-		}
-	}
-	
-	if s.GracefulTimeout <= 0 {
-		return &ConfigError{
-			Key:     "SERVER_GRACEFUL_TIMEOUT",
-			Value:   s.GracefulTimeout.String(),
-			Message: "Graceful timeout must be positive",
-		}
-	}
-	
-	if s.MaxConnections <= 0 {
-		return &ConfigError{
-			Key:     "SERVER_MAX_CONNECTIONS",
-			Value:   fmt.Sprintf("%d", s.MaxConnections),
-			Message: "Max connections must be positive",
-		}
-	}
-	
-	return nil
-}
-
-// Validate validates DatabaseConfig
-func (d *DatabaseConfig) Validate() error {
-	if d.Host == "" {
-		return &ConfigError{
-			Key:     "DB_HOST",
-			Value:   d.Host,
-			Message: "Database host cannot be empty",
-		}
-	}
-	
-	if d.Port == 0 {
-		return &ConfigError{
-			Key:     "DB_PORT",
-			Value:   fmt.Sprintf("%d", d.Port),
-			Message: "Database port cannot be 0",
-		}
-	}
-	
-	if d.Port < 1 || d.Port > 65535 {
-		return &ConfigError{
-			Key:     "DB_PORT",
-			Value:   fmt.Sprintf("%d", d.Port),
-			Message: "Database port must be between 1 and 65535",
-		}
-	}
-	
-	if d.Database == "" {
-		return &ConfigError{
-			Key:     "DB_NAME",
-			Value:   d.Database,
-			Message: "Database name cannot be empty",
-		}
-	}
-	
-	if d.Username == "" {
-		return &ConfigError{
-			Key:     "DB_USERNAME",
-			Value:   d.Username,
-			Message: "Database username cannot be empty",
-		}
-	}
-	
-	if d.Password == "" {
-		return &ConfigError{
-			Key:     "DB_PASSWORD",
-			Value:   "***",
-			Message: "Database password cannot be empty",
-		}
-	}
-	
-	if d.MaxConnections <= 0 {
-		return &ConfigError{
-			Key:     "DB_MAX_CONNECTIONS",
-			Value:   fmt.Sprintf("%d", d.MaxConnections),
-			Message: "Max connections must be positive",
-		}
-	}
-	
-	if d.MinConnections < 0 {
-		return &ConfigError{
-			Key:     "DB_MIN_CONNECTIONS",
-			Value:   fmt.Sprintf("%d", d.MinConnections),
-			Message: "Min connections cannot be negative",
-		}
-	}
-	
-	if d.MinConnections > d.MaxConnections {
-		return &ConfigError{
-			Key:     "DB_CONNECTIONS",
-			Value:   fmt.Sprintf("%d/%d", d.MinConnections, d.MaxConnections),
-			Message: "Min connections cannot be greater than max connections",
-		}
-	}
-	
-	// Validate SSL mode
-	validSSLModes := []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
-	isValidSSLMode := false
-	for _, mode := range validSSLModes {
-		if d.SSLMode == mode {
-			isValidSSLMode = true
-			break
-		}
-	}
-	if !isValidSSLMode {
-		return &ConfigError{
-			Key:     "DB_SSL_MODE",
-			Value:   d.SSLMode,
-			Message: fmt.Sprintf("SSL mode must be one of: %s", strings.Join(validSSLModes, ", ")),
-		}
-	}
-	
-	if d.MaxIdleTime <= 0 {
-		return &ConfigError{
-			Key:     "DB_MAX_IDLE_TIME",
-			Value:   d.MaxIdleTime.String(),
-			Message: "Max idle time must be positive",
-		}
-	}
-	
-	if d.MaxLifetime <= 0 {
-		return &ConfigError{
-			Key:     "DB_MAX_LIFETIME",
-			Value:   d.MaxLifetime.String(),
-			Message: "Max lifetime must be positive",
-		}
-	}
-	
-	if d.ConnectTimeout <= 0 {
-		return &ConfigError{
-			Key:     "DB_CONNECT_TIMEOUT",
-			Value:   d.ConnectTimeout.String(),
-			Message: "Connect timeout must be positive",
-		}
-	}
-	
-	if d.QueryTimeout <= 0 {
-		return &ConfigError{
-			Key:     "DB_QUERY_TIMEOUT",
-			Value:   d.QueryTimeout.String(),
-			Message: "Query timeout must be positive",
-		}
-	}
-	
-	return nil
-}
-
-// Validate validates RedisConfig
-func (r *RedisConfig) Validate() error {
-	if r.Host == "" {
-		return &ConfigError{
-			Key:     "REDIS_HOST",
-			Value:   r.Host,
-			Message: "Redis host cannot be empty",
-		}
-	}
-	
-	if r.Port == 0 {
-		return &ConfigError{
-			Key:     "REDIS_PORT",
-			Value:   fmt.Sprintf("%d", r.Port),
-			Message: "Redis port cannot be 0",
-		}
-	}
-	
-	if r.Port < 1 || r.Port > 65535 {
-		return &ConfigError{
-			Key:     "REDIS_PORT",
-			Value:   fmt.Sprintf("%d", r.Port),
-			Message: "Redis port must be between 1 and 65535",
-		}
-	}
-	
-	if r.Database < 0 || r.Database > 15 {
-		return &ConfigError{
-			Key:     "REDIS_DATABASE",
-			Value:   fmt.Sprintf("%d", r.Database),
-			Message: "Redis database must be between 0 and 15",
-		}
-	}
-	
-	if r.MaxRetries < 0 {
-		return &ConfigError{
-			Key:     "REDIS_MAX_RETRIES",
-			Value:   fmt.Sprintf("%d", r.MaxRetries),
-			Message: "Max retries cannot be negative",
-		}
-	}
-	
-	if r.DialTimeout <= 0 {
-		return &ConfigError{
-			Key:     "REDIS_DIAL_TIMEOUT",
-			Value:   r.DialTimeout.String(),
-			Message: "Dial timeout must be positive",
-		}
-	}
-	
-	if r.ReadTimeout <= 0 {
-		return &ConfigError{
-			Key:     "REDIS_READ_TIMEOUT",
-			Value:   r.ReadTimeout.String(),
-			Message: "Read timeout must be positive",
-		}
-	}
-	
-	if r.WriteTimeout <= 0 {
-		return &ConfigError{
-			Key:     "REDIS_WRITE_TIMEOUT",
-			Value:   r.WriteTimeout.String(),
-			Message: "Write timeout must be positive",
-		}
-	}
-	
-	if r.PoolSize <= 0 {
-		return &ConfigError{
-			Key:     "REDIS_POOL_SIZE",
-			Value:   fmt.Sprintf("%d", r.PoolSize),
-			Message: "Pool size must be positive",
-		}
-	}
-	
-	if r.MinIdleConns < 0 {
-		return &ConfigError{
-			Key:     "REDIS_MIN_IDLE_CONNS",
-			Value:   fmt.Sprintf("%d", r.MinIdleConns),
-			Message: "Min idle connections cannot be negative",
-		}
-	}
-	
-	if r.MinIdleConns > r.PoolSize {
-		return &ConfigError{
-			Key:     "REDIS_CONNECTIONS",
-			Value:   fmt.Sprintf("%d/%d", r.MinIdleConns, r.PoolSize),
-			Message: "Min idle connections cannot be greater than pool size",
-		}
-	}
-	
-	if r.MaxConnAge <= 0 {
-		return &ConfigError{
-			Key:     "REDIS_MAX_CONN_AGE",
-			Value:   r.MaxConnAge.String(),
-			Message: "Max connection age must be positive",
-		}
-	}
-	
-	return nil
-}
-
-// Validate validates KafkaConfig
-func (k *KafkaConfig) Validate() error {
-	if len(k.Brokers) == 0 {
-		return &ConfigError{
-			Key:     "KAFKA_BROKERS",
-			Value:   strings.Join(k.Brokers, ","),
-			Message: "At least one Kafka broker must be specified",
-		}
-	}
-	
-	for i, broker := range k.Brokers {
-		if broker == "" {
-			return &ConfigError{
-				Key:     "KAFKA_BROKERS",
-				Value:   strings.Join(k.Brokers, ","),
-				Message: fmt.Sprintf("Broker %d cannot be empty", i),
-			}
-		}
-		
-		// Validate broker format (host:port)
-		if !isValidBroker(broker) {
-			return &ConfigError{
-				Key:     "KAFKA_BROKERS",
-				Value:   broker,
-				Message: "Broker must be in format host:port",
-			}
-		}
-	}
-	
-	if k.ConsumerGroup == "" {
-		return &ConfigError{
-			Key:     "KAFKA_CONSUMER_GROUP",
-			Value:   k.ConsumerGroup,
-			Message: "Consumer group cannot be empty",
-		}
-	}
-	
-	if k.CompressionType == "" {
-		return &ConfigError{
-			Key:     "KAFKA_COMPRESSION_TYPE",
-			Value:   k.CompressionType,
-			Message: "Compression type cannot be empty",
-		}
-	}
-	
-	validCompressionTypes := []string{"none", "gzip", "snappy", "lz4", "zstd"}
-	isValidCompressionType := false
-	for _, ct := range validCompressionTypes {
-		if k.CompressionType == ct {
-			isValidCompressionType = true
-			break
-		}
-	}
-	if !isValidCompressionType {
-		return &ConfigError{
-			Key:     "KAFKA_COMPRESSION_TYPE",
-			Value:   k.CompressionType,
-			Message: fmt.Sprintf("Compression type must be one of: %s", strings.Join(validCompressionTypes, ", ")),
-		}
-	}
-	
-	if k.BatchSize <= 0 {
-		return &ConfigError{
-			Key:     "KAFKA_BATCH_SIZE",
-			Value:   fmt.Sprintf("%d", k.BatchSize),
-			Message: "Batch size must be positive",
-		}
-	}
-	
-	if k.BatchTimeout < 0 {
-		return &ConfigError{
-			Key:     "KAFKA_BATCH_TIMEOUT",
-			Value:   k.BatchTimeout.String(),
-			Message: "Batch timeout cannot be negative",
-		}
-	}
-	
-	if k.CompressionLevel < 0 || k.CompressionLevel > 9 {
-		return &ConfigError{
-			Key:     "KAFKA_COMPRESSION_LEVEL",
-			Value:   fmt.Sprintf("%d", k.CompressionLevel),
-			Message: "Compression level must be between 0 and 9",
-		}
-	}
-	
-	if k.MaxMessageBytes <= 0 {
-		return &ConfigError{
-			Key:     "KAFKA_MAX_MESSAGE_BYTES",
-			Value:   fmt.Sprintf("%d", k.MaxMessageBytes),
-			Message: "Max message bytes must be positive",
-		}
-	}
-	
-	if k.ConsumerFetchMin <= 0 {
-		return &ConfigError{
-			Key:     "KAFKA_CONSUMER_FETCH_MIN",
-			Value:   fmt.Sprintf("%d", k.ConsumerFetchMin),
-			Message: "Consumer fetch min must be positive",
-		}
-	}
-	
-	if k.ConsumerFetchDefault <= 0 {
-		return &ConfigError{
-			Key:     "KAFKA_CONSUMER_FETCH_DEFAULT",
-			Value:   fmt.Sprintf("%d", k.ConsumerFetchDefault),
-			Message: "Consumer fetch default must be positive",
-		}
-	}
-	
-	if k.ConsumerFetchMax <= 0 {
-		return &ConfigError{
-			Key:     "KAFKA_CONSUMER_FETCH_MAX",
-			Value:   fmt.Sprintf("%d", k.ConsumerFetchMax),
-			Message: "Consumer fetch max must be positive",
-		}
-	}
-	
-	if k.ConsumerFetchMin > k.ConsumerFetchDefault || k.ConsumerFetchDefault > k.ConsumerFetchMax {
-		return &ConfigError{
-			Key:     "KAFKA_CONSUMER_FETCH",
-			Value:   fmt.Sprintf("%d/%d/%d", k.ConsumerFetchMin, k.ConsumerFetchDefault, k.ConsumerFetchMax),
-			Message: "Fetch values must be: min <= default <= max",
-		}
-	}
-	
-	return nil
-}
-
-// Validate validates ServiceURLs
-func (s *ServiceURLs) Validate() error {
-	if s.CoreEngine == "" {
-		return &ConfigError{
-			Key:     "CORE_ENGINE_URL",
-			Value:   s.CoreEngine,
-			Message: "Core engine URL cannot be empty",
-		}
-	}
-	
-	if s.AuthService == "" {
-		return &ConfigError{
-			Key:     "AUTH_SERVICE_URL",
-			Value:   s.AuthService,
-			Message: "Auth service URL cannot be empty",
-		}
-	}
-	
-	if s.Analytics == "" {
-		return &ConfigError{
-			Key:     "ANALYTICS_SERVICE_URL",
-			Value:   s.Analytics,
-			Message: "Analytics service URL cannot be empty",
-		}
-	}
-	
-	if s.VectorStore == "" {
-		return &ConfigError{
-			Key:     "VECTOR_STORE_URL",
-			Value:   s.VectorStore,
-			Message: "Vector store URL cannot be empty",
-		}
-	}
-	
-	// Validate URL formats
-	services := map[string]string{
-		"CoreEngine":  s.CoreEngine,
-		"AuthService": s.AuthService,
-		"Analytics":   s.Analytics,
-		"VectorStore": s.VectorStore,
-	}
-	
-	for name, serviceURL := range services {
-		if !isValidServiceURL(serviceURL) {
-			return &ConfigError{
-				Key:     fmt.Sprintf("%s_URL", name),
-				Value:   serviceURL,
-				Message: "Service URL must be in format host:port or https://host:port",
-			}
-		}
-	}
-	
-	return nil
-}
-
-// Validate validates SecurityConfig
-func (s *SecurityConfig) Validate() error {
-	if s.JWTSecret == "" {
-		return &ConfigError{
-			Key:     "JWT_SECRET",
-			Value:   "***",
-			Message: "JWT secret cannot be empty",
-		}
-	}
-	
-	if len(s.JWTSecret) < 32 {
-		return &ConfigError{
-			Key:     "JWT_SECRET",
-			Value:   "***",
-			Message: "JWT secret must be at least 32 characters long",
-		}
-	}
-	
-	if s.JWTExpiration <= 0 {
-		return &ConfigError{
-			Key:     "JWT_EXPIRATION",
-			Value:   s.JWTExpiration.String(),
-			Message: "JWT expiration must be positive",
-		}
-	}
-	
-	if s.RefreshExpiration <= 0 {
-		return &ConfigError{
-			Key:     "REFRESH_TOKEN_EXPIRATION",
-			Value:   s.RefreshExpiration.String(),
-			Message: "Refresh token expiration must be positive",
-		}
-	}
-	
-	if s.RefreshExpiration < s.JWTExpiration {
-		return &ConfigError{
-			Key:     "TOKEN_EXPIRATION",
-			Value:   fmt.Sprintf("%s/%s", s.JWTExpiration.String(), s.RefreshExpiration.String()),
-			Message: "Refresh token expiration must be greater than or equal to JWT expiration",
-		}
-	}
-	
-	if s.BcryptCost < 4 || s.BcryptCost > 31 {
-		return &ConfigError{
-			Key:     "BCRYPT_COST",
-			Value:   fmt.Sprintf("%d", s.BcryptCost),
-			Message: "Bcrypt cost must be between 4 and 31",
-		}
-	}
-	
-	if s.RateLimitRPS <= 0 {
-		return &ConfigError{
-			Key:     "RATE_LIMIT_RPS",
-			Value:   fmt.Sprintf("%d", s.RateLimitRPS),
-			Message: "Rate limit RPS must be positive",
-		}
-	}
-	
-	if s.RateLimitBurst <= 0 {
-		return &ConfigError{
-			Key:     "RATE_LIMIT_BURST",
-			Value:   fmt.Sprintf("%d", s.RateLimitBurst),
-			Message: "Rate limit burst must be positive",
-		}
-	}
-	
-	if s.RateLimitBurst < s.RateLimitRPS {
-		return &ConfigError{
-			Key:     "RATE_LIMIT",
-			Value:   fmt.Sprintf("%d/%d", s.RateLimitRPS, s.RateLimitBurst),
-			Message: "Rate limit burst must be greater than or equal to RPS",
-		}
-	}
-	
-	// Validate CORS origins
-	for i, origin := range s.CORSAllowedOrigins {
-		origin = strings.TrimSpace(origin)
-		if origin == "" {
-			continue
-		}
-		
-		if origin != "*" && !isValidOrigin(origin) {
-			return &ConfigError{
-				Key:     "CORS_ALLOWED_ORIGINS",
-				Value:   origin,
-				Message: fmt.Sprintf("CORS origin %d is invalid", i),
-			}
-		}
-	}
-	
-	return nil
-}
-
-// Validate validates LoggingConfig
-func (l *LoggingConfig) Validate() error {
-	validLevels := []string{"trace", "debug", "info", "warn", "error", "fatal", "panic"}
-	isValidLevel := false
-	for _, level := range validLevels {
-		if l.Level == level {
-			isValidLevel = true
-			break
-		}
-	}
-	if !isValidLevel {
-		return &ConfigError{
-			Key:     "LOG_LEVEL",
-			Value:   l.Level,
-			Message: fmt.Sprintf("Log level must be one of: %s", strings.Join(validLevels, ", ")),
-		}
-	}
-	
-	validFormats := []string{"json", "text"}
-	isValidFormat := false
-	for _, format := range validFormats {
-		if l.Format == format {
-			isValidFormat = true
-			break
-		}
-	}
-	if !isValidFormat {
-		return &ConfigError{
-			Key:     "LOG_FORMAT",
-			Value:   l.Format,
-			Message: fmt.Sprintf("Log format must be one of: %s", strings.Join(validFormats, ", ")),
-		}
-	}
-	
-	validOutputs := []string{"stdout", "stderr", "file"}
-	isValidOutput := false
-	for _, output := range validOutputs {
-		if l.Output == output {
-			isValidOutput = true
-			break
-		}
-	}
-	if !isValidOutput {
-		return &ConfigError{
-			Key:     "LOG_OUTPUT",
-			Value:   l.Output,
-			Message: fmt.Sprintf("Log output must be one of: %s", strings.Join(validOutputs, ", ")),
-		}
-	}
-	
-	if l.EnableFile && l.FilePath == "" {
-		return &ConfigError{
-			Key:     "LOG_FILE_PATH",
-			Value:   l.FilePath,
-			Message: "Log file path cannot be empty when file logging is enabled",
-		}
-	}
-	
-	if l.MaxSize <= 0 {
-		return &ConfigError{
-			Key:     "LOG_MAX_SIZE",
-			Value:   fmt.Sprintf("%d", l.MaxSize),
-			Message: "Log max size must be positive",
-		}
-	}
-	
-	if l.MaxBackups < 0 {
-		return &ConfigError{
-			Key:     "LOG_MAX_BACKUPS",
-			Value:   fmt.Sprintf("%d", l.MaxBackups),
-			Message: "Log max backups cannot be negative",
-		}
-	}
-	
-	if l.MaxAge < 0 {
-		return &ConfigError{
-			Key:     "LOG_MAX_AGE",
-			Value:   fmt.Sprintf("%d", l.MaxAge),
-			Message: "Log max age cannot be negative",
-		}
-	}
-	
-	return nil
-}
-
-// Validate validates MetricsConfig
-func (m *MetricsConfig) Validate() error {
-	if m.Path == "" {
-		return &ConfigError{
-			Key:     "METRICS_PATH",
-			Value:   m.Path,
-			Message: "Metrics path cannot be empty",
-		}
-	}
-	
-	if !strings.HasPrefix(m.Path, "/") {
-		return &ConfigError{
-			Key:     "METRICS_PATH",
-			Value:   m.Path,
-			Message: "Metrics path must start with '/'",
-		}
-	}
-	
-	if m.Port == 0 {
-		return &ConfigError{
-			Key:     "METRICS_PORT",
-			Value:   fmt.Sprintf("%d", m.Port),
-			Message: "Metrics port cannot be 0",
-		}
-	}
-	
-	if m.Port < 1 || m.Port > 65535 {
-		return &ConfigError{
-			Key:     "METRICS_PORT",
-			Value:   fmt.Sprintf("%d", m.Port),
-			Message: "Metrics port must be between 1 and 65535",
-		}
-	}
-	
-	if m.Namespace == "" {
-		return &ConfigError{
-			Key:     "METRICS_NAMESPACE",
-			Value:   m.Namespace,
-			Message: "Metrics namespace cannot be empty",
-		}
-	}
-	
-	if m.Subsystem == "" {
-		return &ConfigError{
-			Key:     "METRICS_SUBSYSTEM",
-			Value:   m.Subsystem,
-			Message: "Metrics subsystem cannot be empty",
-		}
-	}
-	
-	// Validate namespace and subsystem format (should match regex: ^[a-zA-Z_][a-zA-Z0-9_]*$)
-	validNamespace := regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
-	if !validNamespace.MatchString(m.Namespace) {
-		return &ConfigError{
-			Key:     "METRICS_NAMESPACE",
-			Value:   m.Namespace,
-			Message: "Metrics namespace must contain only letters, numbers, and underscores, and start with a letter or underscore",
-		}
-	}
-	
-	if !validNamespace.MatchString(m.Subsystem) {
-		return &ConfigError{
-			Key:     "METRICS_SUBSYSTEM",
-			Value:   m.Subsystem,
-			Message: "Metrics subsystem must contain only letters, numbers, and underscores, and start with a letter or underscore",
-		}
-	}
-	
-	return nil
-}
-
-// Helper validation functions
-
-func isValidBroker(broker string) bool {
-	parts := strings.Split(broker, ":")
-	if len(parts) != 2 {
-		return false
-	}
-	
-	host := parts[0]
-	port := parts[1]
-	
-	if host == "" {
-		return false
-	}
-	
-	// Validate port
-	if len(port) == 0 {
-		return false
-	}
-	
-	for _, char := range port {
-		if char < '0' || char > '9' {
-			return false
-		}
-	}
-	
-	return true
-}
-
-func isValidServiceURL(serviceURL string) bool {
-	// Check if it's a full URL
-	if strings.HasPrefix(serviceURL, "http://") || strings.HasPrefix(serviceURL, "https://") {
-		_, err := url.Parse(serviceURL)
-		return err == nil
-	}
-	
-	// Otherwise, check if it's host:port
-	return isValidBroker(serviceURL)
-}
-
-func isValidOrigin(origin string) bool {
-	// Check if it's a valid URL
-	if strings.HasPrefix(origin, "http://") || strings.HasPrefix(origin, "https://") {
-		_, err := url.Parse(origin)
-		return err == nil
-	}
-	
-	// Check if it's a valid hostname pattern
-	validOrigin := regexp.MustCompile(`^[a-zA-Z0-9.-]+$`)
-	return validOrigin.MatchString(origin)
-}
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/market-intel/api-gateway/internal/config/validate"
+)
+
+func init() {
+	validate.Register("broker", func(value string) string {
+		if !isValidBroker(value) {
+			return fmt.Sprintf("broker %q must be in format host:port", value)
+		}
+		return ""
+	})
+
+	validate.Register("cidr", func(value string) string {
+		if _, _, err := net.ParseCIDR(value); err != nil {
+			return fmt.Sprintf("%q is not a valid CIDR: %v", value, err)
+		}
+		return ""
+	})
+}
+
+// Validation methods for each configuration struct
+
+// Validate validates ServerConfig
+func (s *ServerConfig) Validate() error {
+	if err := validate.Struct(s); err != nil {
+		return asConfigError(err)
+	}
+
+	if s.HTTPPort == s.GRPCPort {
+		return &ConfigError{
+			Key:     "SERVER_PORTS",
+			Value:   fmt.Sprintf("%d/%d", s.HTTPPort, s.GRPCPort),
+			Message: "HTTP and gRPC ports cannot be the same",
+		}
+	}
+
+	if !isValidListenAddr(s.ListenAddr) {
+		return &ConfigError{
+			Key:     "SERVER_HTTP_LISTEN_ADDR",
+			Value:   s.ListenAddr,
+			Message: "must be empty, unix://path, or tcp://host:port",
+		}
+	}
+
+	return nil
+}
+
+// isValidListenAddr reports whether listenAddr is the empty string (use the
+// server's plain TCP port) or a unix:// / tcp:// target. It only checks the
+// scheme; internal/server.resolveListener is what actually dials it.
+func isValidListenAddr(listenAddr string) bool {
+	return listenAddr == "" || strings.HasPrefix(listenAddr, "unix://") || strings.HasPrefix(listenAddr, "tcp://")
+}
+
+// Validate validates DatabaseConfig
+func (d *DatabaseConfig) Validate() error {
+	return asConfigError(validate.Struct(d))
+}
+
+// Validate validates RedisConfig
+func (r *RedisConfig) Validate() error {
+	return asConfigError(validate.Struct(r))
+}
+
+// Validate validates KafkaConfig
+func (k *KafkaConfig) Validate() error {
+	if err := validate.Struct(k); err != nil {
+		return asConfigError(err)
+	}
+
+	// Idempotent producers require acks=all and bound in-flight requests to
+	// 5 to preserve ordering across retries (see KIP-185).
+	if k.EnableIdempotence {
+		if k.Acks != "all" {
+			return &ConfigError{
+				Key:     "KAFKA_ACKS",
+				Value:   k.Acks,
+				Message: "acks must be \"all\" when idempotence is enabled",
+			}
+		}
+		if k.MaxInFlightRequestsPerConnection > 5 {
+			return &ConfigError{
+				Key:     "KAFKA_MAX_IN_FLIGHT_REQUESTS_PER_CONNECTION",
+				Value:   strconv.Itoa(k.MaxInFlightRequestsPerConnection),
+				Message: "max in-flight requests per connection must be <= 5 when idempotence is enabled",
+			}
+		}
+	}
+
+	if k.SecurityProtocol == "sasl_plaintext" || k.SecurityProtocol == "sasl_ssl" {
+		if k.SASLMechanism == "" {
+			return &ConfigError{
+				Key:     "KAFKA_SASL_MECHANISM",
+				Value:   k.SASLMechanism,
+				Message: fmt.Sprintf("sasl_mechanism is required when security_protocol is %q", k.SecurityProtocol),
+			}
+		}
+
+		if k.SASLMechanism == "OAUTHBEARER" {
+			if k.SASLTokenURL == "" {
+				return &ConfigError{
+					Key:     "KAFKA_SASL_TOKEN_URL",
+					Value:   k.SASLTokenURL,
+					Message: "sasl_token_url is required when sasl_mechanism is OAUTHBEARER",
+				}
+			}
+			if err := requireReachableURL("KAFKA_SASL_TOKEN_URL", k.SASLTokenURL, 3*time.Second); err != nil {
+				return err
+			}
+		} else if k.SASLUsername == "" || k.SASLPassword == "" {
+			return &ConfigError{
+				Key:     "KAFKA_SASL_USERNAME",
+				Value:   k.SASLUsername,
+				Message: fmt.Sprintf("sasl_username and sasl_password are required when security_protocol is %q", k.SecurityProtocol),
+			}
+		}
+	}
+
+	if k.SecurityProtocol == "ssl" || k.SecurityProtocol == "sasl_ssl" {
+		if err := requireReadableFile("KAFKA_TLS_CA_FILE", k.TLSCAFile); err != nil {
+			return err
+		}
+		if k.TLSCertFile != "" || k.TLSKeyFile != "" {
+			if err := requireReadableFile("KAFKA_TLS_CERT_FILE", k.TLSCertFile); err != nil {
+				return err
+			}
+			if err := requireReadableFile("KAFKA_TLS_KEY_FILE", k.TLSKeyFile); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := k.validateCompressionLevel(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateCompressionLevel enforces a per-codec range for
+// compression_level: none and snappy ignore it and require 0, gzip and lz4
+// are held to compress/flate's 0-9, and zstd takes klauspost/
+// compress/zstd's 1-22.
+func (k *KafkaConfig) validateCompressionLevel() error {
+	switch k.CompressionType {
+	case "none", "snappy":
+		if k.CompressionLevel != 0 {
+			return &ConfigError{
+				Key:     "KAFKA_COMPRESSION_LEVEL",
+				Value:   strconv.Itoa(k.CompressionLevel),
+				Message: fmt.Sprintf("compression_level is not used by compression_type %q and must be 0", k.CompressionType),
+			}
+		}
+	case "gzip", "lz4":
+		if k.CompressionLevel < 0 || k.CompressionLevel > 9 {
+			return &ConfigError{
+				Key:     "KAFKA_COMPRESSION_LEVEL",
+				Value:   strconv.Itoa(k.CompressionLevel),
+				Message: fmt.Sprintf("compression_level for compression_type %q must be between 0 and 9", k.CompressionType),
+			}
+		}
+	case "zstd":
+		if k.CompressionLevel < 1 || k.CompressionLevel > 22 {
+			return &ConfigError{
+				Key:     "KAFKA_COMPRESSION_LEVEL",
+				Value:   strconv.Itoa(k.CompressionLevel),
+				Message: "compression_level for compression_type \"zstd\" must be between 1 and 22",
+			}
+		}
+	}
+	return nil
+}
+
+// requireReadableFile fails validation if path is empty or not readable
+// from disk, reporting the failure under the given env key.
+func requireReadableFile(key, path string) error {
+	if path == "" {
+		return &ConfigError{
+			Key:     key,
+			Value:   path,
+			Message: "file path cannot be empty",
+		}
+	}
+	if _, err := os.Stat(path); err != nil {
+		return &ConfigError{
+			Key:     key,
+			Value:   path,
+			Message: fmt.Sprintf("file is not readable: %v", err),
+		}
+	}
+	return nil
+}
+
+// requireReachableURL fails validation unless a TCP connection can be
+// established to rawURL's host:port within timeout. It only proves the
+// endpoint is accepting connections at boot, not that it will still be up
+// by the time a token is actually requested.
+func requireReachableURL(key, rawURL string, timeout time.Duration) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return &ConfigError{
+			Key:     key,
+			Value:   rawURL,
+			Message: fmt.Sprintf("is not a valid URL: %v", err),
+		}
+	}
+
+	addr := parsed.Host
+	if parsed.Port() == "" {
+		if parsed.Scheme == "https" {
+			addr = net.JoinHostPort(parsed.Hostname(), "443")
+		} else {
+			addr = net.JoinHostPort(parsed.Hostname(), "80")
+		}
+	}
+
+	conn, err := net.DialTimeout("tcp", addr, timeout)
+	if err != nil {
+		return &ConfigError{
+			Key:     key,
+			Value:   rawURL,
+			Message: fmt.Sprintf("is not reachable: %v", err),
+		}
+	}
+	conn.Close()
+	return nil
+}
+
+// Validate validates ServiceURLs
+func (s *ServiceURLs) Validate() error {
+	if s.CoreEngineStream == "" {
+		return &ConfigError{
+			Key:     "CORE_ENGINE_STREAM_URL",
+			Value:   s.CoreEngineStream,
+			Message: "Core engine stream URL cannot be empty",
+		}
+	}
+	if !isValidServiceURL(s.CoreEngineStream) {
+		return &ConfigError{
+			Key:     "CORE_ENGINE_STREAM_URL",
+			Value:   s.CoreEngineStream,
+			Message: "Service URL must be in format host:port or https://host:port",
+		}
+	}
+
+	endpoints := map[string]ServiceEndpoint{
+		"CoreEngine":  s.CoreEngine,
+		"AuthService": s.AuthService,
+		"Analytics":   s.Analytics,
+		"VectorStore": s.VectorStore,
+	}
+
+	for name, endpoint := range endpoints {
+		if err := endpoint.Validate(name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate checks that an endpoint has a usable target and a recognized
+// load-balancing policy. name is the ServiceURLs field it came from (e.g.
+// "CoreEngine"), used to build the offending env var key in any error.
+func (e *ServiceEndpoint) Validate(name string) error {
+	key := fmt.Sprintf("%s_URL", name)
+
+	if e.Target == "" {
+		return &ConfigError{Key: key, Value: e.Target, Message: "target cannot be empty"}
+	}
+
+	// Discovery targets (consul://, etcd://) are resolved by pkg/discovery
+	// at dial time and don't fit the host:port/https:// shape isValidServiceURL
+	// checks; only plain dial targets are validated that way.
+	if !isDiscoveryTarget(e.Target) && !isValidServiceURL(e.Target) {
+		return &ConfigError{
+			Key:     key,
+			Value:   e.Target,
+			Message: "target must be host:port, https://host:port, or a consul://, etcd:// discovery target",
+		}
+	}
+
+	switch e.LoadBalancing {
+	case "", "round_robin", "pick_first", "least_request":
+	default:
+		return &ConfigError{
+			Key:     fmt.Sprintf("%s_LOAD_BALANCING", name),
+			Value:   e.LoadBalancing,
+			Message: "load_balancing must be one of round_robin, pick_first, least_request",
+		}
+	}
+
+	return nil
+}
+
+// isDiscoveryTarget reports whether target names a resolver scheme handled
+// by pkg/discovery rather than a plain dial address.
+func isDiscoveryTarget(target string) bool {
+	return strings.HasPrefix(target, "consul://") || strings.HasPrefix(target, "etcd://")
+}
+
+// Validate validates SecurityConfig
+func (s *SecurityConfig) Validate() error {
+	if err := validate.Struct(s); err != nil {
+		return asConfigError(err)
+	}
+
+	// CORS origins allow a bare "*" and tolerate blank entries from a
+	// trailing comma in CORS_ALLOWED_ORIGINS, which doesn't fit the
+	// generic tag DSL, so it stays a manual loop.
+	for i, origin := range s.CORSAllowedOrigins {
+		origin = strings.TrimSpace(origin)
+		if origin == "" {
+			continue
+		}
+
+		if origin != "*" && !isValidOrigin(origin) {
+			return &ConfigError{
+				Key:     "CORS_ALLOWED_ORIGINS",
+				Value:   origin,
+				Message: fmt.Sprintf("CORS origin %d is invalid", i),
+			}
+		}
+	}
+
+	return nil
+}
+
+// Validate validates RateLimitConfig
+func (r *RateLimitConfig) Validate() error {
+	if err := validate.Struct(r); err != nil {
+		return asConfigError(err)
+	}
+
+	// Per-route RPS/burst come from RATE_LIMIT_ROUTES, a hand-parsed
+	// "path:rps:burst" list rather than a struct field, so the tag DSL
+	// can't reach them.
+	for route, limit := range r.Routes {
+		if limit.RPS <= 0 {
+			return &ConfigError{
+				Key:     "RATE_LIMIT_ROUTES",
+				Value:   route,
+				Message: "rps must be positive",
+			}
+		}
+		if limit.Burst < limit.RPS {
+			return &ConfigError{
+				Key:     "RATE_LIMIT_ROUTES",
+				Value:   route,
+				Message: "burst must be >= rps",
+			}
+		}
+	}
+
+	return nil
+}
+
+// Validate validates DataSourcesConfig
+func (d *DataSourcesConfig) Validate() error {
+	if !d.Enabled {
+		return nil
+	}
+
+	validProviders := []string{"env", "file", "vault"}
+	isValidProvider := false
+	for _, provider := range validProviders {
+		if d.CredentialProvider == provider {
+			isValidProvider = true
+			break
+		}
+	}
+	if !isValidProvider {
+		return &ConfigError{
+			Key:     "DATA_SOURCES_CREDENTIAL_PROVIDER",
+			Value:   d.CredentialProvider,
+			Message: fmt.Sprintf("Credential provider must be one of: %s", strings.Join(validProviders, ", ")),
+		}
+	}
+
+	if d.CredentialProvider == "file" && d.CredentialFilePath == "" {
+		return &ConfigError{
+			Key:     "DATA_SOURCES_CREDENTIAL_FILE_PATH",
+			Value:   d.CredentialFilePath,
+			Message: "Credential file path cannot be empty when the file credential provider is selected",
+		}
+	}
+
+	if d.CredentialProvider == "vault" {
+		if d.VaultAddr == "" {
+			return &ConfigError{
+				Key:     "DATA_SOURCES_VAULT_ADDR",
+				Value:   d.VaultAddr,
+				Message: "Vault address cannot be empty when the vault credential provider is selected",
+			}
+		}
+
+		if d.VaultToken == "" {
+			return &ConfigError{
+				Key:     "DATA_SOURCES_VAULT_TOKEN",
+				Value:   "***",
+				Message: "Vault token cannot be empty when the vault credential provider is selected",
+			}
+		}
+
+		if d.VaultMountPath == "" {
+			return &ConfigError{
+				Key:     "DATA_SOURCES_VAULT_MOUNT_PATH",
+				Value:   d.VaultMountPath,
+				Message: "Vault mount path cannot be empty when the vault credential provider is selected",
+			}
+		}
+	}
+
+	return nil
+}
+
+// Validate validates ResponseCacheConfig
+func (r *ResponseCacheConfig) Validate() error {
+	if !r.Enabled {
+		return nil
+	}
+
+	validBackends := []string{"memory", "redis"}
+	isValidBackend := false
+	for _, backend := range validBackends {
+		if r.Backend == backend {
+			isValidBackend = true
+			break
+		}
+	}
+	if !isValidBackend {
+		return &ConfigError{
+			Key:     "RESPONSE_CACHE_BACKEND",
+			Value:   r.Backend,
+			Message: fmt.Sprintf("Response cache backend must be one of: %s", strings.Join(validBackends, ", ")),
+		}
+	}
+
+	if r.MaxEntries <= 0 {
+		return &ConfigError{
+			Key:     "RESPONSE_CACHE_MAX_ENTRIES",
+			Value:   strconv.Itoa(r.MaxEntries),
+			Message: "Response cache max entries must be positive",
+		}
+	}
+
+	if r.MarketDataTTL <= 0 {
+		return &ConfigError{
+			Key:     "RESPONSE_CACHE_MARKET_DATA_TTL",
+			Value:   r.MarketDataTTL.String(),
+			Message: "Response cache market data TTL must be positive",
+		}
+	}
+
+	if r.NewsTTL <= 0 {
+		return &ConfigError{
+			Key:     "RESPONSE_CACHE_NEWS_TTL",
+			Value:   r.NewsTTL.String(),
+			Message: "Response cache news TTL must be positive",
+		}
+	}
+
+	return nil
+}
+
+// Validate validates GRPCServerConfig
+func (g *GRPCServerConfig) Validate() error {
+	if (g.TLSCertFile == "") != (g.TLSKeyFile == "") {
+		return &ConfigError{
+			Key:     "GRPC_TLS_CERT_FILE",
+			Value:   g.TLSCertFile,
+			Message: "gRPC TLS cert file and key file must both be set or both be empty",
+		}
+	}
+
+	if g.DrainTimeout <= 0 {
+		return &ConfigError{
+			Key:     "GRPC_DRAIN_TIMEOUT",
+			Value:   g.DrainTimeout.String(),
+			Message: "gRPC drain timeout must be positive",
+		}
+	}
+
+	if !isValidListenAddr(g.ListenAddr) {
+		return &ConfigError{
+			Key:     "SERVER_GRPC_LISTEN_ADDR",
+			Value:   g.ListenAddr,
+			Message: "must be empty, unix://path, or tcp://host:port",
+		}
+	}
+
+	if g.MaxConcurrentRequests < 0 {
+		return &ConfigError{
+			Key:     "GRPC_MAX_CONCURRENT_REQUESTS",
+			Value:   strconv.FormatInt(g.MaxConcurrentRequests, 10),
+			Message: "gRPC max concurrent requests cannot be negative",
+		}
+	}
+
+	if g.KeepaliveTime <= 0 {
+		return &ConfigError{
+			Key:     "GRPC_KEEPALIVE_TIME",
+			Value:   g.KeepaliveTime.String(),
+			Message: "gRPC keepalive time must be positive",
+		}
+	}
+
+	if g.KeepaliveTimeout <= 0 {
+		return &ConfigError{
+			Key:     "GRPC_KEEPALIVE_TIMEOUT",
+			Value:   g.KeepaliveTimeout.String(),
+			Message: "gRPC keepalive timeout must be positive",
+		}
+	}
+
+	return nil
+}
+
+// Validate validates GatewayConfig. Every field below only matters when
+// Enabled is set, since a disabled gateway never reads its port, timeout,
+// or CORS settings.
+func (g *GatewayConfig) Validate() error {
+	if !g.Enabled {
+		return nil
+	}
+
+	if !g.MultiplexHTTP {
+		if g.Port < 1 || g.Port > 65535 {
+			return &ConfigError{
+				Key:     "GATEWAY_PORT",
+				Value:   strconv.Itoa(g.Port),
+				Message: "must be between 1 and 65535",
+			}
+		}
+
+		if !isValidListenAddr(g.ListenAddr) {
+			return &ConfigError{
+				Key:     "GATEWAY_LISTEN_ADDR",
+				Value:   g.ListenAddr,
+				Message: "must be empty, unix://path, or tcp://host:port",
+			}
+		}
+	}
+
+	if g.DrainTimeout <= 0 {
+		return &ConfigError{
+			Key:     "GATEWAY_DRAIN_TIMEOUT",
+			Value:   g.DrainTimeout.String(),
+			Message: "gateway drain timeout must be positive",
+		}
+	}
+
+	return nil
+}
+
+// Validate validates LoggingConfig
+func (l *LoggingConfig) Validate() error {
+	if err := validate.Struct(l); err != nil {
+		return asConfigError(err)
+	}
+
+	// FilePath is only required when file logging is enabled, which the
+	// static tag DSL can't express, so it stays a manual check.
+	if l.EnableFile && l.FilePath == "" {
+		return &ConfigError{
+			Key:     "LOG_FILE_PATH",
+			Value:   l.FilePath,
+			Message: "Log file path cannot be empty when file logging is enabled",
+		}
+	}
+
+	return nil
+}
+
+// Validate validates MetricsConfig
+func (m *MetricsConfig) Validate() error {
+	return asConfigError(validate.Struct(m))
+}
+
+// Validate validates HealthConfig
+func (h *HealthConfig) Validate() error {
+	return asConfigError(validate.Struct(h))
+}
+
+// Helper validation functions
+
+func isValidBroker(broker string) bool {
+	parts := strings.Split(broker, ":")
+	if len(parts) != 2 {
+		return false
+	}
+
+	host := parts[0]
+	port := parts[1]
+
+	if host == "" {
+		return false
+	}
+
+	// Validate port
+	if len(port) == 0 {
+		return false
+	}
+
+	for _, char := range port {
+		if char < '0' || char > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isValidServiceURL(serviceURL string) bool {
+	// Check if it's a full URL
+	if strings.HasPrefix(serviceURL, "http://") || strings.HasPrefix(serviceURL, "https://") ||
+		strings.HasPrefix(serviceURL, "ws://") || strings.HasPrefix(serviceURL, "wss://") {
+		_, err := url.Parse(serviceURL)
+		return err == nil
+	}
+
+	// Otherwise, check if it's host:port
+	return isValidBroker(serviceURL)
+}
+
+func isValidOrigin(origin string) bool {
+	// Check if it's a valid URL
+	if strings.HasPrefix(origin, "http://") || strings.HasPrefix(origin, "https://") {
+		_, err := url.Parse(origin)
+		return err == nil
+	}
+
+	// Check if it's a valid hostname pattern
+	validOrigin := regexp.MustCompile(`^[a-zA-Z0-9.-]+$`)
+	return validOrigin.MatchString(origin)
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/market-intel/api-gateway/internal/config"
+	"github.com/market-intel/api-gateway/internal/config/reload"
+	"github.com/market-intel/api-gateway/pkg/logger"
+)
+
+// AdminHandler exposes operational endpoints for managing the running
+// gateway process: triggering a config reload without a restart, and
+// Kafka cluster operations (partition reassignment, consumer-group lag)
+// useful to operators of the market-intel topics.
+type AdminHandler struct {
+	reloadManager *reload.Manager
+	loadConfig    func() (*config.Config, error)
+	kafkaConfig   config.KafkaConfig
+}
+
+// NewAdminHandler creates an AdminHandler. reloadManager may be nil if
+// hot-reload hasn't been wired up, in which case Reload responds 503.
+// loadConfig re-reads configuration the same way the process did at
+// startup (e.g. config.Load(configFile)). kafkaConfig backs the
+// /admin/kafka/* endpoints; each call opens and closes its own
+// sarama.ClusterAdmin rather than holding one open for the server's
+// lifetime.
+func NewAdminHandler(kafkaConfig config.KafkaConfig, reloadManager *reload.Manager, loadConfig func() (*config.Config, error)) *AdminHandler {
+	return &AdminHandler{kafkaConfig: kafkaConfig, reloadManager: reloadManager, loadConfig: loadConfig}
+}
+
+// ReloadResponse reports the outcome of a POST /admin/reload call.
+type ReloadResponse struct {
+	Status  string   `json:"status"`
+	Changed []string `json:"changed,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// Reload re-reads configuration from the environment and applies it to the
+// running process the same way a SIGHUP would, returning the list of
+// fields that changed. It responds 409 if a changed field requires a
+// restart, and 422 if any other part of the reload (validation or a
+// subscriber) rejects the candidate config.
+func (h *AdminHandler) Reload(c *gin.Context) {
+	if h.reloadManager == nil {
+		c.JSON(http.StatusServiceUnavailable, ReloadResponse{Status: "unavailable", Error: "config hot-reload is not enabled"})
+		return
+	}
+
+	result, err := h.reloadManager.Reload(h.loadConfig)
+	if err != nil {
+		var restartErr *reload.RestartRequiredError
+		if errors.As(err, &restartErr) {
+			c.JSON(http.StatusConflict, ReloadResponse{Status: "restart_required", Error: err.Error()})
+			return
+		}
+
+		logger.Errorf("admin reload failed: %v", err)
+		c.JSON(http.StatusUnprocessableEntity, ReloadResponse{Status: "rejected", Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, ReloadResponse{Status: "reloaded", Changed: result.Changed})
+}
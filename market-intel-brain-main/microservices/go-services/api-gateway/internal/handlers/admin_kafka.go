@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/market-intel/api-gateway/pkg/kafka"
+	"github.com/market-intel/api-gateway/pkg/logger"
+)
+
+// PartitionReassignmentStatus mirrors sarama's per-partition view of an
+// in-progress replica reassignment (KIP-455).
+type PartitionReassignmentStatus struct {
+	Partition        int32   `json:"partition"`
+	Replicas         []int32 `json:"replicas"`
+	AddingReplicas   []int32 `json:"adding_replicas,omitempty"`
+	RemovingReplicas []int32 `json:"removing_replicas,omitempty"`
+}
+
+// ListPartitionReassignmentsResponse reports in-flight reassignments for a topic.
+type ListPartitionReassignmentsResponse struct {
+	Status     string                        `json:"status"`
+	Topic      string                        `json:"topic"`
+	Partitions []PartitionReassignmentStatus `json:"partitions,omitempty"`
+	Error      string                        `json:"error,omitempty"`
+}
+
+// ListPartitionReassignments handles GET
+// /admin/kafka/topics/:topic/reassignments, reporting any partition
+// replica reassignments currently in progress for the topic. An optional
+// ?partitions=0,1,2 query param limits the result to those partitions;
+// omitted, sarama reports on every partition of the topic.
+func (h *AdminHandler) ListPartitionReassignments(c *gin.Context) {
+	topic := c.Param("topic")
+
+	var partitions []int32
+	if raw := c.Query("partitions"); raw != "" {
+		for _, s := range strings.Split(raw, ",") {
+			n, err := strconv.Atoi(strings.TrimSpace(s))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, ListPartitionReassignmentsResponse{
+					Status: "error",
+					Topic:  topic,
+					Error:  "invalid partitions query param: " + err.Error(),
+				})
+				return
+			}
+			partitions = append(partitions, int32(n))
+		}
+	}
+
+	admin, err := kafka.NewAdminClient(&h.kafkaConfig)
+	if err != nil {
+		logger.Errorf("admin: failed to open Kafka cluster admin: %v", err)
+		c.JSON(http.StatusServiceUnavailable, ListPartitionReassignmentsResponse{Status: "error", Topic: topic, Error: err.Error()})
+		return
+	}
+	defer admin.Close()
+
+	statuses, err := admin.ListPartitionReassignments(topic, partitions)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, ListPartitionReassignmentsResponse{Status: "error", Topic: topic, Error: err.Error()})
+		return
+	}
+
+	resp := ListPartitionReassignmentsResponse{Status: "ok", Topic: topic}
+	for partition, status := range statuses[topic] {
+		resp.Partitions = append(resp.Partitions, PartitionReassignmentStatus{
+			Partition:        partition,
+			Replicas:         status.Replicas,
+			AddingReplicas:   status.AddingReplicas,
+			RemovingReplicas: status.RemovingReplicas,
+		})
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// AlterPartitionReassignmentsRequest is the body for POST
+// /admin/kafka/topics/:topic/reassignments. Assignment[i] is the target
+// replica list for partition i, in the same order sarama's
+// AlterPartitionReassignments expects.
+type AlterPartitionReassignmentsRequest struct {
+	Assignment [][]int32 `json:"assignment" binding:"required"`
+}
+
+// AlterPartitionReassignmentsResponse reports the outcome of submitting a
+// reassignment.
+type AlterPartitionReassignmentsResponse struct {
+	Status string `json:"status"`
+	Topic  string `json:"topic"`
+	Error  string `json:"error,omitempty"`
+}
+
+// AlterPartitionReassignments handles POST
+// /admin/kafka/topics/:topic/reassignments, submitting a new replica
+// assignment for the topic's partitions (KIP-455). The brokers carry out
+// the reassignment asynchronously; poll ListPartitionReassignments to
+// track progress.
+func (h *AdminHandler) AlterPartitionReassignments(c *gin.Context) {
+	topic := c.Param("topic")
+
+	var req AlterPartitionReassignmentsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, AlterPartitionReassignmentsResponse{Status: "error", Topic: topic, Error: err.Error()})
+		return
+	}
+
+	admin, err := kafka.NewAdminClient(&h.kafkaConfig)
+	if err != nil {
+		logger.Errorf("admin: failed to open Kafka cluster admin: %v", err)
+		c.JSON(http.StatusServiceUnavailable, AlterPartitionReassignmentsResponse{Status: "error", Topic: topic, Error: err.Error()})
+		return
+	}
+	defer admin.Close()
+
+	if err := admin.AlterPartitionReassignments(topic, req.Assignment); err != nil {
+		c.JSON(http.StatusBadGateway, AlterPartitionReassignmentsResponse{Status: "error", Topic: topic, Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, AlterPartitionReassignmentsResponse{Status: "accepted", Topic: topic})
+}
+
+// ConsumerGroupLagResponse reports a consumer group's state and
+// per-partition lag.
+type ConsumerGroupLagResponse struct {
+	Status string                  `json:"status"`
+	Group  string                  `json:"group,omitempty"`
+	Lag    *kafka.ConsumerGroupLag `json:"lag,omitempty"`
+	Error  string                  `json:"error,omitempty"`
+}
+
+// ConsumerGroupLag handles GET /admin/kafka/consumer-groups/:group/lag,
+// reporting the group's state and, for each partition it has committed
+// an offset on, how far that offset trails the partition's current log
+// end offset.
+func (h *AdminHandler) ConsumerGroupLag(c *gin.Context) {
+	group := c.Param("group")
+
+	lag, err := kafka.DescribeConsumerGroupLag(&h.kafkaConfig, group)
+	if err != nil {
+		logger.Errorf("admin: failed to describe lag for consumer group %s: %v", group, err)
+		c.JSON(http.StatusBadGateway, ConsumerGroupLagResponse{Status: "error", Group: group, Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, ConsumerGroupLagResponse{Status: "ok", Group: group, Lag: lag})
+}
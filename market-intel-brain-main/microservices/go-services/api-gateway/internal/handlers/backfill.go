@@ -0,0 +1,336 @@
+// Historical Backfill API
+// Streams a large [start, end) historical range as NDJSON instead of making
+// the caller issue thousands of one-off FetchMarketData calls. The range is
+// split into bar-count-sized chunks, fetched with bounded concurrency, and
+// re-ordered back onto the wire in chunk order with a resumable cursor so a
+// client that disconnects mid-stream can pick up where it left off.
+
+package handlers
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/market-intel/api-gateway/pb"
+	"github.com/market-intel/api-gateway/pkg/logger"
+	"github.com/market-intel/api-gateway/pkg/neterr"
+)
+
+const (
+	// backfillDefaultChunkSize is the bar-count budget per chunk when the
+	// request doesn't specify one
+	backfillDefaultChunkSize = 5000
+
+	// backfillMaxConcurrency bounds how many FetchHistoricalRange calls are
+	// in flight at once, across all symbols/chunks of a single request
+	backfillMaxConcurrency = 4
+
+	// backfillOverallTimeout is the hard deadline for an entire backfill
+	// request, regardless of how many chunks remain
+	backfillOverallTimeout = 5 * time.Minute
+)
+
+// Chunk retry policy for transient FetchHistoricalRange failures, same
+// shape as sourceRetry* but tuned shorter since a stuck chunk blocks the
+// reorder buffer from advancing
+const (
+	backfillRetryMaxAttempts  = 3
+	backfillRetryInitialDelay = 100 * time.Millisecond
+	backfillRetryMaxDelay     = 2 * time.Second
+)
+
+// backfillIntervalDurations maps a supported bar interval to its duration,
+// used to size chunks and to validate the request
+var backfillIntervalDurations = map[string]time.Duration{
+	"1m":  time.Minute,
+	"5m":  5 * time.Minute,
+	"15m": 15 * time.Minute,
+	"1h":  time.Hour,
+	"4h":  4 * time.Hour,
+	"1d":  24 * time.Hour,
+}
+
+// BackfillRequest describes a historical range to stream back as NDJSON.
+// Start/End are Unix milliseconds. Cursor, if set, resumes a previous
+// request at the point it left off instead of starting at Start.
+type BackfillRequest struct {
+	Symbols   []string `json:"symbols" binding:"required"`
+	SourceID  string   `json:"source_id"`
+	Start     int64    `json:"start" binding:"required"`
+	End       int64    `json:"end" binding:"required"`
+	Interval  string   `json:"interval" binding:"required"`
+	ChunkSize int      `json:"chunk_size"`
+	Cursor    string   `json:"cursor"`
+}
+
+// backfillCursor is the opaque, base64-encoded-JSON resume token returned
+// at the end of a stream (and accepted back as BackfillRequest.Cursor).
+// SymbolOffset/ChunkIdx identify the next chunk to fetch; LastTs is the
+// timestamp of the last row already emitted for that chunk, so a retry
+// doesn't re-send rows the client already has.
+type backfillCursor struct {
+	LastTs       int64 `json:"last_ts"`
+	SymbolOffset int   `json:"symbol_offset"`
+	ChunkIdx     int   `json:"chunk_idx"`
+}
+
+func encodeBackfillCursor(cur backfillCursor) string {
+	raw, _ := json.Marshal(cur)
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
+func decodeBackfillCursor(encoded string) (backfillCursor, error) {
+	var cur backfillCursor
+	if encoded == "" {
+		return cur, nil
+	}
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return cur, fmt.Errorf("invalid cursor: %w", err)
+	}
+	if err := json.Unmarshal(raw, &cur); err != nil {
+		return cur, fmt.Errorf("invalid cursor: %w", err)
+	}
+	return cur, nil
+}
+
+// backfillChunk is one [startMs, endMs) slice of the requested range
+type backfillChunk struct {
+	idx     int
+	startMs int64
+	endMs   int64
+}
+
+// buildBackfillChunks splits [start, end) into consecutive chunks spanning
+// chunkSize bars of the given interval
+func buildBackfillChunks(start, end int64, interval time.Duration, chunkSize int) []backfillChunk {
+	spanMs := interval.Milliseconds() * int64(chunkSize)
+	if spanMs <= 0 {
+		return nil
+	}
+
+	chunks := make([]backfillChunk, 0, (end-start)/spanMs+1)
+	idx := 0
+	for s := start; s < end; s += spanMs {
+		e := s + spanMs
+		if e > end {
+			e = end
+		}
+		chunks = append(chunks, backfillChunk{idx: idx, startMs: s, endMs: e})
+		idx++
+	}
+	return chunks
+}
+
+// backfillJobResult is what a single chunk fetch produces for the reorder
+// buffer: either rows (sorted by Timestamp) or an error
+type backfillJobResult struct {
+	chunk backfillChunk
+	rows  []pb.MarketData
+	err   error
+}
+
+// BackfillMarketData streams a historical range as NDJSON MarketData rows
+// followed by a final {"cursor":"..."} frame. It fans out up to
+// backfillMaxConcurrency concurrent FetchHistoricalRange calls per symbol,
+// reorders chunk results back into ascending order before writing them, and
+// stops (emitting a resumable cursor) on the first chunk that exhausts its
+// retries or on the overall deadline.
+func (h *DataIngestionHandler) BackfillMarketData(c *gin.Context) {
+	var req BackfillRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Errorf("Failed to bind backfill request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format", "details": err.Error()})
+		return
+	}
+
+	if req.SourceID == "" {
+		req.SourceID = "yahoo_finance"
+	}
+	if req.ChunkSize <= 0 {
+		req.ChunkSize = backfillDefaultChunkSize
+	}
+	if req.End <= req.Start {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "end must be after start"})
+		return
+	}
+
+	interval, ok := backfillIntervalDurations[req.Interval]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unsupported interval %q", req.Interval)})
+		return
+	}
+
+	resume, err := decodeBackfillCursor(req.Cursor)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if resume.SymbolOffset < 0 || resume.SymbolOffset > len(req.Symbols) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "cursor symbol_offset out of range"})
+		return
+	}
+
+	writer, err := newSSEWriter(c, "ndjson")
+	if err != nil {
+		logger.Errorf("Failed to start backfill stream: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), backfillOverallTimeout)
+	defer cancel()
+
+	chunks := buildBackfillChunks(req.Start, req.End, interval, req.ChunkSize)
+
+	symbolOffset := resume.SymbolOffset
+	startChunkIdx := resume.ChunkIdx
+	lastTs := resume.LastTs
+
+	for symbolOffset < len(req.Symbols) {
+		symbol := req.Symbols[symbolOffset]
+
+		stopped, failedChunkIdx, newLastTs := h.streamBackfillSymbol(ctx, writer, symbol, req.SourceID, req.Interval, chunks[minInt(startChunkIdx, len(chunks)):], lastTs)
+		if stopped {
+			cur := backfillCursor{LastTs: newLastTs, SymbolOffset: symbolOffset, ChunkIdx: failedChunkIdx}
+			_ = writer.WriteEvent("", "", gin.H{"cursor": encodeBackfillCursor(cur)})
+			return
+		}
+
+		symbolOffset++
+		startChunkIdx = 0
+		lastTs = 0
+	}
+
+	cur := backfillCursor{LastTs: 0, SymbolOffset: len(req.Symbols), ChunkIdx: 0}
+	_ = writer.WriteEvent("", "", gin.H{"cursor": encodeBackfillCursor(cur)})
+}
+
+// streamBackfillSymbol fetches chunks for a single symbol with bounded
+// concurrency, writes their rows to writer in ascending chunk order via a
+// small reorder buffer, and reports whether it stopped early (overall
+// deadline or exhausted chunk retries) along with where to resume.
+func (h *DataIngestionHandler) streamBackfillSymbol(ctx context.Context, writer *sseWriter, symbol, sourceID, interval string, chunks []backfillChunk, resumeLastTs int64) (stopped bool, failedChunkIdx int, lastTs int64) {
+	if len(chunks) == 0 {
+		return false, 0, 0
+	}
+
+	sem := make(chan struct{}, backfillMaxConcurrency)
+	results := make(chan backfillJobResult, len(chunks))
+
+	jobCtx, cancelJobs := context.WithCancel(ctx)
+	defer cancelJobs()
+
+	for _, chunk := range chunks {
+		sem <- struct{}{}
+		go func(chunk backfillChunk) {
+			defer func() { <-sem }()
+			rows, err := h.fetchHistoricalRangeWithRetry(jobCtx, &pb.FetchHistoricalRangeRequest{
+				Symbol:   symbol,
+				SourceId: sourceID,
+				Interval: interval,
+				StartMs:  chunk.startMs,
+				EndMs:    chunk.endMs,
+			})
+			results <- backfillJobResult{chunk: chunk, rows: rows, err: err}
+		}(chunk)
+	}
+
+	// Reorder buffer: hold completed chunks here until the one we need next
+	// (by ascending idx) has arrived, then flush it and any that follow it.
+	pending := make(map[int]backfillJobResult, backfillMaxConcurrency)
+	nextIdx := chunks[0].idx
+	lastIdx := chunks[len(chunks)-1].idx
+	lastTs = resumeLastTs
+
+	for received := 0; received < len(chunks); received++ {
+		select {
+		case <-ctx.Done():
+			return true, nextIdx, lastTs
+		case res := <-results:
+			pending[res.chunk.idx] = res
+		}
+
+		for {
+			res, ok := pending[nextIdx]
+			if !ok {
+				break
+			}
+			delete(pending, nextIdx)
+
+			if res.err != nil {
+				logger.Errorf("Backfill chunk %d for %s exhausted retries: %v", res.chunk.idx, symbol, res.err)
+				return true, nextIdx, lastTs
+			}
+
+			for _, row := range res.rows {
+				// On the first chunk of a resumed request, skip rows the
+				// client already received before it disconnected.
+				if res.chunk.idx == chunks[0].idx && row.Timestamp <= resumeLastTs {
+					continue
+				}
+				if err := writer.WriteEvent("", "", row); err != nil {
+					logger.Errorf("Backfill write failed for %s: %v", symbol, err)
+					return true, nextIdx, lastTs
+				}
+				if row.Timestamp > lastTs {
+					lastTs = row.Timestamp
+				}
+			}
+
+			if nextIdx == lastIdx {
+				return false, 0, lastTs
+			}
+			nextIdx++
+		}
+	}
+
+	return false, 0, lastTs
+}
+
+// fetchHistoricalRangeWithRetry calls the core engine's
+// FetchHistoricalRange, retrying with exponential backoff on transient
+// errors and returning the chunk's rows sorted by Timestamp
+func (h *DataIngestionHandler) fetchHistoricalRangeWithRetry(ctx context.Context, req *pb.FetchHistoricalRangeRequest) ([]pb.MarketData, error) {
+	var lastErr error
+
+	for attempt := 0; attempt < backfillRetryMaxAttempts; attempt++ {
+		response, err := h.coreEngineClient.FetchHistoricalRange(ctx, req)
+		if err == nil {
+			rows := response.MarketData
+			sort.Slice(rows, func(i, j int) bool { return rows[i].Timestamp < rows[j].Timestamp })
+			return rows, nil
+		}
+
+		lastErr = err
+		if !neterr.Classify(err).Retryable() || attempt == backfillRetryMaxAttempts-1 {
+			return nil, err
+		}
+
+		delay := neterr.Backoff(attempt, backfillRetryInitialDelay, backfillRetryMaxDelay)
+		logger.Warnf("FetchHistoricalRange failed for %s (attempt %d/%d, kind=%s): %v, retrying in %v",
+			req.Symbol, attempt+1, backfillRetryMaxAttempts, neterr.Classify(err), err, delay)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+
+	return nil, lastErr
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -3,30 +3,119 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
-	"github.com/sirupsen/logrus"
+	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/market-intel/api-gateway/internal/cache"
 	"github.com/market-intel/api-gateway/internal/config"
 	"github.com/market-intel/api-gateway/internal/services"
+	"github.com/market-intel/api-gateway/internal/sources"
+	"github.com/market-intel/api-gateway/pb"
+	"github.com/market-intel/api-gateway/pkg/kafka"
 	"github.com/market-intel/api-gateway/pkg/logger"
+	"github.com/market-intel/api-gateway/pkg/mqtt"
+	"github.com/market-intel/api-gateway/pkg/neterr"
+	"github.com/market-intel/api-gateway/pkg/stream"
+)
+
+// maxMQTTBufferSize bounds the in-memory ring buffer fed by MQTT subscribers
+const maxMQTTBufferSize = 1000
+
+// maxKafkaBufferSize bounds the in-memory ring buffer fed by Kafka consumers
+const maxKafkaBufferSize = 1000
+
+// WebSocket read/write deadlines for the market-data subscription
+// subprotocol; the read deadline is extended on every pong so a live but
+// quiet connection (no new subscriptions, no ticks) isn't mistaken for dead.
+const (
+	wsReadTimeout  = 90 * time.Second
+	wsWriteTimeout = 10 * time.Second
+)
+
+// Source connectivity retry policy for transient core-engine/upstream errors
+const (
+	sourceRetryMaxAttempts  = 3
+	sourceRetryInitialDelay = 100 * time.Millisecond
+	sourceRetryMaxDelay     = 2 * time.Second
 )
 
 // DataIngestionHandler handles data ingestion endpoints
 type DataIngestionHandler struct {
 	config           *config.Config
-	coreEngineClient *services.CoreEngineClient
+	coreEngineClient services.CoreEngineClient
 	upgrader         *websocket.Upgrader
+	streamClient     *stream.Client
+	registry         *sources.Registry
+
+	// responseCache and coalescer sit in front of coreEngineClient/adapter
+	// calls so that many requests for the same (symbols, source_id) tuple
+	// within a short TTL window share one upstream fetch; cacheMetrics is
+	// nil when no Prometheus registry was supplied (e.g. in tests)
+	responseCache cache.Cache
+	coalescer     *cache.Coalescer
+	cacheMetrics  *cache.Metrics
+
+	// shutdownCh is closed by Shutdown so open SSE streams drain on
+	// graceful server shutdown instead of being aborted mid-write
+	shutdownCh     chan struct{}
+	sseConnections int64
+
+	mqttMu      sync.RWMutex
+	mqttBrokers map[string]*mqtt.Broker
+	mqttMarket  []pb.MarketData
+	mqttNews    []pb.NewsItem
+
+	// lastMQTTUpdate is a UnixNano timestamp of the most recent MQTT append,
+	// read by BufferHealthy; zero means no MQTT item has ever arrived
+	lastMQTTUpdate int64
+
+	kafkaMu        sync.RWMutex
+	kafkaConsumers map[string]*kafka.Consumer
+	kafkaMarket    []pb.MarketData
+	kafkaNews      []pb.NewsItem
+
+	// lastKafkaUpdate is a UnixNano timestamp of the most recent Kafka
+	// append, read by BufferHealthy; zero means no Kafka item has ever
+	// arrived
+	lastKafkaUpdate int64
 }
 
-// NewDataIngestionHandler creates a new data ingestion handler
-func NewDataIngestionHandler(config *config.Config, coreEngineClient *services.CoreEngineClient) *DataIngestionHandler {
+// mqttStaleAfter is how long the MQTT buffers may go without a new item
+// before BufferHealthy reports them unhealthy
+const mqttStaleAfter = 2 * time.Minute
+
+// kafkaStaleAfter is how long the Kafka buffers may go without a new item
+// before BufferHealthy reports them unhealthy
+const kafkaStaleAfter = 2 * time.Minute
+
+// NewDataIngestionHandler creates a new data ingestion handler. metricsRegistry
+// may be nil (e.g. in tests), in which case the response cache still
+// coalesces and caches requests, it just doesn't emit Prometheus counters.
+func NewDataIngestionHandler(config *config.Config, coreEngineClient services.CoreEngineClient, metricsRegistry *prometheus.Registry) *DataIngestionHandler {
+	// The fetcher is left nil when there is no core-engine connection; the
+	// stream client then skips backfill and relies on live ticks alone.
+	var fetcher stream.MarketDataFetcher
+	if coreEngineClient != nil {
+		fetcher = coreEngineClient
+	}
+
+	var cacheMetrics *cache.Metrics
+	if metricsRegistry != nil {
+		cacheMetrics = cache.NewMetrics(metricsRegistry)
+	}
+
 	return &DataIngestionHandler{
 		config:           config,
 		coreEngineClient: coreEngineClient,
@@ -34,6 +123,58 @@ func NewDataIngestionHandler(config *config.Config, coreEngineClient *services.C
 			ReadBufferSize:  1024,
 			WriteBufferSize: 1024,
 		},
+		streamClient:   stream.NewClient(stream.DefaultConfig(config.Services.CoreEngineStream), fetcher),
+		registry:       newSourceRegistry(config),
+		responseCache:  newResponseCache(config),
+		coalescer:      cache.NewCoalescer(),
+		cacheMetrics:   cacheMetrics,
+		shutdownCh:     make(chan struct{}),
+		mqttBrokers:    make(map[string]*mqtt.Broker),
+		kafkaConsumers: make(map[string]*kafka.Consumer),
+	}
+}
+
+// Shutdown signals every open SSE stream to stop so a graceful server
+// shutdown drains them instead of having http.Server.Close abort them
+// mid-write. Safe to call at most once.
+func (h *DataIngestionHandler) Shutdown() {
+	close(h.shutdownCh)
+}
+
+// newSourceRegistry builds the in-process adapter registry from cfg, or
+// returns nil if data sources are disabled or the registry fails to build
+// (e.g. a misconfigured credential store), in which case every fetch falls
+// back to the core engine exactly as it did before the registry existed.
+func newSourceRegistry(cfg *config.Config) *sources.Registry {
+	if !cfg.DataSources.Enabled {
+		return nil
+	}
+
+	credStore, err := newCredentialStore(cfg.DataSources)
+	if err != nil {
+		logger.Errorf("data_ingestion: failed to build credential store: %v", err)
+		return nil
+	}
+
+	registry, err := sources.NewRegistry(sources.DefaultAdapterConfigs(), credStore)
+	if err != nil {
+		logger.Errorf("data_ingestion: failed to build source adapter registry: %v", err)
+		return nil
+	}
+
+	return registry
+}
+
+// newCredentialStore builds the CredentialStore backend named by
+// cfg.CredentialProvider
+func newCredentialStore(cfg config.DataSourcesConfig) (sources.CredentialStore, error) {
+	switch cfg.CredentialProvider {
+	case "file":
+		return sources.NewFileCredentialStore(cfg.CredentialFilePath)
+	case "vault":
+		return sources.NewVaultCredentialStore(cfg.VaultAddr, cfg.VaultToken, cfg.VaultMountPath), nil
+	default:
+		return sources.NewEnvCredentialStore(), nil
 	}
 }
 
@@ -61,11 +202,11 @@ type FetchNewsDataRequest struct {
 
 // FetchNewsDataResponse represents the response for news data
 type FetchNewsDataResponse struct {
-	Success    bool                 `json:"success"`
-	Message    string               `json:"message"`
-	NewsItems  []pb.NewsItem        `json:"news_items,omitempty"`
-	Metadata   map[string]interface{} `json:"metadata"`
-	Timestamp  time.Time             `json:"timestamp"`
+	Success   bool                   `json:"success"`
+	Message   string                 `json:"message"`
+	NewsItems []pb.NewsItem          `json:"news_items,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata"`
+	Timestamp time.Time              `json:"timestamp"`
 }
 
 // GetMarketDataBufferRequest represents the request for getting market data buffer
@@ -91,17 +232,51 @@ type GetNewsBufferRequest struct {
 
 // GetNewsBufferResponse represents the response for news buffer
 type GetNewsBufferResponse struct {
-	Success    bool                 `json:"success"`
-	Message    string               `json:"message"`
-	NewsItems  []pb.NewsItem        `json:"news_items,omitempty"`
-	Metadata   map[string]interface{} `json:"metadata"`
-	Timestamp  time.Time             `json:"timestamp"`
+	Success   bool                   `json:"success"`
+	Message   string                 `json:"message"`
+	NewsItems []pb.NewsItem          `json:"news_items,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata"`
+	Timestamp time.Time              `json:"timestamp"`
+}
+
+// PurgeCacheRequest represents the request for purging response cache entries
+type PurgeCacheRequest struct {
+	Pattern string `json:"pattern" binding:"required"`
+}
+
+// PurgeCacheResponse represents the response for a cache purge
+type PurgeCacheResponse struct {
+	Success   bool                   `json:"success"`
+	Message   string                 `json:"message"`
+	Removed   int                    `json:"removed"`
+	Metadata  map[string]interface{} `json:"metadata"`
+	Timestamp time.Time              `json:"timestamp"`
 }
 
 // ConnectDataSourceRequest represents the request for connecting to a data source
 type ConnectDataSourceRequest struct {
-	SourceID string `json:"source_id" binding:"required"`
-	APIKey   string `json:"api_key"`
+	SourceID   string           `json:"source_id" binding:"required"`
+	APIKey     string           `json:"api_key"`
+	SourceType string           `json:"source_type"`
+	MQTT       *MQTTSourceSpec  `json:"mqtt,omitempty"`
+	Kafka      *KafkaSourceSpec `json:"kafka,omitempty"`
+}
+
+// MQTTSourceSpec configures an MQTT broker connection when SourceType is "mqtt"
+type MQTTSourceSpec struct {
+	BrokerURL string   `json:"broker_url" binding:"required"`
+	Username  string   `json:"username"`
+	Password  string   `json:"password"`
+	Topics    []string `json:"topics"`
+}
+
+// KafkaSourceSpec names the topics to consume when SourceType is "kafka".
+// The broker connection itself (brokers, SASL/TLS, consumer tuning) comes
+// from the application's own config.KafkaConfig rather than the request,
+// since those are operational settings, not per-source credentials.
+type KafkaSourceSpec struct {
+	MarketTopics []string `json:"market_topics"`
+	NewsTopics   []string `json:"news_topics"`
 }
 
 // ConnectDataSourceResponse represents the response for connecting to a data source
@@ -125,7 +300,7 @@ type GetIngestionStatsResponse struct {
 // FetchMarketData handles the market data fetching endpoint
 func (h *DataIngestionHandler) FetchMarketData(c *gin.Context) {
 	startTime := time.Now()
-	
+
 	var req FetchMarketDataRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		logger.Errorf("Failed to bind request: %v", err)
@@ -158,33 +333,69 @@ func (h *DataIngestionHandler) FetchMarketData(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
 	defer cancel()
 
-	// Call Rust service
-	response, err := h.coreEngineClient.FetchMarketData(ctx, &pb.FetchMarketDataRequest{
-		Symbols:  req.Symbols,
-		SourceId: req.SourceID,
+	// fetchWithCache coalesces concurrent requests for the same
+	// (source_id, symbols) tuple onto a single fetch and, when the response
+	// cache is enabled, serves repeats within the TTL straight out of it.
+	// Within fn, prefer an in-process adapter when the registry has one
+	// registered for this source; ErrUnknownSource falls through to the
+	// core engine.
+	key := marketDataCacheKey(req.SourceID, req.Symbols)
+	marketData, payload, hit, coalesced, err := fetchWithCache(ctx, h.responseCache, h.coalescer, h.cacheMetrics, "market_data", key, h.config.ResponseCache.MarketDataTTL, func() ([]pb.MarketData, error) {
+		if h.registry != nil {
+			if data, ferr := h.registry.Fetch(ctx, req.SourceID, req.Symbols); ferr == nil {
+				return data, nil
+			} else if !errors.Is(ferr, sources.ErrUnknownSource) {
+				return nil, &adapterFetchError{sourceID: req.SourceID, err: ferr}
+			}
+		}
+
+		response, ferr := h.fetchMarketDataWithRetry(ctx, &pb.FetchMarketDataRequest{
+			Symbols:  req.Symbols,
+			SourceId: req.SourceID,
+		})
+		if ferr != nil {
+			return nil, ferr
+		}
+		if response.Status != pb.ResponseStatus_RESPONSE_STATUS_SUCCESS {
+			return nil, &grpcStatusError{status: response.Status}
+		}
+		return response.MarketData, nil
 	})
 
 	if err != nil {
-		logger.Errorf("Failed to fetch market data: %v", err)
-		statusCode, message := h.mapGRPCToHTTPError(err)
-		c.JSON(statusCode, FetchMarketDataResponse{
-			Success:   false,
-			Message:   message,
-			Metadata:  map[string]interface{}{"error": err.Error()},
-			Timestamp: startTime,
-		})
+		var adapterErr *adapterFetchError
+		var statusErr *grpcStatusError
+		switch {
+		case errors.As(err, &adapterErr):
+			logger.Errorf("Failed to fetch market data from adapter %s: %v", adapterErr.sourceID, adapterErr.err)
+			c.JSON(http.StatusBadGateway, FetchMarketDataResponse{
+				Success:   false,
+				Message:   "Failed to fetch market data from data source",
+				Metadata:  map[string]interface{}{"error": adapterErr.err.Error(), "source_id": adapterErr.sourceID, "adapter": true},
+				Timestamp: startTime,
+			})
+		case errors.As(err, &statusErr):
+			statusCode, message := h.mapResponseStatus(statusErr.status)
+			c.JSON(statusCode, FetchMarketDataResponse{
+				Success:   false,
+				Message:   message,
+				Metadata:  map[string]interface{}{"grpc_status": statusErr.status.String()},
+				Timestamp: startTime,
+			})
+		default:
+			logger.Errorf("Failed to fetch market data: %v", err)
+			statusCode, message, errKind := h.mapGRPCToHTTPError(err)
+			c.JSON(statusCode, FetchMarketDataResponse{
+				Success:   false,
+				Message:   message,
+				Metadata:  map[string]interface{}{"error": err.Error(), "error_kind": string(errKind), "retryable": errKind.Retryable()},
+				Timestamp: startTime,
+			})
+		}
 		return
 	}
 
-	// Check response status
-	if response.Status != pb.ResponseStatus_RESPONSE_STATUS_SUCCESS {
-		statusCode, message := h.mapResponseStatus(response.Status)
-		c.JSON(statusCode, FetchMarketDataResponse{
-			Success:   false,
-			Message:   message,
-			Metadata:  map[string]interface{}{"grpc_status": response.Status.String()},
-			Timestamp: startTime,
-		})
+	if h.respondNotModified(c, payload, h.config.ResponseCache.MarketDataTTL) {
 		return
 	}
 
@@ -193,15 +404,16 @@ func (h *DataIngestionHandler) FetchMarketData(c *gin.Context) {
 		"source_id":     req.SourceID,
 		"symbols_count": len(req.Symbols),
 		"response_time": time.Since(startTime).Seconds(),
-		"grpc_status":   response.Status.String(),
+		"cache_hit":     hit,
+		"coalesced":     coalesced,
 	}
 
 	logger.Infof("Successfully fetched market data for %d symbols from %s", len(req.Symbols), req.SourceID)
 
 	c.JSON(http.StatusOK, FetchMarketDataResponse{
 		Success:    true,
-		Message:    response.Message,
-		MarketData: response.MarketData,
+		Message:    "market data fetched successfully",
+		MarketData: marketData,
 		Metadata:   metadata,
 		Timestamp:  startTime,
 	})
@@ -210,7 +422,7 @@ func (h *DataIngestionHandler) FetchMarketData(c *gin.Context) {
 // FetchNewsData handles the news data fetching endpoint
 func (h *DataIngestionHandler) FetchNewsData(c *gin.Context) {
 	startTime := time.Now()
-	
+
 	var req FetchNewsDataRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		logger.Errorf("Failed to bind request: %v", err)
@@ -246,53 +458,86 @@ func (h *DataIngestionHandler) FetchNewsData(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
 	defer cancel()
 
-	// Call Rust service
-	response, err := h.coreEngineClient.FetchNewsData(ctx, &pb.FetchNewsDataRequest{
-		Keywords:  req.Keywords,
-		SourceId:  req.SourceID,
-		HoursBack: int32(req.HoursBack),
+	// See FetchMarketData for why fn tries the adapter registry first and
+	// falls through to the core engine on ErrUnknownSource.
+	key := newsCacheKey(req.SourceID, req.Keywords, req.HoursBack)
+	newsItems, payload, hit, coalesced, err := fetchWithCache(ctx, h.responseCache, h.coalescer, h.cacheMetrics, "news", key, h.config.ResponseCache.NewsTTL, func() ([]pb.NewsItem, error) {
+		if h.registry != nil {
+			if items, ferr := h.registry.FetchNews(ctx, req.SourceID, req.Keywords, req.HoursBack); ferr == nil {
+				return items, nil
+			} else if !errors.Is(ferr, sources.ErrUnknownSource) {
+				return nil, &adapterFetchError{sourceID: req.SourceID, err: ferr}
+			}
+		}
+
+		response, ferr := h.coreEngineClient.FetchNewsData(ctx, &pb.FetchNewsDataRequest{
+			Keywords:  req.Keywords,
+			SourceId:  req.SourceID,
+			HoursBack: int32(req.HoursBack),
+		})
+		if ferr != nil {
+			return nil, ferr
+		}
+		if response.Status != pb.ResponseStatus_RESPONSE_STATUS_SUCCESS {
+			return nil, &grpcStatusError{status: response.Status}
+		}
+		return response.NewsItems, nil
 	})
 
 	if err != nil {
-		logger.Errorf("Failed to fetch news data: %v", err)
-		statusCode, message := h.mapGRPCToHTTPError(err)
-		c.JSON(statusCode, FetchNewsDataResponse{
-			Success:   false,
-			Message:   message,
-			Metadata:  map[string]interface{}{"error": err.Error()},
-			Timestamp: startTime,
-		})
+		var adapterErr *adapterFetchError
+		var statusErr *grpcStatusError
+		switch {
+		case errors.As(err, &adapterErr):
+			logger.Errorf("Failed to fetch news data from adapter %s: %v", adapterErr.sourceID, adapterErr.err)
+			c.JSON(http.StatusBadGateway, FetchNewsDataResponse{
+				Success:   false,
+				Message:   "Failed to fetch news data from data source",
+				Metadata:  map[string]interface{}{"error": adapterErr.err.Error(), "source_id": adapterErr.sourceID, "adapter": true},
+				Timestamp: startTime,
+			})
+		case errors.As(err, &statusErr):
+			statusCode, message := h.mapResponseStatus(statusErr.status)
+			c.JSON(statusCode, FetchNewsDataResponse{
+				Success:   false,
+				Message:   message,
+				Metadata:  map[string]interface{}{"grpc_status": statusErr.status.String()},
+				Timestamp: startTime,
+			})
+		default:
+			logger.Errorf("Failed to fetch news data: %v", err)
+			statusCode, message, errKind := h.mapGRPCToHTTPError(err)
+			c.JSON(statusCode, FetchNewsDataResponse{
+				Success:   false,
+				Message:   message,
+				Metadata:  map[string]interface{}{"error": err.Error(), "error_kind": string(errKind), "retryable": errKind.Retryable()},
+				Timestamp: startTime,
+			})
+		}
 		return
 	}
 
-	// Check response status
-	if response.Status != pb.ResponseStatus_RESPONSE_STATUS_SUCCESS {
-		statusCode, message := h.mapResponseStatus(response.Status)
-		c.JSON(statusCode, FetchNewsDataResponse{
-			Success:   false,
-			Message:   message,
-			Metadata:  map[string]interface{}{"grpc_status": response.Status.String()},
-			Timestamp: startTime,
-		})
+	if h.respondNotModified(c, payload, h.config.ResponseCache.NewsTTL) {
 		return
 	}
 
 	// Prepare response metadata
 	metadata := map[string]interface{}{
-		"source_id":     req.SourceID,
+		"source_id":      req.SourceID,
 		"keywords_count": len(req.Keywords),
-		"hours_back":    req.HoursBack,
-		"response_time": time.Since(startTime).Seconds(),
-		"grpc_status":   response.Status.String(),
+		"hours_back":     req.HoursBack,
+		"response_time":  time.Since(startTime).Seconds(),
+		"cache_hit":      hit,
+		"coalesced":      coalesced,
 	}
 
-	logger.Infof("Successfully fetched %d news items for %d keywords from %s", 
-		len(response.NewsItems), len(req.Keywords), req.SourceID)
+	logger.Infof("Successfully fetched %d news items for %d keywords from %s",
+		len(newsItems), len(req.Keywords), req.SourceID)
 
 	c.JSON(http.StatusOK, FetchNewsDataResponse{
 		Success:   true,
-		Message:   response.Message,
-		NewsItems: response.NewsItems,
+		Message:   "news data fetched successfully",
+		NewsItems: newsItems,
 		Metadata:  metadata,
 		Timestamp: startTime,
 	})
@@ -301,10 +546,10 @@ func (h *DataIngestionHandler) FetchNewsData(c *gin.Context) {
 // GetMarketDataBuffer handles getting market data from buffer
 func (h *DataIngestionHandler) GetMarketDataBuffer(c *gin.Context) {
 	startTime := time.Now()
-	
+
 	symbol := c.Query("symbol")
 	limitStr := c.DefaultQuery("limit", "100")
-	
+
 	limit, err := strconv.Atoi(limitStr)
 	if err != nil || limit <= 0 {
 		limit = 100
@@ -314,33 +559,58 @@ func (h *DataIngestionHandler) GetMarketDataBuffer(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 	defer cancel()
 
-	// Call Rust service
-	response, err := h.coreEngineClient.GetMarketDataBuffer(ctx, &pb.GetMarketDataBufferRequest{
-		Symbol: symbol,
-		Limit:  int32(limit),
+	// The core engine's buffer is coalesced/cached like any other fetch;
+	// the MQTT- and Kafka-fed live buffers are merged in afterwards, uncached, so a busy
+	// poller still sees ticks that arrived since the cache entry was set.
+	key := bufferCacheKey("market_data", symbol, limit)
+	bufferedData, _, hit, coalesced, err := fetchWithCache(ctx, h.responseCache, h.coalescer, h.cacheMetrics, "market_data_buffer", key, h.config.ResponseCache.MarketDataTTL, func() ([]pb.MarketData, error) {
+		response, ferr := h.coreEngineClient.GetMarketDataBuffer(ctx, &pb.GetMarketDataBufferRequest{
+			Symbol: symbol,
+			Limit:  int32(limit),
+		})
+		if ferr != nil {
+			return nil, ferr
+		}
+		if response.Status != pb.ResponseStatus_RESPONSE_STATUS_SUCCESS {
+			return nil, &grpcStatusError{status: response.Status}
+		}
+		return response.MarketData, nil
 	})
 
 	if err != nil {
+		var statusErr *grpcStatusError
+		if errors.As(err, &statusErr) {
+			statusCode, message := h.mapResponseStatus(statusErr.status)
+			c.JSON(statusCode, GetMarketDataBufferResponse{
+				Success:   false,
+				Message:   message,
+				Metadata:  map[string]interface{}{"grpc_status": statusErr.status.String()},
+				Timestamp: startTime,
+			})
+			return
+		}
 		logger.Errorf("Failed to get market data buffer: %v", err)
-		statusCode, message := h.mapGRPCToHTTPError(err)
+		statusCode, message, errKind := h.mapGRPCToHTTPError(err)
 		c.JSON(statusCode, GetMarketDataBufferResponse{
 			Success:   false,
 			Message:   message,
-			Metadata:  map[string]interface{}{"error": err.Error()},
+			Metadata:  map[string]interface{}{"error": err.Error(), "error_kind": string(errKind), "retryable": errKind.Retryable()},
 			Timestamp: startTime,
 		})
 		return
 	}
 
-	// Check response status
-	if response.Status != pb.ResponseStatus_RESPONSE_STATUS_SUCCESS {
-		statusCode, message := h.mapResponseStatus(response.Status)
-		c.JSON(statusCode, GetMarketDataBufferResponse{
-			Success:   false,
-			Message:   message,
-			Metadata:  map[string]interface{}{"grpc_status": response.Status.String()},
-			Timestamp: startTime,
-		})
+	// Merge in market data pushed by MQTT subscribers and Kafka consumers,
+	// then trim to limit
+	marketData := h.mqttMarketDataSince(symbol, limit)
+	marketData = append(marketData, h.kafkaMarketDataSince(symbol, limit)...)
+	marketData = append(marketData, bufferedData...)
+	if len(marketData) > limit {
+		marketData = marketData[:limit]
+	}
+
+	mergedPayload, merr := json.Marshal(marketData)
+	if merr == nil && h.respondNotModified(c, mergedPayload, h.config.ResponseCache.MarketDataTTL) {
 		return
 	}
 
@@ -348,17 +618,18 @@ func (h *DataIngestionHandler) GetMarketDataBuffer(c *gin.Context) {
 	metadata := map[string]interface{}{
 		"symbol":        symbol,
 		"limit":         limit,
-		"items_count":   len(response.MarketData),
+		"items_count":   len(marketData),
 		"response_time": time.Since(startTime).Seconds(),
-		"grpc_status":   response.Status.String(),
+		"cache_hit":     hit,
+		"coalesced":     coalesced,
 	}
 
-	logger.Infof("Retrieved %d market data items from buffer", len(response.MarketData))
+	logger.Infof("Retrieved %d market data items from buffer", len(marketData))
 
 	c.JSON(http.StatusOK, GetMarketDataBufferResponse{
 		Success:    true,
-		Message:    response.Message,
-		MarketData: response.MarketData,
+		Message:    "market data buffer retrieved successfully",
+		MarketData: marketData,
 		Metadata:   metadata,
 		Timestamp:  startTime,
 	})
@@ -367,10 +638,10 @@ func (h *DataIngestionHandler) GetMarketDataBuffer(c *gin.Context) {
 // GetNewsBuffer handles getting news from buffer
 func (h *DataIngestionHandler) GetNewsBuffer(c *gin.Context) {
 	startTime := time.Now()
-	
+
 	keywords := c.QueryArray("keywords")
 	limitStr := c.DefaultQuery("limit", "100")
-	
+
 	limit, err := strconv.Atoi(limitStr)
 	if err != nil || limit <= 0 {
 		limit = 100
@@ -380,33 +651,57 @@ func (h *DataIngestionHandler) GetNewsBuffer(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 	defer cancel()
 
-	// Call Rust service
-	response, err := h.coreEngineClient.GetNewsBuffer(ctx, &pb.GetNewsBufferRequest{
-		Keywords: keywords,
-		Limit:    int32(limit),
+	// See GetMarketDataBuffer for why only the core engine fetch is
+	// coalesced/cached; the MQTT- and Kafka-fed live buffers are merged in uncached.
+	key := bufferCacheKey("news", strings.Join(keywords, ","), limit)
+	bufferedItems, _, hit, coalesced, err := fetchWithCache(ctx, h.responseCache, h.coalescer, h.cacheMetrics, "news_buffer", key, h.config.ResponseCache.NewsTTL, func() ([]pb.NewsItem, error) {
+		response, ferr := h.coreEngineClient.GetNewsBuffer(ctx, &pb.GetNewsBufferRequest{
+			Keywords: keywords,
+			Limit:    int32(limit),
+		})
+		if ferr != nil {
+			return nil, ferr
+		}
+		if response.Status != pb.ResponseStatus_RESPONSE_STATUS_SUCCESS {
+			return nil, &grpcStatusError{status: response.Status}
+		}
+		return response.NewsItems, nil
 	})
 
 	if err != nil {
+		var statusErr *grpcStatusError
+		if errors.As(err, &statusErr) {
+			statusCode, message := h.mapResponseStatus(statusErr.status)
+			c.JSON(statusCode, GetNewsBufferResponse{
+				Success:   false,
+				Message:   message,
+				Metadata:  map[string]interface{}{"grpc_status": statusErr.status.String()},
+				Timestamp: startTime,
+			})
+			return
+		}
 		logger.Errorf("Failed to get news buffer: %v", err)
-		statusCode, message := h.mapGRPCToHTTPError(err)
+		statusCode, message, errKind := h.mapGRPCToHTTPError(err)
 		c.JSON(statusCode, GetNewsBufferResponse{
 			Success:   false,
 			Message:   message,
-			Metadata:  map[string]interface{}{"error": err.Error()},
+			Metadata:  map[string]interface{}{"error": err.Error(), "error_kind": string(errKind), "retryable": errKind.Retryable()},
 			Timestamp: startTime,
 		})
 		return
 	}
 
-	// Check response status
-	if response.Status != pb.ResponseStatus_RESPONSE_STATUS_SUCCESS {
-		statusCode, message := h.mapResponseStatus(response.Status)
-		c.JSON(statusCode, GetNewsBufferResponse{
-			Success:   false,
-			Message:   message,
-			Metadata:  map[string]interface{}{"grpc_status": response.Status.String()},
-			Timestamp: startTime,
-		})
+	// Merge in news items pushed by MQTT subscribers and Kafka consumers,
+	// then trim to limit
+	newsItems := h.mqttNewsSince(keywords, limit)
+	newsItems = append(newsItems, h.kafkaNewsSince(keywords, limit)...)
+	newsItems = append(newsItems, bufferedItems...)
+	if len(newsItems) > limit {
+		newsItems = newsItems[:limit]
+	}
+
+	mergedPayload, merr := json.Marshal(newsItems)
+	if merr == nil && h.respondNotModified(c, mergedPayload, h.config.ResponseCache.NewsTTL) {
 		return
 	}
 
@@ -414,17 +709,18 @@ func (h *DataIngestionHandler) GetNewsBuffer(c *gin.Context) {
 	metadata := map[string]interface{}{
 		"keywords":      keywords,
 		"limit":         limit,
-		"items_count":   len(response.NewsItems),
+		"items_count":   len(newsItems),
 		"response_time": time.Since(startTime).Seconds(),
-		"grpc_status":   response.Status.String(),
+		"cache_hit":     hit,
+		"coalesced":     coalesced,
 	}
 
-	logger.Infof("Retrieved %d news items from buffer", len(response.NewsItems))
+	logger.Infof("Retrieved %d news items from buffer", len(newsItems))
 
 	c.JSON(http.StatusOK, GetNewsBufferResponse{
 		Success:   true,
-		Message:   response.Message,
-		NewsItems: response.NewsItems,
+		Message:   "news buffer retrieved successfully",
+		NewsItems: newsItems,
 		Metadata:  metadata,
 		Timestamp: startTime,
 	})
@@ -439,15 +735,15 @@ func (h *DataIngestionHandler) GetIngestionStats(c *gin.Context) {
 	defer cancel()
 
 	// Call Rust service
-	response, err := h.coreEngineClient.GetIngestionStats(ctx, &pb.Empty{})
+	response, err := h.coreEngineClient.GetIngestionStats(ctx, &pb.GetIngestionStatsRequest{})
 
 	if err != nil {
 		logger.Errorf("Failed to get ingestion stats: %v", err)
-		statusCode, message := h.mapGRPCToHTTPError(err)
+		statusCode, message, errKind := h.mapGRPCToHTTPError(err)
 		c.JSON(statusCode, GetIngestionStatsResponse{
 			Success:   false,
 			Message:   message,
-			Metadata:  map[string]interface{}{"error": err.Error()},
+			Metadata:  map[string]interface{}{"error": err.Error(), "error_kind": string(errKind), "retryable": errKind.Retryable()},
 			Timestamp: startTime,
 		})
 		return
@@ -470,6 +766,10 @@ func (h *DataIngestionHandler) GetIngestionStats(c *gin.Context) {
 		"response_time": time.Since(startTime).Seconds(),
 		"grpc_status":   response.Status.String(),
 	}
+	if h.registry != nil {
+		metadata["adapter_stats"] = h.registry.Stats()
+	}
+	metadata["sse_connections"] = atomic.LoadInt64(&h.sseConnections)
 
 	logger.Info("Retrieved ingestion statistics")
 
@@ -482,10 +782,63 @@ func (h *DataIngestionHandler) GetIngestionStats(c *gin.Context) {
 	})
 }
 
+// PurgeCache handles operator-triggered invalidation of the response cache,
+// e.g. after a corporate action changes a symbol's market data mid-TTL
+func (h *DataIngestionHandler) PurgeCache(c *gin.Context) {
+	startTime := time.Now()
+
+	var req PurgeCacheRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Errorf("Failed to bind request: %v", err)
+		c.JSON(http.StatusBadRequest, PurgeCacheResponse{
+			Success:   false,
+			Message:   "Invalid request format",
+			Metadata:  map[string]interface{}{"error": err.Error()},
+			Timestamp: startTime,
+		})
+		return
+	}
+
+	if h.responseCache == nil {
+		c.JSON(http.StatusOK, PurgeCacheResponse{
+			Success:   true,
+			Message:   "response cache is disabled, nothing to purge",
+			Metadata:  map[string]interface{}{"pattern": req.Pattern},
+			Timestamp: startTime,
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	removed, err := h.responseCache.Purge(ctx, req.Pattern)
+	if err != nil {
+		logger.Errorf("Failed to purge response cache pattern %q: %v", req.Pattern, err)
+		c.JSON(http.StatusInternalServerError, PurgeCacheResponse{
+			Success:   false,
+			Message:   "Failed to purge cache",
+			Metadata:  map[string]interface{}{"error": err.Error(), "pattern": req.Pattern},
+			Timestamp: startTime,
+		})
+		return
+	}
+
+	logger.Infof("Purged %d response cache entries matching pattern %q", removed, req.Pattern)
+
+	c.JSON(http.StatusOK, PurgeCacheResponse{
+		Success:   true,
+		Message:   "cache purged",
+		Removed:   removed,
+		Metadata:  map[string]interface{}{"pattern": req.Pattern},
+		Timestamp: startTime,
+	})
+}
+
 // ConnectDataSource handles connecting to a data source
 func (h *DataIngestionHandler) ConnectDataSource(c *gin.Context) {
 	startTime := time.Now()
-	
+
 	var req ConnectDataSourceRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		logger.Errorf("Failed to bind request: %v", err)
@@ -509,23 +862,34 @@ func (h *DataIngestionHandler) ConnectDataSource(c *gin.Context) {
 		return
 	}
 
+	// MQTT and Kafka sources are subscribed to in-process rather than
+	// forwarded to the core engine
+	if req.SourceType == "mqtt" {
+		h.connectMQTTSource(c, req, startTime)
+		return
+	}
+	if req.SourceType == "kafka" {
+		h.connectKafkaSource(c, req, startTime)
+		return
+	}
+
 	// Create context with timeout
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
 	defer cancel()
 
-	// Call Rust service
-	response, err := h.coreEngineClient.ConnectDataSource(ctx, &pb.ConnectDataSourceRequest{
+	// Call Rust service, retrying with backoff on transient failures
+	response, err := h.connectDataSourceWithRetry(ctx, &pb.ConnectDataSourceRequest{
 		SourceId: req.SourceID,
 		ApiKey:   req.APIKey,
 	})
 
 	if err != nil {
 		logger.Errorf("Failed to connect to data source: %v", err)
-		statusCode, message := h.mapGRPCToHTTPError(err)
+		statusCode, message, errKind := h.mapGRPCToHTTPError(err)
 		c.JSON(statusCode, ConnectDataSourceResponse{
 			Success:   false,
 			Message:   message,
-			Metadata:  map[string]interface{}{"error": err.Error()},
+			Metadata:  map[string]interface{}{"error": err.Error(), "error_kind": string(errKind), "retryable": errKind.Retryable()},
 			Timestamp: startTime,
 		})
 		return
@@ -562,7 +926,27 @@ func (h *DataIngestionHandler) ConnectDataSource(c *gin.Context) {
 	})
 }
 
-// WebSocketMarketData handles WebSocket connections for real-time market data
+// wsWriter serializes writes to a *websocket.Conn shared by the inbound
+// message handler and the outbound stream pump, since gorilla/websocket
+// permits only one concurrent writer per connection.
+type wsWriter struct {
+	conn *websocket.Conn
+	mu   sync.Mutex
+}
+
+func (w *wsWriter) WriteMessage(messageType int, data []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_ = w.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	return w.conn.WriteMessage(messageType, data)
+}
+
+// WebSocketMarketData handles WebSocket connections for real-time market
+// data. Callers opt into a live stream of ticks (reconnect/backfill handled
+// transparently by the pkg/stream client) via a "symbols" query parameter,
+// in addition to the SignalFlow-style subscribe/unsubscribe/authenticate
+// subprotocol driven by subscriptionManager and the legacy one-off
+// fetch-by-message protocol.
 func (h *DataIngestionHandler) WebSocketMarketData(c *gin.Context) {
 	// Upgrade HTTP connection to WebSocket
 	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
@@ -573,14 +957,89 @@ func (h *DataIngestionHandler) WebSocketMarketData(c *gin.Context) {
 	}
 	defer conn.Close()
 
-	logger.Info("WebSocket market data connection established")
+	conn.SetReadDeadline(time.Now().Add(wsReadTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsReadTimeout))
+	})
+
+	w := &wsWriter{conn: conn}
+	symbols := parseSymbolsParam(c.Query("symbols"))
+
+	logger.Infof("WebSocket market data connection established for symbols=%v", symbols)
+
+	var streamDone chan struct{}
+	var cancelStream func()
+	if h.streamClient != nil {
+		ticks, cancel := h.streamClient.Subscribe(symbols)
+		cancelStream = cancel
+		streamDone = make(chan struct{})
+		go func() {
+			defer close(streamDone)
+			h.pumpMarketDataTicks(w, ticks)
+		}()
+	}
+
+	subMgr := newSubscriptionManager(h.coreEngineClient, w, defaultSubscriptionManagerConfig())
+
+	// Handle inbound WebSocket messages; blocks until the client disconnects
+	h.handleWebSocketMarketData(conn, w, subMgr)
+
+	subMgr.Close()
+
+	if cancelStream != nil {
+		cancelStream()
+		<-streamDone
+	}
+}
+
+// pumpMarketDataTicks forwards deduplicated, backfilled ticks from the
+// shared stream.Client to this connection until ticks is closed
+func (h *DataIngestionHandler) pumpMarketDataTicks(w *wsWriter, ticks <-chan pb.MarketData) {
+	for data := range ticks {
+		payload, err := json.Marshal(map[string]interface{}{
+			"timestamp":   time.Now(),
+			"type":        "market_data",
+			"market_data": data,
+		})
+		if err != nil {
+			logger.Errorf("Failed to marshal streamed market data: %v", err)
+			continue
+		}
+
+		if err := w.WriteMessage(websocket.TextMessage, payload); err != nil {
+			logger.Errorf("Failed to write streamed market data: %v", err)
+			return
+		}
+	}
+}
+
+// parseSymbolsParam splits a comma-separated "symbols" query parameter
+func parseSymbolsParam(raw string) []string {
+	if raw == "" {
+		return nil
+	}
+
+	parts := strings.Split(raw, ",")
+	symbols := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			symbols = append(symbols, strings.ToUpper(p))
+		}
+	}
+	return symbols
+}
 
-	// Handle WebSocket messages in a goroutine
-	go h.handleWebSocketMarketData(conn)
+// controlMessageTypes identifies messages that belong to the subscribe/
+// unsubscribe/authenticate subprotocol rather than the legacy one-off
+// fetch-by-message protocol
+var controlMessageTypes = map[string]bool{
+	controlTypeSubscribe:    true,
+	controlTypeUnsubscribe:  true,
+	controlTypeAuthenticate: true,
 }
 
 // handleWebSocketMarketData handles WebSocket messages for market data
-func (h *DataIngestionHandler) handleWebSocketMarketData(conn *websocket.Conn) {
+func (h *DataIngestionHandler) handleWebSocketMarketData(conn *websocket.Conn, w *wsWriter, subMgr *subscriptionManager) {
 	for {
 		// Read message from client
 		messageType, message, err := conn.ReadMessage()
@@ -596,20 +1055,33 @@ func (h *DataIngestionHandler) handleWebSocketMarketData(conn *websocket.Conn) {
 		// Handle different message types
 		switch messageType {
 		case websocket.TextMessage:
-			// Parse JSON message
+			var envelope struct {
+				Type string `json:"type"`
+			}
+			if err := json.Unmarshal(message, &envelope); err != nil {
+				logger.Errorf("Failed to unmarshal WebSocket message: %v", err)
+				h.sendWebSocketError(w, "Invalid message format")
+				continue
+			}
+
+			if controlMessageTypes[envelope.Type] {
+				subMgr.handleControl(message)
+				continue
+			}
+
+			// Legacy one-off fetch-by-message protocol
 			var req FetchMarketDataRequest
 			if err := json.Unmarshal(message, &req); err != nil {
 				logger.Errorf("Failed to unmarshal WebSocket message: %v", err)
-				h.sendWebSocketError(conn, "Invalid message format")
+				h.sendWebSocketError(w, "Invalid message format")
 				continue
 			}
 
-			// Process market data request
-			go h.processWebSocketMarketDataRequest(conn, req)
+			go h.processWebSocketMarketDataRequest(w, req)
 
 		case websocket.BinaryMessage:
 			logger.Warn("Binary message not supported")
-			h.sendWebSocketError(conn, "Binary messages not supported")
+			h.sendWebSocketError(w, "Binary messages not supported")
 
 		case websocket.CloseMessage:
 			logger.Info("WebSocket close message received")
@@ -617,13 +1089,13 @@ func (h *DataIngestionHandler) handleWebSocketMarketData(conn *websocket.Conn) {
 
 		case websocket.PingMessage:
 			// Respond with pong
-			conn.WriteMessage(websocket.PongMessage, message)
+			w.WriteMessage(websocket.PongMessage, message)
 		}
 	}
 }
 
 // processWebSocketMarketDataRequest processes market data requests from WebSocket
-func (h *DataIngestionHandler) processWebSocketMarketDataRequest(conn *websocket.Conn, req FetchMarketDataRequest) {
+func (h *DataIngestionHandler) processWebSocketMarketDataRequest(w *wsWriter, req FetchMarketDataRequest) {
 	startTime := time.Now()
 
 	// Set default source if not provided
@@ -663,13 +1135,13 @@ func (h *DataIngestionHandler) processWebSocketMarketDataRequest(conn *websocket
 		return
 	}
 
-	if err := conn.WriteMessage(websocket.TextMessage, jsonResponse); err != nil {
+	if err := w.WriteMessage(websocket.TextMessage, jsonResponse); err != nil {
 		logger.Errorf("Failed to send WebSocket response: %v", err)
 	}
 }
 
 // sendWebSocketError sends an error message over WebSocket
-func (h *DataIngestionHandler) sendWebSocketError(conn *websocket.Conn, message string) {
+func (h *DataIngestionHandler) sendWebSocketError(w *wsWriter, message string) {
 	errorResponse := map[string]interface{}{
 		"timestamp": time.Now(),
 		"success":   false,
@@ -682,19 +1154,374 @@ func (h *DataIngestionHandler) sendWebSocketError(conn *websocket.Conn, message
 		return
 	}
 
-	if err := conn.WriteMessage(websocket.TextMessage, jsonResponse); err != nil {
+	if err := w.WriteMessage(websocket.TextMessage, jsonResponse); err != nil {
 		logger.Errorf("Failed to send WebSocket error: %v", err)
 	}
 }
 
-// mapGRPCToHTTPError maps gRPC errors to HTTP status codes
-func (h *DataIngestionHandler) mapGRPCToHTTPError(err error) (int, string) {
+// connectMQTTSource wires up an MQTT broker connection and subscribes it to
+// the topics given in req.MQTT, pushing decoded messages into the handler's
+// in-process buffers rather than forwarding the request to the core engine.
+func (h *DataIngestionHandler) connectMQTTSource(c *gin.Context, req ConnectDataSourceRequest, startTime time.Time) {
+	if req.MQTT == nil || req.MQTT.BrokerURL == "" {
+		c.JSON(http.StatusBadRequest, ConnectDataSourceResponse{
+			Success:   false,
+			Message:   "MQTT broker URL is required",
+			Metadata:  map[string]interface{}{"error": "missing_mqtt_config"},
+			Timestamp: startTime,
+		})
+		return
+	}
+
+	h.mqttMu.Lock()
+	if _, exists := h.mqttBrokers[req.SourceID]; exists {
+		h.mqttMu.Unlock()
+		c.JSON(http.StatusConflict, ConnectDataSourceResponse{
+			Success:   false,
+			Message:   "Source already connected",
+			Metadata:  map[string]interface{}{"source_id": req.SourceID},
+			Timestamp: startTime,
+		})
+		return
+	}
+	h.mqttMu.Unlock()
+
+	mqttConfig := mqtt.DefaultConfig(req.MQTT.BrokerURL)
+	mqttConfig.ClientID = "api-gateway-" + req.SourceID
+	mqttConfig.Username = req.MQTT.Username
+	mqttConfig.Password = req.MQTT.Password
+	if len(req.MQTT.Topics) > 0 {
+		subscribers := make([]mqtt.Subscriber, 0, len(req.MQTT.Topics))
+		for _, topic := range req.MQTT.Topics {
+			subscribers = append(subscribers, mqtt.Subscriber{Topic: topic, QoS: mqtt.QoSAtLeastOnce, Format: mqtt.PayloadFormatJSON})
+		}
+		mqttConfig.Subscribers = subscribers
+	}
+
+	broker, err := mqtt.NewBroker(mqttConfig, h.appendMQTTMarketData, h.appendMQTTNewsItem)
+	if err != nil {
+		logger.Errorf("Failed to connect MQTT source %s: %v", req.SourceID, err)
+		mqttErrKind := neterr.Classify(err)
+		c.JSON(http.StatusBadGateway, ConnectDataSourceResponse{
+			Success:   false,
+			Message:   "Failed to connect to MQTT broker",
+			Metadata:  map[string]interface{}{"error": err.Error(), "error_kind": string(mqttErrKind), "retryable": mqttErrKind.Retryable()},
+			Timestamp: startTime,
+		})
+		return
+	}
+
+	h.mqttMu.Lock()
+	h.mqttBrokers[req.SourceID] = broker
+	h.mqttMu.Unlock()
+
+	metadata := map[string]interface{}{
+		"source_id":     req.SourceID,
+		"broker_url":    req.MQTT.BrokerURL,
+		"subscriptions": len(mqttConfig.Subscribers),
+		"response_time": time.Since(startTime).Seconds(),
+	}
+
+	logger.Infof("Successfully connected MQTT data source: %s", req.SourceID)
+
+	c.JSON(http.StatusOK, ConnectDataSourceResponse{
+		Success:   true,
+		Message:   "Connected to MQTT broker",
+		Connected: true,
+		Metadata:  metadata,
+		Timestamp: startTime,
+	})
+}
+
+// appendMQTTMarketData is the mqtt.MarketDataHandler callback that feeds
+// decoded ticks into the shared buffer read by GetMarketDataBuffer
+func (h *DataIngestionHandler) appendMQTTMarketData(data *pb.MarketData) {
+	h.mqttMu.Lock()
+	defer h.mqttMu.Unlock()
+
+	h.mqttMarket = append(h.mqttMarket, *data)
+	if len(h.mqttMarket) > maxMQTTBufferSize {
+		h.mqttMarket = h.mqttMarket[len(h.mqttMarket)-maxMQTTBufferSize:]
+	}
+	atomic.StoreInt64(&h.lastMQTTUpdate, time.Now().UnixNano())
+}
+
+// appendMQTTNewsItem is the mqtt.NewsItemHandler callback that feeds decoded
+// news items into the shared buffer read by GetNewsBuffer
+func (h *DataIngestionHandler) appendMQTTNewsItem(item *pb.NewsItem) {
+	h.mqttMu.Lock()
+	defer h.mqttMu.Unlock()
+
+	h.mqttNews = append(h.mqttNews, *item)
+	if len(h.mqttNews) > maxMQTTBufferSize {
+		h.mqttNews = h.mqttNews[len(h.mqttNews)-maxMQTTBufferSize:]
+	}
+	atomic.StoreInt64(&h.lastMQTTUpdate, time.Now().UnixNano())
+}
+
+// connectKafkaSource joins a Kafka consumer group for the topics given in
+// req.Kafka, using the application's own Kafka broker/security settings,
+// and pushes decoded messages into the handler's in-process buffers rather
+// than forwarding the request to the core engine.
+func (h *DataIngestionHandler) connectKafkaSource(c *gin.Context, req ConnectDataSourceRequest, startTime time.Time) {
+	if req.Kafka == nil || (len(req.Kafka.MarketTopics) == 0 && len(req.Kafka.NewsTopics) == 0) {
+		c.JSON(http.StatusBadRequest, ConnectDataSourceResponse{
+			Success:   false,
+			Message:   "At least one Kafka market or news topic is required",
+			Metadata:  map[string]interface{}{"error": "missing_kafka_topics"},
+			Timestamp: startTime,
+		})
+		return
+	}
+
+	h.kafkaMu.Lock()
+	if _, exists := h.kafkaConsumers[req.SourceID]; exists {
+		h.kafkaMu.Unlock()
+		c.JSON(http.StatusConflict, ConnectDataSourceResponse{
+			Success:   false,
+			Message:   "Source already connected",
+			Metadata:  map[string]interface{}{"source_id": req.SourceID},
+			Timestamp: startTime,
+		})
+		return
+	}
+	h.kafkaMu.Unlock()
+
+	groupID := h.config.Kafka.ConsumerGroup + "-" + req.SourceID
+	consumer, err := kafka.NewConsumer(&h.config.Kafka, groupID, req.Kafka.MarketTopics, req.Kafka.NewsTopics, h.appendKafkaMarketData, h.appendKafkaNewsItem)
+	if err != nil {
+		logger.Errorf("Failed to connect Kafka source %s: %v", req.SourceID, err)
+		kafkaErrKind := neterr.Classify(err)
+		c.JSON(http.StatusBadGateway, ConnectDataSourceResponse{
+			Success:   false,
+			Message:   "Failed to join Kafka consumer group",
+			Metadata:  map[string]interface{}{"error": err.Error(), "error_kind": string(kafkaErrKind), "retryable": kafkaErrKind.Retryable()},
+			Timestamp: startTime,
+		})
+		return
+	}
+
+	h.kafkaMu.Lock()
+	h.kafkaConsumers[req.SourceID] = consumer
+	h.kafkaMu.Unlock()
+
+	metadata := map[string]interface{}{
+		"source_id":      req.SourceID,
+		"consumer_group": groupID,
+		"market_topics":  req.Kafka.MarketTopics,
+		"news_topics":    req.Kafka.NewsTopics,
+		"response_time":  time.Since(startTime).Seconds(),
+	}
+
+	logger.Infof("Successfully connected Kafka data source: %s", req.SourceID)
+
+	c.JSON(http.StatusOK, ConnectDataSourceResponse{
+		Success:   true,
+		Message:   "Joined Kafka consumer group",
+		Connected: true,
+		Metadata:  metadata,
+		Timestamp: startTime,
+	})
+}
+
+// appendKafkaMarketData is the kafka.MarketDataHandler callback that feeds
+// decoded ticks into the shared buffer read by GetMarketDataBuffer
+func (h *DataIngestionHandler) appendKafkaMarketData(data *pb.MarketData) {
+	h.kafkaMu.Lock()
+	defer h.kafkaMu.Unlock()
+
+	h.kafkaMarket = append(h.kafkaMarket, *data)
+	if len(h.kafkaMarket) > maxKafkaBufferSize {
+		h.kafkaMarket = h.kafkaMarket[len(h.kafkaMarket)-maxKafkaBufferSize:]
+	}
+	atomic.StoreInt64(&h.lastKafkaUpdate, time.Now().UnixNano())
+}
+
+// appendKafkaNewsItem is the kafka.NewsItemHandler callback that feeds
+// decoded news items into the shared buffer read by GetNewsBuffer
+func (h *DataIngestionHandler) appendKafkaNewsItem(item *pb.NewsItem) {
+	h.kafkaMu.Lock()
+	defer h.kafkaMu.Unlock()
+
+	h.kafkaNews = append(h.kafkaNews, *item)
+	if len(h.kafkaNews) > maxKafkaBufferSize {
+		h.kafkaNews = h.kafkaNews[len(h.kafkaNews)-maxKafkaBufferSize:]
+	}
+	atomic.StoreInt64(&h.lastKafkaUpdate, time.Now().UnixNano())
+}
+
+// kafkaMarketDataSince returns up to limit buffered Kafka market data
+// items, optionally filtered to a single symbol, most recent first
+func (h *DataIngestionHandler) kafkaMarketDataSince(symbol string, limit int) []pb.MarketData {
+	h.kafkaMu.RLock()
+	defer h.kafkaMu.RUnlock()
+
+	result := make([]pb.MarketData, 0, limit)
+	for i := len(h.kafkaMarket) - 1; i >= 0 && len(result) < limit; i-- {
+		if symbol != "" && h.kafkaMarket[i].Symbol != symbol {
+			continue
+		}
+		result = append(result, h.kafkaMarket[i])
+	}
+	return result
+}
+
+// kafkaNewsSince returns up to limit buffered Kafka news items, optionally
+// filtered by keyword match against the title/content, most recent first
+func (h *DataIngestionHandler) kafkaNewsSince(keywords []string, limit int) []pb.NewsItem {
+	h.kafkaMu.RLock()
+	defer h.kafkaMu.RUnlock()
+
+	result := make([]pb.NewsItem, 0, limit)
+	for i := len(h.kafkaNews) - 1; i >= 0 && len(result) < limit; i-- {
+		if len(keywords) > 0 && !newsItemMatchesKeywords(h.kafkaNews[i], keywords) {
+			continue
+		}
+		result = append(result, h.kafkaNews[i])
+	}
+	return result
+}
+
+// BufferHealthy reports whether the MQTT and Kafka ingestion buffers are
+// still receiving fresh data, for GRPCServer's health service to fold into
+// its overall serving status. If a given source has never delivered an
+// item, its buffer is trivially healthy -- there's nothing to go stale.
+func (h *DataIngestionHandler) BufferHealthy() error {
+	if last := atomic.LoadInt64(&h.lastMQTTUpdate); last != 0 {
+		if age := time.Since(time.Unix(0, last)); age > mqttStaleAfter {
+			return fmt.Errorf("MQTT ingestion buffers stale for %s (threshold %s)", age.Round(time.Second), mqttStaleAfter)
+		}
+	}
+
+	if last := atomic.LoadInt64(&h.lastKafkaUpdate); last != 0 {
+		if age := time.Since(time.Unix(0, last)); age > kafkaStaleAfter {
+			return fmt.Errorf("Kafka ingestion buffers stale for %s (threshold %s)", age.Round(time.Second), kafkaStaleAfter)
+		}
+	}
+
+	return nil
+}
+
+// mqttMarketDataSince returns up to limit buffered MQTT market data items,
+// optionally filtered to a single symbol, most recent first
+func (h *DataIngestionHandler) mqttMarketDataSince(symbol string, limit int) []pb.MarketData {
+	h.mqttMu.RLock()
+	defer h.mqttMu.RUnlock()
+
+	result := make([]pb.MarketData, 0, limit)
+	for i := len(h.mqttMarket) - 1; i >= 0 && len(result) < limit; i-- {
+		if symbol != "" && h.mqttMarket[i].Symbol != symbol {
+			continue
+		}
+		result = append(result, h.mqttMarket[i])
+	}
+	return result
+}
+
+// mqttNewsSince returns up to limit buffered MQTT news items, optionally
+// filtered by keyword match against the title/content, most recent first
+func (h *DataIngestionHandler) mqttNewsSince(keywords []string, limit int) []pb.NewsItem {
+	h.mqttMu.RLock()
+	defer h.mqttMu.RUnlock()
+
+	result := make([]pb.NewsItem, 0, limit)
+	for i := len(h.mqttNews) - 1; i >= 0 && len(result) < limit; i-- {
+		if len(keywords) > 0 && !newsItemMatchesKeywords(h.mqttNews[i], keywords) {
+			continue
+		}
+		result = append(result, h.mqttNews[i])
+	}
+	return result
+}
+
+// newsItemMatchesKeywords reports whether any keyword appears in the item's
+// title or content (case-sensitive substring match, matching the simple
+// filtering already done by the core engine for consistency)
+func newsItemMatchesKeywords(item pb.NewsItem, keywords []string) bool {
+	for _, kw := range keywords {
+		if strings.Contains(item.Title, kw) || strings.Contains(item.Content, kw) {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchMarketDataWithRetry calls the core engine's FetchMarketData, retrying
+// with exponential backoff on transient errors (timeouts, DNS, connection
+// refused, rate limiting) and giving up immediately on permanent ones
+// (auth failures, bad arguments) so callers can return 4xx without delay.
+func (h *DataIngestionHandler) fetchMarketDataWithRetry(ctx context.Context, req *pb.FetchMarketDataRequest) (*pb.FetchMarketDataResponse, error) {
+	var lastErr error
+
+	for attempt := 0; attempt < sourceRetryMaxAttempts; attempt++ {
+		response, err := h.coreEngineClient.FetchMarketData(ctx, req)
+		if err == nil {
+			return response, nil
+		}
+
+		lastErr = err
+		if !neterr.Classify(err).Retryable() || attempt == sourceRetryMaxAttempts-1 {
+			return nil, err
+		}
+
+		delay := neterr.Backoff(attempt, sourceRetryInitialDelay, sourceRetryMaxDelay)
+		logger.Warnf("FetchMarketData failed (attempt %d/%d, kind=%s): %v, retrying in %v",
+			attempt+1, sourceRetryMaxAttempts, neterr.Classify(err), err, delay)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+
+	return nil, lastErr
+}
+
+// connectDataSourceWithRetry calls the core engine's ConnectDataSource,
+// retrying with exponential backoff on transient errors per the same
+// source connectivity retry policy as fetchMarketDataWithRetry.
+func (h *DataIngestionHandler) connectDataSourceWithRetry(ctx context.Context, req *pb.ConnectDataSourceRequest) (*pb.ConnectDataSourceResponse, error) {
+	var lastErr error
+
+	for attempt := 0; attempt < sourceRetryMaxAttempts; attempt++ {
+		response, err := h.coreEngineClient.ConnectDataSource(ctx, req)
+		if err == nil {
+			return response, nil
+		}
+
+		lastErr = err
+		if !neterr.Classify(err).Retryable() || attempt == sourceRetryMaxAttempts-1 {
+			return nil, err
+		}
+
+		delay := neterr.Backoff(attempt, sourceRetryInitialDelay, sourceRetryMaxDelay)
+		logger.Warnf("ConnectDataSource failed (attempt %d/%d, kind=%s): %v, retrying in %v",
+			attempt+1, sourceRetryMaxAttempts, neterr.Classify(err), err, delay)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+
+	return nil, lastErr
+}
+
+// mapGRPCToHTTPError maps gRPC errors to HTTP status codes and classifies
+// the error via neterr so callers can surface error_kind/retryable in the
+// response body and decide whether a retry is worthwhile.
+func (h *DataIngestionHandler) mapGRPCToHTTPError(err error) (int, string, neterr.Kind) {
+	kind := neterr.Classify(err)
+
 	if grpcErr, ok := status.FromError(err); ok {
-		return mapGRPCToHTTPStatus(grpcErr.Code())
+		statusCode, message := mapGRPCToHTTPStatus(grpcErr.Code())
+		return statusCode, message, kind
 	}
-	
+
 	// Non-gRPC errors
-	return http.StatusInternalServerError, err.Error()
+	return http.StatusInternalServerError, err.Error(), kind
 }
 
 // mapGRPCToHTTPStatus maps gRPC status codes to HTTP status codes
@@ -738,3 +1565,28 @@ func mapGRPCToHTTPStatus(grpcStatus codes.Code) (int, string) {
 		return http.StatusInternalServerError, "Unknown error"
 	}
 }
+
+// mapResponseStatus maps a core engine ResponseStatus to an HTTP status code
+// and message. This is distinct from mapGRPCToHTTPStatus: that one covers
+// transport-level gRPC failures, while this covers application-level
+// outcomes the core engine reports inside a successful RPC response.
+func (h *DataIngestionHandler) mapResponseStatus(respStatus pb.ResponseStatus) (int, string) {
+	switch respStatus {
+	case pb.ResponseStatus_RESPONSE_STATUS_SUCCESS:
+		return http.StatusOK, "Success"
+	case pb.ResponseStatus_RESPONSE_STATUS_ERROR:
+		return http.StatusInternalServerError, "Internal server error"
+	case pb.ResponseStatus_RESPONSE_STATUS_NOT_FOUND:
+		return http.StatusNotFound, "Not found"
+	case pb.ResponseStatus_RESPONSE_STATUS_UNAUTHORIZED:
+		return http.StatusUnauthorized, "Unauthorized"
+	case pb.ResponseStatus_RESPONSE_STATUS_FORBIDDEN:
+		return http.StatusForbidden, "Forbidden"
+	case pb.ResponseStatus_RESPONSE_STATUS_VALIDATION_ERROR:
+		return http.StatusBadRequest, "Validation error"
+	case pb.ResponseStatus_RESPONSE_STATUS_INTERNAL_ERROR:
+		return http.StatusInternalServerError, "Internal error"
+	default:
+		return http.StatusInternalServerError, "Unknown status"
+	}
+}
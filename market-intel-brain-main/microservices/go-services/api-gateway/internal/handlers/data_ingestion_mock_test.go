@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/market-intel/api-gateway/internal/config"
+	"github.com/market-intel/api-gateway/internal/mocks"
+	"github.com/market-intel/api-gateway/pb"
+)
+
+// TestFetchMarketDataWithGeneratedMock exercises the handler against the
+// mockery-generated mocks.CoreEngineClient. It's kept small on purpose and
+// only covers the happy path to prove the generated mock wires up cleanly.
+func TestFetchMarketDataWithGeneratedMock(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	coreEngineClient := mocks.NewCoreEngineClient(t)
+	coreEngineClient.EXPECT().
+		FetchMarketData(mock.Anything, mock.Anything).
+		Return(&pb.FetchMarketDataResponse{
+			Status:  pb.ResponseStatus_RESPONSE_STATUS_SUCCESS,
+			Message: "ok",
+			MarketData: []pb.MarketData{
+				{Symbol: "AAPL", Price: 150.25, Volume: 1000000, Timestamp: 1, Source: "yahoo_finance"},
+			},
+		}, nil)
+
+	handler := NewDataIngestionHandler(&config.Config{}, coreEngineClient, nil)
+	router := gin.New()
+	router.POST("/api/v1/market-data/fetch", handler.FetchMarketData)
+
+	body, err := json.Marshal(FetchMarketDataRequest{Symbols: []string{"AAPL"}, SourceID: "yahoo_finance"})
+	require.NoError(t, err)
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/market-data/fetch", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp FetchMarketDataResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.True(t, resp.Success)
+	require.Len(t, resp.MarketData, 1)
+	require.Equal(t, "AAPL", resp.MarketData[0].Symbol)
+}
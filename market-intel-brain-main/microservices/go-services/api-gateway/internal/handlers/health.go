@@ -6,77 +6,133 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/market-intel/api-gateway/internal/config"
+	"github.com/market-intel/api-gateway/internal/health"
 	"github.com/market-intel/api-gateway/internal/services"
 	"github.com/market-intel/api-gateway/pkg/logger"
 )
 
+// HealthHandler serves the gateway's Kubernetes-style health endpoints over
+// a health.Registry built from config: Healthz is pure liveness, Readyz
+// gates on the registry's critical checks, and Health returns every check's
+// detail.
 type HealthHandler struct {
 	config           *config.Config
-	coreEngineClient *services.CoreEngineClient
+	coreEngineClient services.CoreEngineClient
+	registry         *health.Registry
+	readyGates       []<-chan struct{}
 }
 
-func NewHealthHandler(config *config.Config, coreEngineClient *services.CoreEngineClient) *HealthHandler {
+// NewHealthHandler builds a HealthHandler whose registry probes Postgres,
+// Redis, the Kafka brokers, the core engine, and any other gRPC service
+// configured in config.Services, caching results for config.Health.CacheFor
+// so a burst of probe traffic doesn't turn into a burst of load on every
+// dependency. metricsRegistry may be nil, in which case per-check
+// Prometheus gauges are skipped. readyGates are additional components (e.g.
+// the gRPC server) that Readyz refuses to report ready for until all of
+// them have closed, on top of the registry's own checks.
+func NewHealthHandler(cfg *config.Config, coreEngineClient services.CoreEngineClient, metricsRegistry *prometheus.Registry, readyGates []<-chan struct{}) *HealthHandler {
+	var m *health.Metrics
+	if metricsRegistry != nil {
+		m = health.NewMetrics(metricsRegistry)
+	}
+
+	timeout := cfg.Health.CheckTimeout
+	checks := []health.Check{
+		healthCheckAPIGateway(cfg),
+		healthCheckPostgres(cfg.Database, timeout),
+		healthCheckRedis(cfg.Redis, timeout),
+		healthCheckKafka(cfg.Kafka, timeout),
+	}
+	if coreEngineClient != nil {
+		checks = append(checks, healthCheckCoreEngine(coreEngineClient, timeout))
+	}
+
+	factory := services.NewClientFactory()
+	for _, dep := range []struct {
+		name     string
+		endpoint config.ServiceEndpoint
+	}{
+		{"auth_service", cfg.Services.AuthService},
+		{"analytics", cfg.Services.Analytics},
+		{"vector_store", cfg.Services.VectorStore},
+	} {
+		if dep.endpoint.Target != "" {
+			checks = append(checks, healthCheckGRPCService(factory, dep.name, dep.endpoint, timeout))
+		}
+	}
+
 	return &HealthHandler{
-		config:           config,
+		config:           cfg,
 		coreEngineClient: coreEngineClient,
+		registry:         health.NewRegistry(checks, cfg.Health.CacheFor, m),
+		readyGates:       readyGates,
 	}
 }
 
+// HealthResponse is the detailed, per-dependency shape Health returns
 type HealthResponse struct {
-	Status    string                 `json:"status"`
-	Timestamp time.Time              `json:"timestamp"`
-	Services  map[string]interface{} `json:"services"`
+	Status    health.Status   `json:"status"`
+	Timestamp time.Time       `json:"timestamp"`
+	Checks    []health.Result `json:"checks"`
 }
 
-func (h *HealthHandler) Health(c *gin.Context) {
-	response := HealthResponse{
-		Status:    "healthy",
-		Timestamp: time.Now(),
-		Services:  make(map[string]interface{}),
-	}
-
-	// Check API Gateway health
-	response.Services["api_gateway"] = map[string]interface{}{
-		"status":      "healthy",
-		"version":     "0.1.0",
-		"environment": h.config.Environment,
-	}
+// Healthz is the liveness probe: if the process can respond at all, it's
+// alive. It intentionally runs no dependency checks -- those belong to
+// Readyz and Health -- so a slow dependency can't make Kubernetes restart a
+// perfectly healthy pod.
+func (h *HealthHandler) Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
 
-	// Check Core Engine health
-	if h.coreEngineClient != nil {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-
-		coreEngineHealth, err := h.coreEngineClient.HealthCheck(ctx, "api-gateway")
-		if err != nil {
-			logger.Errorf("Core Engine health check failed: %v", err)
-			response.Services["core_engine"] = map[string]interface{}{
-				"status": "unhealthy",
-				"error":  err.Error(),
-			}
-			response.Status = "degraded"
-		} else {
-			response.Services["core_engine"] = map[string]interface{}{
-				"status":  "healthy",
-				"version": coreEngineHealth.Version,
-				"details": coreEngineHealth.Details,
-			}
-		}
-	} else {
-		response.Services["core_engine"] = map[string]interface{}{
-			"status": "not_connected",
+// Readyz is the readiness probe: Kubernetes-style, it fails closed only if a
+// critical dependency is unhealthy, so a degraded non-critical dependency
+// (e.g. the analytics service) keeps the pod in rotation. It also fails
+// closed, before running any dependency check, while any of readyGates
+// hasn't closed yet -- this is what keeps the pod out of rotation during the
+// brief window where the HTTP listener is up but the gRPC server is still
+// binding.
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	for _, gate := range h.readyGates {
+		select {
+		case <-gate:
+		default:
+			c.JSON(http.StatusServiceUnavailable, gin.H{"status": "starting"})
+			return
 		}
-		response.Status = "degraded"
 	}
 
-	// Set HTTP status based on overall health
+	results := h.registry.Run(c.Request.Context())
+	status := health.Aggregate(results)
+
 	httpStatus := http.StatusOK
-	if response.Status == "degraded" {
+	if status == health.StatusUnhealthy {
 		httpStatus = http.StatusServiceUnavailable
 	}
+	c.JSON(httpStatus, HealthResponse{
+		Status:    status,
+		Timestamp: time.Now(),
+		Checks:    results,
+	})
+}
 
-	c.JSON(httpStatus, response)
+// Health runs every registered dependency check and reports each one's
+// detail alongside the aggregate status.
+func (h *HealthHandler) Health(c *gin.Context) {
+	results := h.registry.Run(c.Request.Context())
+	status := health.Aggregate(results)
+
+	httpStatus := http.StatusOK
+	if status != health.StatusHealthy {
+		httpStatus = http.StatusServiceUnavailable
+	}
+	c.JSON(httpStatus, HealthResponse{
+		Status:    status,
+		Timestamp: time.Now(),
+		Checks:    results,
+	})
 }
 
 func (h *HealthHandler) Ping(c *gin.Context) {
@@ -98,7 +154,7 @@ func (h *HealthHandler) PingCoreEngine(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
-	health, err := h.coreEngineClient.HealthCheck(ctx, "api-gateway")
+	engineHealth, err := h.coreEngineClient.HealthCheck(ctx, "api-gateway")
 	if err != nil {
 		logger.Errorf("Failed to ping Core Engine: %v", err)
 		c.JSON(http.StatusServiceUnavailable, gin.H{
@@ -110,9 +166,9 @@ func (h *HealthHandler) PingCoreEngine(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"message":   "Core Engine ping successful",
-		"healthy":   health.Healthy,
-		"status":    health.Status,
-		"version":   health.Version,
+		"healthy":   engineHealth.Healthy,
+		"status":    engineHealth.Status,
+		"version":   engineHealth.Version,
 		"timestamp": time.Now(),
 	})
 }
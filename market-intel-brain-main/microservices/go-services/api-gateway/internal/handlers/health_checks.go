@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/IBM/sarama"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/market-intel/api-gateway/internal/cache"
+	"github.com/market-intel/api-gateway/internal/config"
+	"github.com/market-intel/api-gateway/internal/health"
+	"github.com/market-intel/api-gateway/internal/services"
+)
+
+// healthCheckAPIGateway reports the gateway process itself -- always
+// healthy if it's running this code at all -- so /health's check list has
+// an entry for the service in front of every other dependency.
+func healthCheckAPIGateway(cfg *config.Config) health.Check {
+	return health.Check{
+		Name:     "api_gateway",
+		Critical: true,
+		Timeout:  time.Second,
+		Func: func(ctx context.Context) (health.Status, string, error) {
+			return health.StatusHealthy, fmt.Sprintf("version 0.1.0, environment %s", cfg.Environment), nil
+		},
+	}
+}
+
+// healthCheckPostgres dials the configured Postgres host:port. The gateway
+// has no Postgres driver of its own, so this only confirms the database is
+// accepting TCP connections, not that it's accepting queries.
+func healthCheckPostgres(cfg config.DatabaseConfig, timeout time.Duration) health.Check {
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	return health.Check{
+		Name:     "postgres",
+		Critical: true,
+		Timeout:  timeout,
+		Func: func(ctx context.Context) (health.Status, string, error) {
+			var d net.Dialer
+			conn, err := d.DialContext(ctx, "tcp", addr)
+			if err != nil {
+				return health.StatusUnhealthy, "", fmt.Errorf("dial %s: %w", addr, err)
+			}
+			conn.Close()
+			return health.StatusHealthy, addr, nil
+		},
+	}
+}
+
+// healthCheckRedis round-trips a RESP PING over the same hand-rolled client
+// internal/cache.RedisCache uses for the response cache.
+func healthCheckRedis(cfg config.RedisConfig, timeout time.Duration) health.Check {
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	rc := cache.NewRedisCache(addr, cfg.Password, cfg.Database)
+	return health.Check{
+		Name:     "redis",
+		Critical: true,
+		Timeout:  timeout,
+		Func: func(ctx context.Context) (health.Status, string, error) {
+			if err := rc.Ping(ctx); err != nil {
+				return health.StatusUnhealthy, "", err
+			}
+			return health.StatusHealthy, addr, nil
+		},
+	}
+}
+
+// healthCheckKafka opens a short-lived sarama client against cfg.Brokers
+// using the same SaramaConfig ingestion consumers build from, so the check
+// exercises the gateway's actual SASL/TLS settings rather than a bare dial.
+func healthCheckKafka(cfg config.KafkaConfig, timeout time.Duration) health.Check {
+	return health.Check{
+		Name:     "kafka",
+		Critical: true,
+		Timeout:  timeout,
+		Func: func(ctx context.Context) (health.Status, string, error) {
+			saramaCfg, err := cfg.SaramaConfig()
+			if err != nil {
+				return health.StatusUnhealthy, "", fmt.Errorf("build sarama config: %w", err)
+			}
+			if deadline, ok := ctx.Deadline(); ok {
+				saramaCfg.Net.DialTimeout = time.Until(deadline)
+			}
+
+			client, err := sarama.NewClient(cfg.Brokers, saramaCfg)
+			if err != nil {
+				return health.StatusUnhealthy, "", fmt.Errorf("connect to brokers %v: %w", cfg.Brokers, err)
+			}
+			defer client.Close()
+
+			return health.StatusHealthy, fmt.Sprintf("%d broker(s) reachable", len(client.Brokers())), nil
+		},
+	}
+}
+
+// healthCheckCoreEngine reuses the gateway's existing CoreEngineClient
+// rather than opening a second connection just to probe it.
+func healthCheckCoreEngine(client services.CoreEngineClient, timeout time.Duration) health.Check {
+	return health.Check{
+		Name:     "core_engine",
+		Critical: true,
+		Timeout:  timeout,
+		Func: func(ctx context.Context) (health.Status, string, error) {
+			resp, err := client.HealthCheck(ctx, "api-gateway")
+			if err != nil {
+				return health.StatusUnhealthy, "", err
+			}
+			if !resp.Healthy {
+				return health.StatusUnhealthy, resp.Status, fmt.Errorf("core engine reports %s", resp.Status)
+			}
+			return health.StatusHealthy, resp.Version, nil
+		},
+	}
+}
+
+// healthCheckGRPCService probes a downstream gRPC service's standard
+// grpc_health_v1 health service over a connection dialed by factory, so a
+// consul:// or etcd:// endpoint is probed against whichever instance its
+// resolver currently reports rather than a single fixed address. It's
+// non-critical: these services (auth, analytics, vector store) aren't
+// required for the gateway's own liveness, so a single one being down only
+// degrades /health rather than failing /readyz. The connection is opened
+// once and reused by every probe rather than redialed per check; if that
+// initial dial fails (factory.Dial blocks until the resolver produces a
+// first address), later checks retry it instead of reporting unhealthy
+// forever.
+func healthCheckGRPCService(factory *services.ClientFactory, name string, endpoint config.ServiceEndpoint, timeout time.Duration) health.Check {
+	var conn *grpc.ClientConn
+
+	return health.Check{
+		Name:     name,
+		Critical: false,
+		Timeout:  timeout,
+		Func: func(ctx context.Context) (health.Status, string, error) {
+			if conn == nil {
+				dialed, err := factory.Dial(name, endpoint)
+				if err != nil {
+					return health.StatusUnhealthy, "", fmt.Errorf("dial %s: %w", endpoint.Target, err)
+				}
+				conn = dialed
+			}
+
+			resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+			if err != nil {
+				return health.StatusUnhealthy, "", fmt.Errorf("check %s (%s): %w", name, endpoint.Target, err)
+			}
+			if resp.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+				return health.StatusUnhealthy, resp.Status.String(), fmt.Errorf("%s (%s) reports %s", name, endpoint.Target, resp.Status)
+			}
+			return health.StatusHealthy, fmt.Sprintf("%s (%s)", conn.Target(), conn.GetState().String()), nil
+		},
+	}
+}
@@ -0,0 +1,404 @@
+// Server-Sent Events Streaming
+// Alternative to the market-data WebSocket subprotocol for clients that
+// can't hold a WebSocket open (curl, browser EventSource, serverless
+// functions): negotiates text/event-stream (or newline-delimited JSON via
+// ?format=ndjson), replays buffered items newer than Last-Event-ID, then
+// switches to the live stream shared with WebSocketMarketData.
+
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/market-intel/api-gateway/pb"
+	"github.com/market-intel/api-gateway/pkg/logger"
+)
+
+const (
+	// sseHeartbeatInterval is how often a ": ping" comment is sent to keep
+	// intermediaries (proxies, load balancers) from closing an idle stream
+	sseHeartbeatInterval = 15 * time.Second
+
+	// sseRetryMillis is the "retry:" hint sent with every event, telling
+	// EventSource how long to wait before reconnecting after a drop
+	sseRetryMillis = 3000
+
+	// sseWriteDeadline bounds how long a single write may take before the
+	// connection is evicted as a slow consumer
+	sseWriteDeadline = 10 * time.Second
+)
+
+// deadlineTimer closes Done() after d unless Reset is called first,
+// mirroring the read/write deadlines a net.Conn gets for free but
+// http.ResponseWriter does not. The SSE handlers arm one before every write
+// and reset it on every successful flush, so a stalled consumer is evicted
+// deterministically instead of leaking a goroutine and an open connection
+// forever.
+type deadlineTimer struct {
+	mu     sync.Mutex
+	timer  *time.Timer
+	done   chan struct{}
+	closed bool
+}
+
+func newDeadlineTimer(d time.Duration) *deadlineTimer {
+	dt := &deadlineTimer{done: make(chan struct{})}
+	dt.timer = time.AfterFunc(d, dt.fire)
+	return dt
+}
+
+func (dt *deadlineTimer) fire() {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+	if !dt.closed {
+		dt.closed = true
+		close(dt.done)
+	}
+}
+
+// Reset re-arms the deadline for another d; a no-op once it has already fired
+func (dt *deadlineTimer) Reset(d time.Duration) {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+	if !dt.closed {
+		dt.timer.Reset(d)
+	}
+}
+
+// Done is closed once the deadline fires without an intervening Reset
+func (dt *deadlineTimer) Done() <-chan struct{} {
+	return dt.done
+}
+
+// Stop cancels the deadline and releases its timer
+func (dt *deadlineTimer) Stop() {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+	dt.timer.Stop()
+	if !dt.closed {
+		dt.closed = true
+		close(dt.done)
+	}
+}
+
+// sseWriter serializes Server-Sent Event frames (or ndjson lines) onto a
+// gin ResponseWriter and flushes after every write, so each event reaches
+// the client immediately instead of sitting in a buffer.
+type sseWriter struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+	format  string
+	mu      sync.Mutex
+}
+
+// newSSEWriter negotiates the response headers for format ("json" for SSE
+// framing, "ndjson" for newline-delimited JSON) and returns a writer, or an
+// error if the underlying ResponseWriter can't be flushed incrementally.
+func newSSEWriter(c *gin.Context, format string) (*sseWriter, error) {
+	flusher, ok := c.Writer.(http.Flusher)
+	if !ok {
+		return nil, fmt.Errorf("streaming is not supported by this response writer")
+	}
+
+	contentType := "text/event-stream"
+	if format == "ndjson" {
+		contentType = "application/x-ndjson"
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // disable nginx response buffering
+	c.Writer.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	return &sseWriter{w: c.Writer, flusher: flusher, format: format}, nil
+}
+
+// WriteEvent JSON-encodes data and writes it as one SSE frame tagged with
+// id/event (or one ndjson line, in which case id/event are dropped), then
+// flushes
+func (s *sseWriter) WriteEvent(id, event string, data interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal SSE event: %w", err)
+	}
+
+	if s.format == "ndjson" {
+		if _, err := fmt.Fprintf(s.w, "%s\n", payload); err != nil {
+			return err
+		}
+		s.flusher.Flush()
+		return nil
+	}
+
+	if id != "" {
+		if _, err := fmt.Fprintf(s.w, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	if event != "" {
+		if _, err := fmt.Fprintf(s.w, "event: %s\n", event); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(s.w, "retry: %d\ndata: %s\n\n", sseRetryMillis, payload); err != nil {
+		return err
+	}
+	s.flusher.Flush()
+	return nil
+}
+
+// WriteComment writes an SSE comment line (e.g. the ":ping" heartbeat),
+// which EventSource ignores but which keeps intermediaries from treating
+// the connection as idle. No-op in ndjson format, which has no comment
+// syntax to piggyback on.
+func (s *sseWriter) WriteComment(comment string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.format == "ndjson" {
+		return nil
+	}
+
+	if _, err := fmt.Fprintf(s.w, ":%s\n\n", comment); err != nil {
+		return err
+	}
+	s.flusher.Flush()
+	return nil
+}
+
+// lastEventIDCursor reads the replay cursor from the standard Last-Event-ID
+// header, falling back to a ?last_event_id= query parameter for clients
+// (like curl) that can't set custom headers on a plain GET
+func lastEventIDCursor(c *gin.Context) int64 {
+	raw := c.GetHeader("Last-Event-ID")
+	if raw == "" {
+		raw = c.Query("last_event_id")
+	}
+	cursor, _ := strconv.ParseInt(raw, 10, 64)
+	return cursor
+}
+
+// filterMarketDataBySymbols returns the items matching symbols, or all of
+// items when symbols is empty
+func filterMarketDataBySymbols(items []pb.MarketData, symbols []string) []pb.MarketData {
+	if len(symbols) == 0 {
+		return items
+	}
+
+	wanted := make(map[string]bool, len(symbols))
+	for _, s := range symbols {
+		wanted[s] = true
+	}
+
+	out := make([]pb.MarketData, 0, len(items))
+	for _, item := range items {
+		if wanted[item.Symbol] {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// newerMarketData returns the items with Timestamp > cursor, oldest first,
+// so a reconnecting client replays its backlog in the order it was produced
+func newerMarketData(items []pb.MarketData, cursor int64) []pb.MarketData {
+	out := make([]pb.MarketData, 0, len(items))
+	for _, item := range items {
+		if item.Timestamp > cursor {
+			out = append(out, item)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Timestamp < out[j].Timestamp })
+	return out
+}
+
+// newerNewsItems returns the items with Timestamp > cursor and, if cutoff is
+// non-zero, Timestamp >= cutoff, oldest first
+func newerNewsItems(items []pb.NewsItem, cursor, cutoff int64) []pb.NewsItem {
+	out := make([]pb.NewsItem, 0, len(items))
+	for _, item := range items {
+		if item.Timestamp <= cursor {
+			continue
+		}
+		if cutoff > 0 && item.Timestamp < cutoff {
+			continue
+		}
+		out = append(out, item)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Timestamp < out[j].Timestamp })
+	return out
+}
+
+// StreamMarketDataSSE streams market data ticks as Server-Sent Events,
+// honoring ?symbols=, Last-Event-ID (or ?last_event_id=) for replay from the
+// MQTT buffer also used by GetMarketDataBuffer, and ?format=json|ndjson.
+func (h *DataIngestionHandler) StreamMarketDataSSE(c *gin.Context) {
+	symbols := parseSymbolsParam(c.Query("symbols"))
+	format := c.DefaultQuery("format", "json")
+	cursor := lastEventIDCursor(c)
+
+	w, err := newSSEWriter(c, format)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	atomic.AddInt64(&h.sseConnections, 1)
+	defer atomic.AddInt64(&h.sseConnections, -1)
+
+	logger.Infof("SSE market data stream opened for symbols=%v, last_event_id=%d", symbols, cursor)
+
+	deadline := newDeadlineTimer(sseWriteDeadline)
+	defer deadline.Stop()
+
+	writeTick := func(data pb.MarketData) error {
+		if err := w.WriteEvent(strconv.FormatInt(data.Timestamp, 10), "tick", data); err != nil {
+			return err
+		}
+		deadline.Reset(sseWriteDeadline)
+		return nil
+	}
+
+	backlog := newerMarketData(filterMarketDataBySymbols(h.mqttMarketDataSince("", maxMQTTBufferSize), symbols), cursor)
+	for _, data := range backlog {
+		if err := writeTick(data); err != nil {
+			logger.Errorf("SSE market data write failed, closing stream: %v", err)
+			return
+		}
+	}
+
+	if h.streamClient == nil {
+		return
+	}
+
+	ticks, cancel := h.streamClient.Subscribe(symbols)
+	defer cancel()
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-h.shutdownCh:
+			return
+		case <-deadline.Done():
+			logger.Warnf("SSE market data stream evicted after %s without a successful write", sseWriteDeadline)
+			return
+		case <-heartbeat.C:
+			if err := w.WriteComment("ping"); err != nil {
+				return
+			}
+		case data, ok := <-ticks:
+			if !ok {
+				return
+			}
+			if err := writeTick(data); err != nil {
+				logger.Errorf("SSE market data write failed, closing stream: %v", err)
+				return
+			}
+		}
+	}
+}
+
+// StreamNewsSSE streams news items as Server-Sent Events, honoring
+// ?keywords=, ?hours_back= (age cutoff, default 24), Last-Event-ID (or
+// ?last_event_id=) for replay from the MQTT buffer also used by
+// GetNewsBuffer, and ?format=json|ndjson.
+func (h *DataIngestionHandler) StreamNewsSSE(c *gin.Context) {
+	keywords := c.QueryArray("keywords")
+	format := c.DefaultQuery("format", "json")
+	hoursBack, err := strconv.Atoi(c.DefaultQuery("hours_back", "24"))
+	if err != nil || hoursBack < 0 {
+		hoursBack = 24
+	}
+	cursor := lastEventIDCursor(c)
+
+	var cutoff int64
+	if hoursBack > 0 {
+		cutoff = time.Now().Add(-time.Duration(hoursBack) * time.Hour).Unix()
+	}
+
+	w, werr := newSSEWriter(c, format)
+	if werr != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": werr.Error()})
+		return
+	}
+
+	atomic.AddInt64(&h.sseConnections, 1)
+	defer atomic.AddInt64(&h.sseConnections, -1)
+
+	logger.Infof("SSE news stream opened for keywords=%v, last_event_id=%d", keywords, cursor)
+
+	deadline := newDeadlineTimer(sseWriteDeadline)
+	defer deadline.Stop()
+
+	writeItem := func(item pb.NewsItem) error {
+		if err := w.WriteEvent(strconv.FormatInt(item.Timestamp, 10), "news", item); err != nil {
+			return err
+		}
+		deadline.Reset(sseWriteDeadline)
+		cursor = item.Timestamp
+		return nil
+	}
+
+	backlog := newerNewsItems(h.mqttNewsSince(keywords, maxMQTTBufferSize), cursor, cutoff)
+	for _, item := range backlog {
+		if err := writeItem(item); err != nil {
+			logger.Errorf("SSE news write failed, closing stream: %v", err)
+			return
+		}
+	}
+
+	if h.streamClient == nil {
+		return
+	}
+
+	items, cancel := h.streamClient.SubscribeNews(keywords)
+	defer cancel()
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-h.shutdownCh:
+			return
+		case <-deadline.Done():
+			logger.Warnf("SSE news stream evicted after %s without a successful write", sseWriteDeadline)
+			return
+		case <-heartbeat.C:
+			if err := w.WriteComment("ping"); err != nil {
+				return
+			}
+		case item, ok := <-items:
+			if !ok {
+				return
+			}
+			if cutoff > 0 && item.Timestamp < cutoff {
+				continue
+			}
+			if err := writeItem(item); err != nil {
+				logger.Errorf("SSE news write failed, closing stream: %v", err)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,364 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/market-intel/api-gateway/internal/services"
+	"github.com/market-intel/api-gateway/pb"
+	"github.com/market-intel/api-gateway/pkg/logger"
+)
+
+// Control message types accepted on the market-data WebSocket subprotocol:
+// {"type":"subscribe","symbols":[...],"source_id":"..."},
+// {"type":"unsubscribe","symbols":[...]}, {"type":"authenticate","token":"..."}
+const (
+	controlTypeSubscribe    = "subscribe"
+	controlTypeUnsubscribe  = "unsubscribe"
+	controlTypeAuthenticate = "authenticate"
+)
+
+// Event types the server streams back on the market-data WebSocket
+// subprotocol, each tagged with the channel ID of the subscribe call it
+// belongs to so a client can multiplex several subscriptions over one socket.
+const (
+	eventTypeTick      = "tick"
+	eventTypeHeartbeat = "heartbeat"
+	eventTypeError     = "error"
+	eventTypeEnd       = "end"
+)
+
+// controlMessage is the envelope for client-sent subscription control
+// messages
+type controlMessage struct {
+	Type     string   `json:"type"`
+	Symbols  []string `json:"symbols,omitempty"`
+	SourceID string   `json:"source_id,omitempty"`
+	Token    string   `json:"token,omitempty"`
+}
+
+// streamEvent is the envelope for server-sent events, multiplexed across
+// subscriptions by Channel
+type streamEvent struct {
+	Type       string         `json:"type"`
+	Channel    string         `json:"channel,omitempty"`
+	MarketData *pb.MarketData `json:"market_data,omitempty"`
+	Message    string         `json:"message,omitempty"`
+}
+
+// subscriptionManagerConfig controls keepalive cadence and per-channel
+// backpressure for a subscriptionManager
+type subscriptionManagerConfig struct {
+	PingInterval        time.Duration
+	ChannelBacklogLimit int
+	WriterQueueSize     int
+}
+
+// defaultSubscriptionManagerConfig returns sane defaults for the WebSocket
+// subscription subprotocol
+func defaultSubscriptionManagerConfig() subscriptionManagerConfig {
+	return subscriptionManagerConfig{
+		PingInterval:        30 * time.Second,
+		ChannelBacklogLimit: 32,
+		WriterQueueSize:     256,
+	}
+}
+
+// subscriptionChannel is one subscribe call's live tail of a StreamMarketData
+// RPC, multiplexed onto the connection's single writer goroutine
+type subscriptionChannel struct {
+	id      string
+	symbols map[string]bool
+	cancel  context.CancelFunc
+	pending int64 // atomic: frames handed to the writer but not yet flushed
+}
+
+// writeJob pairs an outbound event with the channel it originated from, so
+// the writer goroutine can release that channel's backlog slot once flushed
+type writeJob struct {
+	ch    *subscriptionChannel
+	event streamEvent
+}
+
+// subscriptionManager implements the SignalFlow-style subscribe protocol on
+// top of a single WebSocket connection. Each subscribe call spawns a
+// goroutine tailing a StreamMarketData RPC; every such goroutine forwards
+// its frames onto one buffered channel drained by a single writer goroutine,
+// so gorilla's one-writer-at-a-time rule is never violated by the tick
+// traffic. A subscription whose frames pile up faster than the writer can
+// flush them is torn down with an error frame instead of blocking every
+// other subscription multiplexed over the same socket.
+type subscriptionManager struct {
+	client services.CoreEngineClient
+	w      *wsWriter
+	cfg    subscriptionManagerConfig
+
+	mu       sync.Mutex
+	channels map[string]*subscriptionChannel
+	nextID   int
+	closed   bool
+
+	writeCh chan writeJob
+	writeWG sync.WaitGroup
+	chWG    sync.WaitGroup
+}
+
+// newSubscriptionManager starts the connection's single writer goroutine and
+// returns a subscriptionManager ready to accept control messages
+func newSubscriptionManager(client services.CoreEngineClient, w *wsWriter, cfg subscriptionManagerConfig) *subscriptionManager {
+	m := &subscriptionManager{
+		client:   client,
+		w:        w,
+		cfg:      cfg,
+		channels: make(map[string]*subscriptionChannel),
+		writeCh:  make(chan writeJob, cfg.WriterQueueSize),
+	}
+
+	m.writeWG.Add(1)
+	go m.writeLoop()
+
+	return m
+}
+
+// Close cancels every live subscription, waits for their tailing goroutines
+// to exit, then stops the writer goroutine
+func (m *subscriptionManager) Close() {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return
+	}
+	m.closed = true
+	for _, ch := range m.channels {
+		ch.cancel()
+	}
+	m.mu.Unlock()
+
+	m.chWG.Wait()
+	close(m.writeCh)
+	m.writeWG.Wait()
+}
+
+// handleControl decodes and dispatches one client control message
+func (m *subscriptionManager) handleControl(raw []byte) {
+	var msg controlMessage
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		m.sendDirect(streamEvent{Type: eventTypeError, Message: "invalid control message"})
+		return
+	}
+
+	switch msg.Type {
+	case controlTypeAuthenticate:
+		// Token verification happens upstream of the WebSocket upgrade (JWT
+		// middleware); this just lets clients that send it before their
+		// first subscribe avoid guessing whether it was needed at all.
+		logger.Infof("subscriptionManager: authenticate message received")
+	case controlTypeSubscribe:
+		m.subscribe(msg.Symbols, msg.SourceID)
+	case controlTypeUnsubscribe:
+		m.unsubscribe(msg.Symbols)
+	default:
+		m.sendDirect(streamEvent{Type: eventTypeError, Message: fmt.Sprintf("unknown control message type %q", msg.Type)})
+	}
+}
+
+// subscribe opens a StreamMarketData RPC for symbols and starts a goroutine
+// tailing it into a freshly assigned channel
+func (m *subscriptionManager) subscribe(symbols []string, sourceID string) {
+	if len(symbols) == 0 {
+		m.sendDirect(streamEvent{Type: eventTypeError, Message: "subscribe requires at least one symbol"})
+		return
+	}
+	if m.client == nil {
+		m.sendDirect(streamEvent{Type: eventTypeError, Message: "core engine unavailable"})
+		return
+	}
+
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return
+	}
+	m.nextID++
+	id := fmt.Sprintf("ch-%d", m.nextID)
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := &subscriptionChannel{id: id, symbols: normalizeSymbolSet(symbols), cancel: cancel}
+	m.channels[id] = ch
+	m.mu.Unlock()
+
+	stream, err := m.client.StreamMarketData(ctx, &pb.StreamMarketDataRequest{Symbols: symbols, SourceId: sourceID})
+	if err != nil {
+		cancel()
+		m.removeChannel(id)
+		m.sendDirect(streamEvent{Type: eventTypeError, Message: fmt.Sprintf("failed to subscribe: %v", err)})
+		return
+	}
+
+	m.chWG.Add(1)
+	go m.tail(ch, stream)
+}
+
+// unsubscribe tears down every live channel whose symbol set matches the
+// one given at subscribe time. Channel IDs are assigned server-side and
+// surfaced via channel-tagged events, so a client that did not record one
+// can still drop a subscription by replaying its original symbol set.
+func (m *subscriptionManager) unsubscribe(symbols []string) {
+	target := normalizeSymbolSet(symbols)
+
+	m.mu.Lock()
+	var toCancel []*subscriptionChannel
+	for id, ch := range m.channels {
+		if sameSymbolSet(ch.symbols, target) {
+			toCancel = append(toCancel, ch)
+			delete(m.channels, id)
+		}
+	}
+	m.mu.Unlock()
+
+	for _, ch := range toCancel {
+		ch.cancel()
+	}
+}
+
+// tail drains one StreamMarketData RPC and forwards each tick onto the
+// connection's single writer goroutine until the stream ends or its
+// backlog against the writer exceeds the configured threshold
+func (m *subscriptionManager) tail(ch *subscriptionChannel, stream pb.MarketDataStream) {
+	defer m.chWG.Done()
+	defer m.removeChannel(ch.id)
+
+	for {
+		data, err := stream.Recv()
+		if err != nil {
+			if err != io.EOF {
+				logger.Warnf("subscriptionManager: stream for channel %s ended: %v", ch.id, err)
+			}
+			m.enqueue(ch, streamEvent{Type: eventTypeEnd, Channel: ch.id})
+			return
+		}
+
+		if !m.enqueue(ch, streamEvent{Type: eventTypeTick, Channel: ch.id, MarketData: data}) {
+			logger.Warnf("subscriptionManager: dropping channel %s, outbound queue exceeded backpressure threshold", ch.id)
+			m.sendDirect(streamEvent{Type: eventTypeError, Channel: ch.id, Message: "subscription dropped: outbound queue exceeded backpressure threshold"})
+			return
+		}
+	}
+}
+
+// enqueue hands a frame to the writer goroutine, refusing it if this
+// channel already has ChannelBacklogLimit frames in flight or if the
+// connection's shared writer queue is itself full
+func (m *subscriptionManager) enqueue(ch *subscriptionChannel, ev streamEvent) bool {
+	if atomic.LoadInt64(&ch.pending) >= int64(m.cfg.ChannelBacklogLimit) {
+		return false
+	}
+	atomic.AddInt64(&ch.pending, 1)
+
+	select {
+	case m.writeCh <- writeJob{ch: ch, event: ev}:
+		return true
+	default:
+		atomic.AddInt64(&ch.pending, -1)
+		return false
+	}
+}
+
+// removeChannel drops id from the live channel set; a no-op if already gone
+func (m *subscriptionManager) removeChannel(id string) {
+	m.mu.Lock()
+	delete(m.channels, id)
+	m.mu.Unlock()
+}
+
+// writeLoop is the connection's single writer goroutine: it flushes queued
+// tick/end/error frames and sends a WebSocket ping plus a heartbeat event
+// per live channel on every tick of the keepalive interval. Error frames
+// from control handling and from a dropped channel's tail goroutine are
+// written directly via sendDirect instead; wsWriter's mutex serializes them
+// against this goroutine's writes.
+func (m *subscriptionManager) writeLoop() {
+	defer m.writeWG.Done()
+
+	ticker := time.NewTicker(m.cfg.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case job, ok := <-m.writeCh:
+			if !ok {
+				return
+			}
+			atomic.AddInt64(&job.ch.pending, -1)
+			m.sendDirect(job.event)
+
+		case <-ticker.C:
+			if err := m.w.WriteMessage(websocket.PingMessage, nil); err != nil {
+				logger.Errorf("subscriptionManager: failed to send ping: %v", err)
+				return
+			}
+			for _, id := range m.liveChannelIDs() {
+				m.sendDirect(streamEvent{Type: eventTypeHeartbeat, Channel: id})
+			}
+		}
+	}
+}
+
+// liveChannelIDs returns a snapshot of the currently subscribed channel IDs
+func (m *subscriptionManager) liveChannelIDs() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ids := make([]string, 0, len(m.channels))
+	for id := range m.channels {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// sendDirect marshals and writes an event immediately via the shared
+// wsWriter (mutex-serialized against every other writer on this
+// connection), bypassing per-channel backlog accounting -- used for acks,
+// heartbeats, and errors that must not be silently dropped by backpressure.
+func (m *subscriptionManager) sendDirect(ev streamEvent) {
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		logger.Errorf("subscriptionManager: failed to marshal event: %v", err)
+		return
+	}
+	if err := m.w.WriteMessage(websocket.TextMessage, payload); err != nil {
+		logger.Errorf("subscriptionManager: failed to write event: %v", err)
+	}
+}
+
+// normalizeSymbolSet upper-cases symbols into a set, matching the
+// convention parseSymbolsParam already applies to query-param symbols
+func normalizeSymbolSet(symbols []string) map[string]bool {
+	set := make(map[string]bool, len(symbols))
+	for _, s := range symbols {
+		if s = strings.ToUpper(strings.TrimSpace(s)); s != "" {
+			set[s] = true
+		}
+	}
+	return set
+}
+
+// sameSymbolSet reports whether a and b contain exactly the same symbols
+func sameSymbolSet(a, b map[string]bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for s := range a {
+		if !b[s] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/market-intel/api-gateway/internal/mocks"
+	"github.com/market-intel/api-gateway/pb"
+)
+
+// fakeMarketDataStream is a fake server-streaming RPC response used to drive
+// subscriptionManager tests without a real core-engine connection. Recv
+// blocks on either a pushed tick or the request context ending, mirroring
+// how a real gRPC stream unblocks Recv when its context is canceled.
+type fakeMarketDataStream struct {
+	ctx   context.Context
+	ticks chan *pb.MarketData
+}
+
+func newFakeMarketDataStream(ctx context.Context) *fakeMarketDataStream {
+	return &fakeMarketDataStream{ctx: ctx, ticks: make(chan *pb.MarketData, 64)}
+}
+
+func (f *fakeMarketDataStream) push(data *pb.MarketData) { f.ticks <- data }
+
+func (f *fakeMarketDataStream) Recv() (*pb.MarketData, error) {
+	select {
+	case <-f.ctx.Done():
+		return nil, f.ctx.Err()
+	case data, ok := <-f.ticks:
+		if !ok {
+			return nil, io.EOF
+		}
+		return data, nil
+	}
+}
+
+// newTestWSConn upgrades a single httptest connection to a WebSocket and
+// returns both ends: the server-side conn a wsWriter wraps, and the
+// client-side conn the test reads events from
+func newTestWSConn(t *testing.T) (serverConn, clientConn *websocket.Conn) {
+	t.Helper()
+
+	upgrader := websocket.Upgrader{}
+	ready := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		serverConn = c
+		close(ready)
+	}))
+	t.Cleanup(server.Close)
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	clientConn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { clientConn.Close() })
+
+	<-ready
+	return serverConn, clientConn
+}
+
+// readEvent reads and decodes the next streamEvent off conn, failing the
+// test if none arrives within timeout
+func readEvent(t *testing.T, conn *websocket.Conn, timeout time.Duration) streamEvent {
+	t.Helper()
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(timeout)))
+	_, data, err := conn.ReadMessage()
+	require.NoError(t, err)
+
+	var ev streamEvent
+	require.NoError(t, json.Unmarshal(data, &ev))
+	return ev
+}
+
+func TestSubscriptionManagerSubscribeStreamsTicks(t *testing.T) {
+	serverConn, clientConn := newTestWSConn(t)
+
+	streams := make(chan *fakeMarketDataStream, 1)
+	coreEngineClient := mocks.NewCoreEngineClient(t)
+	coreEngineClient.EXPECT().
+		StreamMarketData(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, req *pb.StreamMarketDataRequest) (pb.MarketDataStream, error) {
+			require.Equal(t, []string{"AAPL"}, req.Symbols)
+			s := newFakeMarketDataStream(ctx)
+			streams <- s
+			return s, nil
+		})
+
+	cfg := defaultSubscriptionManagerConfig()
+	cfg.PingInterval = time.Hour
+	mgr := newSubscriptionManager(coreEngineClient, &wsWriter{conn: serverConn}, cfg)
+	defer mgr.Close()
+
+	raw, err := json.Marshal(controlMessage{Type: controlTypeSubscribe, Symbols: []string{"AAPL"}, SourceID: "yahoo_finance"})
+	require.NoError(t, err)
+	mgr.handleControl(raw)
+
+	var fs *fakeMarketDataStream
+	select {
+	case fs = <-streams:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for StreamMarketData to be called")
+	}
+
+	fs.push(&pb.MarketData{Symbol: "AAPL", Price: 101.5, Timestamp: 1})
+
+	ev := readEvent(t, clientConn, time.Second)
+	require.Equal(t, eventTypeTick, ev.Type)
+	require.NotEmpty(t, ev.Channel)
+	require.NotNil(t, ev.MarketData)
+	require.Equal(t, "AAPL", ev.MarketData.Symbol)
+}
+
+func TestSubscriptionManagerUnsubscribeTearsDownChannel(t *testing.T) {
+	serverConn, clientConn := newTestWSConn(t)
+
+	streams := make(chan *fakeMarketDataStream, 1)
+	coreEngineClient := mocks.NewCoreEngineClient(t)
+	coreEngineClient.EXPECT().
+		StreamMarketData(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, req *pb.StreamMarketDataRequest) (pb.MarketDataStream, error) {
+			s := newFakeMarketDataStream(ctx)
+			streams <- s
+			return s, nil
+		})
+
+	cfg := defaultSubscriptionManagerConfig()
+	cfg.PingInterval = time.Hour
+	mgr := newSubscriptionManager(coreEngineClient, &wsWriter{conn: serverConn}, cfg)
+	defer mgr.Close()
+
+	subscribeMsg, err := json.Marshal(controlMessage{Type: controlTypeSubscribe, Symbols: []string{"MSFT"}})
+	require.NoError(t, err)
+	mgr.handleControl(subscribeMsg)
+
+	select {
+	case <-streams:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for StreamMarketData to be called")
+	}
+
+	unsubscribeMsg, err := json.Marshal(controlMessage{Type: controlTypeUnsubscribe, Symbols: []string{"MSFT"}})
+	require.NoError(t, err)
+	mgr.handleControl(unsubscribeMsg)
+
+	ev := readEvent(t, clientConn, time.Second)
+	require.Equal(t, eventTypeEnd, ev.Type)
+
+	mgr.mu.Lock()
+	remaining := len(mgr.channels)
+	mgr.mu.Unlock()
+	require.Equal(t, 0, remaining)
+}
+
+func TestSubscriptionManagerEnqueueDropsWhenBacklogExceedsThreshold(t *testing.T) {
+	cfg := defaultSubscriptionManagerConfig()
+	cfg.ChannelBacklogLimit = 2
+
+	mgr := &subscriptionManager{cfg: cfg, writeCh: make(chan writeJob, 10)}
+	ch := &subscriptionChannel{id: "ch-1"}
+
+	for i := 0; i < cfg.ChannelBacklogLimit; i++ {
+		require.True(t, mgr.enqueue(ch, streamEvent{Type: eventTypeTick, Channel: ch.id}))
+	}
+	require.False(t, mgr.enqueue(ch, streamEvent{Type: eventTypeTick, Channel: ch.id}))
+}
+
+func TestSubscriptionManagerCloseStopsAllChannels(t *testing.T) {
+	serverConn, clientConn := newTestWSConn(t)
+	_ = clientConn
+
+	var streamCtx context.Context
+	var mu sync.Mutex
+
+	coreEngineClient := mocks.NewCoreEngineClient(t)
+	coreEngineClient.EXPECT().
+		StreamMarketData(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, req *pb.StreamMarketDataRequest) (pb.MarketDataStream, error) {
+			mu.Lock()
+			streamCtx = ctx
+			mu.Unlock()
+			return newFakeMarketDataStream(ctx), nil
+		})
+
+	cfg := defaultSubscriptionManagerConfig()
+	cfg.PingInterval = time.Hour
+	mgr := newSubscriptionManager(coreEngineClient, &wsWriter{conn: serverConn}, cfg)
+
+	raw, err := json.Marshal(controlMessage{Type: controlTypeSubscribe, Symbols: []string{"GOOG"}})
+	require.NoError(t, err)
+	mgr.handleControl(raw)
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return streamCtx != nil
+	}, time.Second, 10*time.Millisecond)
+
+	mgr.Close()
+
+	mu.Lock()
+	ctx := streamCtx
+	mu.Unlock()
+	require.Error(t, ctx.Err())
+}
@@ -0,0 +1,37 @@
+package health
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Metrics holds the Prometheus gauge for each check's last observed
+// status, registered once and shared across Run calls, mirroring
+// internal/cache.Metrics' collector lifecycle.
+type Metrics struct {
+	up *prometheus.GaugeVec
+}
+
+// NewMetrics builds and registers the health check gauge against registry.
+// The gauge is 1 when a check last reported StatusHealthy and 0 otherwise,
+// so "sum by (name) (api_gateway_health_check_up == 0)" finds failing
+// dependencies at a glance.
+func NewMetrics(registry *prometheus.Registry) *Metrics {
+	m := &Metrics{
+		up: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "api_gateway_health_check_up",
+				Help: "Whether the named dependency health check last reported healthy (1) or not (0)",
+			},
+			[]string{"name"},
+		),
+	}
+
+	registry.MustRegister(m.up)
+	return m
+}
+
+func (m *Metrics) record(name string, status Status) {
+	value := 0.0
+	if status == StatusHealthy {
+		value = 1.0
+	}
+	m.up.WithLabelValues(name).Set(value)
+}
@@ -0,0 +1,173 @@
+// Package health implements a pluggable dependency health registry. Each
+// dependency (Postgres, Redis, a Kafka broker, a downstream gRPC service)
+// registers a Check; Registry runs them concurrently with per-check
+// timeouts, caches the results for a configurable interval to bound load
+// on the dependencies, and aggregates them into the healthy/degraded/
+// unhealthy status Kubernetes-style liveness/readiness probes expect.
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Status is the outcome of a single check, or of the aggregate of all of
+// them.
+type Status string
+
+const (
+	StatusHealthy   Status = "healthy"
+	StatusDegraded  Status = "degraded"
+	StatusUnhealthy Status = "unhealthy"
+)
+
+// CheckFunc probes one dependency and returns its status, a short
+// human-readable detail string (e.g. a version or latency), and an error
+// if the probe itself failed to run (as opposed to the dependency
+// reporting itself unhealthy, which CheckFunc can also represent as a
+// non-nil error).
+type CheckFunc func(ctx context.Context) (Status, string, error)
+
+// Check is one registered dependency probe.
+type Check struct {
+	// Name identifies the check in the JSON response and Prometheus labels.
+	Name string
+	// Critical checks gate /readyz: any critical check that reports
+	// unhealthy makes the whole registry unhealthy, while a degraded critical
+	// check only degrades it. Non-critical checks can only pull the aggregate
+	// down to degraded.
+	Critical bool
+	// Timeout bounds how long Run waits for Func before treating the check
+	// as unhealthy.
+	Timeout time.Duration
+	Func    CheckFunc
+}
+
+// Result is a Check's most recent outcome.
+type Result struct {
+	Name      string    `json:"name"`
+	Critical  bool      `json:"critical"`
+	Status    Status    `json:"status"`
+	Detail    string    `json:"detail,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Registry runs a fixed set of Checks concurrently and caches their
+// results for CacheFor, so a burst of liveness/readiness probe traffic
+// doesn't turn into a burst of load against every dependency.
+type Registry struct {
+	checks   []Check
+	cacheFor time.Duration
+	metrics  *Metrics
+
+	mu      sync.RWMutex
+	results map[string]Result
+	lastRun time.Time
+}
+
+// NewRegistry builds a Registry over checks, caching each Run's results for
+// cacheFor (a non-positive value disables caching, running every check on
+// every call).
+func NewRegistry(checks []Check, cacheFor time.Duration, m *Metrics) *Registry {
+	return &Registry{
+		checks:   checks,
+		cacheFor: cacheFor,
+		metrics:  m,
+		results:  make(map[string]Result, len(checks)),
+	}
+}
+
+// Run returns the registry's cached results if they're still within
+// cacheFor, otherwise runs every Check concurrently (each bounded by its
+// own Timeout) and caches the fresh results.
+func (r *Registry) Run(ctx context.Context) []Result {
+	r.mu.RLock()
+	fresh := r.cacheFor > 0 && time.Since(r.lastRun) < r.cacheFor && len(r.results) == len(r.checks)
+	if fresh {
+		results := r.snapshotLocked()
+		r.mu.RUnlock()
+		return results
+	}
+	r.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	results := make([]Result, len(r.checks))
+	for i, check := range r.checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			results[i] = r.runOne(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	r.mu.Lock()
+	for _, result := range results {
+		r.results[result.Name] = result
+	}
+	r.lastRun = time.Now()
+	r.mu.Unlock()
+
+	return results
+}
+
+func (r *Registry) runOne(ctx context.Context, check Check) Result {
+	timeout := check.Timeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	checkCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	status, detail, err := check.Func(checkCtx)
+	result := Result{
+		Name:      check.Name,
+		Critical:  check.Critical,
+		Status:    status,
+		Detail:    detail,
+		CheckedAt: time.Now(),
+	}
+	if err != nil {
+		result.Error = err.Error()
+		if result.Status == "" {
+			result.Status = StatusUnhealthy
+		}
+	}
+	if result.Status == "" {
+		result.Status = StatusHealthy
+	}
+
+	if r.metrics != nil {
+		r.metrics.record(check.Name, result.Status)
+	}
+	return result
+}
+
+func (r *Registry) snapshotLocked() []Result {
+	results := make([]Result, 0, len(r.checks))
+	for _, check := range r.checks {
+		if result, ok := r.results[check.Name]; ok {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// Aggregate computes the overall Status from a set of Results: any
+// critical check that isn't healthy makes the aggregate unhealthy; any
+// other non-healthy check (critical-but-degraded, or any non-critical
+// failure) makes it degraded; otherwise healthy.
+func Aggregate(results []Result) Status {
+	status := StatusHealthy
+	for _, result := range results {
+		switch {
+		case result.Critical && result.Status == StatusUnhealthy:
+			return StatusUnhealthy
+		case result.Status != StatusHealthy:
+			status = StatusDegraded
+		}
+	}
+	return status
+}
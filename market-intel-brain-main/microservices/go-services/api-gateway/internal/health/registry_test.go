@@ -0,0 +1,106 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAggregateAllHealthy(t *testing.T) {
+	results := []Result{
+		{Name: "postgres", Critical: true, Status: StatusHealthy},
+		{Name: "analytics", Critical: false, Status: StatusHealthy},
+	}
+
+	if got := Aggregate(results); got != StatusHealthy {
+		t.Fatalf("expected %s, got %s", StatusHealthy, got)
+	}
+}
+
+func TestAggregateCriticalFailureIsUnhealthy(t *testing.T) {
+	results := []Result{
+		{Name: "postgres", Critical: true, Status: StatusUnhealthy},
+		{Name: "analytics", Critical: false, Status: StatusHealthy},
+	}
+
+	if got := Aggregate(results); got != StatusUnhealthy {
+		t.Fatalf("expected %s, got %s", StatusUnhealthy, got)
+	}
+}
+
+func TestAggregateNonCriticalFailureIsDegraded(t *testing.T) {
+	results := []Result{
+		{Name: "postgres", Critical: true, Status: StatusHealthy},
+		{Name: "analytics", Critical: false, Status: StatusUnhealthy},
+	}
+
+	if got := Aggregate(results); got != StatusDegraded {
+		t.Fatalf("expected %s, got %s", StatusDegraded, got)
+	}
+}
+
+func TestRegistryRunReturnsPerCheckResults(t *testing.T) {
+	checks := []Check{
+		{Name: "ok", Critical: true, Func: func(ctx context.Context) (Status, string, error) {
+			return StatusHealthy, "fine", nil
+		}},
+		{Name: "down", Critical: true, Func: func(ctx context.Context) (Status, string, error) {
+			return "", "", errors.New("connection refused")
+		}},
+	}
+
+	r := NewRegistry(checks, 0, nil)
+	results := r.Run(context.Background())
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+
+	byName := make(map[string]Result, len(results))
+	for _, result := range results {
+		byName[result.Name] = result
+	}
+
+	if byName["ok"].Status != StatusHealthy {
+		t.Errorf("expected ok check to be healthy, got %s", byName["ok"].Status)
+	}
+	if byName["down"].Status != StatusUnhealthy || byName["down"].Error == "" {
+		t.Errorf("expected down check to be unhealthy with an error, got %+v", byName["down"])
+	}
+}
+
+func TestRegistryRunCachesWithinCacheFor(t *testing.T) {
+	var calls int
+	checks := []Check{
+		{Name: "counted", Critical: true, Func: func(ctx context.Context) (Status, string, error) {
+			calls++
+			return StatusHealthy, "", nil
+		}},
+	}
+
+	r := NewRegistry(checks, time.Minute, nil)
+	r.Run(context.Background())
+	r.Run(context.Background())
+
+	if calls != 1 {
+		t.Fatalf("expected the check to run once while within cacheFor, ran %d times", calls)
+	}
+}
+
+func TestRegistryRunSkipsCacheWhenDisabled(t *testing.T) {
+	var calls int
+	checks := []Check{
+		{Name: "counted", Critical: true, Func: func(ctx context.Context) (Status, string, error) {
+			calls++
+			return StatusHealthy, "", nil
+		}},
+	}
+
+	r := NewRegistry(checks, 0, nil)
+	r.Run(context.Background())
+	r.Run(context.Background())
+
+	if calls != 2 {
+		t.Fatalf("expected the check to run on every call with caching disabled, ran %d times", calls)
+	}
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"sync"
+	"time"
+)
+
+// memoryLimiter is a Limiter backed by an in-process token bucket per key.
+// It's the default Backend when RateLimitConfig.Backend isn't "redis", and
+// enforces limits per gateway replica rather than across the fleet.
+type memoryLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+type tokenBucket struct {
+	tokens     float64
+	lastRefill time.Time
+}
+
+func newMemoryLimiter() *memoryLimiter {
+	return &memoryLimiter{buckets: make(map[string]*tokenBucket)}
+}
+
+// Allow consumes one token from key's bucket, creating it at full burst on
+// first use, and reports whether a token was available.
+func (l *memoryLimiter) Allow(key string, rps, burst int) bool {
+	if rps <= 0 {
+		return true
+	}
+	if burst <= 0 {
+		burst = rps
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: float64(burst), lastRefill: time.Now()}
+		l.buckets[key] = b
+	}
+
+	now := time.Now()
+	elapsed := now.Sub(b.lastRefill).Seconds()
+	b.lastRefill = now
+
+	b.tokens += elapsed * float64(rps)
+	if b.tokens > float64(burst) {
+		b.tokens = float64(burst)
+	}
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
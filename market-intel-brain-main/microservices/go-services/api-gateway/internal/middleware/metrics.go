@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/market-intel/api-gateway/internal/config"
+)
+
+// metrics holds the Prometheus counters for RateLimit, registered under
+// MetricsConfig's namespace/subsystem so they share a naming scheme with
+// the rest of the gateway's /metrics output, mirroring internal/cache.Metrics'
+// collector lifecycle.
+type metrics struct {
+	allowed  *prometheus.CounterVec
+	rejected *prometheus.CounterVec
+}
+
+// newMetrics builds and registers the rate limiter counters against registry
+func newMetrics(cfg config.MetricsConfig, registry *prometheus.Registry) *metrics {
+	m := &metrics{
+		allowed: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: cfg.Namespace,
+				Subsystem: cfg.Subsystem,
+				Name:      "rate_limit_allowed_total",
+				Help:      "Total number of requests allowed through the rate limiter, by route and scope",
+			},
+			[]string{"route", "scope"},
+		),
+		rejected: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: cfg.Namespace,
+				Subsystem: cfg.Subsystem,
+				Name:      "rate_limit_rejected_total",
+				Help:      "Total number of requests rejected by the rate limiter, by route and scope",
+			},
+			[]string{"route", "scope"},
+		),
+	}
+
+	registry.MustRegister(m.allowed, m.rejected)
+	return m
+}
+
+func (m *metrics) recordAllowed(route, scope string)  { m.allowed.WithLabelValues(route, scope).Inc() }
+func (m *metrics) recordRejected(route, scope string) { m.rejected.WithLabelValues(route, scope).Inc() }
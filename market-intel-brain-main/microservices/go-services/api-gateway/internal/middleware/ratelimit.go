@@ -0,0 +1,229 @@
+// Package middleware holds Gin middleware for the API Gateway that spans
+// multiple handlers rather than belonging to one, starting with RateLimit:
+// a per-route, per-API-key, per-user-agent, and per-origin rate limiter
+// layered on top of SecurityConfig's global enable flag and default
+// RPS/burst, backed by either an in-process token bucket or Redis.
+package middleware
+
+import (
+	"net"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/market-intel/api-gateway/internal/config"
+	"github.com/market-intel/api-gateway/pkg/resilience"
+)
+
+// Limiter is implemented by every rate-limit backend (memoryLimiter,
+// redisLimiter) so RateLimit can be wired to whichever is configured
+// without knowing its storage details, the role internal/cache.Cache plays
+// for the response cache.
+type Limiter interface {
+	// Allow reports whether the caller identified by key may proceed under
+	// a budget of rps requests/second up to burst, consuming one unit of
+	// budget if so.
+	Allow(key string, rps, burst int) bool
+}
+
+// rules is the subset of config.Config a reload can change without
+// restarting the process -- everything RateLimit.Apply swaps in atomically.
+// Backend is excluded: it's tagged reload:"restart" in RateLimitConfig since
+// switching it would mean re-dialing Redis or discarding in-memory buckets
+// mid-traffic.
+type rules struct {
+	enabled          bool
+	defaultRPS       int
+	defaultBurst     int
+	perAPIKeyRPS     int
+	perAPIKeyBurst   int
+	routes           map[string]config.RouteLimit
+	exemptUserAgents map[string]struct{}
+	exemptOrigins    map[string]struct{}
+	exemptNets       []*net.IPNet
+}
+
+// RateLimit is Gin middleware enforcing SecurityConfig's global
+// RateLimitRPS/RateLimitBurst plus RateLimitConfig's per-route and
+// per-API-key overrides, exempting configured user-agents, origins, and
+// CIDRs outright. It implements reload.Subscriber so an operator can
+// retune limits and exemption lists without restarting the gateway.
+type RateLimit struct {
+	rules   atomic.Pointer[rules]
+	limiter Limiter
+	metrics *metrics
+}
+
+// New builds a RateLimit backed by an in-process token bucket, or by Redis
+// when cfg.RateLimit.Backend is "redis" against cfg.Redis. registry is the
+// Prometheus registry rate_limit_* counters are registered into, shared
+// with the rest of the gateway's /metrics output.
+func New(cfg *config.Config, registry *prometheus.Registry) *RateLimit {
+	var limiter Limiter
+	if cfg.RateLimit.Backend == "redis" {
+		limiter = newRedisLimiter(cfg.Redis)
+	} else {
+		limiter = newMemoryLimiter()
+	}
+
+	rl := &RateLimit{
+		limiter: limiter,
+		metrics: newMetrics(cfg.Metrics, registry),
+	}
+	rl.rules.Store(buildRules(cfg))
+	return rl
+}
+
+func buildRules(cfg *config.Config) *rules {
+	r := &rules{
+		enabled:          cfg.Security.RateLimitEnabled,
+		defaultRPS:       cfg.Security.RateLimitRPS,
+		defaultBurst:     cfg.Security.RateLimitBurst,
+		perAPIKeyRPS:     cfg.RateLimit.PerAPIKeyRPS,
+		perAPIKeyBurst:   cfg.RateLimit.PerAPIKeyBurst,
+		routes:           cfg.RateLimit.Routes,
+		exemptUserAgents: toSet(cfg.RateLimit.ExemptUserAgents),
+		exemptOrigins:    toSet(cfg.RateLimit.ExemptOrigins),
+	}
+	for _, cidr := range cfg.RateLimit.ExemptCIDRs {
+		if _, n, err := net.ParseCIDR(cidr); err == nil {
+			r.exemptNets = append(r.exemptNets, n)
+		}
+	}
+	return r
+}
+
+func toSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+	return set
+}
+
+// Name identifies this Subscriber to reload.Manager's logs and rollback
+// error messages.
+func (rl *RateLimit) Name() string { return "rate-limit" }
+
+// Apply rebuilds the live rule set from new's Security/RateLimit sections.
+// Rebuilding is cheap and idempotent, so -- unlike LoggingLevelSubscriber --
+// it doesn't bother diffing old against new first; calling Apply a second
+// time with old and new swapped (reload.Manager's rollback path) correctly
+// restores the prior rules.
+func (rl *RateLimit) Apply(old, new *config.Config) error {
+	rl.rules.Store(buildRules(new))
+	return nil
+}
+
+// Middleware returns the Gin middleware function
+func (rl *RateLimit) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		r := rl.rules.Load()
+		if !r.enabled {
+			c.Next()
+			return
+		}
+
+		if r.exempt(c) {
+			c.Next()
+			return
+		}
+
+		route := c.FullPath()
+		if route == "" {
+			route = c.Request.URL.Path
+		}
+
+		rps, burst, key, scope := r.limitsAndKey(c, route)
+		if !rl.limiter.Allow(key, rps, burst) {
+			rl.metrics.recordRejected(route, scope)
+			resilience.SendErrorResponse(c, resilience.RateLimitedError(route, requestID(c)))
+			c.Abort()
+			return
+		}
+
+		rl.metrics.recordAllowed(route, scope)
+		c.Next()
+	}
+}
+
+// exempt reports whether c should bypass rate limiting entirely because
+// its user-agent, Origin header, or client IP matches a configured
+// exemption.
+func (r *rules) exempt(c *gin.Context) bool {
+	if _, ok := r.exemptUserAgents[c.Request.UserAgent()]; ok {
+		return true
+	}
+
+	if origin := c.GetHeader("Origin"); origin != "" {
+		if _, ok := r.exemptOrigins[origin]; ok {
+			return true
+		}
+	}
+
+	if ip := net.ParseIP(c.ClientIP()); ip != nil {
+		for _, n := range r.exemptNets {
+			if n.Contains(ip) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// limitsAndKey picks the rps/burst budget and bucket key for c against
+// route, in order of precedence: a per-route override, then a per-API-key
+// budget when the request carries an API key, then Security's global
+// default -- each scope gets its own bucket per route so a noisy API key
+// on one endpoint doesn't burn through another caller's budget on the
+// same endpoint.
+func (r *rules) limitsAndKey(c *gin.Context, route string) (rps, burst int, key, scope string) {
+	rps, burst = r.defaultRPS, r.defaultBurst
+	scope = "ip"
+	identity := c.ClientIP()
+
+	if apiKey := apiKeyFrom(c); apiKey != "" {
+		identity = apiKey
+		scope = "api_key"
+		if r.perAPIKeyRPS > 0 {
+			rps = r.perAPIKeyRPS
+		}
+		if r.perAPIKeyBurst > 0 {
+			burst = r.perAPIKeyBurst
+		}
+	}
+
+	if override, ok := r.routes[route]; ok {
+		rps, burst = override.RPS, override.Burst
+		scope = "route"
+	}
+
+	return rps, burst, route + "|" + scope + "|" + identity, scope
+}
+
+// apiKeyFrom returns the caller's API key from the X-API-Key header, or
+// failing that the raw bearer token, or "" if neither is present.
+func apiKeyFrom(c *gin.Context) string {
+	if key := c.GetHeader("X-API-Key"); key != "" {
+		return key
+	}
+	if auth := c.GetHeader("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimPrefix(auth, "Bearer ")
+	}
+	return ""
+}
+
+// requestID mirrors pkg/resilience's unexported getRequestID so
+// RateLimitedError's responses carry the same request_id other error
+// responses do.
+func requestID(c *gin.Context) string {
+	if v, exists := c.Get("request_id"); exists {
+		if id, ok := v.(string); ok {
+			return id
+		}
+	}
+	return ""
+}
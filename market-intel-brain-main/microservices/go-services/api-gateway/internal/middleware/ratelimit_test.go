@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/market-intel/api-gateway/internal/config"
+)
+
+func TestMemoryLimiterAllowsUpToBurstThenRejects(t *testing.T) {
+	l := newMemoryLimiter()
+
+	for i := 0; i < 3; i++ {
+		if !l.Allow("k", 1, 3) {
+			t.Fatalf("Allow() call %d = false, want true (within burst)", i)
+		}
+	}
+
+	if l.Allow("k", 1, 3) {
+		t.Fatal("Allow() = true, want false once burst is exhausted")
+	}
+}
+
+func TestMemoryLimiterTracksKeysIndependently(t *testing.T) {
+	l := newMemoryLimiter()
+
+	if !l.Allow("a", 1, 1) {
+		t.Fatal("Allow(a) = false, want true")
+	}
+	if !l.Allow("b", 1, 1) {
+		t.Fatal("Allow(b) = false, want true on a different key")
+	}
+	if l.Allow("a", 1, 1) {
+		t.Fatal("Allow(a) = true on second call, want false (burst exhausted)")
+	}
+}
+
+func newTestConfig() *config.Config {
+	return &config.Config{
+		Security: config.SecurityConfig{
+			RateLimitEnabled: true,
+			RateLimitRPS:     1,
+			RateLimitBurst:   1,
+		},
+		RateLimit: config.RateLimitConfig{
+			Backend: "memory",
+		},
+		Metrics: config.MetricsConfig{
+			Namespace: "test",
+			Subsystem: "gateway",
+		},
+	}
+}
+
+func TestRateLimitMiddlewareRejectsOverBudget(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	rl := New(newTestConfig(), prometheus.NewRegistry())
+	router := gin.New()
+	router.Use(rl.Middleware())
+	router.GET("/api/v1/ping", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest("GET", "/api/v1/ping", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("first request status = %d, want %d", w.Code, http.StatusOK)
+	}
+
+	req = httptest.NewRequest("GET", "/api/v1/ping", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("second request status = %d, want %d", w.Code, http.StatusTooManyRequests)
+	}
+}
+
+func TestRateLimitMiddlewareExemptsConfiguredUserAgent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := newTestConfig()
+	cfg.RateLimit.ExemptUserAgents = []string{"healthcheck-bot"}
+
+	rl := New(cfg, prometheus.NewRegistry())
+	router := gin.New()
+	router.Use(rl.Middleware())
+	router.GET("/api/v1/ping", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest("GET", "/api/v1/ping", nil)
+		req.Header.Set("User-Agent", "healthcheck-bot")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("request %d status = %d, want %d (exempt user-agent)", i, w.Code, http.StatusOK)
+		}
+	}
+}
+
+func TestRateLimitApplyRebuildsRules(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := newTestConfig()
+	cfg.Security.RateLimitRPS = 1
+	cfg.Security.RateLimitBurst = 1
+	rl := New(cfg, prometheus.NewRegistry())
+
+	looser := newTestConfig()
+	looser.Security.RateLimitBurst = 10
+	if err := rl.Apply(cfg, looser); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	router := gin.New()
+	router.Use(rl.Middleware())
+	router.GET("/api/v1/ping", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for i := 0; i < 5; i++ {
+		req := httptest.NewRequest("GET", "/api/v1/ping", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("request %d status = %d, want %d after raising burst via Apply", i, w.Code, http.StatusOK)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package middleware
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/market-intel/api-gateway/internal/config"
+)
+
+// fakeRedisServer answers INCR/EXPIRE requests over a single connection the
+// same way a real Redis server would: one request read and one reply
+// written at a time, in arrival order. It's enough to expose redisLimiter.Allow
+// racing its own connection, since an unserialized client would interleave
+// writes/reads against this same loop and get back a reply for the wrong
+// command.
+type fakeRedisServer struct {
+	t        *testing.T
+	listener net.Listener
+
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+func newFakeRedisServer(t *testing.T) *fakeRedisServer {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to start fake redis listener: %v", err)
+	}
+	s := &fakeRedisServer{t: t, listener: lis, counts: make(map[string]int64)}
+	go s.serve()
+	t.Cleanup(func() { lis.Close() })
+	return s
+}
+
+func (s *fakeRedisServer) addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *fakeRedisServer) serve() {
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	for {
+		args, err := readRESPArray(r)
+		if err != nil {
+			return
+		}
+		if len(args) == 0 {
+			continue
+		}
+
+		switch args[0] {
+		case "INCR":
+			s.mu.Lock()
+			s.counts[args[1]]++
+			n := s.counts[args[1]]
+			s.mu.Unlock()
+			fmt.Fprintf(conn, ":%d\r\n", n)
+		case "EXPIRE":
+			fmt.Fprintf(conn, "+OK\r\n")
+		default:
+			fmt.Fprintf(conn, "-ERR unknown command %s\r\n", args[0])
+		}
+	}
+}
+
+// readRESPArray reads one RESP array-of-bulk-strings request, the format
+// writeCommand produces.
+func readRESPArray(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[0] != '*' {
+		return nil, fmt.Errorf("unexpected request line %q", line)
+	}
+	n, err := strconv.Atoi(line[1 : len(line)-2])
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, n)
+	for i := 0; i < n; i++ {
+		head, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		if len(head) < 3 || head[0] != '$' {
+			return nil, fmt.Errorf("unexpected bulk header %q", head)
+		}
+		size, err := strconv.Atoi(head[1 : len(head)-2])
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func TestRedisLimiterAllowSerializesConcurrentCallsOnSharedConn(t *testing.T) {
+	server := newFakeRedisServer(t)
+
+	host, portStr, err := net.SplitHostPort(server.addr())
+	if err != nil {
+		t.Fatalf("failed to split fake redis addr: %v", err)
+	}
+	port, _ := strconv.Atoi(portStr)
+
+	l := newRedisLimiter(config.RedisConfig{
+		Host:        host,
+		Port:        port,
+		DialTimeout: time.Second,
+	})
+
+	const goroutines = 50
+	var wg sync.WaitGroup
+	var rejected int64
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			if !l.Allow("shared-key", 1, goroutines) {
+				atomic.AddInt64(&rejected, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if rejected != 0 {
+		t.Fatalf("Allow() rejected %d of %d calls within burst -- a corrupted reply was likely misread as an error or a wrong count", rejected, goroutines)
+	}
+
+	server.mu.Lock()
+	got := server.counts["ratelimit:shared-key"]
+	server.mu.Unlock()
+	if got != goroutines {
+		t.Fatalf("server observed %d INCRs, want %d -- concurrent Allow() calls corrupted the shared connection's RESP framing", got, goroutines)
+	}
+}
@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/market-intel/api-gateway/internal/services"
+)
+
+// TestCoreEngineClientImplementsInterface guards against silently stale
+// mocks: if a method is added to services.CoreEngineClient and nobody
+// regenerates this mock, the type assertion below fails to compile and a
+// reviewer sees exactly why.
+func TestCoreEngineClientImplementsInterface(t *testing.T) {
+	var _ services.CoreEngineClient = (*CoreEngineClient)(nil)
+}
+
+// TestCoreEngineClientHasExpecterForEveryMethod fails if a method is added
+// to the interface without a matching EXPECT().<Method>() helper on the
+// generated mock, which would otherwise go unnoticed until a test tried to
+// stub the new RPC and got a compile error far from here.
+func TestCoreEngineClientHasExpecterForEveryMethod(t *testing.T) {
+	ifaceType := reflect.TypeOf((*services.CoreEngineClient)(nil)).Elem()
+	expecterType := reflect.TypeOf(&CoreEngineClient_Expecter{})
+
+	for i := 0; i < ifaceType.NumMethod(); i++ {
+		name := ifaceType.Method(i).Name
+		if _, ok := expecterType.MethodByName(name); !ok {
+			t.Errorf("mocks.CoreEngineClient_Expecter is missing a %s() helper; run `make mocks`", name)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// envProvider resolves env://NAME references against the process
+// environment. It exists mainly for symmetry with the other backends, so a
+// config value can say "env://JWT_SECRET" explicitly instead of relying on
+// the implicit bare-value fallback.
+type envProvider struct{}
+
+func (envProvider) Scheme() string { return "env" }
+
+func (envProvider) Resolve(_ context.Context, ref Reference) (Secret, error) {
+	value, ok := os.LookupEnv(ref.Path)
+	if !ok {
+		return Secret{}, fmt.Errorf("secrets: environment variable %q is not set", ref.Path)
+	}
+	return Secret{Value: value}, nil
+}
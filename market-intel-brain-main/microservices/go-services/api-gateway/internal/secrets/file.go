@@ -0,0 +1,23 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// fileProvider resolves file:///path/to/secret references by reading the
+// file's contents, trimming every trailing newline (Kubernetes and Docker
+// secret mounts typically end the file with one).
+type fileProvider struct{}
+
+func (fileProvider) Scheme() string { return "file" }
+
+func (fileProvider) Resolve(_ context.Context, ref Reference) (Secret, error) {
+	data, err := os.ReadFile(ref.Path)
+	if err != nil {
+		return Secret{}, fmt.Errorf("secrets: reading %s: %w", ref.Raw, err)
+	}
+	return Secret{Value: strings.TrimRight(string(data), "\n")}, nil
+}
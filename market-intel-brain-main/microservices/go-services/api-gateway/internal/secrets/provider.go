@@ -0,0 +1,67 @@
+// Package secrets implements the SecretProvider abstraction backing the
+// gateway's config loader: instead of reading plaintext values straight out
+// of the environment, config fields like DB_PASSWORD and JWT_SECRET may hold
+// a reference string such as "vault://secret/data/db#password",
+// "awssm://prod/jwt", or "file:///run/secrets/jwt", which Resolver resolves
+// through the matching backend, caches for the backend's reported TTL, and
+// re-resolves whenever config.Load runs again (e.g. on the hot-reload
+// signal in internal/config/reload).
+package secrets
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Secret is a value resolved from a backend, along with how long Resolver
+// may cache it before fetching again. LeaseID is set only for Vault
+// dynamic secrets that support lease renewal.
+type Secret struct {
+	Value   string
+	TTL     time.Duration
+	LeaseID string
+}
+
+// Reference is a parsed secret reference such as
+// "vault://secret/data/db#password". Field is empty for backends (file,
+// env) that resolve to a single value rather than a set of named fields.
+type Reference struct {
+	Scheme string
+	Path   string
+	Field  string
+	Raw    string
+}
+
+// Provider resolves references for a single scheme.
+type Provider interface {
+	// Scheme is the reference prefix this provider handles, e.g. "vault".
+	Scheme() string
+	Resolve(ctx context.Context, ref Reference) (Secret, error)
+}
+
+// ParseReference parses raw as a secret reference if it begins with one of
+// the supported schemes. ok is false for a plain literal (e.g. a password
+// set directly in the environment), which callers should use as-is.
+func ParseReference(raw string) (ref Reference, ok bool) {
+	idx := strings.Index(raw, "://")
+	if idx < 0 {
+		return Reference{}, false
+	}
+
+	scheme := raw[:idx]
+	switch scheme {
+	case "env", "file", "vault", "awssm", "gcpsm":
+	default:
+		return Reference{}, false
+	}
+
+	rest := raw[idx+3:]
+	path := rest
+	field := ""
+	if h := strings.LastIndex(rest, "#"); h >= 0 {
+		path, field = rest[:h], rest[h+1:]
+	}
+
+	return Reference{Scheme: scheme, Path: path, Field: field, Raw: raw}, true
+}
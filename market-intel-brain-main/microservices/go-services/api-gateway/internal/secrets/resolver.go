@@ -0,0 +1,152 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Resolver resolves secret reference strings through the provider matching
+// their scheme, caching each resolved value for the TTL the backend
+// reports. A bare value (no recognized scheme) passes through unchanged,
+// so existing plaintext env vars keep working untouched. Config re-runs
+// Load (and therefore Resolve) on every hot-reload trigger, which is what
+// picks up a rotated secret once its cache entry expires.
+type Resolver struct {
+	providers map[string]Provider
+	vault     *vaultProvider // kept by concrete type for lease renewal
+
+	mu      sync.Mutex
+	cache   map[string]cacheEntry
+	renewal map[string]context.CancelFunc
+}
+
+type cacheEntry struct {
+	value     string
+	expiresAt time.Time
+}
+
+// NewResolver builds a Resolver with providers wired from the environment:
+// VAULT_ADDR/VAULT_TOKEN for vault://, AWS_* for awssm://, and the GCE/GKE
+// metadata server for gcpsm://. env:// and file:// need no configuration.
+func NewResolver(vaultAddr, vaultToken string) *Resolver {
+	r := &Resolver{
+		providers: make(map[string]Provider),
+		cache:     make(map[string]cacheEntry),
+		renewal:   make(map[string]context.CancelFunc),
+	}
+
+	r.register(envProvider{})
+	r.register(fileProvider{})
+	r.register(newAWSSecretsManagerProvider())
+	r.register(newGCPSecretManagerProvider())
+
+	if vaultAddr != "" {
+		r.vault = newVaultProvider(vaultAddr, vaultToken)
+		r.register(r.vault)
+	}
+
+	return r
+}
+
+func (r *Resolver) register(p Provider) {
+	r.providers[p.Scheme()] = p
+}
+
+// Resolve returns raw unchanged if it isn't a recognized secret reference,
+// otherwise the referenced value -- from cache if still fresh, or freshly
+// fetched (and, for a Vault dynamic secret with a renewable lease, kept
+// alive with a background renewal loop) otherwise.
+func (r *Resolver) Resolve(ctx context.Context, raw string) (string, error) {
+	ref, ok := ParseReference(raw)
+	if !ok {
+		return raw, nil
+	}
+
+	r.mu.Lock()
+	if entry, found := r.cache[raw]; found && time.Now().Before(entry.expiresAt) {
+		r.mu.Unlock()
+		return entry.value, nil
+	}
+	r.mu.Unlock()
+
+	provider, ok := r.providers[ref.Scheme]
+	if !ok {
+		return "", fmt.Errorf("secrets: no provider registered for scheme %q (%s)", ref.Scheme, raw)
+	}
+
+	secret, err := provider.Resolve(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+
+	r.mu.Lock()
+	expiresAt := time.Now()
+	if secret.TTL > 0 {
+		expiresAt = expiresAt.Add(secret.TTL)
+	}
+	r.cache[raw] = cacheEntry{value: secret.Value, expiresAt: expiresAt}
+	r.mu.Unlock()
+
+	if secret.LeaseID != "" && r.vault != nil {
+		r.startLeaseRenewal(raw, secret.LeaseID, secret.TTL)
+	}
+
+	return secret.Value, nil
+}
+
+// startLeaseRenewal keeps a Vault dynamic secret's lease alive by renewing
+// it at half its duration, replacing any renewal loop already running for
+// the same reference. It stops on its own once Vault refuses a renewal
+// (e.g. the lease hit its max TTL), at which point the cache entry simply
+// expires and the next Resolve call fetches a brand-new credential.
+func (r *Resolver) startLeaseRenewal(raw, leaseID string, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+
+	r.mu.Lock()
+	if cancel, running := r.renewal[raw]; running {
+		cancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	r.renewal[raw] = cancel
+	r.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(ttl / 2)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				renewCtx, cancelRenew := context.WithTimeout(ctx, 10*time.Second)
+				newTTL, err := r.vault.RenewLease(renewCtx, leaseID, ttl)
+				cancelRenew()
+				if err != nil || newTTL <= 0 {
+					return
+				}
+
+				r.mu.Lock()
+				if entry, ok := r.cache[raw]; ok {
+					entry.expiresAt = time.Now().Add(newTTL)
+					r.cache[raw] = entry
+				}
+				r.mu.Unlock()
+			}
+		}
+	}()
+}
+
+// Close stops any in-flight Vault lease renewal loops.
+func (r *Resolver) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, cancel := range r.renewal {
+		cancel()
+	}
+	r.renewal = make(map[string]context.CancelFunc)
+}
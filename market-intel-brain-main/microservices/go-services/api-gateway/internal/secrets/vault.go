@@ -0,0 +1,140 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// vaultProvider resolves vault://<path>#<field> references against a
+// HashiCorp Vault HTTP API, reached directly with net/http rather than the
+// Vault SDK, the same hand-rolled-client approach internal/cache takes for
+// Redis. <path> is whatever Vault expects after /v1/, so a KV v2 secret is
+// "vault://secret/data/db#password" and a database-engine dynamic
+// credential lease is "vault://database/creds/readonly#username" (and
+// "...#password" for its paired credential). Address and token come from
+// VAULT_ADDR/VAULT_TOKEN.
+type vaultProvider struct {
+	addr   string
+	token  string
+	client *http.Client
+}
+
+func newVaultProvider(addr, token string) *vaultProvider {
+	return &vaultProvider{
+		addr:   strings.TrimRight(addr, "/"),
+		token:  token,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *vaultProvider) Scheme() string { return "vault" }
+
+// vaultResponse is the subset of Vault's read-secret response shape shared
+// by KV v2 reads and dynamic-credential leases.
+type vaultResponse struct {
+	LeaseID       string          `json:"lease_id"`
+	LeaseDuration int             `json:"lease_duration"`
+	Renewable     bool            `json:"renewable"`
+	Data          json.RawMessage `json:"data"`
+}
+
+func (p *vaultProvider) Resolve(ctx context.Context, ref Reference) (Secret, error) {
+	body, err := p.read(ctx, ref.Path)
+	if err != nil {
+		return Secret{}, err
+	}
+
+	// KV v2 nests the actual key/value pairs under data.data; the database
+	// engine and other non-KV mounts put them directly under data.
+	var kv struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(body.Data, &kv); err == nil && kv.Data != nil {
+		fields = kv.Data
+	} else if err := json.Unmarshal(body.Data, &fields); err != nil {
+		return Secret{}, fmt.Errorf("secrets: decoding vault data for %s: %w", ref.Raw, err)
+	}
+
+	value, ok := fields[ref.Field]
+	if !ok {
+		return Secret{}, fmt.Errorf("secrets: field %q not present in vault response for %s", ref.Field, ref.Raw)
+	}
+
+	secret := Secret{
+		Value: fmt.Sprintf("%v", value),
+		TTL:   time.Duration(body.LeaseDuration) * time.Second,
+	}
+	if body.Renewable {
+		secret.LeaseID = body.LeaseID
+	}
+	return secret, nil
+}
+
+func (p *vaultProvider) read(ctx context.Context, path string) (vaultResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.addr+"/v1/"+path, nil)
+	if err != nil {
+		return vaultResponse{}, fmt.Errorf("secrets: building vault request for %s: %w", path, err)
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return vaultResponse{}, fmt.Errorf("secrets: vault request for %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return vaultResponse{}, fmt.Errorf("secrets: vault returned %s for %s", resp.Status, path)
+	}
+
+	var body vaultResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return vaultResponse{}, fmt.Errorf("secrets: decoding vault response for %s: %w", path, err)
+	}
+	return body, nil
+}
+
+// RenewLease extends a Vault-issued lease (used for dynamic database
+// credentials) by increment and returns the new lease duration. It does not
+// change the underlying credential value; once the lease can no longer be
+// renewed, the next Resolve call past expiry fetches a freshly-generated
+// credential, which config.Load's reload:"restart" tag on DatabaseConfig
+// then surfaces as a restart-required diff.
+func (p *vaultProvider) RenewLease(ctx context.Context, leaseID string, increment time.Duration) (time.Duration, error) {
+	payload, err := json.Marshal(map[string]interface{}{
+		"lease_id":  leaseID,
+		"increment": int(increment.Seconds()),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("secrets: encoding lease renewal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, p.addr+"/v1/sys/leases/renew", bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("secrets: building lease renewal request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("secrets: renewing lease %s: %w", leaseID, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("secrets: vault returned %s renewing lease %s", resp.Status, leaseID)
+	}
+
+	var renewed vaultResponse
+	if err := json.NewDecoder(resp.Body).Decode(&renewed); err != nil {
+		return 0, fmt.Errorf("secrets: decoding lease renewal response for %s: %w", leaseID, err)
+	}
+	return time.Duration(renewed.LeaseDuration) * time.Second, nil
+}
@@ -0,0 +1,209 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"github.com/soheilhy/cmux"
+
+	"github.com/market-intel/api-gateway/internal/config"
+	"github.com/market-intel/api-gateway/pkg/logger"
+)
+
+// GatewayServer exposes GRPCServer's registered services to clients that
+// can't speak native gRPC: gRPC-Web (browsers), via
+// improbable-eng/grpc-web, and plain HTTP/JSON, via grpc-gateway's
+// transcoding ServeMux. Both wrap the same *grpc.Server GRPCServer builds,
+// so registering an RPC there is the only thing a handler author has to do
+// -- this never duplicates handler code.
+type GatewayServer struct {
+	config  *config.Config
+	grpcSrv *GRPCServer
+
+	wrapped   *grpcweb.WrappedGrpcServer
+	jsonMux   *runtime.ServeMux
+	allowlist map[string]struct{}
+
+	server   *http.Server
+	ready    chan struct{}
+	serveErr chan error
+}
+
+// NewGatewayServer builds a GatewayServer wrapping grpcSrv's *grpc.Server.
+// grpcSrv.Start must run first, since the *grpc.Server it wraps doesn't
+// exist until then.
+func NewGatewayServer(cfg *config.Config, grpcSrv *GRPCServer) *GatewayServer {
+	allowlist := make(map[string]struct{}, len(cfg.Gateway.MethodAllowlist))
+	for _, method := range cfg.Gateway.MethodAllowlist {
+		allowlist[strings.TrimPrefix(method, "/")] = struct{}{}
+	}
+
+	allowedOrigin := corsOriginAllower(cfg.Gateway.AllowedOrigins)
+	wrapped := grpcweb.WrapServer(grpcSrv.Server(),
+		grpcweb.WithOriginFunc(allowedOrigin),
+		grpcweb.WithWebsockets(cfg.Gateway.EnableWebSocketStreaming),
+		grpcweb.WithWebsocketOriginFunc(func(req *http.Request) bool {
+			return allowedOrigin(req.Header.Get("Origin"))
+		}),
+	)
+
+	return &GatewayServer{
+		config:    cfg,
+		grpcSrv:   grpcSrv,
+		wrapped:   wrapped,
+		jsonMux:   runtime.NewServeMux(),
+		allowlist: allowlist,
+		ready:     make(chan struct{}),
+		serveErr:  make(chan error, 1),
+	}
+}
+
+// JSONMux returns the grpc-gateway ServeMux that transcodes HTTP/JSON to
+// the wrapped gRPC server. Generated *.pb.gw.go RegisterXHandler calls
+// register against this, the same way business RPCs register against
+// GRPCServer.Server() directly.
+func (s *GatewayServer) JSONMux() *runtime.ServeMux {
+	return s.jsonMux
+}
+
+// Ready closes once the gateway's listener is bound and accepting
+// connections.
+func (s *GatewayServer) Ready() <-chan struct{} {
+	return s.ready
+}
+
+// Err reports the result of Serve once it returns, for an errgroup goroutine
+// to pick up and turn into a group-wide shutdown. It never receives
+// anything if Start itself failed to bind.
+func (s *GatewayServer) Err() <-chan error {
+	return s.serveErr
+}
+
+// Start binds the gateway's own listener (Gateway.ListenAddr, falling back
+// to Gateway.Port) synchronously, returning any bind error immediately, then
+// serves in the background. Use StartOn instead when Gateway.MultiplexHTTP
+// shares the HTTP server's port via cmux rather than binding a port here.
+func (s *GatewayServer) Start() error {
+	lis, err := resolveListener(s.config.Gateway.ListenAddr, s.config.GetGatewayPort())
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	return s.serve(lis)
+}
+
+// StartOn serves the gateway on a listener obtained elsewhere -- the cmux
+// split of the HTTP server's port that Gateway.MultiplexHTTP produces.
+func (s *GatewayServer) StartOn(lis net.Listener) error {
+	return s.serve(lis)
+}
+
+func (s *GatewayServer) serve(lis net.Listener) error {
+	s.server = &http.Server{
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	logger.Infof("gateway (gRPC-Web + JSON transcoding) listening on %s", lis.Addr())
+	close(s.ready)
+
+	go func() {
+		s.serveErr <- s.server.Serve(lis)
+	}()
+
+	return nil
+}
+
+// ServeHTTP routes a request to the gRPC-Web wrapper or the JSON
+// transcoding mux, whichever it matches, enforcing the configured method
+// allowlist against gRPC-Web requests (the JSON mux has no equivalent
+// concept of a method path until a service is registered against it, so
+// the allowlist only applies to the former).
+func (s *GatewayServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.wrapped.IsGrpcWebRequest(r) || s.wrapped.IsGrpcWebSocketRequest(r) {
+		if !s.methodAllowed(r.URL.Path) {
+			http.Error(w, "method not allowed", http.StatusForbidden)
+			return
+		}
+		s.wrapped.ServeHTTP(w, r)
+		return
+	}
+	s.jsonMux.ServeHTTP(w, r)
+}
+
+func (s *GatewayServer) methodAllowed(path string) bool {
+	if len(s.allowlist) == 0 {
+		return true
+	}
+	_, ok := s.allowlist[strings.TrimPrefix(path, "/")]
+	return ok
+}
+
+// Stop stops accepting new gRPC-Web/JSON requests immediately, then waits
+// for in-flight ones to finish for up to Gateway.DrainTimeout before
+// force-closing whatever is still running -- the same graceful-then-forced
+// shape GRPCServer.Stop uses for native gRPC connections.
+func (s *GatewayServer) Stop() error {
+	if s.server == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.Gateway.DrainTimeout)
+	defer cancel()
+
+	if err := s.server.Shutdown(ctx); err != nil {
+		logger.Warnf("gateway graceful shutdown exceeded drain timeout %v, forcing close", s.config.Gateway.DrainTimeout)
+		return s.server.Close()
+	}
+
+	removeSocketFile(s.config.Gateway.ListenAddr)
+	return nil
+}
+
+// NewMultiplexedListeners resolves the HTTP server's configured listen
+// address and splits it with cmux into two listeners: gatewayLis for
+// gRPC-Web requests, identified by their Content-Type, and httpLis for
+// everything else. The returned serve func runs the multiplexer's accept
+// loop -- call it in a goroutine after both listeners have been handed to
+// their respective Server.StartOn. The returned close func releases the
+// underlying root listener cmux wraps; call it during shutdown once both
+// Server.Stop calls have returned, since closing it out from under an
+// in-flight cmux.Serve would otherwise leave the OS socket bound until
+// process exit. Used when Gateway.MultiplexHTTP is set, so browser clients
+// don't need a second port.
+func NewMultiplexedListeners(cfg *config.Config) (httpLis, gatewayLis net.Listener, serve func() error, closeRoot func() error, err error) {
+	lis, err := resolveListener(cfg.Server.ListenAddr, cfg.GetHTTPPort())
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("failed to listen: %w", err)
+	}
+
+	m := cmux.New(lis)
+	gatewayLis = m.Match(cmux.HTTP1HeaderFieldPrefix("content-type", "application/grpc-web"))
+	httpLis = m.Match(cmux.Any())
+
+	return httpLis, gatewayLis, m.Serve, lis.Close, nil
+}
+
+// corsOriginAllower builds the gRPC-Web CORS origin check from
+// Gateway.AllowedOrigins; "*" (the default) allows any origin.
+func corsOriginAllower(allowedOrigins []string) func(origin string) bool {
+	for _, origin := range allowedOrigins {
+		if origin == "*" {
+			return func(string) bool { return true }
+		}
+	}
+
+	allowed := make(map[string]struct{}, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		allowed[origin] = struct{}{}
+	}
+	return func(origin string) bool {
+		_, ok := allowed[origin]
+		return ok
+	}
+}
@@ -1,34 +1,338 @@
 package server
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net"
+	"runtime/debug"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
+	grpcstatus "google.golang.org/grpc/status"
 
 	"github.com/market-intel/api-gateway/internal/config"
+	"github.com/market-intel/api-gateway/internal/services"
+	"github.com/market-intel/api-gateway/pkg/grpcerrors"
 	"github.com/market-intel/api-gateway/pkg/logger"
+	"github.com/market-intel/api-gateway/pkg/resilience"
 )
 
+// healthCheckInterval is how often the gRPC health service re-probes the
+// core engine and the ingestion buffers to refresh its serving status
+const healthCheckInterval = 10 * time.Second
+
+// BufferHealthChecker reports whether a handler's ingestion buffers are
+// still receiving fresh data; handlers.DataIngestionHandler implements it
+// so GRPCServer's health service can fail even if the core engine
+// connection itself looks fine.
+type BufferHealthChecker interface {
+	BufferHealthy() error
+}
+
+// GRPCServer hosts the API Gateway's inter-service gRPC endpoints: a
+// grpc_health_v1 health service backed by the same checks HealthHandler
+// uses, reflection for ad-hoc debugging, and OTel-aware, panic-safe,
+// load-shedding interceptors shared across every registered service.
 type GRPCServer struct {
-	config *config.Config
+	config           *config.Config
+	coreEngineClient services.CoreEngineClient
+	bufferChecker    BufferHealthChecker
+
+	server    *grpc.Server
+	healthSrv *health.Server
+	limiter   *resilience.CircuitBreaker
+
+	stopHealthChecks context.CancelFunc
+	ready            chan struct{}
+	serveErr         chan error
 }
 
-func NewGRPCServer(config *config.Config) *GRPCServer {
+// NewGRPCServer creates a GRPCServer. bufferChecker may be nil (e.g. if
+// ingestion hasn't been wired up yet), in which case buffer liveness is
+// left out of the health calculation entirely.
+func NewGRPCServer(config *config.Config, coreEngineClient services.CoreEngineClient, bufferChecker BufferHealthChecker) *GRPCServer {
 	return &GRPCServer{
-		config: config,
+		config:           config,
+		coreEngineClient: coreEngineClient,
+		bufferChecker:    bufferChecker,
+		ready:            make(chan struct{}),
+		serveErr:         make(chan error, 1),
 	}
 }
 
+// Ready closes once the gRPC server's listener is bound and accepting
+// connections. A /readyz check can block on it (with a select against its
+// own deadline) instead of guessing how long startup takes.
+func (s *GRPCServer) Ready() <-chan struct{} {
+	return s.ready
+}
+
+// Err reports the result of Serve once it returns, for an errgroup goroutine
+// to pick up and turn into a group-wide shutdown. It never receives
+// anything if Start itself failed to bind.
+func (s *GRPCServer) Err() <-chan error {
+	return s.serveErr
+}
+
+// Start binds the gRPC listener synchronously, returning any bind error to
+// the caller immediately, then serves in the background -- send the result
+// to Err() once Serve returns. This lets main fail fast on a bad port/socket
+// instead of discovering it later from a goroutine's logged error.
 func (s *GRPCServer) Start() error {
-	// TODO: Implement gRPC server for API Gateway
-	// This will be used for inter-service communication
-	logger.Infof("gRPC server not yet implemented for API Gateway")
+	lis, err := s.Listen()
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	if s.config.GRPC.MaxConcurrentRequests > 0 {
+		s.limiter = newLoadShedLimiter(s.config.GRPC.MaxConcurrentRequests)
+	}
+
+	opts := []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			MaxConnectionIdle:     s.config.GRPC.MaxConnectionIdle,
+			MaxConnectionAge:      s.config.GRPC.MaxConnectionAge,
+			MaxConnectionAgeGrace: s.config.GRPC.MaxConnectionAgeGrace,
+			Time:                  s.config.GRPC.KeepaliveTime,
+			Timeout:               s.config.GRPC.KeepaliveTimeout,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             s.config.GRPC.EnforcementMinTime,
+			PermitWithoutStream: s.config.GRPC.EnforcementPermitWithoutStream,
+		}),
+		grpc.ChainUnaryInterceptor(s.unaryInterceptors()...),
+		grpc.ChainStreamInterceptor(s.streamInterceptors()...),
+	}
+
+	if s.config.GRPC.TLSCertFile != "" {
+		creds, err := credentials.NewServerTLSFromFile(s.config.GRPC.TLSCertFile, s.config.GRPC.TLSKeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load gRPC TLS credentials: %w", err)
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	s.server = grpc.NewServer(opts...)
+
+	s.healthSrv = health.NewServer()
+	grpc_health_v1.RegisterHealthServer(s.server, s.healthSrv)
+
+	if s.config.GRPC.EnableReflection {
+		reflection.Register(s.server)
+	}
+
+	healthCtx, cancel := context.WithCancel(context.Background())
+	s.stopHealthChecks = cancel
+	go s.runHealthChecks(healthCtx)
+
+	logger.Infof("gRPC server listening on %s", lis.Addr())
+	close(s.ready)
+
+	go func() {
+		s.serveErr <- s.server.Serve(lis)
+	}()
+
 	return nil
 }
 
+// Stop stops accepting new RPCs immediately, marks the health service
+// NOT_SERVING so load balancers drain traffic away, then waits for in-flight
+// RPCs to finish for up to config.GRPC.DrainTimeout before force-closing
+// whatever is still running.
 func (s *GRPCServer) Stop() error {
-	// TODO: Implement gRPC server shutdown
+	if s.server == nil {
+		return nil
+	}
+	if s.stopHealthChecks != nil {
+		s.stopHealthChecks()
+	}
+	s.healthSrv.Shutdown()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(s.config.GRPC.DrainTimeout):
+		logger.Warnf("gRPC graceful stop exceeded drain timeout %v, forcing shutdown", s.config.GRPC.DrainTimeout)
+		s.server.Stop()
+	}
+
+	removeSocketFile(s.config.GRPC.ListenAddr)
 	return nil
 }
 
 func (s *GRPCServer) Listen() (net.Listener, error) {
-	return net.Listen("tcp", s.config.GetGRPCPort())
+	return resolveListener(s.config.GRPC.ListenAddr, s.config.GetGRPCPort())
+}
+
+// Server returns the underlying *grpc.Server, for GatewayServer to wrap with
+// gRPC-Web and HTTP/JSON transcoding. It's nil until Start has built it, so
+// NewGatewayServer must run after GRPCServer.Start, not before.
+func (s *GRPCServer) Server() *grpc.Server {
+	return s.server
+}
+
+// unaryInterceptors builds the chain applied to every unary RPC: recovery
+// outermost so it catches panics from the tracing and limiter layers too,
+// then otelgrpc so HTTP traces continue into gRPC handlers, then the
+// load-shedding limiter, then grpcerrors closest to the handler so it sees
+// a handler's typed domain error before anything else touches it.
+func (s *GRPCServer) unaryInterceptors() []grpc.UnaryServerInterceptor {
+	interceptors := []grpc.UnaryServerInterceptor{
+		s.recoveryUnaryInterceptor,
+		otelgrpc.UnaryServerInterceptor(),
+	}
+	if s.limiter != nil {
+		interceptors = append(interceptors, s.loadSheddingUnaryInterceptor)
+	}
+	interceptors = append(interceptors, grpcerrors.ErrorUnaryServerInterceptor())
+	return interceptors
+}
+
+func (s *GRPCServer) streamInterceptors() []grpc.StreamServerInterceptor {
+	interceptors := []grpc.StreamServerInterceptor{
+		s.recoveryStreamInterceptor,
+		otelgrpc.StreamServerInterceptor(),
+	}
+	if s.limiter != nil {
+		interceptors = append(interceptors, s.loadSheddingStreamInterceptor)
+	}
+	interceptors = append(interceptors, grpcerrors.ErrorStreamServerInterceptor())
+	return interceptors
+}
+
+func (s *GRPCServer) recoveryUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recoverToStatus(ctx, info.FullMethod, r)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+func (s *GRPCServer) recoveryStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recoverToStatus(ss.Context(), info.FullMethod, r)
+		}
+	}()
+	return handler(srv, ss)
+}
+
+// recoverToStatus records a recovered panic into whatever span ctx carries
+// and converts it into an Internal status so the panic doesn't take down
+// the server. The recovery interceptor is chained outside otelgrpc, so ctx
+// holds the span the RPC arrived with (if any), not otelgrpc's server span.
+func recoverToStatus(ctx context.Context, fullMethod string, r any) error {
+	err := fmt.Errorf("panic in %s: %v", fullMethod, r)
+
+	span := trace.SpanFromContext(ctx)
+	span.RecordError(err)
+	span.SetStatus(otelcodes.Error, err.Error())
+
+	logger.Errorf("Recovered from panic in gRPC handler %s: %v\n%s", fullMethod, r, debug.Stack())
+	return grpcstatus.Error(codes.Internal, "internal server error")
+}
+
+// loadSheddingUnaryInterceptor runs the handler through s.limiter so
+// requests past the configured concurrency limit are rejected with
+// ResourceExhausted instead of queuing up behind slow downstreams.
+func (s *GRPCServer) loadSheddingUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	var resp any
+	err := s.limiter.Execute(ctx, func() error {
+		var handlerErr error
+		resp, handlerErr = handler(ctx, req)
+		return handlerErr
+	})
+	if errors.Is(err, resilience.ErrConcurrencyLimit) {
+		return nil, grpcstatus.Error(codes.ResourceExhausted, "server is shedding load, try again later")
+	}
+	return resp, err
+}
+
+func (s *GRPCServer) loadSheddingStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	err := s.limiter.Execute(ss.Context(), func() error {
+		return handler(srv, ss)
+	})
+	if errors.Is(err, resilience.ErrConcurrencyLimit) {
+		return grpcstatus.Error(codes.ResourceExhausted, "server is shedding load, try again later")
+	}
+	return err
+}
+
+// newLoadShedLimiter builds a CircuitBreaker used purely as a fixed
+// concurrency gate: the failure-rate window is disabled and MaxFailures is
+// effectively unreachable so a downstream handler error never trips it --
+// only the in-flight request count does.
+func newLoadShedLimiter(maxConcurrent int64) *resilience.CircuitBreaker {
+	cfg := resilience.DefaultCircuitBreakerConfig()
+	cfg.WindowBuckets = 0
+	cfg.MaxFailures = 1 << 30
+
+	cfg.ConcurrencyLimitEnabled = true
+	cfg.InitialConcurrencyLimit = maxConcurrent
+	cfg.MinConcurrencyLimit = maxConcurrent
+	cfg.MaxConcurrencyLimit = maxConcurrent
+	cfg.ConcurrencyAdditiveIncrease = 0
+	cfg.ConcurrencyMultiplicativeDecrease = 1
+
+	return resilience.NewCircuitBreaker(cfg)
+}
+
+// runHealthChecks refreshes the gRPC health service's serving status on
+// healthCheckInterval until ctx is canceled, folding in a core engine ping
+// and, when a BufferHealthChecker was given, its ingestion buffer liveness
+// check (which HealthHandler's HTTP checks do not include).
+func (s *GRPCServer) runHealthChecks(ctx context.Context) {
+	s.updateHealth()
+
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.updateHealth()
+		}
+	}
+}
+
+func (s *GRPCServer) updateHealth() {
+	status := grpc_health_v1.HealthCheckResponse_SERVING
+
+	if s.coreEngineClient != nil {
+		checkCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		_, err := s.coreEngineClient.HealthCheck(checkCtx, "api-gateway")
+		cancel()
+		if err != nil {
+			logger.Warnf("gRPC health check: core engine ping failed: %v", err)
+			status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+		}
+	}
+
+	if s.bufferChecker != nil {
+		if err := s.bufferChecker.BufferHealthy(); err != nil {
+			logger.Warnf("gRPC health check: buffer liveness failed: %v", err)
+			status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+		}
+	}
+
+	s.healthSrv.SetServingStatus("", status)
+	s.healthSrv.SetServingStatus("api-gateway", status)
 }
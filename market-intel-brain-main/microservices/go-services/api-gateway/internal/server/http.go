@@ -1,36 +1,93 @@
 package server
 
 import (
+	"context"
+	"fmt"
+	"net"
 	"net/http"
 	"net/http/pprof"
 	"runtime"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/market-intel/api-gateway/internal/auth"
 	"github.com/market-intel/api-gateway/internal/config"
+	"github.com/market-intel/api-gateway/internal/config/reload"
 	"github.com/market-intel/api-gateway/internal/handlers"
+	"github.com/market-intel/api-gateway/internal/middleware"
 	"github.com/market-intel/api-gateway/internal/services"
 	"github.com/market-intel/api-gateway/pkg/logger"
 	"github.com/market-intel/api-gateway/pkg/otel"
 )
 
 type HTTPServer struct {
-	config           *config.Config
-	coreEngineClient *services.CoreEngineClient
-	server           *http.Server
-	otelMiddleware    *otel.OtelMiddleware
-	metricsMiddleware *otel.MetricsMiddleware
+	config               *config.Config
+	coreEngineClient     services.CoreEngineClient
+	server               *http.Server
+	otelMiddleware       *otel.OtelMiddleware
+	metricsMiddleware    *otel.MetricsMiddleware
+	rateLimit            *middleware.RateLimit
+	dataIngestionHandler *handlers.DataIngestionHandler
+	adminHandler         *handlers.AdminHandler
+	readyGates           []<-chan struct{}
+
+	ready    chan struct{}
+	serveErr chan error
 }
 
-func NewHTTPServer(config *config.Config, coreEngineClient *services.CoreEngineClient) *HTTPServer {
+// NewHTTPServer builds an HTTPServer. reloadManager may be nil, in which
+// case the /admin/reload endpoint stays registered but responds 503
+// instead of reloading anything, and the rate limiter's rules are fixed at
+// startup. loadConfig is the function /admin/reload uses to produce a
+// reload candidate (e.g. config.Load(configFile)).
+func NewHTTPServer(config *config.Config, coreEngineClient services.CoreEngineClient, reloadManager *reload.Manager, loadConfig func() (*config.Config, error)) *HTTPServer {
+	metricsMiddleware := otel.NewMetricsMiddleware()
+	rateLimit := middleware.New(config, metricsMiddleware.Registry())
+	if reloadManager != nil {
+		reloadManager.Subscribe(rateLimit)
+	}
+
 	return &HTTPServer{
-		config:           config,
-		coreEngineClient: coreEngineClient,
-		otelMiddleware:    otel.NewOtelMiddleware("api-gateway"),
-		metricsMiddleware: otel.NewMetricsMiddleware(),
+		config:               config,
+		coreEngineClient:     coreEngineClient,
+		otelMiddleware:       otel.NewOtelMiddleware("api-gateway"),
+		metricsMiddleware:    metricsMiddleware,
+		rateLimit:            rateLimit,
+		dataIngestionHandler: handlers.NewDataIngestionHandler(config, coreEngineClient, metricsMiddleware.Registry()),
+		adminHandler:         handlers.NewAdminHandler(config.Kafka, reloadManager, loadConfig),
+		ready:                make(chan struct{}),
+		serveErr:             make(chan error, 1),
 	}
 }
 
+// AddReadyGate registers another component's readiness channel that must
+// close before Readyz reports ready. main wires the gRPC server's Ready()
+// in here once both servers exist, so /readyz can't return 200 before the
+// gRPC listener is actually accepting connections.
+func (s *HTTPServer) AddReadyGate(ch <-chan struct{}) {
+	s.readyGates = append(s.readyGates, ch)
+}
+
+// Ready closes once the HTTP server's listener is bound and accepting
+// connections.
+func (s *HTTPServer) Ready() <-chan struct{} {
+	return s.ready
+}
+
+// Err reports the result of Serve once it returns, for an errgroup goroutine
+// to pick up and turn into a group-wide shutdown. It never receives
+// anything if Start itself failed to bind.
+func (s *HTTPServer) Err() <-chan error {
+	return s.serveErr
+}
+
+// DataIngestionHandler returns the ingestion handler shared with the HTTP
+// routes, so GRPCServer can fold its MQTT buffer liveness into the gRPC
+// health service without standing up a second handler instance.
+func (s *HTTPServer) DataIngestionHandler() *handlers.DataIngestionHandler {
+	return s.dataIngestionHandler
+}
+
 func (s *HTTPServer) SetupRoutes() *gin.Engine {
 	// Set Gin mode
 	if s.config.Environment == "production" {
@@ -63,14 +120,23 @@ func (s *HTTPServer) SetupRoutes() *gin.Engine {
 		c.Next()
 	})
 
+	// Add rate limiting middleware (per-route, per-API-key, exempting
+	// configured user-agents/origins/CIDRs)
+	router.Use(s.rateLimit.Middleware())
+
 	// Create handlers
-	healthHandler := handlers.NewHealthHandler(s.config, s.coreEngineClient)
-	dataIngestionHandler := handlers.NewDataIngestionHandler(s.config, s.coreEngineClient)
-	
+	healthHandler := handlers.NewHealthHandler(s.config, s.coreEngineClient, s.metricsMiddleware.Registry(), s.readyGates)
+	dataIngestionHandler := s.dataIngestionHandler
+
 	// Setup routes
 	v1 := router.Group("/api/v1")
+	if s.config.Security.JWTSecret != "" {
+		v1.Use(auth.NewAuthMiddleware(s.config.Security.JWTSecret).Middleware())
+	}
 	{
 		// Health endpoints
+		v1.GET("/healthz", healthHandler.Healthz)
+		v1.GET("/readyz", healthHandler.Readyz)
 		v1.GET("/health", healthHandler.Health)
 		v1.GET("/ping", healthHandler.Ping)
 		v1.GET("/ping/core-engine", healthHandler.PingCoreEngine)
@@ -82,6 +148,13 @@ func (s *HTTPServer) SetupRoutes() *gin.Engine {
 		v1.GET("/news/buffer", dataIngestionHandler.GetNewsBuffer)
 		v1.GET("/ingestion/stats", dataIngestionHandler.GetIngestionStats)
 		v1.POST("/data-sources/connect", dataIngestionHandler.ConnectDataSource)
+		v1.POST("/cache/purge", dataIngestionHandler.PurgeCache)
+		v1.POST("/market-data/backfill", dataIngestionHandler.BackfillMarketData)
+
+		// Server-Sent Events endpoints (WebSocket alternative for clients
+		// that can only hold a plain HTTP connection)
+		v1.GET("/market-data/stream", dataIngestionHandler.StreamMarketDataSSE)
+		v1.GET("/news/stream", dataIngestionHandler.StreamNewsSSE)
 
 		// WebSocket endpoints
 		v1.GET("/ws/market-data", dataIngestionHandler.WebSocketMarketData)
@@ -91,10 +164,20 @@ func (s *HTTPServer) SetupRoutes() *gin.Engine {
 	}
 
 	// Root endpoints
+	router.GET("/healthz", healthHandler.Healthz)
+	router.GET("/readyz", healthHandler.Readyz)
 	router.GET("/health", healthHandler.Health)
 	router.GET("/ping", healthHandler.Ping)
 	router.GET("/ping/core-engine", healthHandler.PingCoreEngine)
 
+	// Admin endpoints (config hot-reload, Kafka cluster operations); not
+	// grouped under /api/v1 since they manage the process and its
+	// dependencies rather than serve application data
+	router.POST("/admin/reload", s.adminHandler.Reload)
+	router.GET("/admin/kafka/topics/:topic/reassignments", s.adminHandler.ListPartitionReassignments)
+	router.POST("/admin/kafka/topics/:topic/reassignments", s.adminHandler.AlterPartitionReassignments)
+	router.GET("/admin/kafka/consumer-groups/:group/lag", s.adminHandler.ConsumerGroupLag)
+
 	// Profiling endpoints (only in non-production environments)
 	if s.config.Environment != "production" {
 		router.GET("/debug/pprof/", gin.WrapF(http.HandlerFunc(pprof.Index)))
@@ -107,7 +190,7 @@ func (s *HTTPServer) SetupRoutes() *gin.Engine {
 		router.GET("/debug/pprof/threadcreate", gin.WrapF(http.HandlerFunc(pprof.ThreadCreate)))
 		router.GET("/debug/pprof/block", gin.WrapF(http.HandlerFunc(pprof.Block)))
 		router.GET("/debug/pprof/mutex", gin.WrapF(http.HandlerFunc(pprof.Mutex)))
-		
+
 		// Additional profiling endpoints
 		router.GET("/debug/pprof/allocs", gin.WrapF(http.HandlerFunc(pprof.Allocs)))
 		router.GET("/debug/pprof/lookups", gin.WrapF(http.HandlerFunc(pprof.Lookups)))
@@ -132,12 +215,31 @@ func (s *HTTPServer) SetupRoutes() *gin.Engine {
 	return router
 }
 
-func (s *HTTPServer) ListenAndServe() error {
+// Start binds the HTTP listener synchronously, returning any bind error to
+// the caller immediately, then serves in the background -- send the result
+// to Err() once Serve returns. This lets main fail fast on a bad port/socket
+// instead of discovering it later from a goroutine's logged error.
+func (s *HTTPServer) Start() error {
+	lis, err := resolveListener(s.config.Server.ListenAddr, s.config.GetHTTPPort())
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	return s.serve(lis)
+}
+
+// StartOn serves on a listener obtained elsewhere instead of resolving
+// Server.ListenAddr itself -- used when Gateway.MultiplexHTTP splits this
+// server's configured port between a cmux listener for gRPC-Web traffic and
+// this one for everything else.
+func (s *HTTPServer) StartOn(lis net.Listener) error {
+	return s.serve(lis)
+}
+
+func (s *HTTPServer) serve(lis net.Listener) error {
 	router := s.SetupRoutes()
 
 	// Create a custom server with profiling support
 	s.server = &http.Server{
-		Addr:    s.config.GetHTTPPort(),
 		Handler: router,
 		// Configure timeouts for production
 		ReadTimeout:       30 * time.Second,
@@ -147,16 +249,29 @@ func (s *HTTPServer) ListenAndServe() error {
 		MaxHeaderBytes:    1 << 20, // 1MB
 	}
 
-	logger.Infof("Starting HTTP server on %s", s.config.GetHTTPPort())
+	logger.Infof("Starting HTTP server on %s", lis.Addr())
 	logger.Infof("Profiling endpoints available at: http://localhost%s/debug/pprof/", s.config.GetHTTPPort())
 	logger.Infof("GOMAXPROCS set to: %d", runtime.GOMAXPROCS(0))
 
-	return s.server.ListenAndServe()
+	close(s.ready)
+
+	go func() {
+		s.serveErr <- s.server.Serve(lis)
+	}()
+
+	return nil
 }
 
-func (s *HTTPServer) Shutdown() error {
+// Shutdown drains in-flight requests for up to ctx's deadline before closing
+// the listener.
+func (s *HTTPServer) Shutdown(ctx context.Context) error {
+	if s.dataIngestionHandler != nil {
+		s.dataIngestionHandler.Shutdown()
+	}
 	if s.server != nil {
-		return s.server.Close()
+		err := s.server.Shutdown(ctx)
+		removeSocketFile(s.config.Server.ListenAddr)
+		return err
 	}
 	return nil
 }
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/market-intel/api-gateway/pkg/logger"
+)
+
+// socketPermissions restricts a Unix domain socket to its owner and group.
+// UDS have no transport security of their own, so this is meant for a
+// co-located sidecar (envoy/nginx) sharing the pod/host, not a
+// multi-tenant one.
+const socketPermissions = 0o660
+
+// resolveListener builds a net.Listener for a server's listen address.
+// listenAddr empty falls back to fallbackTCPAddr (the server's legacy
+// ":<port>" form). "unix:///path/to.sock" listens on a Unix domain socket,
+// removing any stale socket file left behind by a previous, uncleanly
+// terminated process before binding. "tcp://host:port" is accepted as an
+// explicit alternative to the bare ":port" form.
+func resolveListener(listenAddr, fallbackTCPAddr string) (net.Listener, error) {
+	network, address, err := parseListenAddr(listenAddr, fallbackTCPAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	if network == "unix" {
+		if err := os.Remove(address); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("removing stale socket %s: %w", address, err)
+		}
+	}
+
+	lis, err := net.Listen(network, address)
+	if err != nil {
+		return nil, fmt.Errorf("listen %s %s: %w", network, address, err)
+	}
+
+	if network == "unix" {
+		if err := os.Chmod(address, socketPermissions); err != nil {
+			lis.Close()
+			os.Remove(address)
+			return nil, fmt.Errorf("chmod socket %s: %w", address, err)
+		}
+	}
+
+	return lis, nil
+}
+
+// parseListenAddr splits a listen address into the network and address
+// net.Listen expects.
+func parseListenAddr(listenAddr, fallbackTCPAddr string) (network, address string, err error) {
+	switch {
+	case listenAddr == "":
+		return "tcp", fallbackTCPAddr, nil
+	case strings.HasPrefix(listenAddr, "unix://"):
+		return "unix", strings.TrimPrefix(listenAddr, "unix://"), nil
+	case strings.HasPrefix(listenAddr, "tcp://"):
+		return "tcp", strings.TrimPrefix(listenAddr, "tcp://"), nil
+	default:
+		return "", "", fmt.Errorf("unsupported listen address %q: must be empty, unix://path, or tcp://host:port", listenAddr)
+	}
+}
+
+// removeSocketFile removes the Unix domain socket file backing listenAddr,
+// if any, so a clean shutdown doesn't leave a stale socket for the next
+// startup's resolveListener to have to clean up instead.
+func removeSocketFile(listenAddr string) {
+	if path, ok := strings.CutPrefix(listenAddr, "unix://"); ok {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			logger.Warnf("failed to remove socket file %s: %v", path, err)
+		}
+	}
+}
@@ -0,0 +1,328 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/market-intel/api-gateway/pb"
+	"github.com/market-intel/api-gateway/pkg/logger"
+	"github.com/market-intel/api-gateway/pkg/resilience"
+	"github.com/market-intel/api-gateway/pkg/tls"
+)
+
+const (
+	defaultProbeInterval    = 15 * time.Second
+	defaultProbeTimeout     = 3 * time.Second
+	defaultFailureThreshold = 3
+	defaultCooldown         = 30 * time.Second
+)
+
+// ErrNoHealthyEndpoints is returned by the balancer when every core-engine
+// endpoint in the pool is currently marked unhealthy.
+var ErrNoHealthyEndpoints = errors.New("core engine: no healthy endpoints available")
+
+// endpointConn is one core-engine replica's gRPC connection plus the health
+// bookkeeping the balancer uses to decide whether to route to it. Each
+// endpoint gets its own circuit breaker so one bad replica tripping its
+// breaker doesn't affect requests routed to the others.
+type endpointConn struct {
+	address          string
+	conn             *grpc.ClientConn
+	marketDataClient pb.MarketDataServiceClient
+	newsClient       pb.NewsServiceClient
+	ingestionClient  pb.IngestionServiceClient
+	circuitBreaker   *resilience.CircuitBreakerWithRetry
+
+	mu               sync.RWMutex
+	healthy          bool
+	consecutiveFails int
+	cooldownUntil    time.Time
+}
+
+// recordFailure accounts for an RPC failure observed on the live request
+// path (as opposed to the background probe). Unavailable and
+// DeadlineExceeded trip the endpoint unhealthy immediately, since both
+// indicate the replica itself is the problem rather than the request;
+// anything else only trips it after failureThreshold consecutive failures.
+func (e *endpointConn) recordFailure(err error, failureThreshold int, cooldown time.Duration) {
+	code := status.Code(err)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.consecutiveFails++
+	if code == codes.Unavailable || code == codes.DeadlineExceeded || e.consecutiveFails >= failureThreshold {
+		e.markUnhealthyLocked(cooldown)
+	}
+}
+
+func (e *endpointConn) recordSuccess() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.consecutiveFails = 0
+}
+
+func (e *endpointConn) markUnhealthyLocked(cooldown time.Duration) {
+	if e.healthy {
+		logger.Errorf("core engine endpoint %s marked unhealthy, excluded from routing for %s", e.address, cooldown)
+	}
+	e.healthy = false
+	e.cooldownUntil = time.Now().Add(cooldown)
+}
+
+func (e *endpointConn) isHealthy() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.healthy
+}
+
+// inCooldown reports whether the endpoint is unhealthy and still within its
+// cool-down window, meaning the probe loop should leave it alone rather
+// than re-probing it every tick.
+func (e *endpointConn) inCooldown() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return !e.healthy && time.Now().Before(e.cooldownUntil)
+}
+
+func (e *endpointConn) close() error {
+	if e.conn != nil {
+		return e.conn.Close()
+	}
+	return nil
+}
+
+// balancer is a health-aware, round-robin load balancer over a fixed set of
+// core-engine endpoints, inspired by etcd clientv3's healthBalancer: a
+// background goroutine probes every endpoint on an interval with the
+// HealthCheck RPC, and the request path only ever picks from the endpoints
+// that probe reported healthy. A caller-supplied affinity key can stick
+// repeated calls to the same endpoint as long as it stays healthy, which
+// keeps a symbol's requests landing on the replica most likely to have it
+// warm in cache.
+type balancer struct {
+	endpoints []*endpointConn
+
+	probeInterval    time.Duration
+	probeTimeout     time.Duration
+	failureThreshold int
+	cooldown         time.Duration
+
+	rrCursor uint64
+
+	affinityMu sync.Mutex
+	affinity   map[string]*endpointConn
+
+	registry      *prometheus.Registry
+	healthyGauge  *prometheus.GaugeVec
+	inflightGauge *prometheus.GaugeVec
+
+	cancelProbe context.CancelFunc
+}
+
+func newBalancer(endpoints []*endpointConn, registry *prometheus.Registry) *balancer {
+	b := &balancer{
+		endpoints:        endpoints,
+		probeInterval:    defaultProbeInterval,
+		probeTimeout:     defaultProbeTimeout,
+		failureThreshold: defaultFailureThreshold,
+		cooldown:         defaultCooldown,
+		affinity:         make(map[string]*endpointConn),
+		registry:         registry,
+		healthyGauge: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "core_engine_endpoint_healthy",
+				Help: "Whether the gateway's balancer currently considers a core-engine endpoint healthy (1) or not (0)",
+			},
+			[]string{"address"},
+		),
+		inflightGauge: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "core_engine_endpoint_inflight",
+				Help: "Number of in-flight RPCs the gateway has open against a core-engine endpoint",
+			},
+			[]string{"address"},
+		),
+	}
+
+	registry.MustRegister(b.healthyGauge, b.inflightGauge)
+
+	for _, ep := range endpoints {
+		ep.healthy = true
+		b.healthyGauge.WithLabelValues(ep.address).Set(1)
+	}
+
+	return b
+}
+
+// start launches the background probe loop. It is separate from
+// newBalancer so tests can exercise selection logic without a goroutine
+// racing the assertions.
+func (b *balancer) start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	b.cancelProbe = cancel
+
+	go func() {
+		ticker := time.NewTicker(b.probeInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				for _, ep := range b.endpoints {
+					b.probeOne(ctx, ep)
+				}
+			}
+		}
+	}()
+}
+
+func (b *balancer) stop() {
+	if b.cancelProbe != nil {
+		b.cancelProbe()
+	}
+}
+
+// probeOne issues a HealthCheck RPC against ep and updates its health state
+// and gauge from the result. An endpoint still inside its cool-down window
+// is skipped, since it fell unhealthy on the live request path and hasn't
+// had time to recover yet.
+func (b *balancer) probeOne(ctx context.Context, ep *endpointConn) {
+	if ep.inCooldown() {
+		return
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, b.probeTimeout)
+	defer cancel()
+
+	resp, err := ep.ingestionClient.HealthCheck(probeCtx, &pb.HealthCheckRequest{
+		ServiceName: "core-engine",
+		Metadata:    map[string]string{"client": "api-gateway-probe"},
+	})
+	healthy := err == nil && resp.Healthy
+
+	ep.mu.Lock()
+	wasHealthy := ep.healthy
+	if healthy {
+		ep.healthy = true
+		ep.consecutiveFails = 0
+	} else {
+		ep.markUnhealthyLocked(b.cooldown)
+	}
+	ep.mu.Unlock()
+
+	if healthy {
+		b.healthyGauge.WithLabelValues(ep.address).Set(1)
+	} else {
+		b.healthyGauge.WithLabelValues(ep.address).Set(0)
+	}
+
+	switch {
+	case healthy && !wasHealthy:
+		logger.Infof("core engine endpoint %s passed health probe, re-entering rotation", ep.address)
+	case !healthy && wasHealthy:
+		logger.Errorf("core engine endpoint %s failed health probe: %v", ep.address, err)
+	}
+}
+
+// next picks an endpoint to route to. If affinityKey is non-empty and is
+// already stuck to a healthy endpoint, that endpoint is returned; otherwise
+// selection falls back to round-robin over the healthy endpoints, and, for
+// a non-empty affinityKey, the endpoint picked is stuck to that key for
+// future calls.
+func (b *balancer) next(affinityKey string) (*endpointConn, error) {
+	if affinityKey != "" {
+		if ep := b.affinityEndpoint(affinityKey); ep != nil {
+			return ep, nil
+		}
+	}
+
+	n := len(b.endpoints)
+	start := int(atomic.AddUint64(&b.rrCursor, 1))
+	for i := 0; i < n; i++ {
+		ep := b.endpoints[(start+i)%n]
+		if ep.isHealthy() {
+			if affinityKey != "" {
+				b.setAffinity(affinityKey, ep)
+			}
+			return ep, nil
+		}
+	}
+
+	return nil, ErrNoHealthyEndpoints
+}
+
+func (b *balancer) affinityEndpoint(key string) *endpointConn {
+	b.affinityMu.Lock()
+	ep, ok := b.affinity[key]
+	b.affinityMu.Unlock()
+
+	if !ok || !ep.isHealthy() {
+		return nil
+	}
+	return ep
+}
+
+func (b *balancer) setAffinity(key string, ep *endpointConn) {
+	b.affinityMu.Lock()
+	b.affinity[key] = ep
+	b.affinityMu.Unlock()
+}
+
+// dialEndpoint opens the mTLS connection for a single core-engine address,
+// mirroring the dial options NewCoreEngineClient used for its single
+// connection.
+func dialEndpoint(address string) (*endpointConn, error) {
+	tlsConfig := tls.NewTLSConfigFromEnv()
+	if err := tlsConfig.Validate(); err != nil {
+		return nil, logger.Errorf("TLS configuration validation failed: %w", err)
+	}
+
+	grpcCreds, err := tlsConfig.CreateGRPCredentials()
+	if err != nil {
+		return nil, logger.Errorf("failed to create gRPC credentials: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, address,
+		grpc.WithTransportCredentials(grpcCreds),
+		grpc.WithBlock(),
+		grpc.WithTimeout(5*time.Second),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                10 * time.Second,
+			Timeout:             3 * time.Second,
+			PermitWithoutStream: true,
+		}),
+	)
+	if err != nil {
+		return nil, logger.Errorf("failed to connect to core engine endpoint %s: %w", address, err)
+	}
+
+	logger.Infof("Connected to core engine endpoint %s with mTLS", address)
+
+	cbConfig := resilience.DefaultCircuitBreakerConfig()
+	retryConfig := resilience.DefaultRetryConfig()
+
+	return &endpointConn{
+		address:          address,
+		conn:             conn,
+		marketDataClient: pb.NewMarketDataServiceClient(conn),
+		newsClient:       pb.NewNewsServiceClient(conn),
+		ingestionClient:  pb.NewIngestionServiceClient(conn),
+		circuitBreaker:   resilience.NewCircuitBreakerWithRetry(cbConfig, retryConfig),
+	}, nil
+}
@@ -1,333 +1,574 @@
-package services
-
-import (
-	"context"
-	"fmt"
-	"time"
-
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
-	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/grpc/metadata"
-
-	"github.com/market-intel/api-gateway/pkg/logger"
-	"github.com/market-intel/api-gateway/pkg/otel"
-	"github.com/market-intel/api-gateway/pkg/resilience"
-	"github.com/market-intel/api-gateway/pkg/tls"
-)
-
-type CoreEngineClient struct {
-	conn           *grpc.ClientConn
-	circuitBreaker *resilience.CircuitBreakerWithRetry
-}
-
-func NewCoreEngineClient(address string) (*CoreEngineClient, error) {
-	// Load TLS configuration
-	tlsConfig, err := tls.NewTLSConfigFromEnv()()
-	
-	// Validate TLS configuration
-	if err := tlsConfig.Validate(); err != nil {
-		return nil, logger.Errorf("TLS configuration validation failed: %w", err)
-	}
-	
-	// Create gRPC credentials with TLS
-	grpcCreds, err := tlsConfig.CreateGRPCCredentials()
-	if err != nil {
-		return nil, logger.Errorf("failed to create gRPC credentials: %w", err)
-	}
-	
-	// Get certificate info for logging
-	if certInfo, err := tlsConfig.GetCertificateInfo(); err == nil {
-		logger.Infof("Using client certificate: %s issued by %s", certInfo.Subject, certInfo.Issuer)
-		logger.Infof("Certificate expires: %s", certInfo.NotAfter)
-		if certInfo.IsExpired() {
-			return nil, logger.Errorf("client certificate has expired")
-		}
-	}
-	
-	// Create connection with timeout and TLS
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	
-	conn, err := grpc.DialContext(ctx, address, 
-		grpc.WithTransportCredentials(grpcCreds),
-		grpc.WithBlock(),
-		grpc.WithTimeout(5*time.Second),
-		grpc.WithKeepaliveParams(keepalive.ClientParameters{
-			Time:                10 * time.Second,
-			Timeout:              3 * time.Second,
-			PermitWithoutStream: true,
-		}),
-	)
-	if err != nil {
-		return nil, logger.Errorf("failed to connect to core engine: %w", err)
-	}
-
-	client := pb.NewCoreEngineServiceClient(conn)
-	
-	logger.Infof("Connected to core engine at %s with mTLS", address)
-	
-	// Initialize circuit breaker with retry
-	cbConfig := resilience.DefaultCircuitBreakerConfig()
-	retryConfig := resilience.DefaultRetryConfig()
-	circuitBreaker := resilience.NewCircuitBreakerWithRetry(cbConfig, retryConfig)
-	
-	return &CoreEngineClient{
-		conn:           conn,
-		client:         client,
-		circuitBreaker: circuitBreaker,
-	}, nil
-}
-
-func (c *CoreEngineClient) Close() error {
-	if c.conn != nil {
-		return c.conn.Close()
-	}
-	return nil
-}
-
-// injectTraceContext injects OpenTelemetry trace context into gRPC metadata
-func (c *CoreEngineClient) injectTraceContext(ctx context.Context) context.Context {
-	// Extract trace ID from context
-	traceID := otel.GetTraceID(ctx)
-	if traceID != "" {
-		// Create metadata with trace ID
-		md := metadata.New(map[string]string{
-			"trace_id": traceID,
-		})
-		return metadata.NewOutgoingContext(ctx, md)
-	}
-	return ctx
-}
-
-func (c *CoreEngineClient) HealthCheck(ctx context.Context, serviceName string) (*pb.HealthCheckResponse, error) {
-	// Inject trace context
-	ctx = c.injectTraceContext(ctx)
-	
-	req := &pb.HealthCheckRequest{
-		ServiceName: serviceName,
-		Metadata:    map[string]string{
-			"client": "api-gateway",
-		},
-	}
-
-	resp, err := c.client.HealthCheck(ctx, req)
-	if err != nil {
-		logger.Errorf("Core Engine health check failed: %v", err)
-		return nil, fmt.Errorf("health check failed: %w", err)
-	}
-
-	logger.Infof("Core Engine health check: healthy=%v, status=%s", resp.Healthy, resp.Status)
-
-	return resp, nil
-}
-
-func (c *CoreEngineClient) GetStatus(ctx context.Context) (*pb.EngineStatusResponse, error) {
-	// Inject trace context
-	ctx = c.injectTraceContext(ctx)
-	
-	req := &pb.Empty{}
-
-	resp, err := c.client.GetStatus(ctx, req)
-	if err != nil {
-		logger.Errorf("Core Engine get status failed: %v", err)
-		return nil, fmt.Errorf("get status failed: %w", err)
-	}
-
-	logger.Infof("Core Engine status: %s", resp.Message)
-
-	return resp, nil
-}
-
-// FetchMarketData fetches market data from the core engine with circuit breaker protection
-func (c *CoreEngineClient) FetchMarketData(ctx context.Context, req *pb.FetchMarketDataRequest) (*pb.FetchMarketDataResponse, error) {
-	logger.Infof("Fetching market data from core engine with circuit breaker protection")
-	
-	var response *pb.FetchMarketDataResponse
-	err := c.circuitBreaker.Execute(ctx, func() error {
-		// Inject trace context
-		ctx = c.injectTraceContext(ctx)
-		
-		// Add timeout to context
-		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
-		defer cancel()
-		
-		// Make gRPC call
-		resp, err := c.client.FetchMarketData(ctx, req)
-		if err != nil {
-			return logger.Errorf("failed to fetch market data: %w", err)
-		}
-		
-		response = resp
-		return nil
-	})
-	
-	if err != nil {
-		logger.Errorf("Circuit breaker error for market data fetch: %v", err)
-		return nil, err
-	}
-	
-	logger.Infof("Market data fetched successfully")
-	return response, nil
-}
-
-// FetchNewsData fetches news data from the core engine with circuit breaker protection
-func (c *CoreEngineClient) FetchNewsData(ctx context.Context, req *pb.FetchNewsDataRequest) (*pb.FetchNewsDataResponse, error) {
-	logger.Infof("Fetching news data from core engine with circuit breaker protection")
-	
-	var response *pb.FetchNewsDataResponse
-	err := c.circuitBreaker.Execute(ctx, func() error {
-		// Inject trace context
-		ctx = c.injectTraceContext(ctx)
-		
-		// Add timeout to context
-		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
-		defer cancel()
-		
-		// Make gRPC call
-		resp, err := c.client.FetchNewsData(ctx, req)
-		if err != nil {
-			return logger.Errorf("failed to fetch news data: %w", err)
-		}
-		
-		response = resp
-		return nil
-	})
-	
-	if err != nil {
-		logger.Errorf("Circuit breaker error for news data fetch: %v", err)
-		return nil, err
-	}
-	
-	logger.Infof("News data fetched successfully")
-	return response, nil
-}
-
-// GetMarketDataBuffer gets market data buffer from the core engine with circuit breaker protection
-func (c *CoreEngineClient) GetMarketDataBuffer(ctx context.Context, req *pb.GetMarketDataBufferRequest) (*pb.GetMarketDataBufferResponse, error) {
-	logger.Infof("Getting market data buffer from core engine with circuit breaker protection")
-	
-	var response *pb.GetMarketDataBufferResponse
-	err := c.circuitBreaker.Execute(ctx, func() error {
-		// Inject trace context
-		ctx = c.injectTraceContext(ctx)
-		
-		// Add timeout to context
-		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
-		defer cancel()
-		
-		// Make gRPC call
-		resp, err := c.client.GetMarketDataBuffer(ctx, req)
-		if err != nil {
-			return logger.Errorf("failed to get market data buffer: %w", err)
-		}
-		
-		response = resp
-		return nil
-	})
-	
-	if err != nil {
-		logger.Errorf("Circuit breaker error for market data buffer: %v", err)
-		return nil, err
-	}
-	
-	logger.Infof("Market data buffer retrieved successfully")
-	return response, nil
-}
-
-// GetNewsBuffer gets news buffer from the core engine with circuit breaker protection
-func (c *CoreEngineClient) GetNewsBuffer(ctx context.Context, req *pb.GetNewsBufferRequest) (*pb.GetNewsBufferResponse, error) {
-	logger.Infof("Getting news buffer from core engine with circuit breaker protection")
-	
-	var response *pb.GetNewsBufferResponse
-	err := c.circuitBreaker.Execute(ctx, func() error {
-		// Inject trace context
-		ctx = c.injectTraceContext(ctx)
-		
-		// Add timeout to context
-		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
-		defer cancel()
-		
-		// Make gRPC call
-		resp, err := c.client.GetNewsBuffer(ctx, req)
-		if err != nil {
-			return logger.Errorf("failed to get news buffer: %w", err)
-		}
-		
-		response = resp
-		return nil
-	})
-	
-	if err != nil {
-		logger.Errorf("Circuit breaker error for news buffer: %v", err)
-		return nil, err
-	}
-	
-	logger.Infof("News buffer retrieved successfully")
-	return response, nil
-}
-
-// GetIngestionStats gets ingestion stats from the core engine with circuit breaker protection
-func (c *CoreEngineClient) GetIngestionStats(ctx context.Context, req *pb.GetIngestionStatsRequest) (*pb.GetIngestionStatsResponse, error) {
-	logger.Infof("Getting ingestion stats from core engine with circuit breaker protection")
-	
-	var response *pb.GetIngestionStatsResponse
-	err := c.circuitBreaker.Execute(ctx, func() error {
-		// Inject trace context
-		ctx = c.injectTraceContext(ctx)
-		
-		// Add timeout to context
-		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
-		defer cancel()
-		
-		// Make gRPC call
-		resp, err := c.client.GetIngestionStats(ctx, req)
-		if err != nil {
-			return logger.Errorf("failed to get ingestion stats: %w", err)
-		}
-		
-		response = resp
-		return nil
-	})
-	
-	if err != nil {
-		logger.Errorf("Circuit breaker error for ingestion stats: %v", err)
-		return nil, err
-	}
-	
-	logger.Infof("Ingestion stats retrieved successfully")
-	return response, nil
-}
-
-// ConnectDataSource connects a data source to the core engine with circuit breaker protection
-func (c *CoreEngineClient) ConnectDataSource(ctx context.Context, req *pb.ConnectDataSourceRequest) (*pb.ConnectDataSourceResponse, error) {
-	logger.Infof("Connecting data source to core engine with circuit breaker protection")
-	
-	var response *pb.ConnectDataSourceResponse
-	err := c.circuitBreaker.Execute(ctx, func() error {
-		// Inject trace context
-		ctx = c.injectTraceContext(ctx)
-		
-		// Add timeout to context
-		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
-		defer cancel()
-		
-		// Make gRPC call
-		resp, err := c.client.ConnectDataSource(ctx, req)
-		if err != nil {
-			return logger.Errorf("failed to connect data source: %w", err)
-		}
-		
-		response = resp
-		return nil
-	})
-	
-	if err != nil {
-		logger.Errorf("Circuit breaker error for data source connection: %v", err)
-		return nil, err
-	}
-	
-	logger.Infof("Data source connected successfully")
-	return response, nil
-}
-"github.com/market-intel/api-gateway/pb
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/market-intel/api-gateway/internal/config"
+	"github.com/market-intel/api-gateway/pb"
+	"github.com/market-intel/api-gateway/pkg/grpcerrors"
+	"github.com/market-intel/api-gateway/pkg/logger"
+	"github.com/market-intel/api-gateway/pkg/otel"
+	"github.com/market-intel/api-gateway/pkg/resilience"
+	"github.com/market-intel/api-gateway/pkg/tls"
+)
+
+// CoreEngineClient is the set of RPCs the gateway makes against the core
+// engine. It is satisfied by grpcCoreEngineClient in production and by
+// mocks.CoreEngineClient (see .mockery.yaml) in tests.
+type CoreEngineClient interface {
+	HealthCheck(ctx context.Context, serviceName string) (*pb.HealthCheckResponse, error)
+	GetStatus(ctx context.Context) (*pb.EngineStatusResponse, error)
+	FetchMarketData(ctx context.Context, req *pb.FetchMarketDataRequest) (*pb.FetchMarketDataResponse, error)
+	FetchNewsData(ctx context.Context, req *pb.FetchNewsDataRequest) (*pb.FetchNewsDataResponse, error)
+	GetMarketDataBuffer(ctx context.Context, req *pb.GetMarketDataBufferRequest) (*pb.GetMarketDataBufferResponse, error)
+	GetNewsBuffer(ctx context.Context, req *pb.GetNewsBufferRequest) (*pb.GetNewsBufferResponse, error)
+	GetIngestionStats(ctx context.Context, req *pb.GetIngestionStatsRequest) (*pb.GetIngestionStatsResponse, error)
+	ConnectDataSource(ctx context.Context, req *pb.ConnectDataSourceRequest) (*pb.ConnectDataSourceResponse, error)
+	FetchHistoricalRange(ctx context.Context, req *pb.FetchHistoricalRangeRequest) (*pb.FetchHistoricalRangeResponse, error)
+	StreamMarketData(ctx context.Context, req *pb.StreamMarketDataRequest) (pb.MarketDataStream, error)
+	StreamMarketDataChannel(ctx context.Context, req *pb.StreamMarketDataRequest) (<-chan *pb.MarketData, <-chan error)
+	StreamNewsChannel(ctx context.Context, req *pb.SubscribeNewsRequest) (<-chan *pb.NewsItem, <-chan error)
+	Close() error
+}
+
+// grpcCoreEngineClient is the production CoreEngineClient backed by a real
+// gRPC connection to the core engine
+type grpcCoreEngineClient struct {
+	conn             *grpc.ClientConn
+	marketDataClient pb.MarketDataServiceClient
+	newsClient       pb.NewsServiceClient
+	ingestionClient  pb.IngestionServiceClient
+	circuitBreaker   *resilience.CircuitBreakerWithRetry
+}
+
+// NewCoreEngineClient dials the core engine over mTLS and returns a
+// CoreEngineClient backed by that connection. endpoint.LoadBalancing and
+// endpoint.MTLSEnabled are accepted for symmetry with ClientFactory.Dial
+// (used for the gateway's other service dependencies) but this client
+// still dials directly rather than through ClientFactory: it keeps its own
+// single shared circuitBreaker wrapping every RPC method below, and
+// layering ClientFactory's per-method circuit breaker interceptor on top
+// would double up breaking on the same calls.
+func NewCoreEngineClient(endpoint config.ServiceEndpoint) (CoreEngineClient, error) {
+	address := endpoint.Target
+
+	// Load TLS configuration
+	tlsConfig := tls.NewTLSConfigFromEnv()
+
+	// Validate TLS configuration
+	if err := tlsConfig.Validate(); err != nil {
+		return nil, logger.Errorf("TLS configuration validation failed: %w", err)
+	}
+
+	// Create gRPC credentials with TLS
+	grpcCreds, err := tlsConfig.CreateGRPCredentials()
+	if err != nil {
+		return nil, logger.Errorf("failed to create gRPC credentials: %w", err)
+	}
+
+	// Get certificate info for logging
+	if certInfo, err := tlsConfig.GetCertificateInfo(); err == nil {
+		logger.Infof("Using client certificate: %s issued by %s", certInfo.Subject, certInfo.Issuer)
+		logger.Infof("Certificate expires: %s", certInfo.NotAfter)
+		if certInfo.IsExpired() {
+			return nil, logger.Errorf("client certificate has expired")
+		}
+	}
+
+	// Create connection with timeout and TLS
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, address,
+		grpc.WithTransportCredentials(grpcCreds),
+		grpc.WithBlock(),
+		grpc.WithTimeout(5*time.Second),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                10 * time.Second,
+			Timeout:             3 * time.Second,
+			PermitWithoutStream: true,
+		}),
+		grpc.WithChainUnaryInterceptor(grpcerrors.ErrorUnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(grpcerrors.ErrorStreamClientInterceptor()),
+	)
+	if err != nil {
+		return nil, logger.Errorf("failed to connect to core engine: %w", err)
+	}
+
+	logger.Infof("Connected to core engine at %s with mTLS", address)
+
+	// Initialize circuit breaker with retry
+	cbConfig := resilience.DefaultCircuitBreakerConfig()
+	retryConfig := resilience.DefaultRetryConfig()
+	circuitBreaker := resilience.NewCircuitBreakerWithRetry(cbConfig, retryConfig)
+
+	return &grpcCoreEngineClient{
+		conn:             conn,
+		marketDataClient: pb.NewMarketDataServiceClient(conn),
+		newsClient:       pb.NewNewsServiceClient(conn),
+		ingestionClient:  pb.NewIngestionServiceClient(conn),
+		circuitBreaker:   circuitBreaker,
+	}, nil
+}
+
+func (c *grpcCoreEngineClient) Close() error {
+	if c.conn != nil {
+		return c.conn.Close()
+	}
+	return nil
+}
+
+// injectTraceContext injects OpenTelemetry trace context into gRPC metadata
+func (c *grpcCoreEngineClient) injectTraceContext(ctx context.Context) context.Context {
+	return injectTraceContext(ctx)
+}
+
+// coreEngineRetryPolicies classifies each CoreEngineClient method's retry
+// safety. Methods absent from this map default to
+// resilience.RetryClassIdempotent -- true of every read here
+// (Fetch*/Get*/HealthCheck/GetStatus) -- so only methods with a side effect
+// need an entry. ConnectDataSource registers a data source with the core
+// engine; repeating that call after it may have already taken effect risks
+// a duplicate registration, so it only ever gets gRPC's own notion of a
+// transparent retry (see resilience.RetryClassNonIdempotent).
+var coreEngineRetryPolicies = map[string]resilience.RetryPolicy{
+	"ConnectDataSource": {Class: resilience.RetryClassNonIdempotent},
+}
+
+// circuitBreakerContext attaches the failure classifier and retry policy
+// every grpcCoreEngineClient circuit breaker call uses for method:
+//   - a decoded grpcerrors business error (NotFound, ValidationError, ...)
+//     means the core engine responded, so it shouldn't count against the
+//     breaker's failure rate the way a dropped connection or deadline
+//     exceeded does.
+//   - method's entry in coreEngineRetryPolicies (if any) governs whether a
+//     retryable failure is safe to retry at all, per RetryClass.
+func circuitBreakerContext(ctx context.Context, method string) context.Context {
+	ctx = resilience.WithFailureClassifier(ctx, func(err error) bool {
+		return !grpcerrors.IsBusinessError(err)
+	})
+	if policy, ok := coreEngineRetryPolicies[method]; ok {
+		ctx = resilience.WithRetryPolicy(ctx, policy)
+	}
+	return ctx
+}
+
+// pushbackContext returns ctx carrying a resilience.WithBackoffOverride
+// callback that honors a grpc-retry-pushback-ms trailer (see
+// resilience.ParseRetryPushback) across circuit breaker retry attempts, the
+// same way resilience.UnaryClientInterceptor does for calls that go through
+// that interceptor. grpcCoreEngineClient's methods call the core engine
+// directly instead, so they thread the returned *metadata.MD through
+// grpc.Trailer themselves on each attempt.
+func pushbackContext(ctx context.Context) (context.Context, *metadata.MD) {
+	var trailer metadata.MD
+	ctx = resilience.WithBackoffOverride(ctx, func(error) (time.Duration, bool) {
+		return resilience.ParseRetryPushback(trailer)
+	})
+	return ctx, &trailer
+}
+
+// logRPCOutcome logs one structured line per CoreEngineClient method call,
+// tagged with rpc.method, rpc.duration_ms, and rpc.status_code so it's
+// joinable with the OTel span injectTraceContext already propagates for the
+// same request. Callers log it once after their circuit breaker's Execute
+// returns, not per retry attempt, so the façade's sampling governs volume
+// per logical call rather than per attempt.
+func logRPCOutcome(ctx context.Context, method string, start time.Time, err error) {
+	fields := []zap.Field{
+		zap.String("rpc.method", method),
+		zap.Int64("rpc.duration_ms", time.Since(start).Milliseconds()),
+		zap.String("rpc.status_code", status.Code(err).String()),
+	}
+	if err != nil {
+		logger.With(ctx).Error("core engine RPC failed", append(fields, zap.Error(err))...)
+		return
+	}
+	logger.With(ctx).Info("core engine RPC succeeded", fields...)
+}
+
+// injectTraceContext injects OpenTelemetry trace context into gRPC metadata.
+// It is a package-level function, rather than living only on
+// grpcCoreEngineClient, so poolCoreEngineClient's per-endpoint calls can
+// share it too.
+func injectTraceContext(ctx context.Context) context.Context {
+	// Extract trace ID from context
+	traceID := otel.GetTraceID(ctx)
+	if traceID != "" {
+		// Create metadata with trace ID
+		md := metadata.New(map[string]string{
+			"trace_id": traceID,
+		})
+		return metadata.NewOutgoingContext(ctx, md)
+	}
+	return ctx
+}
+
+func (c *grpcCoreEngineClient) HealthCheck(ctx context.Context, serviceName string) (*pb.HealthCheckResponse, error) {
+	start := time.Now()
+
+	// Inject trace context
+	rpcCtx := c.injectTraceContext(ctx)
+
+	req := &pb.HealthCheckRequest{
+		ServiceName: serviceName,
+		Metadata: map[string]string{
+			"client": "api-gateway",
+		},
+	}
+
+	resp, err := c.ingestionClient.HealthCheck(rpcCtx, req)
+	if err != nil {
+		err = fmt.Errorf("health check failed: %w", err)
+		logRPCOutcome(ctx, "HealthCheck", start, err)
+		return nil, err
+	}
+
+	logRPCOutcome(ctx, "HealthCheck", start, nil)
+	return resp, nil
+}
+
+func (c *grpcCoreEngineClient) GetStatus(ctx context.Context) (*pb.EngineStatusResponse, error) {
+	start := time.Now()
+
+	// Inject trace context
+	rpcCtx := c.injectTraceContext(ctx)
+
+	req := &pb.Empty{}
+
+	resp, err := c.ingestionClient.GetStatus(rpcCtx, req)
+	if err != nil {
+		err = fmt.Errorf("get status failed: %w", err)
+		logRPCOutcome(ctx, "GetStatus", start, err)
+		return nil, err
+	}
+
+	logRPCOutcome(ctx, "GetStatus", start, nil)
+	return resp, nil
+}
+
+// FetchMarketData fetches market data from the core engine with circuit breaker protection
+func (c *grpcCoreEngineClient) FetchMarketData(ctx context.Context, req *pb.FetchMarketDataRequest) (*pb.FetchMarketDataResponse, error) {
+	start := time.Now()
+
+	cbCtx, trailer := pushbackContext(circuitBreakerContext(ctx, "FetchMarketData"))
+
+	var response *pb.FetchMarketDataResponse
+	err := c.circuitBreaker.Execute(cbCtx, func() error {
+		// Inject trace context
+		ctx := c.injectTraceContext(ctx)
+
+		// Add timeout to context
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+
+		// Make gRPC call
+		resp, err := c.marketDataClient.FetchMarketData(ctx, req, grpc.Trailer(trailer))
+		if err != nil {
+			return fmt.Errorf("failed to fetch market data: %w", err)
+		}
+
+		response = resp
+		return nil
+	})
+
+	logRPCOutcome(ctx, "FetchMarketData", start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// FetchNewsData fetches news data from the core engine with circuit breaker protection
+func (c *grpcCoreEngineClient) FetchNewsData(ctx context.Context, req *pb.FetchNewsDataRequest) (*pb.FetchNewsDataResponse, error) {
+	start := time.Now()
+
+	cbCtx, trailer := pushbackContext(circuitBreakerContext(ctx, "FetchNewsData"))
+
+	var response *pb.FetchNewsDataResponse
+	err := c.circuitBreaker.Execute(cbCtx, func() error {
+		// Inject trace context
+		ctx := c.injectTraceContext(ctx)
+
+		// Add timeout to context
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+
+		// Make gRPC call
+		resp, err := c.newsClient.FetchNewsData(ctx, req, grpc.Trailer(trailer))
+		if err != nil {
+			return fmt.Errorf("failed to fetch news data: %w", err)
+		}
+
+		response = resp
+		return nil
+	})
+
+	logRPCOutcome(ctx, "FetchNewsData", start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GetMarketDataBuffer gets market data buffer from the core engine with circuit breaker protection
+func (c *grpcCoreEngineClient) GetMarketDataBuffer(ctx context.Context, req *pb.GetMarketDataBufferRequest) (*pb.GetMarketDataBufferResponse, error) {
+	start := time.Now()
+
+	cbCtx, trailer := pushbackContext(circuitBreakerContext(ctx, "GetMarketDataBuffer"))
+
+	var response *pb.GetMarketDataBufferResponse
+	err := c.circuitBreaker.Execute(cbCtx, func() error {
+		// Inject trace context
+		ctx := c.injectTraceContext(ctx)
+
+		// Add timeout to context
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+
+		// Make gRPC call
+		resp, err := c.marketDataClient.GetMarketDataBuffer(ctx, req, grpc.Trailer(trailer))
+		if err != nil {
+			return fmt.Errorf("failed to get market data buffer: %w", err)
+		}
+
+		response = resp
+		return nil
+	})
+
+	logRPCOutcome(ctx, "GetMarketDataBuffer", start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GetNewsBuffer gets news buffer from the core engine with circuit breaker protection
+func (c *grpcCoreEngineClient) GetNewsBuffer(ctx context.Context, req *pb.GetNewsBufferRequest) (*pb.GetNewsBufferResponse, error) {
+	start := time.Now()
+
+	cbCtx, trailer := pushbackContext(circuitBreakerContext(ctx, "GetNewsBuffer"))
+
+	var response *pb.GetNewsBufferResponse
+	err := c.circuitBreaker.Execute(cbCtx, func() error {
+		// Inject trace context
+		ctx := c.injectTraceContext(ctx)
+
+		// Add timeout to context
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+
+		// Make gRPC call
+		resp, err := c.newsClient.GetNewsBuffer(ctx, req, grpc.Trailer(trailer))
+		if err != nil {
+			return fmt.Errorf("failed to get news buffer: %w", err)
+		}
+
+		response = resp
+		return nil
+	})
+
+	logRPCOutcome(ctx, "GetNewsBuffer", start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GetIngestionStats gets ingestion stats from the core engine with circuit breaker protection
+func (c *grpcCoreEngineClient) GetIngestionStats(ctx context.Context, req *pb.GetIngestionStatsRequest) (*pb.GetIngestionStatsResponse, error) {
+	start := time.Now()
+
+	cbCtx, trailer := pushbackContext(circuitBreakerContext(ctx, "GetIngestionStats"))
+
+	var response *pb.GetIngestionStatsResponse
+	err := c.circuitBreaker.Execute(cbCtx, func() error {
+		// Inject trace context
+		ctx := c.injectTraceContext(ctx)
+
+		// Add timeout to context
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+
+		// Make gRPC call
+		resp, err := c.ingestionClient.GetIngestionStats(ctx, req, grpc.Trailer(trailer))
+		if err != nil {
+			return fmt.Errorf("failed to get ingestion stats: %w", err)
+		}
+
+		response = resp
+		return nil
+	})
+
+	logRPCOutcome(ctx, "GetIngestionStats", start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// StreamMarketData opens a server-streaming tail of ticks for the requested
+// symbols. Unlike the request/response methods above, the circuit breaker
+// only guards opening the stream; once established the caller drains it via
+// Recv for as long as the subscription stays live, so wrapping the whole
+// lifetime in Execute would keep the breaker's in-flight count pinned.
+func (c *grpcCoreEngineClient) StreamMarketData(ctx context.Context, req *pb.StreamMarketDataRequest) (pb.MarketDataStream, error) {
+	start := time.Now()
+
+	cbCtx, trailer := pushbackContext(circuitBreakerContext(ctx, "StreamMarketData"))
+
+	var stream pb.MarketDataStream
+	err := c.circuitBreaker.Execute(cbCtx, func() error {
+		ctx := c.injectTraceContext(ctx)
+
+		s, err := c.marketDataClient.StreamMarketData(ctx, req, grpc.Trailer(trailer))
+		if err != nil {
+			return fmt.Errorf("failed to open market data stream: %w", err)
+		}
+
+		stream = s
+		return nil
+	})
+
+	logRPCOutcome(ctx, "StreamMarketData", start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return stream, nil
+}
+
+// StreamMarketDataChannel is a channel-based alternative to StreamMarketData
+// for push-based consumers (WebSocket/SSE handlers) that would otherwise
+// poll FetchMarketData/GetMarketDataBuffer on a timer: it keeps the
+// underlying stream alive across drops with exponential-backoff reconnects,
+// resuming from the last tick's timestamp via outgoing stream metadata, and
+// only counts stream-open failures toward the circuit breaker; an in-stream
+// Recv error is reported on the error channel and triggers a reconnect
+// without touching the breaker.
+func (c *grpcCoreEngineClient) StreamMarketDataChannel(ctx context.Context, req *pb.StreamMarketDataRequest) (<-chan *pb.MarketData, <-chan error) {
+	return streamMarketDataChannel(ctx, func(ctx context.Context, resumeAfterMs int64) (pb.MarketDataStream, error) {
+		cbCtx, trailer := pushbackContext(circuitBreakerContext(ctx, "StreamMarketDataChannel"))
+
+		var stream pb.MarketDataStream
+		err := c.circuitBreaker.Execute(cbCtx, func() error {
+			reqCtx := withResumeAfter(c.injectTraceContext(ctx), resumeAfterMetadataKey, resumeAfterMs)
+
+			s, err := c.marketDataClient.StreamMarketData(reqCtx, req, grpc.Trailer(trailer))
+			if err != nil {
+				return fmt.Errorf("failed to open market data stream: %w", err)
+			}
+			stream = s
+			return nil
+		})
+		return stream, err
+	})
+}
+
+// StreamNewsChannel is StreamMarketDataChannel's news counterpart, built on
+// the bidi SubscribeNews RPC so the keyword filter in req can be sent again
+// on every reconnect.
+func (c *grpcCoreEngineClient) StreamNewsChannel(ctx context.Context, req *pb.SubscribeNewsRequest) (<-chan *pb.NewsItem, <-chan error) {
+	return streamNewsChannel(ctx, func(ctx context.Context, resumeAfterMs int64) (pb.NewsService_SubscribeNewsClient, error) {
+		cbCtx, trailer := pushbackContext(circuitBreakerContext(ctx, "StreamNewsChannel"))
+
+		var stream pb.NewsService_SubscribeNewsClient
+		err := c.circuitBreaker.Execute(cbCtx, func() error {
+			reqCtx := withResumeAfter(c.injectTraceContext(ctx), resumeAfterMetadataKey, resumeAfterMs)
+
+			s, err := c.newsClient.SubscribeNews(reqCtx, grpc.Trailer(trailer))
+			if err != nil {
+				return fmt.Errorf("failed to open news subscription: %w", err)
+			}
+			if err := s.Send(req); err != nil {
+				return fmt.Errorf("failed to send news subscription filter: %w", err)
+			}
+			stream = s
+			return nil
+		})
+		return stream, err
+	})
+}
+
+// FetchHistoricalRange fetches one chunk of historical bars for a single
+// symbol with circuit breaker protection. Callers (the backfill handler)
+// are expected to issue many of these concurrently across chunks/symbols
+// and to retry individually on transient failures, so this method does not
+// retry itself.
+func (c *grpcCoreEngineClient) FetchHistoricalRange(ctx context.Context, req *pb.FetchHistoricalRangeRequest) (*pb.FetchHistoricalRangeResponse, error) {
+	start := time.Now()
+
+	cbCtx, trailer := pushbackContext(circuitBreakerContext(ctx, "FetchHistoricalRange"))
+
+	var response *pb.FetchHistoricalRangeResponse
+	err := c.circuitBreaker.Execute(cbCtx, func() error {
+		// Inject trace context
+		ctx := c.injectTraceContext(ctx)
+
+		// Add timeout to context
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+
+		// Make gRPC call
+		resp, err := c.marketDataClient.FetchHistoricalRange(ctx, req, grpc.Trailer(trailer))
+		if err != nil {
+			return fmt.Errorf("failed to fetch historical range: %w", err)
+		}
+
+		response = resp
+		return nil
+	})
+
+	logRPCOutcome(ctx, "FetchHistoricalRange", start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ConnectDataSource connects a data source to the core engine with circuit breaker protection
+func (c *grpcCoreEngineClient) ConnectDataSource(ctx context.Context, req *pb.ConnectDataSourceRequest) (*pb.ConnectDataSourceResponse, error) {
+	start := time.Now()
+
+	cbCtx, trailer := pushbackContext(circuitBreakerContext(ctx, "ConnectDataSource"))
+
+	var response *pb.ConnectDataSourceResponse
+	err := c.circuitBreaker.Execute(cbCtx, func() error {
+		// Inject trace context
+		ctx := c.injectTraceContext(ctx)
+
+		// Add timeout to context
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+
+		// Make gRPC call
+		resp, err := c.ingestionClient.ConnectDataSource(ctx, req, grpc.Trailer(trailer))
+		if err != nil {
+			return fmt.Errorf("failed to connect data source: %w", err)
+		}
+
+		response = resp
+		return nil
+	})
+
+	logRPCOutcome(ctx, "ConnectDataSource", start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,349 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/market-intel/api-gateway/pb"
+	"github.com/market-intel/api-gateway/pkg/logger"
+)
+
+// poolCoreEngineClient is a CoreEngineClient backed by a balancer over
+// multiple core-engine replicas instead of a single connection.
+type poolCoreEngineClient struct {
+	balancer *balancer
+}
+
+// PoolOption configures NewCoreEngineClientPool.
+type PoolOption func(*balancer)
+
+// WithProbeInterval overrides how often the background goroutine health
+// checks each endpoint. The default is 15s.
+func WithProbeInterval(interval time.Duration) PoolOption {
+	return func(b *balancer) { b.probeInterval = interval }
+}
+
+// WithFailureThreshold overrides how many consecutive non-Unavailable,
+// non-DeadlineExceeded RPC failures against an endpoint mark it unhealthy.
+// The default is 3.
+func WithFailureThreshold(threshold int) PoolOption {
+	return func(b *balancer) { b.failureThreshold = threshold }
+}
+
+// WithCooldown overrides how long an unhealthy endpoint is excluded from
+// selection and probing before it's eligible to re-enter rotation. The
+// default is 30s.
+func WithCooldown(cooldown time.Duration) PoolOption {
+	return func(b *balancer) { b.cooldown = cooldown }
+}
+
+// NewCoreEngineClientPool dials every address and returns a CoreEngineClient
+// that load balances across them using a health-aware round-robin balancer
+// (see balancer.go). Unlike NewCoreEngineClient's single connection, losing
+// one replica - for example during a rolling restart - only takes that
+// replica out of rotation instead of tripping the circuit breaker for every
+// request the gateway makes.
+func NewCoreEngineClientPool(addresses []string, opts ...PoolOption) (CoreEngineClient, error) {
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("core engine client pool: at least one endpoint address is required")
+	}
+
+	endpoints := make([]*endpointConn, 0, len(addresses))
+	for _, address := range addresses {
+		ep, err := dialEndpoint(address)
+		if err != nil {
+			for _, already := range endpoints {
+				already.close()
+			}
+			return nil, err
+		}
+		endpoints = append(endpoints, ep)
+	}
+
+	registry := prometheus.NewRegistry()
+	b := newBalancer(endpoints, registry)
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	b.start(context.Background())
+
+	logger.Infof("Core engine client pool started with %d endpoint(s): %s", len(endpoints), strings.Join(addresses, ", "))
+
+	return &poolCoreEngineClient{balancer: b}, nil
+}
+
+// Registry returns the Prometheus registry the pool's endpoint_healthy and
+// endpoint_inflight gauges are registered against. It is not part of the
+// CoreEngineClient interface; callers that need it (to merge it into a
+// shared /metrics endpoint) must type-assert the value NewCoreEngineClientPool
+// returns.
+func (c *poolCoreEngineClient) Registry() *prometheus.Registry {
+	return c.balancer.registry
+}
+
+func (c *poolCoreEngineClient) Close() error {
+	c.balancer.stop()
+	var firstErr error
+	for _, ep := range c.balancer.endpoints {
+		if err := ep.close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// withEndpoint picks an endpoint for affinityKey, tracks it in the inflight
+// gauge for the duration of fn, and feeds fn's outcome back into the
+// endpoint's health bookkeeping.
+func (c *poolCoreEngineClient) withEndpoint(affinityKey string, fn func(ep *endpointConn) error) error {
+	ep, err := c.balancer.next(affinityKey)
+	if err != nil {
+		return err
+	}
+
+	c.balancer.inflightGauge.WithLabelValues(ep.address).Inc()
+	defer c.balancer.inflightGauge.WithLabelValues(ep.address).Dec()
+
+	err = ep.circuitBreaker.Execute(context.Background(), func() error { return fn(ep) })
+	if err != nil {
+		ep.recordFailure(err, c.balancer.failureThreshold, c.balancer.cooldown)
+		return err
+	}
+
+	ep.recordSuccess()
+	return nil
+}
+
+func (c *poolCoreEngineClient) HealthCheck(ctx context.Context, serviceName string) (*pb.HealthCheckResponse, error) {
+	var resp *pb.HealthCheckResponse
+	err := c.withEndpoint("", func(ep *endpointConn) error {
+		reqCtx := injectTraceContext(ctx)
+		req := &pb.HealthCheckRequest{ServiceName: serviceName, Metadata: map[string]string{"client": "api-gateway"}}
+
+		r, err := ep.ingestionClient.HealthCheck(reqCtx, req)
+		if err != nil {
+			return fmt.Errorf("health check failed: %w", err)
+		}
+		resp = r
+		return nil
+	})
+	return resp, err
+}
+
+func (c *poolCoreEngineClient) GetStatus(ctx context.Context) (*pb.EngineStatusResponse, error) {
+	var resp *pb.EngineStatusResponse
+	err := c.withEndpoint("", func(ep *endpointConn) error {
+		reqCtx := injectTraceContext(ctx)
+
+		r, err := ep.ingestionClient.GetStatus(reqCtx, &pb.Empty{})
+		if err != nil {
+			return fmt.Errorf("get status failed: %w", err)
+		}
+		resp = r
+		return nil
+	})
+	return resp, err
+}
+
+// FetchMarketData fetches market data, routing by SourceId (falling back to
+// the requested symbols) so repeated fetches for the same source stick to
+// the replica most likely to have it warm.
+func (c *poolCoreEngineClient) FetchMarketData(ctx context.Context, req *pb.FetchMarketDataRequest) (*pb.FetchMarketDataResponse, error) {
+	var resp *pb.FetchMarketDataResponse
+	err := c.withEndpoint(marketDataAffinityKey(req.SourceId, req.Symbols), func(ep *endpointConn) error {
+		reqCtx := injectTraceContext(ctx)
+		reqCtx, cancel := context.WithTimeout(reqCtx, 30*time.Second)
+		defer cancel()
+
+		r, err := ep.marketDataClient.FetchMarketData(reqCtx, req)
+		if err != nil {
+			return fmt.Errorf("failed to fetch market data: %w", err)
+		}
+		resp = r
+		return nil
+	})
+	return resp, err
+}
+
+// FetchNewsData fetches news data from the core engine
+func (c *poolCoreEngineClient) FetchNewsData(ctx context.Context, req *pb.FetchNewsDataRequest) (*pb.FetchNewsDataResponse, error) {
+	var resp *pb.FetchNewsDataResponse
+	err := c.withEndpoint("", func(ep *endpointConn) error {
+		reqCtx := injectTraceContext(ctx)
+		reqCtx, cancel := context.WithTimeout(reqCtx, 30*time.Second)
+		defer cancel()
+
+		r, err := ep.newsClient.FetchNewsData(reqCtx, req)
+		if err != nil {
+			return fmt.Errorf("failed to fetch news data: %w", err)
+		}
+		resp = r
+		return nil
+	})
+	return resp, err
+}
+
+// GetMarketDataBuffer gets the buffered market data for a source, routing by
+// SourceId so repeated polls of the same source's buffer stick to one
+// replica.
+func (c *poolCoreEngineClient) GetMarketDataBuffer(ctx context.Context, req *pb.GetMarketDataBufferRequest) (*pb.GetMarketDataBufferResponse, error) {
+	var resp *pb.GetMarketDataBufferResponse
+	err := c.withEndpoint(req.SourceId, func(ep *endpointConn) error {
+		reqCtx := injectTraceContext(ctx)
+		reqCtx, cancel := context.WithTimeout(reqCtx, 30*time.Second)
+		defer cancel()
+
+		r, err := ep.marketDataClient.GetMarketDataBuffer(reqCtx, req)
+		if err != nil {
+			return fmt.Errorf("failed to get market data buffer: %w", err)
+		}
+		resp = r
+		return nil
+	})
+	return resp, err
+}
+
+func (c *poolCoreEngineClient) GetNewsBuffer(ctx context.Context, req *pb.GetNewsBufferRequest) (*pb.GetNewsBufferResponse, error) {
+	var resp *pb.GetNewsBufferResponse
+	err := c.withEndpoint("", func(ep *endpointConn) error {
+		reqCtx := injectTraceContext(ctx)
+		reqCtx, cancel := context.WithTimeout(reqCtx, 30*time.Second)
+		defer cancel()
+
+		r, err := ep.newsClient.GetNewsBuffer(reqCtx, req)
+		if err != nil {
+			return fmt.Errorf("failed to get news buffer: %w", err)
+		}
+		resp = r
+		return nil
+	})
+	return resp, err
+}
+
+func (c *poolCoreEngineClient) GetIngestionStats(ctx context.Context, req *pb.GetIngestionStatsRequest) (*pb.GetIngestionStatsResponse, error) {
+	var resp *pb.GetIngestionStatsResponse
+	err := c.withEndpoint("", func(ep *endpointConn) error {
+		reqCtx := injectTraceContext(ctx)
+		reqCtx, cancel := context.WithTimeout(reqCtx, 30*time.Second)
+		defer cancel()
+
+		r, err := ep.ingestionClient.GetIngestionStats(reqCtx, req)
+		if err != nil {
+			return fmt.Errorf("failed to get ingestion stats: %w", err)
+		}
+		resp = r
+		return nil
+	})
+	return resp, err
+}
+
+func (c *poolCoreEngineClient) ConnectDataSource(ctx context.Context, req *pb.ConnectDataSourceRequest) (*pb.ConnectDataSourceResponse, error) {
+	var resp *pb.ConnectDataSourceResponse
+	err := c.withEndpoint("", func(ep *endpointConn) error {
+		reqCtx := injectTraceContext(ctx)
+		reqCtx, cancel := context.WithTimeout(reqCtx, 30*time.Second)
+		defer cancel()
+
+		r, err := ep.ingestionClient.ConnectDataSource(reqCtx, req)
+		if err != nil {
+			return fmt.Errorf("failed to connect data source: %w", err)
+		}
+		resp = r
+		return nil
+	})
+	return resp, err
+}
+
+func (c *poolCoreEngineClient) FetchHistoricalRange(ctx context.Context, req *pb.FetchHistoricalRangeRequest) (*pb.FetchHistoricalRangeResponse, error) {
+	var resp *pb.FetchHistoricalRangeResponse
+	err := c.withEndpoint("", func(ep *endpointConn) error {
+		reqCtx := injectTraceContext(ctx)
+		reqCtx, cancel := context.WithTimeout(reqCtx, 30*time.Second)
+		defer cancel()
+
+		r, err := ep.marketDataClient.FetchHistoricalRange(reqCtx, req)
+		if err != nil {
+			return fmt.Errorf("failed to fetch historical range: %w", err)
+		}
+		resp = r
+		return nil
+	})
+	return resp, err
+}
+
+// StreamMarketData opens a server-streaming tail of ticks. As with
+// grpcCoreEngineClient, only opening the stream goes through the circuit
+// breaker and health bookkeeping; the caller drains the returned stream
+// directly for as long as the subscription stays live.
+func (c *poolCoreEngineClient) StreamMarketData(ctx context.Context, req *pb.StreamMarketDataRequest) (pb.MarketDataStream, error) {
+	var stream pb.MarketDataStream
+	err := c.withEndpoint(marketDataAffinityKey(req.SourceId, req.Symbols), func(ep *endpointConn) error {
+		reqCtx := injectTraceContext(ctx)
+
+		s, err := ep.marketDataClient.StreamMarketData(reqCtx, req)
+		if err != nil {
+			return fmt.Errorf("failed to open market data stream: %w", err)
+		}
+		stream = s
+		return nil
+	})
+	return stream, err
+}
+
+// StreamMarketDataChannel is StreamMarketData's channel-based counterpart
+// (see grpcCoreEngineClient.StreamMarketDataChannel): every reconnect
+// attempt goes through the balancer afresh, so a replica that dropped the
+// stream is skipped in favor of a healthy one instead of being retried in a
+// loop.
+func (c *poolCoreEngineClient) StreamMarketDataChannel(ctx context.Context, req *pb.StreamMarketDataRequest) (<-chan *pb.MarketData, <-chan error) {
+	return streamMarketDataChannel(ctx, func(ctx context.Context, resumeAfterMs int64) (pb.MarketDataStream, error) {
+		var stream pb.MarketDataStream
+		err := c.withEndpoint(marketDataAffinityKey(req.SourceId, req.Symbols), func(ep *endpointConn) error {
+			reqCtx := withResumeAfter(injectTraceContext(ctx), resumeAfterMetadataKey, resumeAfterMs)
+
+			s, err := ep.marketDataClient.StreamMarketData(reqCtx, req)
+			if err != nil {
+				return fmt.Errorf("failed to open market data stream: %w", err)
+			}
+			stream = s
+			return nil
+		})
+		return stream, err
+	})
+}
+
+// StreamNewsChannel is StreamMarketDataChannel's news counterpart.
+func (c *poolCoreEngineClient) StreamNewsChannel(ctx context.Context, req *pb.SubscribeNewsRequest) (<-chan *pb.NewsItem, <-chan error) {
+	return streamNewsChannel(ctx, func(ctx context.Context, resumeAfterMs int64) (pb.NewsService_SubscribeNewsClient, error) {
+		var stream pb.NewsService_SubscribeNewsClient
+		err := c.withEndpoint("", func(ep *endpointConn) error {
+			reqCtx := withResumeAfter(injectTraceContext(ctx), resumeAfterMetadataKey, resumeAfterMs)
+
+			s, err := ep.newsClient.SubscribeNews(reqCtx)
+			if err != nil {
+				return fmt.Errorf("failed to open news subscription: %w", err)
+			}
+			if err := s.Send(req); err != nil {
+				return fmt.Errorf("failed to send news subscription filter: %w", err)
+			}
+			stream = s
+			return nil
+		})
+		return stream, err
+	})
+}
+
+// marketDataAffinityKey picks the best available sticky-routing key for a
+// market data request: SourceId when the caller set one, otherwise the
+// requested symbols joined together.
+func marketDataAffinityKey(sourceID string, symbols []string) string {
+	if sourceID != "" {
+		return sourceID
+	}
+	return strings.Join(symbols, ",")
+}
@@ -0,0 +1,218 @@
+package services
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/market-intel/api-gateway/pb"
+	"github.com/market-intel/api-gateway/pkg/logger"
+)
+
+const (
+	streamReconnectInitialDelay = 500 * time.Millisecond
+	streamReconnectMaxDelay     = 30 * time.Second
+	streamReconnectMultiplier   = 2.0
+	streamChannelBufferSize     = 256
+
+	// resumeAfterMetadataKey carries the timestamp of the last message
+	// (MarketData tick or NewsItem) successfully received before a
+	// reconnect, formatted as RFC 3339 by formatResumeOffset, so the core engine can skip replaying data the gateway
+	// already has. Shared by streamMarketDataChannel and streamNewsChannel.
+	resumeAfterMetadataKey = "x-resume-after-ts"
+)
+
+// marketDataStreamOpener opens one attempt of a StreamMarketData RPC.
+// resumeAfterMs is 0 on the first attempt and the timestamp of the last
+// tick received on every reconnect after that.
+type marketDataStreamOpener func(ctx context.Context, resumeAfterMs int64) (pb.MarketDataStream, error)
+
+// newsStreamOpener opens one attempt of a SubscribeNews RPC, mirroring
+// marketDataStreamOpener's resumable-offset contract.
+type newsStreamOpener func(ctx context.Context, resumeAfterMs int64) (pb.NewsService_SubscribeNewsClient, error)
+
+// withResumeAfter attaches a resumable-offset hint to ctx's outgoing gRPC
+// metadata under key, for the core engine to honor on a best-effort basis.
+// A resumeAfterMs of 0 (first attempt, or a server that hasn't ticked yet)
+// is omitted rather than sent as a literal "0".
+func withResumeAfter(ctx context.Context, key string, resumeAfterMs int64) context.Context {
+	if resumeAfterMs <= 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, key, formatResumeOffset(resumeAfterMs))
+}
+
+func formatResumeOffset(resumeAfterMs int64) string {
+	return time.UnixMilli(resumeAfterMs).UTC().Format(time.RFC3339Nano)
+}
+
+// reconnectBackoff tracks the exponential delay between reconnect attempts
+// of a streamMarketDataChannel/streamNewsChannel loop, resetting to the
+// initial delay after every stream that opens successfully.
+type reconnectBackoff struct {
+	delay time.Duration
+}
+
+func (b *reconnectBackoff) reset() {
+	b.delay = streamReconnectInitialDelay
+}
+
+// next returns the delay to wait before the next attempt and advances it
+// for the one after that.
+func (b *reconnectBackoff) next() time.Duration {
+	if b.delay <= 0 {
+		b.delay = streamReconnectInitialDelay
+	}
+	delay := b.delay
+
+	scaled := float64(b.delay) * streamReconnectMultiplier
+	if scaled > float64(streamReconnectMaxDelay) {
+		scaled = float64(streamReconnectMaxDelay)
+	}
+	b.delay = time.Duration(scaled)
+
+	jitter := time.Duration(float64(delay) * 0.25 * (rand.Float64() - 0.5))
+	return delay + jitter
+}
+
+// sleep waits for d or ctx cancellation, reporting whether it woke up
+// because d elapsed (true) rather than ctx being canceled (false).
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// sendErr delivers err on errs without blocking forever if the consumer
+// isn't draining it, reporting whether ctx is still live.
+func sendErr(ctx context.Context, errs chan<- error, err error) bool {
+	select {
+	case errs <- err:
+	default:
+	}
+	return ctx.Err() == nil
+}
+
+// streamMarketDataChannel drives open in a reconnect loop with exponential
+// backoff, forwarding every MarketData tick onto the returned channel.
+// Stream-open failures and non-EOF errors encountered mid-stream (a dropped
+// connection) are both reported on the error channel without blocking, and
+// both trigger a reconnect; only open failures count toward whatever circuit
+// breaker open itself applies. Both channels are closed once ctx is canceled.
+func streamMarketDataChannel(ctx context.Context, open marketDataStreamOpener) (<-chan *pb.MarketData, <-chan error) {
+	out := make(chan *pb.MarketData, streamChannelBufferSize)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(out)
+		defer close(errs)
+
+		var resumeAfterMs int64
+		var backoff reconnectBackoff
+
+		for ctx.Err() == nil {
+			stream, err := open(ctx, resumeAfterMs)
+			if err != nil {
+				if !sendErr(ctx, errs, err) {
+					return
+				}
+				if !sleep(ctx, backoff.next()) {
+					return
+				}
+				continue
+			}
+
+			backoff.reset()
+
+			for {
+				data, err := stream.Recv()
+				if err != nil {
+					if err != io.EOF {
+						logger.Warnf("streamMarketDataChannel: stream ended: %v", err)
+						if !sendErr(ctx, errs, err) {
+							return
+						}
+					}
+					break
+				}
+
+				resumeAfterMs = data.Timestamp
+				select {
+				case out <- data:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if !sleep(ctx, backoff.next()) {
+				return
+			}
+		}
+	}()
+
+	return out, errs
+}
+
+// streamNewsChannel is streamMarketDataChannel's SubscribeNews counterpart.
+func streamNewsChannel(ctx context.Context, open newsStreamOpener) (<-chan *pb.NewsItem, <-chan error) {
+	out := make(chan *pb.NewsItem, streamChannelBufferSize)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(out)
+		defer close(errs)
+
+		var resumeAfterMs int64
+		var backoff reconnectBackoff
+
+		for ctx.Err() == nil {
+			stream, err := open(ctx, resumeAfterMs)
+			if err != nil {
+				if !sendErr(ctx, errs, err) {
+					return
+				}
+				if !sleep(ctx, backoff.next()) {
+					return
+				}
+				continue
+			}
+
+			backoff.reset()
+
+			for {
+				item, err := stream.Recv()
+				if err != nil {
+					if err != io.EOF {
+						logger.Warnf("streamNewsChannel: stream ended: %v", err)
+						if !sendErr(ctx, errs, err) {
+							return
+						}
+					}
+					break
+				}
+
+				resumeAfterMs = item.Timestamp
+				select {
+				case out <- item:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if !sleep(ctx, backoff.next()) {
+				return
+			}
+		}
+	}()
+
+	return out, errs
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"sync"
+
+	"github.com/market-intel/api-gateway/pb"
+)
+
+// SymbolSubscription is a StreamMarketDataChannel subscription whose symbol
+// set can be changed without the caller having to manage stream lifecycle
+// itself. StreamMarketData has no server-side "resubscribe" message, so
+// UpdateSymbols is implemented by tearing down the current stream and
+// opening a new one for the new symbol set; callers only ever see one
+// continuous tick channel.
+type SymbolSubscription struct {
+	client   CoreEngineClient
+	sourceID string
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	out    chan *pb.MarketData
+	errs   chan error
+	closed bool
+}
+
+// SubscribeSymbols opens a SymbolSubscription for the given initial symbols.
+// Passing no symbols is valid; the subscription simply forwards nothing
+// until UpdateSymbols is called with a non-empty set.
+func SubscribeSymbols(client CoreEngineClient, sourceID string, symbols []string) *SymbolSubscription {
+	s := &SymbolSubscription{
+		client:   client,
+		sourceID: sourceID,
+		out:      make(chan *pb.MarketData, streamChannelBufferSize),
+		errs:     make(chan error, 1),
+	}
+	s.restart(symbols)
+	return s
+}
+
+// C returns the channel of ticks for the subscription's current symbol set.
+// The channel identity is stable across calls to UpdateSymbols.
+func (s *SymbolSubscription) C() <-chan *pb.MarketData {
+	return s.out
+}
+
+// Errors returns the channel of stream errors (open failures and mid-stream
+// drops) for the subscription's current symbol set. The channel identity is stable across calls to
+// UpdateSymbols.
+func (s *SymbolSubscription) Errors() <-chan error {
+	return s.errs
+}
+
+// UpdateSymbols replaces the subscription's symbol set, reconnecting the
+// underlying stream with the new set. Ticks for the old set already queued
+// on C() are not discarded; the caller just stops receiving new ones for
+// symbols that are no longer in the set.
+func (s *SymbolSubscription) UpdateSymbols(symbols []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+	s.cancel()
+	s.restartLocked(symbols)
+}
+
+// Close permanently tears down the subscription's underlying stream.
+func (s *SymbolSubscription) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+	s.closed = true
+	s.cancel()
+}
+
+// restart opens the underlying stream for symbols and starts the goroutine
+// that forwards it onto the subscription's stable out/errs channels. It
+// takes mu itself; callers already holding mu use restartLocked instead.
+func (s *SymbolSubscription) restart(symbols []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.restartLocked(symbols)
+}
+
+func (s *SymbolSubscription) restartLocked(symbols []string) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	ticks, errs := s.client.StreamMarketDataChannel(ctx, &pb.StreamMarketDataRequest{
+		Symbols:  symbols,
+		SourceId: s.sourceID,
+	})
+
+	go forward(ctx, ticks, s.out)
+	go forwardErr(ctx, errs, s.errs)
+}
+
+// forward relays every value off in onto out until in closes or ctx is
+// canceled, without blocking forever on a full out if the caller stopped
+// draining it during a symbol-set change.
+func forward(ctx context.Context, in <-chan *pb.MarketData, out chan<- *pb.MarketData) {
+	for {
+		select {
+		case v, ok := <-in:
+			if !ok {
+				return
+			}
+			select {
+			case out <- v:
+			case <-ctx.Done():
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// forwardErr is forward's error-channel counterpart.
+func forwardErr(ctx context.Context, in <-chan error, out chan<- error) {
+	for {
+		select {
+		case err, ok := <-in:
+			if !ok {
+				return
+			}
+			select {
+			case out <- err:
+			default:
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// Data Source Adapter Registry
+// Lets the ingestion handlers satisfy fetch/stream requests from an
+// in-process adapter (Yahoo Finance, Alpha Vantage, Finnhub, Polygon, RSS
+// news) before falling back to the Rust core engine, each adapter carrying
+// its own rate-limit budget, retry policy, and credential resolution.
+
+package sources
+
+import (
+	"context"
+	"time"
+
+	"github.com/market-intel/api-gateway/pb"
+)
+
+// SourceAdapter is implemented by every built-in data source. Stream may
+// return an error for adapters that only support polling; the registry
+// falls back to the core engine's StreamMarketData RPC in that case.
+type SourceAdapter interface {
+	Fetch(ctx context.Context, symbols []string) ([]pb.MarketData, error)
+	Stream(ctx context.Context, symbols []string) (<-chan pb.MarketData, error)
+	HealthCheck(ctx context.Context) error
+	Capabilities() SourceCapabilities
+}
+
+// NewsAdapter is implemented by adapters whose Capabilities().SupportsNews
+// is true (currently just the RSS adapter); the registry type-asserts for
+// it when FetchNewsData's source ID doesn't resolve to a market-data
+// adapter.
+type NewsAdapter interface {
+	FetchNews(ctx context.Context, keywords []string, hoursBack int) ([]pb.NewsItem, error)
+}
+
+// SourceCapabilities describes what an adapter supports and the policy the
+// registry should enforce on its behalf
+type SourceCapabilities struct {
+	Name              string
+	SupportsStreaming bool
+	SupportsNews      bool
+	RateLimit         RateLimitConfig
+	Retry             RetryPolicy
+}
+
+// RateLimitConfig configures the token bucket guarding calls into an adapter
+type RateLimitConfig struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// RetryPolicy configures an adapter's exponential backoff on transient
+// errors, mirroring the sourceRetry* constants the handlers already use for
+// core-engine calls
+type RetryPolicy struct {
+	MaxAttempts  int
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+}
+
+// AdapterConfig is the serializable description of one registered adapter,
+// used both for the built-in default list and for hot-reload updates
+type AdapterConfig struct {
+	SourceID      string          `json:"source_id"`
+	Type          string          `json:"type"`
+	Enabled       bool            `json:"enabled"`
+	CredentialKey string          `json:"credential_key,omitempty"`
+	BaseURL       string          `json:"base_url,omitempty"`
+	RateLimit     RateLimitConfig `json:"rate_limit"`
+	Retry         RetryPolicy     `json:"retry"`
+}
+
+// Adapter type identifiers accepted by AdapterConfig.Type / buildAdapter
+const (
+	TypeYahooFinance = "yahoo_finance"
+	TypeAlphaVantage = "alpha_vantage"
+	TypeFinnhub      = "finnhub"
+	TypePolygon      = "polygon"
+	TypeRSSNews      = "rss_news"
+)
+
+// defaultRateLimit and defaultRetry are applied to built-in adapters that
+// don't need a tighter budget than this
+var (
+	defaultRateLimit = RateLimitConfig{RequestsPerSecond: 5, Burst: 10}
+	defaultRetry     = RetryPolicy{MaxAttempts: 3, InitialDelay: 100 * time.Millisecond, MaxDelay: 2 * time.Second}
+)
+
+// DefaultAdapterConfigs is the configured default adapter list, replacing
+// the previous hard-coded "yahoo_finance" / "news_api" source ID fallbacks
+// sprinkled through the ingestion handlers.
+func DefaultAdapterConfigs() []AdapterConfig {
+	return []AdapterConfig{
+		{
+			SourceID:  TypeYahooFinance,
+			Type:      TypeYahooFinance,
+			Enabled:   true,
+			BaseURL:   "https://query1.finance.yahoo.com",
+			RateLimit: defaultRateLimit,
+			Retry:     defaultRetry,
+		},
+		{
+			SourceID:      TypeAlphaVantage,
+			Type:          TypeAlphaVantage,
+			Enabled:       true,
+			CredentialKey: "ALPHA_VANTAGE_API_KEY",
+			BaseURL:       "https://www.alphavantage.co",
+			RateLimit:     RateLimitConfig{RequestsPerSecond: 0.2, Burst: 1}, // free tier: 5 req/min
+			Retry:         defaultRetry,
+		},
+		{
+			SourceID:      TypeFinnhub,
+			Type:          TypeFinnhub,
+			Enabled:       true,
+			CredentialKey: "FINNHUB_API_KEY",
+			BaseURL:       "https://finnhub.io/api/v1",
+			RateLimit:     RateLimitConfig{RequestsPerSecond: 1, Burst: 5},
+			Retry:         defaultRetry,
+		},
+		{
+			SourceID:      TypePolygon,
+			Type:          TypePolygon,
+			Enabled:       true,
+			CredentialKey: "POLYGON_API_KEY",
+			BaseURL:       "https://api.polygon.io",
+			RateLimit:     RateLimitConfig{RequestsPerSecond: 5, Burst: 10},
+			Retry:         defaultRetry,
+		},
+		{
+			SourceID:  "news_api",
+			Type:      TypeRSSNews,
+			Enabled:   true,
+			BaseURL:   "https://news.google.com/rss",
+			RateLimit: RateLimitConfig{RequestsPerSecond: 1, Burst: 3},
+			Retry:     defaultRetry,
+		},
+	}
+}
+
+// DefaultMarketSourceID and DefaultNewsSourceID name the entries in
+// DefaultAdapterConfigs that FetchMarketData / FetchNewsData fall back to
+// when a request omits SourceID
+const (
+	DefaultMarketSourceID = TypeYahooFinance
+	DefaultNewsSourceID   = "news_api"
+)
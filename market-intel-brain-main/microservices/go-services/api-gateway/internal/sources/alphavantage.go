@@ -0,0 +1,87 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/market-intel/api-gateway/pb"
+)
+
+// alphaVantageAdapter fetches quotes from Alpha Vantage's GLOBAL_QUOTE
+// endpoint. Requires an API key resolved through CredentialKey.
+type alphaVantageAdapter struct {
+	baseURL       string
+	credentialKey string
+	credStore     CredentialStore
+}
+
+func newAlphaVantageAdapter(cfg AdapterConfig, credStore CredentialStore) *alphaVantageAdapter {
+	return &alphaVantageAdapter{baseURL: cfg.BaseURL, credentialKey: cfg.CredentialKey, credStore: credStore}
+}
+
+type alphaVantageQuoteResponse struct {
+	GlobalQuote struct {
+		Symbol string `json:"01. symbol"`
+		Price  string `json:"05. price"`
+		Volume string `json:"06. volume"`
+	} `json:"Global Quote"`
+}
+
+func (a *alphaVantageAdapter) Fetch(ctx context.Context, symbols []string) ([]pb.MarketData, error) {
+	apiKey, err := a.credStore.Get(ctx, a.credentialKey)
+	if err != nil {
+		return nil, fmt.Errorf("alpha_vantage: %w", err)
+	}
+
+	data := make([]pb.MarketData, 0, len(symbols))
+
+	for _, symbol := range symbols {
+		url := fmt.Sprintf("%s/query?function=GLOBAL_QUOTE&symbol=%s&apikey=%s", a.baseURL, symbol, apiKey)
+
+		body, err := httpGet(ctx, url)
+		if err != nil {
+			return nil, fmt.Errorf("alpha_vantage: failed to fetch %s: %w", symbol, err)
+		}
+
+		var resp alphaVantageQuoteResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, fmt.Errorf("alpha_vantage: failed to decode response for %s: %w", symbol, err)
+		}
+		if resp.GlobalQuote.Symbol == "" {
+			return nil, fmt.Errorf("alpha_vantage: no quote returned for symbol %s", symbol)
+		}
+
+		price, _ := strconv.ParseFloat(resp.GlobalQuote.Price, 64)
+		volume, _ := strconv.ParseInt(resp.GlobalQuote.Volume, 10, 64)
+
+		data = append(data, pb.MarketData{
+			Symbol:    resp.GlobalQuote.Symbol,
+			Price:     price,
+			Volume:    volume,
+			Timestamp: time.Now().UnixMilli(),
+			Source:    TypeAlphaVantage,
+		})
+	}
+
+	return data, nil
+}
+
+func (a *alphaVantageAdapter) Stream(ctx context.Context, symbols []string) (<-chan pb.MarketData, error) {
+	return nil, fmt.Errorf("alpha_vantage: streaming is not supported, use the core engine's StreamMarketData instead")
+}
+
+func (a *alphaVantageAdapter) HealthCheck(ctx context.Context) error {
+	apiKey, err := a.credStore.Get(ctx, a.credentialKey)
+	if err != nil {
+		return fmt.Errorf("alpha_vantage: %w", err)
+	}
+	_, err = httpGet(ctx, fmt.Sprintf("%s/query?function=GLOBAL_QUOTE&symbol=AAPL&apikey=%s", a.baseURL, apiKey))
+	return err
+}
+
+func (a *alphaVantageAdapter) Capabilities() SourceCapabilities {
+	return SourceCapabilities{Name: TypeAlphaVantage, SupportsStreaming: false}
+}
@@ -0,0 +1,119 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+// CredentialStore resolves the API key/secret an adapter should use for a
+// given credential key (typically an env var name such as
+// "ALPHA_VANTAGE_API_KEY"). Get returns an error if the key is unknown to
+// the store rather than silently returning an empty credential, so a
+// misconfigured adapter fails HealthCheck loudly instead of hitting the
+// upstream unauthenticated.
+type CredentialStore interface {
+	Get(ctx context.Context, key string) (string, error)
+}
+
+// EnvCredentialStore resolves credentials from process environment
+// variables, mirroring how the rest of the gateway's configuration is
+// sourced (see internal/config's getEnv helpers).
+type EnvCredentialStore struct{}
+
+func NewEnvCredentialStore() *EnvCredentialStore { return &EnvCredentialStore{} }
+
+func (s *EnvCredentialStore) Get(_ context.Context, key string) (string, error) {
+	if value := os.Getenv(key); value != "" {
+		return value, nil
+	}
+	return "", fmt.Errorf("credential %q is not set", key)
+}
+
+// FileCredentialStore resolves credentials from a JSON file of
+// key -> secret pairs, loaded once at construction so hot-reloading the
+// adapter registry doesn't also require re-reading the filesystem for
+// every request.
+type FileCredentialStore struct {
+	secrets map[string]string
+}
+
+func NewFileCredentialStore(path string) (*FileCredentialStore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credential file %s: %w", path, err)
+	}
+
+	var secrets map[string]string
+	if err := json.Unmarshal(data, &secrets); err != nil {
+		return nil, fmt.Errorf("failed to parse credential file %s: %w", path, err)
+	}
+
+	return &FileCredentialStore{secrets: secrets}, nil
+}
+
+func (s *FileCredentialStore) Get(_ context.Context, key string) (string, error) {
+	if value, ok := s.secrets[key]; ok && value != "" {
+		return value, nil
+	}
+	return "", fmt.Errorf("credential %q not found in credential file", key)
+}
+
+// VaultCredentialStore resolves credentials from a HashiCorp Vault KV v2
+// mount, reading "<MountPath>/data/<key>" and returning its "value" field.
+type VaultCredentialStore struct {
+	addr      string
+	token     string
+	mountPath string
+	client    *http.Client
+}
+
+func NewVaultCredentialStore(addr, token, mountPath string) *VaultCredentialStore {
+	return &VaultCredentialStore{
+		addr:      addr,
+		token:     token,
+		mountPath: mountPath,
+		client:    &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+type vaultKVResponse struct {
+	Data struct {
+		Data map[string]string `json:"data"`
+	} `json:"data"`
+}
+
+func (s *VaultCredentialStore) Get(ctx context.Context, key string) (string, error) {
+	url := fmt.Sprintf("%s/v1/%s/data/%s", s.addr, s.mountPath, key)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", s.token)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault returned status %d for %s", resp.StatusCode, url)
+	}
+
+	var kv vaultKVResponse
+	if err := json.NewDecoder(resp.Body).Decode(&kv); err != nil {
+		return "", fmt.Errorf("failed to decode vault response: %w", err)
+	}
+
+	value, ok := kv.Data.Data["value"]
+	if !ok || value == "" {
+		return "", fmt.Errorf("vault secret %s has no \"value\" field", key)
+	}
+
+	return value, nil
+}
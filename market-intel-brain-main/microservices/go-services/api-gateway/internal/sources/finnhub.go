@@ -0,0 +1,78 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/market-intel/api-gateway/pb"
+)
+
+// finnhubAdapter fetches quotes from Finnhub's /quote endpoint. Requires an
+// API key resolved through CredentialKey.
+type finnhubAdapter struct {
+	baseURL       string
+	credentialKey string
+	credStore     CredentialStore
+}
+
+func newFinnhubAdapter(cfg AdapterConfig, credStore CredentialStore) *finnhubAdapter {
+	return &finnhubAdapter{baseURL: cfg.BaseURL, credentialKey: cfg.CredentialKey, credStore: credStore}
+}
+
+type finnhubQuoteResponse struct {
+	CurrentPrice float64 `json:"c"`
+	Timestamp    int64   `json:"t"`
+}
+
+func (a *finnhubAdapter) Fetch(ctx context.Context, symbols []string) ([]pb.MarketData, error) {
+	apiKey, err := a.credStore.Get(ctx, a.credentialKey)
+	if err != nil {
+		return nil, fmt.Errorf("finnhub: %w", err)
+	}
+
+	data := make([]pb.MarketData, 0, len(symbols))
+
+	for _, symbol := range symbols {
+		url := fmt.Sprintf("%s/quote?symbol=%s&token=%s", a.baseURL, symbol, apiKey)
+
+		body, err := httpGet(ctx, url)
+		if err != nil {
+			return nil, fmt.Errorf("finnhub: failed to fetch %s: %w", symbol, err)
+		}
+
+		var resp finnhubQuoteResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, fmt.Errorf("finnhub: failed to decode response for %s: %w", symbol, err)
+		}
+		if resp.CurrentPrice == 0 {
+			return nil, fmt.Errorf("finnhub: no quote returned for symbol %s", symbol)
+		}
+
+		data = append(data, pb.MarketData{
+			Symbol:    symbol,
+			Price:     resp.CurrentPrice,
+			Timestamp: resp.Timestamp * 1000,
+			Source:    TypeFinnhub,
+		})
+	}
+
+	return data, nil
+}
+
+func (a *finnhubAdapter) Stream(ctx context.Context, symbols []string) (<-chan pb.MarketData, error) {
+	return nil, fmt.Errorf("finnhub: streaming is not supported by this adapter, use the core engine's StreamMarketData instead")
+}
+
+func (a *finnhubAdapter) HealthCheck(ctx context.Context) error {
+	apiKey, err := a.credStore.Get(ctx, a.credentialKey)
+	if err != nil {
+		return fmt.Errorf("finnhub: %w", err)
+	}
+	_, err = httpGet(ctx, fmt.Sprintf("%s/quote?symbol=AAPL&token=%s", a.baseURL, apiKey))
+	return err
+}
+
+func (a *finnhubAdapter) Capabilities() SourceCapabilities {
+	return SourceCapabilities{Name: TypeFinnhub, SupportsStreaming: false}
+}
@@ -0,0 +1,42 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// adapterHTTPClient is shared by the HTTP-backed adapters; its timeout
+// bounds each attempt, so a single hung request stalls a retry loop for at
+// most 10s per attempt rather than indefinitely.
+var adapterHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// httpGet issues a GET to url and returns the response body, returning
+// an error classifiable by neterr. Any non-200 response, 4xx included,
+// comes back as a plain error carrying the status code and body, which
+// neterr.Classify reports as ErrTransient and so as retryable.
+func httpGet(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	resp, err := adapterHTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("upstream returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	return body, nil
+}
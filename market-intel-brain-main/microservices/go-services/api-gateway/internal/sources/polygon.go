@@ -0,0 +1,84 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/market-intel/api-gateway/pb"
+)
+
+// polygonAdapter fetches the previous day's aggregate bar from Polygon.io.
+// Requires an API key resolved through CredentialKey.
+type polygonAdapter struct {
+	baseURL       string
+	credentialKey string
+	credStore     CredentialStore
+}
+
+func newPolygonAdapter(cfg AdapterConfig, credStore CredentialStore) *polygonAdapter {
+	return &polygonAdapter{baseURL: cfg.BaseURL, credentialKey: cfg.CredentialKey, credStore: credStore}
+}
+
+type polygonAggsResponse struct {
+	Ticker  string `json:"ticker"`
+	Results []struct {
+		Close     float64 `json:"c"`
+		Volume    int64   `json:"v"`
+		Timestamp int64   `json:"t"` // milliseconds
+	} `json:"results"`
+}
+
+func (a *polygonAdapter) Fetch(ctx context.Context, symbols []string) ([]pb.MarketData, error) {
+	apiKey, err := a.credStore.Get(ctx, a.credentialKey)
+	if err != nil {
+		return nil, fmt.Errorf("polygon: %w", err)
+	}
+
+	data := make([]pb.MarketData, 0, len(symbols))
+
+	for _, symbol := range symbols {
+		url := fmt.Sprintf("%s/v2/aggs/ticker/%s/prev?apiKey=%s", a.baseURL, symbol, apiKey)
+
+		body, err := httpGet(ctx, url)
+		if err != nil {
+			return nil, fmt.Errorf("polygon: failed to fetch %s: %w", symbol, err)
+		}
+
+		var resp polygonAggsResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, fmt.Errorf("polygon: failed to decode response for %s: %w", symbol, err)
+		}
+		if len(resp.Results) == 0 {
+			return nil, fmt.Errorf("polygon: no results for symbol %s", symbol)
+		}
+
+		bar := resp.Results[0]
+		data = append(data, pb.MarketData{
+			Symbol:    symbol,
+			Price:     bar.Close,
+			Volume:    bar.Volume,
+			Timestamp: bar.Timestamp,
+			Source:    TypePolygon,
+		})
+	}
+
+	return data, nil
+}
+
+func (a *polygonAdapter) Stream(ctx context.Context, symbols []string) (<-chan pb.MarketData, error) {
+	return nil, fmt.Errorf("polygon: streaming is not supported by this adapter, use the core engine's StreamMarketData instead")
+}
+
+func (a *polygonAdapter) HealthCheck(ctx context.Context) error {
+	apiKey, err := a.credStore.Get(ctx, a.credentialKey)
+	if err != nil {
+		return fmt.Errorf("polygon: %w", err)
+	}
+	_, err = httpGet(ctx, fmt.Sprintf("%s/v2/aggs/ticker/AAPL/prev?apiKey=%s", a.baseURL, apiKey))
+	return err
+}
+
+func (a *polygonAdapter) Capabilities() SourceCapabilities {
+	return SourceCapabilities{Name: TypePolygon, SupportsStreaming: false}
+}
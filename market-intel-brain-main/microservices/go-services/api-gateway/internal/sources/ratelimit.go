@@ -0,0 +1,54 @@
+package sources
+
+import (
+	"sync"
+	"time"
+)
+
+// tokenBucket is a simple token-bucket rate limiter: tokens refill
+// continuously at RequestsPerSecond up to Burst, and Allow reports whether a
+// token was available without blocking the caller. Registry.Fetch treats a
+// bucket with zero tokens as a rate-limit rejection rather than waiting, so
+// a caller hammering an adapter gets a fast, countable failure instead of
+// stalling the request.
+type tokenBucket struct {
+	mu         sync.Mutex
+	rate       float64 // tokens per second
+	burst      float64
+	tokens     float64
+	lastRefill time.Time
+}
+
+func newTokenBucket(cfg RateLimitConfig) *tokenBucket {
+	burst := float64(cfg.Burst)
+	if burst <= 0 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:       cfg.RequestsPerSecond,
+		burst:      burst,
+		tokens:     burst,
+		lastRefill: time.Now(),
+	}
+}
+
+// Allow consumes one token if available and reports whether it did
+func (b *tokenBucket) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	elapsed := now.Sub(b.lastRefill).Seconds()
+	b.lastRefill = now
+
+	b.tokens += elapsed * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
@@ -0,0 +1,318 @@
+package sources
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/market-intel/api-gateway/pb"
+	"github.com/market-intel/api-gateway/pkg/logger"
+	"github.com/market-intel/api-gateway/pkg/neterr"
+)
+
+// ErrUnknownSource is returned when a caller asks the registry for a
+// source ID it has no adapter registered for; the handlers treat this as a
+// signal to fall back to the core engine rather than a hard failure.
+var ErrUnknownSource = errors.New("no adapter registered for source")
+
+// ErrFingerprintMismatch is returned by Update when the caller's
+// expectedFingerprint no longer matches the registry's current one,
+// meaning another update landed first and the caller must re-read the
+// config before retrying its mutation.
+var ErrFingerprintMismatch = errors.New("adapter registry fingerprint mismatch, reload and retry")
+
+// registryEntry bundles a built adapter with the rate limiter, retry
+// policy, and stats the registry enforces on its behalf
+type registryEntry struct {
+	adapter SourceAdapter
+	limiter *tokenBucket
+	retry   RetryPolicy
+	stats   *adapterStats
+}
+
+// Registry is the pluggable data-source adapter subsystem consulted by the
+// ingestion handlers before they fall back to the core engine. Updates are
+// applied via a fingerprint-guarded compare-and-swap: a caller reads
+// Fingerprint(), computes its mutation against the configs it saw, and
+// calls Update with that fingerprint; a concurrent update in between makes
+// Update fail with ErrFingerprintMismatch instead of silently clobbering it.
+type Registry struct {
+	credStore CredentialStore
+
+	mu          sync.RWMutex
+	fingerprint string
+	configs     []AdapterConfig
+	entries     map[string]*registryEntry
+}
+
+// NewRegistry builds a Registry from the given adapter configs, resolving
+// credentials through credStore
+func NewRegistry(configs []AdapterConfig, credStore CredentialStore) (*Registry, error) {
+	r := &Registry{credStore: credStore}
+
+	entries, err := buildEntries(configs, credStore, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r.configs = cloneConfigs(configs)
+	r.entries = entries
+	r.fingerprint = fingerprintConfigs(configs)
+
+	return r, nil
+}
+
+// buildEntries constructs one registryEntry per enabled config. When prior
+// is non-nil, stats for a source ID that existed before the rebuild are
+// carried over so a hot-reload doesn't reset request/error/latency history.
+func buildEntries(configs []AdapterConfig, credStore CredentialStore, prior map[string]*registryEntry) (map[string]*registryEntry, error) {
+	entries := make(map[string]*registryEntry, len(configs))
+
+	for _, cfg := range configs {
+		if !cfg.Enabled {
+			continue
+		}
+
+		adapter, err := buildAdapter(cfg, credStore)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build adapter %s: %w", cfg.SourceID, err)
+		}
+
+		stats := newAdapterStats()
+		if prior != nil {
+			if old, ok := prior[cfg.SourceID]; ok {
+				stats = old.stats
+			}
+		}
+
+		entries[cfg.SourceID] = &registryEntry{
+			adapter: adapter,
+			limiter: newTokenBucket(cfg.RateLimit),
+			retry:   cfg.Retry,
+			stats:   stats,
+		}
+	}
+
+	return entries, nil
+}
+
+// buildAdapter constructs the concrete SourceAdapter for cfg.Type
+func buildAdapter(cfg AdapterConfig, credStore CredentialStore) (SourceAdapter, error) {
+	switch cfg.Type {
+	case TypeYahooFinance:
+		return newYahooFinanceAdapter(cfg), nil
+	case TypeAlphaVantage:
+		return newAlphaVantageAdapter(cfg, credStore), nil
+	case TypeFinnhub:
+		return newFinnhubAdapter(cfg, credStore), nil
+	case TypePolygon:
+		return newPolygonAdapter(cfg, credStore), nil
+	case TypeRSSNews:
+		return newRSSNewsAdapter(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown adapter type %q", cfg.Type)
+	}
+}
+
+// Get reports whether sourceID has a registered, enabled adapter
+func (r *Registry) Get(sourceID string) (SourceAdapter, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry, ok := r.entries[sourceID]
+	if !ok {
+		return nil, false
+	}
+	return entry.adapter, true
+}
+
+// Fetch resolves sourceID's adapter and fetches symbols through it,
+// enforcing the adapter's declared rate limit and retry policy and
+// recording the outcome in its stats. Returns ErrUnknownSource if sourceID
+// has no registered adapter, which callers treat as a cue to fall back to
+// the core engine.
+func (r *Registry) Fetch(ctx context.Context, sourceID string, symbols []string) ([]pb.MarketData, error) {
+	entry, ok := r.lookup(sourceID)
+	if !ok {
+		return nil, ErrUnknownSource
+	}
+
+	if !entry.limiter.Allow() {
+		entry.stats.recordRateLimited()
+		return nil, fmt.Errorf("rate limit exceeded for source %s", sourceID)
+	}
+
+	start := time.Now()
+	data, err := withRetry(ctx, entry.retry, func() ([]pb.MarketData, error) {
+		return entry.adapter.Fetch(ctx, symbols)
+	})
+	entry.stats.recordRequest(time.Since(start), err)
+
+	return data, err
+}
+
+// FetchNews resolves sourceID's adapter and fetches news through it the
+// same way Fetch does for market data. Returns an error if the adapter
+// doesn't implement NewsAdapter.
+func (r *Registry) FetchNews(ctx context.Context, sourceID string, keywords []string, hoursBack int) ([]pb.NewsItem, error) {
+	entry, ok := r.lookup(sourceID)
+	if !ok {
+		return nil, ErrUnknownSource
+	}
+
+	newsAdapter, ok := entry.adapter.(NewsAdapter)
+	if !ok {
+		return nil, fmt.Errorf("source %s does not support news", sourceID)
+	}
+
+	if !entry.limiter.Allow() {
+		entry.stats.recordRateLimited()
+		return nil, fmt.Errorf("rate limit exceeded for source %s", sourceID)
+	}
+
+	start := time.Now()
+	news, err := withRetry(ctx, entry.retry, func() ([]pb.NewsItem, error) {
+		return newsAdapter.FetchNews(ctx, keywords, hoursBack)
+	})
+	entry.stats.recordRequest(time.Since(start), err)
+
+	return news, err
+}
+
+// withRetry runs fn, retrying with exponential backoff per policy while
+// neterr classifies the error as retryable
+func withRetry[T any](ctx context.Context, policy RetryPolicy, fn func() (T, error)) (T, error) {
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 1
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < policy.MaxAttempts; attempt++ {
+		result, err := fn()
+		if err == nil {
+			return result, nil
+		}
+
+		lastErr = err
+		if !neterr.Classify(err).Retryable() || attempt == policy.MaxAttempts-1 {
+			var zero T
+			return zero, err
+		}
+
+		delay := neterr.Backoff(attempt, policy.InitialDelay, policy.MaxDelay)
+		logger.Warnf("sources: adapter call failed (attempt %d/%d, kind=%s): %v, retrying in %v",
+			attempt+1, policy.MaxAttempts, neterr.Classify(err), err, delay)
+
+		select {
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+
+	var zero T
+	return zero, lastErr
+}
+
+// HealthCheck delegates to sourceID's adapter HealthCheck
+func (r *Registry) HealthCheck(ctx context.Context, sourceID string) error {
+	entry, ok := r.lookup(sourceID)
+	if !ok {
+		return ErrUnknownSource
+	}
+	return entry.adapter.HealthCheck(ctx)
+}
+
+func (r *Registry) lookup(sourceID string) (*registryEntry, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry, ok := r.entries[sourceID]
+	return entry, ok
+}
+
+// Fingerprint returns the hash of the registry's current adapter configs,
+// to be echoed back into Update as expectedFingerprint
+func (r *Registry) Fingerprint() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.fingerprint
+}
+
+// Configs returns a copy of the registry's current adapter configs
+func (r *Registry) Configs() []AdapterConfig {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return cloneConfigs(r.configs)
+}
+
+// Update applies mutate to the registry's configs and rebuilds its
+// adapters, but only if expectedFingerprint still matches the registry's
+// current fingerprint -- a fingerprint-guarded compare-and-swap so two
+// concurrent reload calls can't silently clobber one another. Returns the
+// new fingerprint on success.
+func (r *Registry) Update(expectedFingerprint string, mutate func([]AdapterConfig) []AdapterConfig) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if expectedFingerprint != r.fingerprint {
+		return "", ErrFingerprintMismatch
+	}
+
+	newConfigs := mutate(cloneConfigs(r.configs))
+
+	entries, err := buildEntries(newConfigs, r.credStore, r.entries)
+	if err != nil {
+		return "", err
+	}
+
+	r.configs = cloneConfigs(newConfigs)
+	r.entries = entries
+	r.fingerprint = fingerprintConfigs(newConfigs)
+
+	return r.fingerprint, nil
+}
+
+// Stats returns a snapshot of every registered adapter's request/error/
+// rate-limit counters and latency percentiles, keyed by source ID
+func (r *Registry) Stats() map[string]AdapterStatsSnapshot {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make(map[string]AdapterStatsSnapshot, len(r.entries))
+	for sourceID, entry := range r.entries {
+		out[sourceID] = entry.stats.snapshot()
+	}
+	return out
+}
+
+func cloneConfigs(configs []AdapterConfig) []AdapterConfig {
+	out := make([]AdapterConfig, len(configs))
+	copy(out, configs)
+	return out
+}
+
+// fingerprintConfigs hashes configs in a source-ID-sorted, deterministic
+// JSON encoding so the same logical config always produces the same
+// fingerprint regardless of slice order
+func fingerprintConfigs(configs []AdapterConfig) string {
+	sorted := cloneConfigs(configs)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].SourceID < sorted[j].SourceID })
+
+	payload, err := json.Marshal(sorted)
+	if err != nil {
+		// AdapterConfig is entirely built from primitive fields and cannot
+		// fail to marshal; a panic here would indicate a programming error.
+		panic(fmt.Sprintf("sources: failed to marshal adapter configs: %v", err))
+	}
+
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
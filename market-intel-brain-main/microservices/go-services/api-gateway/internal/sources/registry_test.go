@@ -0,0 +1,158 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/market-intel/api-gateway/pb"
+)
+
+// fakeAdapter lets tests script Fetch outcomes without hitting a real
+// upstream, mirroring pkg/stream's fakeConn/fakeFetcher test doubles.
+type fakeAdapter struct {
+	fetchResponses []fetchResponse
+	calls          int
+}
+
+type fetchResponse struct {
+	data []pb.MarketData
+	err  error
+}
+
+func (f *fakeAdapter) Fetch(ctx context.Context, symbols []string) ([]pb.MarketData, error) {
+	idx := f.calls
+	if idx >= len(f.fetchResponses) {
+		idx = len(f.fetchResponses) - 1
+	}
+	f.calls++
+	resp := f.fetchResponses[idx]
+	return resp.data, resp.err
+}
+
+func (f *fakeAdapter) Stream(ctx context.Context, symbols []string) (<-chan pb.MarketData, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeAdapter) HealthCheck(ctx context.Context) error { return nil }
+
+func (f *fakeAdapter) Capabilities() SourceCapabilities {
+	return SourceCapabilities{Name: "fake"}
+}
+
+// newTestRegistry builds a Registry around a single fake adapter, bypassing
+// buildAdapter/credential resolution so tests can script Fetch directly.
+func newTestRegistry(adapter SourceAdapter, retry RetryPolicy) (*Registry, string) {
+	configs := []AdapterConfig{{SourceID: "fake", Type: TypeYahooFinance, Enabled: true}}
+	entry := &registryEntry{
+		adapter: adapter,
+		limiter: newTokenBucket(RateLimitConfig{RequestsPerSecond: 1000, Burst: 1000}),
+		retry:   retry,
+		stats:   newAdapterStats(),
+	}
+	fingerprint := fingerprintConfigs(configs)
+	r := &Registry{
+		configs:     configs,
+		entries:     map[string]*registryEntry{"fake": entry},
+		fingerprint: fingerprint,
+	}
+	return r, fingerprint
+}
+
+func TestTokenBucketAllowRespectsBurstThenRefills(t *testing.T) {
+	b := newTokenBucket(RateLimitConfig{RequestsPerSecond: 1000, Burst: 2})
+
+	if !b.Allow() || !b.Allow() {
+		t.Fatal("expected the first Burst tokens to be allowed")
+	}
+	if b.Allow() {
+		t.Fatal("expected the bucket to be exhausted after Burst calls")
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	if !b.Allow() {
+		t.Fatal("expected a token to have refilled after waiting")
+	}
+}
+
+func TestRegistryFetchRetriesTransientErrorThenSucceeds(t *testing.T) {
+	adapter := &fakeAdapter{fetchResponses: []fetchResponse{
+		{err: status.Error(codes.Unavailable, "connection refused")},
+		{data: []pb.MarketData{{Symbol: "AAPL", Price: 100}}},
+	}}
+	registry, _ := newTestRegistry(adapter, RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond})
+
+	data, err := registry.Fetch(context.Background(), "fake", []string{"AAPL"})
+	if err != nil {
+		t.Fatalf("expected eventual success, got %v", err)
+	}
+	if len(data) != 1 || data[0].Symbol != "AAPL" {
+		t.Fatalf("unexpected data: %+v", data)
+	}
+
+	stats := registry.Stats()["fake"]
+	if stats.Requests != 1 {
+		t.Fatalf("expected Fetch to record exactly one logical request, got %d", stats.Requests)
+	}
+	if stats.Errors != 0 {
+		t.Fatalf("expected no recorded error once the retry succeeded, got %d", stats.Errors)
+	}
+}
+
+func TestRegistryFetchGivesUpOnPermanentError(t *testing.T) {
+	adapter := &fakeAdapter{fetchResponses: []fetchResponse{
+		{err: status.Error(codes.PermissionDenied, "bad api key")},
+	}}
+	registry, _ := newTestRegistry(adapter, RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond})
+
+	_, err := registry.Fetch(context.Background(), "fake", []string{"AAPL"})
+	if err == nil {
+		t.Fatal("expected a permanent error to be returned without retrying")
+	}
+	if adapter.calls != 1 {
+		t.Fatalf("expected exactly one call for a non-retryable error, adapter was called %d times", adapter.calls)
+	}
+
+	stats := registry.Stats()["fake"]
+	if stats.Errors != 1 {
+		t.Fatalf("expected one recorded error, got %d", stats.Errors)
+	}
+}
+
+func TestRegistryFetchUnknownSourceFallsBack(t *testing.T) {
+	registry, _ := newTestRegistry(&fakeAdapter{}, RetryPolicy{MaxAttempts: 1})
+
+	_, err := registry.Fetch(context.Background(), "not-registered", []string{"AAPL"})
+	if !errors.Is(err, ErrUnknownSource) {
+		t.Fatalf("expected ErrUnknownSource, got %v", err)
+	}
+}
+
+func TestRegistryUpdateFingerprintGuard(t *testing.T) {
+	registry, fingerprint := newTestRegistry(&fakeAdapter{fetchResponses: []fetchResponse{{}}}, RetryPolicy{MaxAttempts: 1})
+
+	if _, err := registry.Update("stale-fingerprint", func(c []AdapterConfig) []AdapterConfig { return c }); !errors.Is(err, ErrFingerprintMismatch) {
+		t.Fatalf("expected ErrFingerprintMismatch for a stale fingerprint, got %v", err)
+	}
+
+	newFingerprint, err := registry.Update(fingerprint, func(configs []AdapterConfig) []AdapterConfig {
+		for i := range configs {
+			configs[i].Enabled = false
+		}
+		return configs
+	})
+	if err != nil {
+		t.Fatalf("expected Update with the current fingerprint to succeed, got %v", err)
+	}
+	if newFingerprint == fingerprint {
+		t.Fatal("expected the fingerprint to change after a config mutation")
+	}
+
+	if _, ok := registry.Get("fake"); ok {
+		t.Fatal("expected the disabled adapter to no longer be registered")
+	}
+}
@@ -0,0 +1,104 @@
+package sources
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/market-intel/api-gateway/pb"
+)
+
+// rssNewsAdapter fetches and scores news items from an RSS/Atom feed (e.g.
+// Google News RSS). It does not produce market data, so Fetch always
+// errors; it implements NewsAdapter for FetchNewsData instead.
+type rssNewsAdapter struct {
+	baseURL string
+}
+
+func newRSSNewsAdapter(cfg AdapterConfig) *rssNewsAdapter {
+	return &rssNewsAdapter{baseURL: cfg.BaseURL}
+}
+
+type rssFeed struct {
+	Channel struct {
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+type rssItem struct {
+	Title   string `xml:"title"`
+	Desc    string `xml:"description"`
+	PubDate string `xml:"pubDate"`
+}
+
+func (a *rssNewsAdapter) Fetch(ctx context.Context, symbols []string) ([]pb.MarketData, error) {
+	return nil, fmt.Errorf("rss_news: this source only provides news, not market data")
+}
+
+func (a *rssNewsAdapter) Stream(ctx context.Context, symbols []string) (<-chan pb.MarketData, error) {
+	return nil, fmt.Errorf("rss_news: this source only provides news, not market data")
+}
+
+func (a *rssNewsAdapter) HealthCheck(ctx context.Context) error {
+	_, err := a.fetchFeed(ctx, nil)
+	return err
+}
+
+func (a *rssNewsAdapter) Capabilities() SourceCapabilities {
+	return SourceCapabilities{Name: TypeRSSNews, SupportsNews: true}
+}
+
+// FetchNews fetches the feed filtered to keywords and returns items
+// published within hoursBack (0 means no age filter)
+func (a *rssNewsAdapter) FetchNews(ctx context.Context, keywords []string, hoursBack int) ([]pb.NewsItem, error) {
+	items, err := a.fetchFeed(ctx, keywords)
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Time{}
+	if hoursBack > 0 {
+		cutoff = time.Now().Add(-time.Duration(hoursBack) * time.Hour)
+	}
+
+	news := make([]pb.NewsItem, 0, len(items))
+	for _, item := range items {
+		published, _ := time.Parse(time.RFC1123Z, item.PubDate)
+		if !cutoff.IsZero() && published.Before(cutoff) {
+			continue
+		}
+
+		news = append(news, pb.NewsItem{
+			Title:     item.Title,
+			Content:   item.Desc,
+			Source:    TypeRSSNews,
+			Timestamp: published.Unix(),
+		})
+	}
+
+	return news, nil
+}
+
+// fetchFeed downloads and parses the RSS feed, optionally scoped to a
+// search query built from keywords
+func (a *rssNewsAdapter) fetchFeed(ctx context.Context, keywords []string) ([]rssItem, error) {
+	feedURL := a.baseURL
+	if len(keywords) > 0 {
+		feedURL = fmt.Sprintf("%s/search?q=%s", a.baseURL, url.QueryEscape(strings.Join(keywords, " ")))
+	}
+
+	body, err := httpGet(ctx, feedURL)
+	if err != nil {
+		return nil, fmt.Errorf("rss_news: failed to fetch feed: %w", err)
+	}
+
+	var feed rssFeed
+	if err := xml.Unmarshal(body, &feed); err != nil {
+		return nil, fmt.Errorf("rss_news: failed to parse feed: %w", err)
+	}
+
+	return feed.Channel.Items, nil
+}
@@ -0,0 +1,85 @@
+package sources
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// maxLatencySamples bounds the rolling window used to estimate p50/p95 per
+// adapter; old samples are dropped FIFO once the window fills.
+const maxLatencySamples = 256
+
+// adapterStats accumulates request/error/rate-limit counters and a rolling
+// latency sample for one registered adapter. Counters are atomic so Fetch
+// calls never contend on the same lock guarding the registry's adapter map.
+type adapterStats struct {
+	requests    int64
+	errors      int64
+	rateLimited int64
+
+	latencyMu sync.Mutex
+	latencies []time.Duration
+	next      int
+}
+
+func newAdapterStats() *adapterStats {
+	return &adapterStats{}
+}
+
+func (s *adapterStats) recordRequest(d time.Duration, err error) {
+	atomic.AddInt64(&s.requests, 1)
+	if err != nil {
+		atomic.AddInt64(&s.errors, 1)
+	}
+
+	s.latencyMu.Lock()
+	defer s.latencyMu.Unlock()
+	if len(s.latencies) < maxLatencySamples {
+		s.latencies = append(s.latencies, d)
+	} else {
+		s.latencies[s.next] = d
+		s.next = (s.next + 1) % maxLatencySamples
+	}
+}
+
+func (s *adapterStats) recordRateLimited() {
+	atomic.AddInt64(&s.rateLimited, 1)
+}
+
+// AdapterStatsSnapshot is the per-adapter stats surface reported by
+// GetIngestionStats
+type AdapterStatsSnapshot struct {
+	Requests     int64   `json:"requests"`
+	Errors       int64   `json:"errors"`
+	RateLimited  int64   `json:"rate_limited"`
+	LatencyP50Ms float64 `json:"latency_p50_ms"`
+	LatencyP95Ms float64 `json:"latency_p95_ms"`
+}
+
+func (s *adapterStats) snapshot() AdapterStatsSnapshot {
+	s.latencyMu.Lock()
+	samples := append([]time.Duration(nil), s.latencies...)
+	s.latencyMu.Unlock()
+
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+
+	return AdapterStatsSnapshot{
+		Requests:     atomic.LoadInt64(&s.requests),
+		Errors:       atomic.LoadInt64(&s.errors),
+		RateLimited:  atomic.LoadInt64(&s.rateLimited),
+		LatencyP50Ms: percentileMs(samples, 0.50),
+		LatencyP95Ms: percentileMs(samples, 0.95),
+	}
+}
+
+// percentileMs returns the p-th percentile (0-1) of sorted as milliseconds,
+// or 0 if sorted is empty
+func percentileMs(sorted []time.Duration, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(p * float64(len(sorted)-1))
+	return float64(sorted[idx]) / float64(time.Millisecond)
+}
@@ -0,0 +1,83 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/market-intel/api-gateway/pb"
+)
+
+// yahooFinanceAdapter fetches quotes from Yahoo Finance's public chart API.
+// It requires no API key and supports only polling, not streaming.
+type yahooFinanceAdapter struct {
+	baseURL string
+}
+
+func newYahooFinanceAdapter(cfg AdapterConfig) *yahooFinanceAdapter {
+	return &yahooFinanceAdapter{baseURL: cfg.BaseURL}
+}
+
+type yahooChartResponse struct {
+	Chart struct {
+		Result []struct {
+			Meta struct {
+				Symbol              string  `json:"symbol"`
+				RegularMarketPrice  float64 `json:"regularMarketPrice"`
+				RegularMarketTime   int64   `json:"regularMarketTime"`
+				RegularMarketVolume int64   `json:"regularMarketVolume"`
+			} `json:"meta"`
+		} `json:"result"`
+		Error *struct {
+			Description string `json:"description"`
+		} `json:"error"`
+	} `json:"chart"`
+}
+
+func (a *yahooFinanceAdapter) Fetch(ctx context.Context, symbols []string) ([]pb.MarketData, error) {
+	data := make([]pb.MarketData, 0, len(symbols))
+
+	for _, symbol := range symbols {
+		url := fmt.Sprintf("%s/v8/finance/chart/%s", a.baseURL, symbol)
+
+		body, err := httpGet(ctx, url)
+		if err != nil {
+			return nil, fmt.Errorf("yahoo_finance: failed to fetch %s: %w", symbol, err)
+		}
+
+		var resp yahooChartResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, fmt.Errorf("yahoo_finance: failed to decode response for %s: %w", symbol, err)
+		}
+		if resp.Chart.Error != nil {
+			return nil, fmt.Errorf("yahoo_finance: %s", resp.Chart.Error.Description)
+		}
+		if len(resp.Chart.Result) == 0 {
+			return nil, fmt.Errorf("yahoo_finance: no result for symbol %s", symbol)
+		}
+
+		meta := resp.Chart.Result[0].Meta
+		data = append(data, pb.MarketData{
+			Symbol:    meta.Symbol,
+			Price:     meta.RegularMarketPrice,
+			Timestamp: meta.RegularMarketTime * 1000,
+			Volume:    meta.RegularMarketVolume,
+			Source:    TypeYahooFinance,
+		})
+	}
+
+	return data, nil
+}
+
+func (a *yahooFinanceAdapter) Stream(ctx context.Context, symbols []string) (<-chan pb.MarketData, error) {
+	return nil, fmt.Errorf("yahoo_finance: streaming is not supported, use the core engine's StreamMarketData instead")
+}
+
+func (a *yahooFinanceAdapter) HealthCheck(ctx context.Context) error {
+	_, err := httpGet(ctx, fmt.Sprintf("%s/v8/finance/chart/AAPL", a.baseURL))
+	return err
+}
+
+func (a *yahooFinanceAdapter) Capabilities() SourceCapabilities {
+	return SourceCapabilities{Name: TypeYahooFinance, SupportsStreaming: false}
+}
@@ -0,0 +1,62 @@
+package pb
+
+import "fmt"
+
+// ResponseStatus is the outcome every core engine RPC reports alongside its
+// payload. Handlers check this before trusting the rest of the response,
+// falling back to the gRPC status code (mapGRPCToHTTPError) only when the
+// call itself failed. Mirrors proto/common.proto's ResponseStatus enum.
+type ResponseStatus int32
+
+const (
+	ResponseStatus_RESPONSE_STATUS_UNSPECIFIED      ResponseStatus = 0
+	ResponseStatus_RESPONSE_STATUS_SUCCESS          ResponseStatus = 1
+	ResponseStatus_RESPONSE_STATUS_ERROR            ResponseStatus = 2
+	ResponseStatus_RESPONSE_STATUS_NOT_FOUND        ResponseStatus = 3
+	ResponseStatus_RESPONSE_STATUS_UNAUTHORIZED     ResponseStatus = 4
+	ResponseStatus_RESPONSE_STATUS_FORBIDDEN        ResponseStatus = 5
+	ResponseStatus_RESPONSE_STATUS_VALIDATION_ERROR ResponseStatus = 6
+	ResponseStatus_RESPONSE_STATUS_INTERNAL_ERROR   ResponseStatus = 7
+)
+
+var responseStatusNames = map[ResponseStatus]string{
+	ResponseStatus_RESPONSE_STATUS_UNSPECIFIED:      "RESPONSE_STATUS_UNSPECIFIED",
+	ResponseStatus_RESPONSE_STATUS_SUCCESS:          "RESPONSE_STATUS_SUCCESS",
+	ResponseStatus_RESPONSE_STATUS_ERROR:            "RESPONSE_STATUS_ERROR",
+	ResponseStatus_RESPONSE_STATUS_NOT_FOUND:        "RESPONSE_STATUS_NOT_FOUND",
+	ResponseStatus_RESPONSE_STATUS_UNAUTHORIZED:     "RESPONSE_STATUS_UNAUTHORIZED",
+	ResponseStatus_RESPONSE_STATUS_FORBIDDEN:        "RESPONSE_STATUS_FORBIDDEN",
+	ResponseStatus_RESPONSE_STATUS_VALIDATION_ERROR: "RESPONSE_STATUS_VALIDATION_ERROR",
+	ResponseStatus_RESPONSE_STATUS_INTERNAL_ERROR:   "RESPONSE_STATUS_INTERNAL_ERROR",
+}
+
+func (s ResponseStatus) String() string {
+	if name, ok := responseStatusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("RESPONSE_STATUS_UNKNOWN(%d)", int32(s))
+}
+
+type Empty struct{}
+
+// HealthCheckRequest/HealthCheckResponse mirror the shape of
+// grpc.health.v1.Health's Check RPC (service_name in, serving bool + status
+// string out) with a couple of gateway-specific extras so
+// IngestionService.HealthCheck stays drop-in compatible with standard
+// health probes.
+type HealthCheckRequest struct {
+	ServiceName string            `json:"service_name"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+type HealthCheckResponse struct {
+	Healthy bool              `json:"healthy"`
+	Status  string            `json:"status"`
+	Version string            `json:"version,omitempty"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+type EngineStatusResponse struct {
+	Status  ResponseStatus `json:"status"`
+	Message string         `json:"message"`
+}
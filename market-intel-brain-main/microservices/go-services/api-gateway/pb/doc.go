@@ -0,0 +1,12 @@
+// Package pb holds the Go types for the core engine's gRPC surface, defined
+// in proto/*.proto: MarketDataService, NewsService, and IngestionService.
+//
+// These are hand-maintained to mirror the proto schema field-for-field
+// (same field names/JSON tags as the original placeholder types) because
+// this checkout has no protoc/buf toolchain available to run `make proto`.
+// They are plain structs and thin gRPC client wrappers, not wire-compatible
+// protobuf messages -- there is no generated Marshal/Unmarshal or
+// ProtoReflect support. Once `make proto` is run somewhere with buf
+// installed, it regenerates this package from proto/*.proto and these files
+// should be deleted in favor of the generated output.
+package pb
@@ -0,0 +1,104 @@
+package pb
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+type ConnectDataSourceRequest struct {
+	SourceId   string `json:"source_id"`
+	ApiKey     string `json:"api_key"`
+	SourceType string `json:"source_type"`
+}
+
+type ConnectDataSourceResponse struct {
+	Status    ResponseStatus `json:"status"`
+	Message   string         `json:"message"`
+	Connected bool           `json:"connected"`
+}
+
+type GetIngestionStatsRequest struct {
+	SourceId string `json:"source_id"`
+}
+
+// DataSourceInfo reports one configured source's adapter kind and live
+// connection state, keyed by source ID in IngestionStats.DataSources.
+type DataSourceInfo struct {
+	Type      string `json:"type"`
+	Enabled   bool   `json:"enabled"`
+	Connected bool   `json:"connected"`
+}
+
+type IngestionStats struct {
+	ActiveConnections    int32                      `json:"active_connections"`
+	ConfiguredSources    int32                      `json:"configured_sources"`
+	MarketDataBufferSize int32                      `json:"market_data_buffer_size"`
+	NewsBufferSize       int32                      `json:"news_buffer_size"`
+	MaxBufferSize        int32                      `json:"max_buffer_size"`
+	DataSources          map[string]*DataSourceInfo `json:"data_sources,omitempty"`
+}
+
+type GetIngestionStatsResponse struct {
+	Status  ResponseStatus  `json:"status"`
+	Message string          `json:"message"`
+	Stats   *IngestionStats `json:"stats,omitempty"`
+}
+
+const (
+	ingestionServiceConnectDataSourceMethod = "/marketintel.v1.IngestionService/ConnectDataSource"
+	ingestionServiceGetIngestionStatsMethod = "/marketintel.v1.IngestionService/GetIngestionStats"
+	ingestionServiceGetStatusMethod         = "/marketintel.v1.IngestionService/GetStatus"
+	ingestionServiceHealthCheckMethod       = "/marketintel.v1.IngestionService/HealthCheck"
+)
+
+// IngestionServiceClient manages the core engine's data source connections
+// and reports on their state. Its HealthCheck RPC mirrors
+// grpc.health.v1.Health's Check contract so standard health-probe tooling
+// can talk to it directly. See proto/ingestion.proto.
+type IngestionServiceClient interface {
+	ConnectDataSource(ctx context.Context, in *ConnectDataSourceRequest, opts ...grpc.CallOption) (*ConnectDataSourceResponse, error)
+	GetIngestionStats(ctx context.Context, in *GetIngestionStatsRequest, opts ...grpc.CallOption) (*GetIngestionStatsResponse, error)
+	GetStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*EngineStatusResponse, error)
+	HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error)
+}
+
+type ingestionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewIngestionServiceClient(cc grpc.ClientConnInterface) IngestionServiceClient {
+	return &ingestionServiceClient{cc}
+}
+
+func (c *ingestionServiceClient) ConnectDataSource(ctx context.Context, in *ConnectDataSourceRequest, opts ...grpc.CallOption) (*ConnectDataSourceResponse, error) {
+	out := new(ConnectDataSourceResponse)
+	if err := c.cc.Invoke(ctx, ingestionServiceConnectDataSourceMethod, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ingestionServiceClient) GetIngestionStats(ctx context.Context, in *GetIngestionStatsRequest, opts ...grpc.CallOption) (*GetIngestionStatsResponse, error) {
+	out := new(GetIngestionStatsResponse)
+	if err := c.cc.Invoke(ctx, ingestionServiceGetIngestionStatsMethod, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ingestionServiceClient) GetStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*EngineStatusResponse, error) {
+	out := new(EngineStatusResponse)
+	if err := c.cc.Invoke(ctx, ingestionServiceGetStatusMethod, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ingestionServiceClient) HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error) {
+	out := new(HealthCheckResponse)
+	if err := c.cc.Invoke(ctx, ingestionServiceHealthCheckMethod, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
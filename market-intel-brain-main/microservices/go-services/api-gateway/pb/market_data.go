@@ -0,0 +1,157 @@
+package pb
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// MarketData is a single priced tick for a symbol. Timestamp is Unix millis
+// (not a google.protobuf.Timestamp) to match the wire shape the handlers,
+// MQTT adapter, and WebSocket/SSE encoders already assume.
+type MarketData struct {
+	Symbol         string            `json:"symbol"`
+	Price          float64           `json:"price"`
+	Timestamp      int64             `json:"timestamp"`
+	Volume         int64             `json:"volume"`
+	Source         string            `json:"source"`
+	AdditionalData map[string]string `json:"additional_data,omitempty"`
+}
+
+type FetchMarketDataRequest struct {
+	Symbols  []string `json:"symbols"`
+	SourceId string   `json:"source_id"`
+}
+
+type FetchMarketDataResponse struct {
+	Status     ResponseStatus `json:"status"`
+	Message    string         `json:"message"`
+	MarketData []MarketData   `json:"market_data,omitempty"`
+}
+
+type GetMarketDataBufferRequest struct {
+	SourceId string `json:"source_id"`
+	Symbol   string `json:"symbol,omitempty"`
+	Limit    int32  `json:"limit"`
+}
+
+type GetMarketDataBufferResponse struct {
+	Status     ResponseStatus `json:"status"`
+	Message    string         `json:"message"`
+	MarketData []MarketData   `json:"market_data,omitempty"`
+}
+
+// StreamMarketDataRequest opens a server-streaming tail of ticks for the
+// given symbols, used by the WebSocket subscription subprotocol.
+type StreamMarketDataRequest struct {
+	Symbols  []string `json:"symbols"`
+	SourceId string   `json:"source_id"`
+}
+
+// FetchHistoricalRangeRequest fetches one chunk of historical bars for a
+// single symbol, bounded by [StartMs, EndMs). Callers split a larger range
+// into chunks sized by a bar-count budget before issuing these.
+type FetchHistoricalRangeRequest struct {
+	Symbol   string `json:"symbol"`
+	SourceId string `json:"source_id"`
+	Interval string `json:"interval"`
+	StartMs  int64  `json:"start_ms"`
+	EndMs    int64  `json:"end_ms"`
+}
+
+type FetchHistoricalRangeResponse struct {
+	Status     ResponseStatus `json:"status"`
+	Message    string         `json:"message"`
+	MarketData []MarketData   `json:"market_data,omitempty"`
+}
+
+// MarketDataStream is the receive side of the StreamMarketData RPC: a
+// server-streaming call yields one MarketData per Recv until the stream
+// ends (io.EOF) or the request context is canceled.
+type MarketDataStream interface {
+	Recv() (*MarketData, error)
+}
+
+// MarketDataService_StreamMarketDataClient is the concrete stream handle
+// MarketDataServiceClient.StreamMarketData returns; it satisfies
+// MarketDataStream and additionally exposes the underlying grpc.ClientStream.
+type MarketDataService_StreamMarketDataClient interface {
+	Recv() (*MarketData, error)
+	grpc.ClientStream
+}
+
+type marketDataServiceStreamMarketDataClient struct {
+	grpc.ClientStream
+}
+
+func (x *marketDataServiceStreamMarketDataClient) Recv() (*MarketData, error) {
+	m := new(MarketData)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+const (
+	marketDataServiceFetchMarketDataMethod      = "/marketintel.v1.MarketDataService/FetchMarketData"
+	marketDataServiceStreamMarketDataMethod     = "/marketintel.v1.MarketDataService/StreamMarketData"
+	marketDataServiceGetMarketDataBufferMethod  = "/marketintel.v1.MarketDataService/GetMarketDataBuffer"
+	marketDataServiceFetchHistoricalRangeMethod = "/marketintel.v1.MarketDataService/FetchHistoricalRange"
+)
+
+// MarketDataServiceClient is the core engine's price-data surface: one-shot
+// fetches, a long-lived tick stream, buffer reads, and chunked historical
+// backfill. See proto/market_data.proto.
+type MarketDataServiceClient interface {
+	FetchMarketData(ctx context.Context, in *FetchMarketDataRequest, opts ...grpc.CallOption) (*FetchMarketDataResponse, error)
+	StreamMarketData(ctx context.Context, in *StreamMarketDataRequest, opts ...grpc.CallOption) (MarketDataService_StreamMarketDataClient, error)
+	GetMarketDataBuffer(ctx context.Context, in *GetMarketDataBufferRequest, opts ...grpc.CallOption) (*GetMarketDataBufferResponse, error)
+	FetchHistoricalRange(ctx context.Context, in *FetchHistoricalRangeRequest, opts ...grpc.CallOption) (*FetchHistoricalRangeResponse, error)
+}
+
+type marketDataServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMarketDataServiceClient(cc grpc.ClientConnInterface) MarketDataServiceClient {
+	return &marketDataServiceClient{cc}
+}
+
+func (c *marketDataServiceClient) FetchMarketData(ctx context.Context, in *FetchMarketDataRequest, opts ...grpc.CallOption) (*FetchMarketDataResponse, error) {
+	out := new(FetchMarketDataResponse)
+	if err := c.cc.Invoke(ctx, marketDataServiceFetchMarketDataMethod, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketDataServiceClient) StreamMarketData(ctx context.Context, in *StreamMarketDataRequest, opts ...grpc.CallOption) (MarketDataService_StreamMarketDataClient, error) {
+	stream, err := c.cc.NewStream(ctx, &grpc.StreamDesc{StreamName: "StreamMarketData", ServerStreams: true}, marketDataServiceStreamMarketDataMethod, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &marketDataServiceStreamMarketDataClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+func (c *marketDataServiceClient) GetMarketDataBuffer(ctx context.Context, in *GetMarketDataBufferRequest, opts ...grpc.CallOption) (*GetMarketDataBufferResponse, error) {
+	out := new(GetMarketDataBufferResponse)
+	if err := c.cc.Invoke(ctx, marketDataServiceGetMarketDataBufferMethod, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketDataServiceClient) FetchHistoricalRange(ctx context.Context, in *FetchHistoricalRangeRequest, opts ...grpc.CallOption) (*FetchHistoricalRangeResponse, error) {
+	out := new(FetchHistoricalRangeResponse)
+	if err := c.cc.Invoke(ctx, marketDataServiceFetchHistoricalRangeMethod, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
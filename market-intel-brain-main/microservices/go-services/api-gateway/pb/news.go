@@ -0,0 +1,130 @@
+package pb
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+type NewsData struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Content   string `json:"content"`
+	Source    string `json:"source"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// NewsItem is the richer news payload shape used by the ingestion handlers
+// and MQTT adapter (scored for downstream relevance ranking).
+type NewsItem struct {
+	Title          string  `json:"title"`
+	Content        string  `json:"content"`
+	Source         string  `json:"source"`
+	Timestamp      int64   `json:"timestamp"`
+	SentimentScore float64 `json:"sentiment_score"`
+	RelevanceScore float64 `json:"relevance_score"`
+}
+
+type FetchNewsDataRequest struct {
+	SourceId  string   `json:"source_id"`
+	Keywords  []string `json:"keywords,omitempty"`
+	HoursBack int32    `json:"hours_back,omitempty"`
+	Limit     int32    `json:"limit"`
+}
+
+type FetchNewsDataResponse struct {
+	Status    ResponseStatus `json:"status"`
+	Message   string         `json:"message"`
+	NewsItems []NewsItem     `json:"news_items,omitempty"`
+}
+
+type GetNewsBufferRequest struct {
+	SourceId string   `json:"source_id"`
+	Keywords []string `json:"keywords,omitempty"`
+	Limit    int32    `json:"limit"`
+}
+
+type GetNewsBufferResponse struct {
+	Status    ResponseStatus `json:"status"`
+	Message   string         `json:"message"`
+	NewsItems []NewsItem     `json:"news_items,omitempty"`
+}
+
+// SubscribeNewsRequest is sent once to open a SubscribeNews stream and again
+// whenever the client wants to change its keyword filter without
+// reconnecting.
+type SubscribeNewsRequest struct {
+	Keywords []string `json:"keywords"`
+}
+
+// NewsService_SubscribeNewsClient is the bidi stream handle
+// NewsServiceClient.SubscribeNews returns: Send re-filters the live
+// subscription, Recv yields matching NewsItems as they arrive.
+type NewsService_SubscribeNewsClient interface {
+	Send(*SubscribeNewsRequest) error
+	Recv() (*NewsItem, error)
+	grpc.ClientStream
+}
+
+type newsServiceSubscribeNewsClient struct {
+	grpc.ClientStream
+}
+
+func (x *newsServiceSubscribeNewsClient) Send(m *SubscribeNewsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *newsServiceSubscribeNewsClient) Recv() (*NewsItem, error) {
+	m := new(NewsItem)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+const (
+	newsServiceFetchNewsDataMethod = "/marketintel.v1.NewsService/FetchNewsData"
+	newsServiceGetNewsBufferMethod = "/marketintel.v1.NewsService/GetNewsBuffer"
+	newsServiceSubscribeNewsMethod = "/marketintel.v1.NewsService/SubscribeNews"
+)
+
+// NewsServiceClient is the core engine's news surface: one-shot fetches,
+// buffer reads, and a live keyword-filtered subscription. See
+// proto/news.proto.
+type NewsServiceClient interface {
+	FetchNewsData(ctx context.Context, in *FetchNewsDataRequest, opts ...grpc.CallOption) (*FetchNewsDataResponse, error)
+	GetNewsBuffer(ctx context.Context, in *GetNewsBufferRequest, opts ...grpc.CallOption) (*GetNewsBufferResponse, error)
+	SubscribeNews(ctx context.Context, opts ...grpc.CallOption) (NewsService_SubscribeNewsClient, error)
+}
+
+type newsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNewsServiceClient(cc grpc.ClientConnInterface) NewsServiceClient {
+	return &newsServiceClient{cc}
+}
+
+func (c *newsServiceClient) FetchNewsData(ctx context.Context, in *FetchNewsDataRequest, opts ...grpc.CallOption) (*FetchNewsDataResponse, error) {
+	out := new(FetchNewsDataResponse)
+	if err := c.cc.Invoke(ctx, newsServiceFetchNewsDataMethod, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newsServiceClient) GetNewsBuffer(ctx context.Context, in *GetNewsBufferRequest, opts ...grpc.CallOption) (*GetNewsBufferResponse, error) {
+	out := new(GetNewsBufferResponse)
+	if err := c.cc.Invoke(ctx, newsServiceGetNewsBufferMethod, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *newsServiceClient) SubscribeNews(ctx context.Context, opts ...grpc.CallOption) (NewsService_SubscribeNewsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &grpc.StreamDesc{StreamName: "SubscribeNews", ServerStreams: true, ClientStreams: true}, newsServiceSubscribeNewsMethod, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &newsServiceSubscribeNewsClient{stream}, nil
+}
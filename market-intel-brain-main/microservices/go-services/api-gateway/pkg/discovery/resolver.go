@@ -0,0 +1,264 @@
+// Package discovery registers gRPC resolver.Builders for the discovery
+// backends ServiceEndpoint.Target can name: consul://service?tag=grpc and
+// etcd://prefix/service. Both poll their backend's HTTP API on an interval
+// rather than holding a long-lived watch connection, since neither the
+// Consul nor etcd client libraries are vendored into this module; add a
+// watch-based resolver if poll latency ever becomes a problem.
+package discovery
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/resolver"
+
+	"github.com/market-intel/api-gateway/pkg/logger"
+)
+
+const pollInterval = 10 * time.Second
+
+func init() {
+	resolver.Register(&consulBuilder{})
+	resolver.Register(&etcdBuilder{})
+}
+
+// consulBuilder implements resolver.Builder for consul://<service>?tag=<tag>
+// targets, listing healthy instances via Consul's HTTP health API.
+type consulBuilder struct{}
+
+func (*consulBuilder) Scheme() string { return "consul" }
+
+func (b *consulBuilder) Build(target resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	service := target.URL.Host
+	if service == "" {
+		service = target.Endpoint()
+	}
+	if service == "" {
+		return nil, fmt.Errorf("discovery: consul target %q has no service name", target.URL.String())
+	}
+
+	agentAddr := getEnv("CONSUL_HTTP_ADDR", "http://localhost:8500")
+	tag := target.URL.Query().Get("tag")
+
+	r := &pollingResolver{
+		cc: cc,
+		list: func(ctx context.Context) ([]resolver.Address, error) {
+			return listConsulInstances(ctx, agentAddr, service, tag)
+		},
+	}
+	r.start()
+	return r, nil
+}
+
+// consulHealthEntry mirrors the fields used from /v1/health/service/<service>.
+type consulHealthEntry struct {
+	Service struct {
+		Address string `json:"Address"`
+		Port    int    `json:"Port"`
+	} `json:"Service"`
+}
+
+func listConsulInstances(ctx context.Context, agentAddr, service, tag string) ([]resolver.Address, error) {
+	u := fmt.Sprintf("%s/v1/health/service/%s?passing=true", strings.TrimRight(agentAddr, "/"), url.PathEscape(service))
+	if tag != "" {
+		u += "&tag=" + url.QueryEscape(tag)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("discovery: consul health query for %s failed: %w", service, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("discovery: consul health query for %s returned status %d", service, resp.StatusCode)
+	}
+
+	var entries []consulHealthEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("discovery: decoding consul response for %s: %w", service, err)
+	}
+
+	addrs := make([]resolver.Address, 0, len(entries))
+	for _, e := range entries {
+		if e.Service.Address == "" || e.Service.Port == 0 {
+			continue
+		}
+		addrs = append(addrs, resolver.Address{Addr: fmt.Sprintf("%s:%d", e.Service.Address, e.Service.Port)})
+	}
+	return addrs, nil
+}
+
+// etcdBuilder implements resolver.Builder for etcd://<key-prefix> targets,
+// listing instances registered as JSON values (`{"addr":"host:port"}`) under
+// that prefix via etcd's HTTP gRPC-gateway range API.
+type etcdBuilder struct{}
+
+func (*etcdBuilder) Scheme() string { return "etcd" }
+
+func (b *etcdBuilder) Build(target resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	prefix := strings.TrimPrefix(target.URL.Host+target.URL.Path, "/")
+	if prefix == "" {
+		return nil, fmt.Errorf("discovery: etcd target %q has no key prefix", target.URL.String())
+	}
+
+	etcdAddr := getEnv("ETCD_HTTP_ADDR", "http://localhost:2379")
+
+	r := &pollingResolver{
+		cc: cc,
+		list: func(ctx context.Context) ([]resolver.Address, error) {
+			return listEtcdInstances(ctx, etcdAddr, prefix)
+		},
+	}
+	r.start()
+	return r, nil
+}
+
+type etcdRangeResponse struct {
+	Kvs []struct {
+		Value string `json:"value"`
+	} `json:"kvs"`
+}
+
+type etcdInstance struct {
+	Addr string `json:"addr"`
+}
+
+func listEtcdInstances(ctx context.Context, etcdAddr, prefix string) ([]resolver.Address, error) {
+	payload, err := json.Marshal(map[string]string{
+		"key":       base64.StdEncoding.EncodeToString([]byte(prefix)),
+		"range_end": base64.StdEncoding.EncodeToString(prefixRangeEnd([]byte(prefix))),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u := strings.TrimRight(etcdAddr, "/") + "/v3/kv/range"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, strings.NewReader(string(payload)))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("discovery: etcd range query for %s failed: %w", prefix, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("discovery: etcd range query for %s returned status %d", prefix, resp.StatusCode)
+	}
+
+	var out etcdRangeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("discovery: decoding etcd response for %s: %w", prefix, err)
+	}
+
+	addrs := make([]resolver.Address, 0, len(out.Kvs))
+	for _, kv := range out.Kvs {
+		raw, err := base64.StdEncoding.DecodeString(kv.Value)
+		if err != nil {
+			logger.Warnf("discovery: skipping undecodable etcd value under %s: %v", prefix, err)
+			continue
+		}
+		var inst etcdInstance
+		if err := json.Unmarshal(raw, &inst); err != nil || inst.Addr == "" {
+			logger.Warnf("discovery: skipping malformed etcd instance under %s: %v", prefix, err)
+			continue
+		}
+		addrs = append(addrs, resolver.Address{Addr: inst.Addr})
+	}
+	return addrs, nil
+}
+
+// prefixRangeEnd computes etcd's conventional "end of prefix" key, the
+// smallest key that is not itself prefixed by prefix, so a range query
+// [prefix, end) returns exactly the keys under prefix.
+func prefixRangeEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return []byte{0} // prefix was all 0xff bytes; range over everything after it
+}
+
+// pollingResolver re-lists addresses on pollInterval and on ResolveNow,
+// pushing each result to cc.UpdateState. It's shared by the consul and
+// etcd builders, which differ only in how they list addresses.
+type pollingResolver struct {
+	cc      resolver.ClientConn
+	list    func(ctx context.Context) ([]resolver.Address, error)
+	cancel  context.CancelFunc
+	resolve chan struct{}
+}
+
+func (r *pollingResolver) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.resolve = make(chan struct{}, 1)
+
+	go func() {
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+
+		r.resolveOnce(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.resolveOnce(ctx)
+			case <-r.resolve:
+				r.resolveOnce(ctx)
+			}
+		}
+	}()
+}
+
+func (r *pollingResolver) resolveOnce(ctx context.Context) {
+	listCtx, cancel := context.WithTimeout(ctx, pollInterval)
+	defer cancel()
+
+	addrs, err := r.list(listCtx)
+	if err != nil {
+		r.cc.ReportError(err)
+		return
+	}
+	if err := r.cc.UpdateState(resolver.State{Addresses: addrs}); err != nil {
+		logger.Warnf("discovery: UpdateState rejected: %v", err)
+	}
+}
+
+func (r *pollingResolver) ResolveNow(resolver.ResolveNowOptions) {
+	select {
+	case r.resolve <- struct{}{}:
+	default:
+	}
+}
+
+func (r *pollingResolver) Close() {
+	r.cancel()
+}
+
+func getEnv(key, defaultValue string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return defaultValue
+}
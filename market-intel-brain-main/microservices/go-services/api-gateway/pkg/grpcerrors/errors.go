@@ -0,0 +1,81 @@
+// Typed gRPC Domain Errors
+// Defines the small set of business error kinds a gRPC handler can return
+// distinctly from a generic failure, so callers don't have to string-match
+// on err.Error() (see pkg/neterr and pkg/resilience's grpcErrorResponse for
+// the same problem solved on the inbound/HTTP side of this gateway). The
+// interceptors in interceptors.go encode these to a *status.Status on the
+// server side and decode them back on the client side.
+
+package grpcerrors
+
+import "time"
+
+// NotFoundError reports that a requested resource (symbol, source, news
+// item, ...) does not exist. It is not a circuit-breaker failure: asking
+// for a symbol the core engine has never heard of says nothing about the
+// core engine's health.
+type NotFoundError struct {
+	Resource string
+	Message  string
+}
+
+func (e *NotFoundError) Error() string {
+	if e.Resource != "" {
+		return e.Resource + " not found: " + e.Message
+	}
+	return e.Message
+}
+
+// PermissionDeniedError reports that the caller's credentials were valid
+// but insufficient for the request.
+type PermissionDeniedError struct {
+	Message string
+}
+
+func (e *PermissionDeniedError) Error() string { return e.Message }
+
+// RateLimitedError reports that the upstream rejected the request for
+// exceeding a rate limit. RetryAfter is the server's suggested backoff, if
+// it sent one; zero means no hint was given.
+type RateLimitedError struct {
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitedError) Error() string { return e.Message }
+
+// ValidationError reports that the request itself was malformed.
+// Violations maps field name to a human-readable description, the same
+// shape google.rpc.BadRequest uses.
+type ValidationError struct {
+	Message    string
+	Violations map[string]string
+}
+
+func (e *ValidationError) Error() string { return e.Message }
+
+// UpstreamUnavailableError reports that a dependency of the service
+// handling the RPC (e.g. the core engine's own database or a data source
+// it proxies to) is down -- distinct from the gRPC connection itself being
+// unavailable, which already surfaces as codes.Unavailable with no details
+// and is handled by the circuit breaker the normal way.
+type UpstreamUnavailableError struct {
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (e *UpstreamUnavailableError) Error() string { return e.Message }
+
+// IsBusinessError reports whether err wraps one of this package's domain
+// error types anywhere in its chain (as opposed to a transport-level
+// failure like a dropped connection or deadline exceeded). It's meant to be
+// passed to resilience.WithFailureClassifier so a circuit breaker stops
+// counting expected business rejections -- a request for a symbol that
+// doesn't exist -- as evidence the upstream is unhealthy. err is typically
+// still wrapped (e.g. fmt.Errorf("...: %w", err)) by the caller that
+// received it from a decoded client interceptor, so this checks the chain
+// rather than err's concrete type.
+func IsBusinessError(err error) bool {
+	_, ok := asDomainError(err)
+	return ok
+}
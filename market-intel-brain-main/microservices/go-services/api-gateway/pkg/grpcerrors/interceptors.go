@@ -0,0 +1,122 @@
+// gRPC Error-Translating Interceptors
+// Paired client/server interceptors that carry this package's typed domain
+// errors across a gRPC call uncorrupted: the server side encodes them onto
+// the returned status (see status.go's ToStatus), and the client side
+// decodes them back (FromStatus) so callers like
+// internal/services.grpcCoreEngineClient get back a *grpcerrors.NotFoundError
+// they can errors.As against, instead of an opaque status string. Modeled
+// on Teleport's api/utils/grpc/interceptors, which solves the same problem
+// for its own typed trace errors.
+
+package grpcerrors
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"google.golang.org/grpc"
+)
+
+// ErrorUnaryClientInterceptor decodes the status of a failed unary call
+// back into one of this package's typed errors, wherever the server
+// attached matching details via ErrorUnaryServerInterceptor.
+func ErrorUnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil {
+			return nil
+		}
+		return FromStatus(err)
+	}
+}
+
+// ErrorStreamClientInterceptor is ErrorUnaryClientInterceptor's streaming
+// counterpart. It decodes a failure to open the stream the same way, and
+// wraps a successfully opened stream so a failure surfaced later through
+// RecvMsg is decoded too.
+func ErrorStreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, FromStatus(err)
+		}
+		return &errorDecodingClientStream{ClientStream: stream}, nil
+	}
+}
+
+// errorDecodingClientStream decodes RecvMsg's returned error the same way
+// ErrorUnaryClientInterceptor decodes a unary call's error. io.EOF (a
+// stream ending normally) is passed through untouched since it isn't a
+// status at all.
+type errorDecodingClientStream struct {
+	grpc.ClientStream
+}
+
+func (s *errorDecodingClientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == nil || err == io.EOF {
+		return err
+	}
+	return FromStatus(err)
+}
+
+// ErrorUnaryServerInterceptor encodes a handler's returned error onto the
+// response status, via ToStatus, whenever it's one of this package's typed
+// domain errors. A plain error (not one of ours) is returned unchanged,
+// which gRPC will in turn report as an opaque Internal/Unknown status the
+// same as it always has.
+func ErrorUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		if domainErr, ok := asDomainError(err); ok {
+			return resp, ToStatus(domainErr).Err()
+		}
+		return resp, err
+	}
+}
+
+// ErrorStreamServerInterceptor is ErrorUnaryServerInterceptor's streaming
+// counterpart, translating the error a streaming handler returns after it's
+// done sending.
+func ErrorStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		if err == nil {
+			return nil
+		}
+		if domainErr, ok := asDomainError(err); ok {
+			return ToStatus(domainErr).Err()
+		}
+		return err
+	}
+}
+
+// asDomainError reports whether err wraps one of this package's typed
+// errors anywhere in its chain, returning that error for ToStatus to encode.
+func asDomainError(err error) (error, bool) {
+	var notFound *NotFoundError
+	if errors.As(err, &notFound) {
+		return notFound, true
+	}
+	var permissionDenied *PermissionDeniedError
+	if errors.As(err, &permissionDenied) {
+		return permissionDenied, true
+	}
+	var rateLimited *RateLimitedError
+	if errors.As(err, &rateLimited) {
+		return rateLimited, true
+	}
+	var validation *ValidationError
+	if errors.As(err, &validation) {
+		return validation, true
+	}
+	var upstreamUnavailable *UpstreamUnavailableError
+	if errors.As(err, &upstreamUnavailable) {
+		return upstreamUnavailable, true
+	}
+	return nil, false
+}
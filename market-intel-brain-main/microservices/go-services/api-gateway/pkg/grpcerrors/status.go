@@ -0,0 +1,150 @@
+package grpcerrors
+
+import (
+	"time"
+
+	"github.com/market-intel/api-gateway/pkg/logger"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// errorInfoDomain is the ErrorInfo.Domain every status ToStatus produces
+// carries, so FromStatus doesn't mistake an ErrorInfo detail attached by
+// some unrelated service for one of this package's kinds.
+const errorInfoDomain = "market-intel.api-gateway"
+
+// reason values, carried in errdetails.ErrorInfo.Reason, identify which of
+// this package's error types a status was encoded from.
+const (
+	reasonNotFound            = "NOT_FOUND"
+	reasonPermissionDenied    = "PERMISSION_DENIED"
+	reasonRateLimited         = "RATE_LIMITED"
+	reasonValidationError     = "VALIDATION_ERROR"
+	reasonUpstreamUnavailable = "UPSTREAM_UNAVAILABLE"
+)
+
+// ToStatus encodes err as a *status.Status with the gRPC code matching its
+// type and an errdetails.ErrorInfo (plus BadRequest/RetryInfo, where
+// applicable) detail FromStatus can use to recover the original error's
+// type and message. A NotFoundError's Resource is carried only inside the
+// status message, so FromStatus returns it with Resource empty. Any error
+// that isn't one of this package's types passes through status.Convert
+// unchanged, so a handler returning a plain error gets codes.Unknown.
+func ToStatus(err error) *status.Status {
+	switch e := err.(type) {
+	case *NotFoundError:
+		return withDetails(status.New(codes.NotFound, e.Error()),
+			&errdetails.ErrorInfo{Reason: reasonNotFound, Domain: errorInfoDomain})
+
+	case *PermissionDeniedError:
+		return withDetails(status.New(codes.PermissionDenied, e.Error()),
+			&errdetails.ErrorInfo{Reason: reasonPermissionDenied, Domain: errorInfoDomain})
+
+	case *RateLimitedError:
+		st := withDetails(status.New(codes.ResourceExhausted, e.Error()),
+			&errdetails.ErrorInfo{Reason: reasonRateLimited, Domain: errorInfoDomain})
+		return withRetryInfo(st, e.RetryAfter)
+
+	case *ValidationError:
+		violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(e.Violations))
+		for field, desc := range e.Violations {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: field, Description: desc})
+		}
+		st := withDetails(status.New(codes.InvalidArgument, e.Error()),
+			&errdetails.ErrorInfo{Reason: reasonValidationError, Domain: errorInfoDomain})
+		return withDetails(st, &errdetails.BadRequest{FieldViolations: violations})
+
+	case *UpstreamUnavailableError:
+		st := withDetails(status.New(codes.Unavailable, e.Error()),
+			&errdetails.ErrorInfo{Reason: reasonUpstreamUnavailable, Domain: errorInfoDomain})
+		return withRetryInfo(st, e.RetryAfter)
+
+	default:
+		return status.Convert(err)
+	}
+}
+
+// FromStatus decodes err, a gRPC status error, back into one of this
+// package's typed errors if its ErrorInfo detail carries a reason this
+// package encoded (see ToStatus). Otherwise it returns err unchanged, so
+// callers can always run their error through FromStatus and get back
+// either a typed domain error or the original error untouched.
+func FromStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	var info *errdetails.ErrorInfo
+	var retryInfo *errdetails.RetryInfo
+	var badRequest *errdetails.BadRequest
+	for _, d := range st.Details() {
+		switch detail := d.(type) {
+		case *errdetails.ErrorInfo:
+			info = detail
+		case *errdetails.RetryInfo:
+			retryInfo = detail
+		case *errdetails.BadRequest:
+			badRequest = detail
+		}
+	}
+
+	if info == nil || info.Domain != errorInfoDomain {
+		return err
+	}
+
+	switch info.Reason {
+	case reasonNotFound:
+		return &NotFoundError{Resource: info.Metadata["resource"], Message: st.Message()}
+	case reasonPermissionDenied:
+		return &PermissionDeniedError{Message: st.Message()}
+	case reasonRateLimited:
+		return &RateLimitedError{Message: st.Message(), RetryAfter: retryAfter(retryInfo)}
+	case reasonValidationError:
+		violations := make(map[string]string, len(badRequest.GetFieldViolations()))
+		for _, v := range badRequest.GetFieldViolations() {
+			violations[v.GetField()] = v.GetDescription()
+		}
+		return &ValidationError{Message: st.Message(), Violations: violations}
+	case reasonUpstreamUnavailable:
+		return &UpstreamUnavailableError{Message: st.Message(), RetryAfter: retryAfter(retryInfo)}
+	default:
+		return err
+	}
+}
+
+// withDetails attaches details to st, falling back to st undecorated (still
+// a valid status with the right code and message, just without the
+// machine-readable extras) if the proto encoding somehow fails -- this
+// should never happen for the well-known detail types this package uses,
+// but a status produced while handling one error is the last place we want
+// a second error to escape from.
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) *status.Status {
+	out, err := st.WithDetails(details...)
+	if err != nil {
+		logger.Warnf("grpcerrors: failed to attach status details: %v", err)
+		return st
+	}
+	return out
+}
+
+func retryAfter(ri *errdetails.RetryInfo) time.Duration {
+	if ri.GetRetryDelay() == nil {
+		return 0
+	}
+	return ri.GetRetryDelay().AsDuration()
+}
+
+func withRetryInfo(st *status.Status, d time.Duration) *status.Status {
+	if d <= 0 {
+		return st
+	}
+	return withDetails(st, &errdetails.RetryInfo{RetryDelay: durationpb.New(d)})
+}
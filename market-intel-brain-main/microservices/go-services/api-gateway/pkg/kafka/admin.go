@@ -0,0 +1,112 @@
+package kafka
+
+import (
+	"fmt"
+
+	"github.com/IBM/sarama"
+
+	"github.com/market-intel/api-gateway/internal/config"
+)
+
+// PartitionLag reports one partition's committed offset against its log
+// end offset for a consumer group.
+type PartitionLag struct {
+	Topic           string `json:"topic"`
+	Partition       int32  `json:"partition"`
+	CommittedOffset int64  `json:"committed_offset"`
+	EndOffset       int64  `json:"end_offset"`
+	Lag             int64  `json:"lag"`
+}
+
+// ConsumerGroupLag is the result of DescribeConsumerGroupLag.
+type ConsumerGroupLag struct {
+	Group      string         `json:"group"`
+	State      string         `json:"state"`
+	Partitions []PartitionLag `json:"partitions"`
+}
+
+// NewAdminClient opens a sarama.ClusterAdmin against cfg.Brokers using the
+// same SaramaConfig ingestion consumers build from, so admin endpoints
+// (partition reassignment, consumer-group lag) exercise the gateway's
+// actual SASL/TLS settings rather than a bare connection. Callers should
+// run cfg.Validate() first; NewAdminClient does not re-check consistency
+// rules.
+func NewAdminClient(cfg *config.KafkaConfig) (sarama.ClusterAdmin, error) {
+	saramaCfg, err := cfg.SaramaConfig()
+	if err != nil {
+		return nil, fmt.Errorf("kafka: failed to build sarama config: %w", err)
+	}
+
+	admin, err := sarama.NewClusterAdmin(cfg.Brokers, saramaCfg)
+	if err != nil {
+		return nil, fmt.Errorf("kafka: failed to open cluster admin on brokers %v: %w", cfg.Brokers, err)
+	}
+
+	return admin, nil
+}
+
+// DescribeConsumerGroupLag reports group's state and, for every
+// topic/partition it has a committed offset on, how far that offset
+// trails the partition's current log end offset. Partitions the group
+// has never committed to (OffsetFetchResponseBlock.Offset == -1) are
+// omitted rather than reported as infinite lag.
+func DescribeConsumerGroupLag(cfg *config.KafkaConfig, group string) (*ConsumerGroupLag, error) {
+	admin, err := NewAdminClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer admin.Close()
+
+	descriptions, err := admin.DescribeConsumerGroups([]string{group})
+	if err != nil {
+		return nil, fmt.Errorf("kafka: failed to describe consumer group %s: %w", group, err)
+	}
+	if len(descriptions) == 0 {
+		return nil, fmt.Errorf("kafka: consumer group %s not found", group)
+	}
+
+	offsets, err := admin.ListConsumerGroupOffsets(group, nil)
+	if err != nil {
+		return nil, fmt.Errorf("kafka: failed to list committed offsets for group %s: %w", group, err)
+	}
+
+	saramaCfg, err := cfg.SaramaConfig()
+	if err != nil {
+		return nil, fmt.Errorf("kafka: failed to build sarama config: %w", err)
+	}
+
+	client, err := sarama.NewClient(cfg.Brokers, saramaCfg)
+	if err != nil {
+		return nil, fmt.Errorf("kafka: failed to connect to brokers %v: %w", cfg.Brokers, err)
+	}
+	defer client.Close()
+
+	lag := &ConsumerGroupLag{Group: group, State: descriptions[0].State}
+	for topic, partitions := range offsets.Blocks {
+		for partition, block := range partitions {
+			if block.Offset < 0 {
+				continue
+			}
+
+			endOffset, err := client.GetOffset(topic, partition, sarama.OffsetNewest)
+			if err != nil {
+				return nil, fmt.Errorf("kafka: failed to get end offset for %s/%d: %w", topic, partition, err)
+			}
+
+			partitionLag := endOffset - block.Offset
+			if partitionLag < 0 {
+				partitionLag = 0
+			}
+
+			lag.Partitions = append(lag.Partitions, PartitionLag{
+				Topic:           topic,
+				Partition:       partition,
+				CommittedOffset: block.Offset,
+				EndOffset:       endOffset,
+				Lag:             partitionLag,
+			})
+		}
+	}
+
+	return lag, nil
+}
@@ -0,0 +1,217 @@
+// Kafka Ingestion Adapter
+// Consumes market-data and news topics from a Kafka cluster via a sarama
+// consumer group and decodes them into the same pb types used by the
+// REST/MQTT/WebSocket ingestion paths.
+
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/IBM/sarama"
+
+	"github.com/market-intel/api-gateway/internal/config"
+	"github.com/market-intel/api-gateway/pb"
+	"github.com/market-intel/api-gateway/pkg/logger"
+)
+
+// TopicKind tells ConsumeClaim which pb type and handler to decode an
+// incoming message's topic into
+type TopicKind string
+
+const (
+	TopicKindMarketData TopicKind = "market_data"
+	TopicKindNews       TopicKind = "news"
+)
+
+// MarketDataHandler is invoked for each decoded market data message
+type MarketDataHandler func(*pb.MarketData)
+
+// NewsItemHandler is invoked for each decoded news item message
+type NewsItemHandler func(*pb.NewsItem)
+
+// Consumer wraps a sarama consumer group joined on the configured
+// market-data and news topics, routing decoded payloads to the handlers
+// registered with NewConsumer.
+type Consumer struct {
+	group      sarama.ConsumerGroup
+	groupID    string
+	topics     []string
+	topicKinds map[string]TopicKind
+
+	onMarketData MarketDataHandler
+	onNewsItem   NewsItemHandler
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewConsumer builds a *sarama.Config from cfg, joins groupID as a
+// consumer group on cfg.Brokers, and starts consuming marketTopics and
+// newsTopics in the background. Callers should run cfg.Validate() first;
+// NewConsumer does not re-check consistency rules.
+func NewConsumer(cfg *config.KafkaConfig, groupID string, marketTopics, newsTopics []string, onMarketData MarketDataHandler, onNewsItem NewsItemHandler) (*Consumer, error) {
+	if len(marketTopics) == 0 && len(newsTopics) == 0 {
+		return nil, fmt.Errorf("kafka: at least one market or news topic is required")
+	}
+
+	saramaCfg, err := cfg.SaramaConfig()
+	if err != nil {
+		return nil, fmt.Errorf("kafka: failed to build sarama config: %w", err)
+	}
+
+	group, err := sarama.NewConsumerGroup(cfg.Brokers, groupID, saramaCfg)
+	if err != nil {
+		return nil, fmt.Errorf("kafka: failed to join consumer group %s: %w", groupID, err)
+	}
+
+	topicKinds := make(map[string]TopicKind, len(marketTopics)+len(newsTopics))
+	topics := make([]string, 0, len(marketTopics)+len(newsTopics))
+	for _, t := range marketTopics {
+		topicKinds[t] = TopicKindMarketData
+		topics = append(topics, t)
+	}
+	for _, t := range newsTopics {
+		topicKinds[t] = TopicKindNews
+		topics = append(topics, t)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &Consumer{
+		group:        group,
+		groupID:      groupID,
+		topics:       topics,
+		topicKinds:   topicKinds,
+		onMarketData: onMarketData,
+		onNewsItem:   onNewsItem,
+		cancel:       cancel,
+		done:         make(chan struct{}),
+	}
+
+	go c.run(ctx)
+
+	logger.Infof("Joined Kafka consumer group %s on brokers %v for topics %v", groupID, cfg.Brokers, topics)
+
+	return c, nil
+}
+
+// run drives the consumer group's claim loop until ctx is cancelled.
+// sarama's Consume call returns whenever the group rebalances, so it must
+// be re-entered in a loop for as long as the consumer should keep running.
+func (c *Consumer) run(ctx context.Context) {
+	defer close(c.done)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		c.logErrors(ctx)
+	}()
+
+	for ctx.Err() == nil {
+		if err := c.group.Consume(ctx, c.topics, c); err != nil && ctx.Err() == nil {
+			logger.Errorf("kafka: consumer group %s session ended with error: %v", c.groupID, err)
+		}
+	}
+
+	wg.Wait()
+}
+
+// logErrors drains the consumer group's async error channel so a blocked
+// reader can't stall broker-side error reporting
+func (c *Consumer) logErrors(ctx context.Context) {
+	for {
+		select {
+		case err, ok := <-c.group.Errors():
+			if !ok {
+				return
+			}
+			logger.Errorf("kafka: consumer group %s error: %v", c.groupID, err)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Setup implements sarama.ConsumerGroupHandler
+func (c *Consumer) Setup(sarama.ConsumerGroupSession) error { return nil }
+
+// Cleanup implements sarama.ConsumerGroupHandler
+func (c *Consumer) Cleanup(sarama.ConsumerGroupSession) error { return nil }
+
+// ConsumeClaim implements sarama.ConsumerGroupHandler, decoding each
+// message per its topic's TopicKind and dispatching it to the registered
+// handler before marking it consumed.
+func (c *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for {
+		select {
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+			c.handleMessage(msg)
+			session.MarkMessage(msg, "")
+		case <-session.Context().Done():
+			return nil
+		}
+	}
+}
+
+func (c *Consumer) handleMessage(msg *sarama.ConsumerMessage) {
+	switch c.topicKinds[msg.Topic] {
+	case TopicKindMarketData:
+		data, err := decodeMarketData(msg.Value)
+		if err != nil {
+			logger.Errorf("Failed to decode Kafka market data on topic %s: %v", msg.Topic, err)
+			return
+		}
+		if c.onMarketData != nil {
+			c.onMarketData(data)
+		}
+	case TopicKindNews:
+		item, err := decodeNewsItem(msg.Value)
+		if err != nil {
+			logger.Errorf("Failed to decode Kafka news item on topic %s: %v", msg.Topic, err)
+			return
+		}
+		if c.onNewsItem != nil {
+			c.onNewsItem(item)
+		}
+	default:
+		logger.Warnf("kafka: received message on unrecognized topic: %s", msg.Topic)
+	}
+}
+
+// decodeMarketData unmarshals a Kafka message value into pb.MarketData
+func decodeMarketData(payload []byte) (*pb.MarketData, error) {
+	data := &pb.MarketData{}
+	if err := json.Unmarshal(payload, data); err != nil {
+		return nil, fmt.Errorf("json unmarshal failed: %w", err)
+	}
+	if data.Source == "" {
+		data.Source = "kafka"
+	}
+	return data, nil
+}
+
+// decodeNewsItem unmarshals a Kafka message value into pb.NewsItem
+func decodeNewsItem(payload []byte) (*pb.NewsItem, error) {
+	item := &pb.NewsItem{}
+	if err := json.Unmarshal(payload, item); err != nil {
+		return nil, fmt.Errorf("json unmarshal failed: %w", err)
+	}
+	if item.Source == "" {
+		item.Source = "kafka"
+	}
+	return item, nil
+}
+
+// Close stops consuming and leaves the consumer group
+func (c *Consumer) Close() error {
+	c.cancel()
+	<-c.done
+	return c.group.Close()
+}
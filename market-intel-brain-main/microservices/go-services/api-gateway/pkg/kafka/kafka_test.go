@@ -0,0 +1,44 @@
+package kafka
+
+import "testing"
+
+func TestDecodeMarketDataJSON(t *testing.T) {
+	payload := []byte(`{"symbol": "AAPL", "price": 123.45, "volume": 1000}`)
+
+	data, err := decodeMarketData(payload)
+	if err != nil {
+		t.Fatalf("decodeMarketData returned error: %v", err)
+	}
+
+	if data.Symbol != "AAPL" {
+		t.Errorf("expected symbol AAPL, got %q", data.Symbol)
+	}
+	if data.Source != "kafka" {
+		t.Errorf("expected source defaulted to kafka, got %q", data.Source)
+	}
+	if data.Price != 123.45 {
+		t.Errorf("expected price 123.45, got %v", data.Price)
+	}
+}
+
+func TestDecodeNewsItemJSON(t *testing.T) {
+	payload := []byte(`{"title": "Fed holds rates steady", "sentiment_score": 0.1}`)
+
+	item, err := decodeNewsItem(payload)
+	if err != nil {
+		t.Fatalf("decodeNewsItem returned error: %v", err)
+	}
+
+	if item.Title != "Fed holds rates steady" {
+		t.Errorf("unexpected title: %q", item.Title)
+	}
+	if item.Source != "kafka" {
+		t.Errorf("expected source defaulted to kafka, got %q", item.Source)
+	}
+}
+
+func TestNewConsumerRejectsNoTopics(t *testing.T) {
+	if _, err := NewConsumer(nil, "test-group", nil, nil, nil, nil); err == nil {
+		t.Fatal("expected error when no topics are given, got nil")
+	}
+}
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/sirupsen/logrus"
@@ -24,6 +25,18 @@ func GetLogger() *logrus.Logger {
 	return log
 }
 
+// SetLevel parses level (e.g. "debug", "info") and applies it to the
+// shared logger, letting callers adjust verbosity (e.g. on a config
+// reload) without restarting the process.
+func SetLevel(level string) error {
+	parsed, err := logrus.ParseLevel(level)
+	if err != nil {
+		return fmt.Errorf("invalid log level %q: %w", level, err)
+	}
+	GetLogger().SetLevel(parsed)
+	return nil
+}
+
 func Info(args ...interface{}) {
 	GetLogger().Info(args...)
 }
@@ -36,8 +49,12 @@ func Error(args ...interface{}) {
 	GetLogger().Error(args...)
 }
 
-func Errorf(format string, args ...interface{}) {
-	GetLogger().Errorf(format, args...)
+// Errorf logs a formatted error and returns it, so callers can both record
+// and propagate a failure in one line: `return logger.Errorf("...: %w", err)`.
+func Errorf(format string, args ...interface{}) error {
+	err := fmt.Errorf(format, args...)
+	GetLogger().Error(err)
+	return err
 }
 
 func Fatal(args ...interface{}) {
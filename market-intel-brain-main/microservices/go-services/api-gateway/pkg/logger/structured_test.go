@@ -0,0 +1,27 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestWithNoSpanReturnsSharedLogger(t *testing.T) {
+	if got := With(context.Background()); got != getStructuredLogger() {
+		t.Fatalf("expected With to return the shared logger unchanged when ctx has no active span")
+	}
+}
+
+func TestWithValidSpanReturnsDerivedLogger(t *testing.T) {
+	spanCtx := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    [16]byte{1},
+		SpanID:     [8]byte{1},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanCtx)
+
+	if got := With(ctx); got == getStructuredLogger() {
+		t.Fatalf("expected With to return a derived logger carrying trace_id/span_id fields")
+	}
+}
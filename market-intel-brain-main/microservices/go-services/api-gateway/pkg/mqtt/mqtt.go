@@ -0,0 +1,239 @@
+// MQTT Ingestion Adapter
+// Subscribes to market-data and news topics published by MQTT brokers and
+// decodes them into the same pb types used by the REST/WebSocket ingestion paths.
+
+package mqtt
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	mqttlib "github.com/eclipse/paho.mqtt.golang"
+
+	"github.com/market-intel/api-gateway/pb"
+	"github.com/market-intel/api-gateway/pkg/logger"
+)
+
+// QoS levels supported by the subscriber
+type QoS byte
+
+const (
+	QoSAtMostOnce  QoS = 0
+	QoSAtLeastOnce QoS = 1
+	QoSExactlyOnce QoS = 2
+)
+
+// PayloadFormat describes how to decode an incoming MQTT message body
+type PayloadFormat string
+
+const (
+	PayloadFormatJSON     PayloadFormat = "json"
+	PayloadFormatProtobuf PayloadFormat = "protobuf"
+)
+
+// Subscriber describes a single topic subscription
+type Subscriber struct {
+	Topic  string        `json:"topic" yaml:"topic"`
+	QoS    QoS           `json:"qos" yaml:"qos"`
+	Format PayloadFormat `json:"format" yaml:"format"`
+}
+
+// Config holds the MQTT broker connection configuration
+type Config struct {
+	BrokerURL      string        `json:"broker_url" yaml:"broker_url"`
+	ClientID       string        `json:"client_id" yaml:"client_id"`
+	Username       string        `json:"username" yaml:"username"`
+	Password       string        `json:"password" yaml:"password"`
+	Subscribers    []Subscriber  `json:"subscribers" yaml:"subscribers"`
+	ConnectTimeout time.Duration `json:"connect_timeout" yaml:"connect_timeout"`
+}
+
+// DefaultConfig returns a Config covering the common market/news topic layout
+func DefaultConfig(brokerURL string) *Config {
+	return &Config{
+		BrokerURL: brokerURL,
+		ClientID:  "api-gateway",
+		Subscribers: []Subscriber{
+			{Topic: "market/+/ticks", QoS: QoSAtLeastOnce, Format: PayloadFormatJSON},
+			{Topic: "news/#", QoS: QoSAtMostOnce, Format: PayloadFormatJSON},
+		},
+		ConnectTimeout: 10 * time.Second,
+	}
+}
+
+// MarketDataHandler is invoked for each decoded market data tick
+type MarketDataHandler func(*pb.MarketData)
+
+// NewsItemHandler is invoked for each decoded news item
+type NewsItemHandler func(*pb.NewsItem)
+
+// Broker wraps a connected MQTT client and routes decoded payloads to the
+// handlers registered with OnMarketData/OnNewsItem.
+type Broker struct {
+	config       *Config
+	client       mqttlib.Client
+	onMarketData MarketDataHandler
+	onNewsItem   NewsItemHandler
+}
+
+// NewBroker creates a Broker and connects to the configured MQTT endpoint
+func NewBroker(config *Config, onMarketData MarketDataHandler, onNewsItem NewsItemHandler) (*Broker, error) {
+	if config == nil {
+		return nil, fmt.Errorf("mqtt config cannot be nil")
+	}
+	if config.BrokerURL == "" {
+		return nil, fmt.Errorf("mqtt broker URL cannot be empty")
+	}
+
+	b := &Broker{
+		config:       config,
+		onMarketData: onMarketData,
+		onNewsItem:   onNewsItem,
+	}
+
+	opts := mqttlib.NewClientOptions().
+		AddBroker(config.BrokerURL).
+		SetClientID(config.ClientID).
+		SetConnectTimeout(config.ConnectTimeout).
+		SetAutoReconnect(true)
+
+	if config.Username != "" {
+		opts.SetUsername(config.Username)
+		opts.SetPassword(config.Password)
+	}
+
+	client := mqttlib.NewClient(opts)
+	token := client.Connect()
+	if !token.WaitTimeout(config.ConnectTimeout) {
+		return nil, fmt.Errorf("timed out connecting to mqtt broker %s", config.BrokerURL)
+	}
+	if token.Error() != nil {
+		return nil, fmt.Errorf("failed to connect to mqtt broker %s: %w", config.BrokerURL, token.Error())
+	}
+
+	b.client = client
+
+	for _, sub := range config.Subscribers {
+		if err := b.subscribe(sub); err != nil {
+			client.Disconnect(250)
+			return nil, err
+		}
+	}
+
+	logger.Infof("Connected to MQTT broker %s with %d subscriptions", config.BrokerURL, len(config.Subscribers))
+
+	return b, nil
+}
+
+// subscribe wires a single Subscriber to the shared message callback
+func (b *Broker) subscribe(sub Subscriber) error {
+	token := b.client.Subscribe(sub.Topic, byte(sub.QoS), func(_ mqttlib.Client, msg mqttlib.Message) {
+		b.handleMessage(sub, msg.Topic(), msg.Payload())
+	})
+
+	if !token.WaitTimeout(5 * time.Second) {
+		return fmt.Errorf("timed out subscribing to topic %s", sub.Topic)
+	}
+	if token.Error() != nil {
+		return fmt.Errorf("failed to subscribe to topic %s: %w", sub.Topic, token.Error())
+	}
+
+	logger.Infof("Subscribed to MQTT topic %s (qos=%d, format=%s)", sub.Topic, sub.QoS, sub.Format)
+	return nil
+}
+
+// handleMessage decodes an incoming payload and dispatches it to the
+// registered handler based on the topic prefix (market/... vs news/...)
+func (b *Broker) handleMessage(sub Subscriber, topic string, payload []byte) {
+	switch {
+	case strings.HasPrefix(topic, "market/"):
+		data, err := decodeMarketData(sub.Format, topic, payload)
+		if err != nil {
+			logger.Errorf("Failed to decode MQTT market data on topic %s: %v", topic, err)
+			return
+		}
+		if b.onMarketData != nil {
+			b.onMarketData(data)
+		}
+	case strings.HasPrefix(topic, "news/"):
+		item, err := decodeNewsItem(sub.Format, payload)
+		if err != nil {
+			logger.Errorf("Failed to decode MQTT news item on topic %s: %v", topic, err)
+			return
+		}
+		if b.onNewsItem != nil {
+			b.onNewsItem(item)
+		}
+	default:
+		logger.Warnf("Received MQTT message on unrecognized topic: %s", topic)
+	}
+}
+
+// symbolFromTopic extracts the ticker symbol from a market/<symbol>/ticks topic
+func symbolFromTopic(topic string) string {
+	parts := strings.Split(topic, "/")
+	if len(parts) >= 2 {
+		return parts[1]
+	}
+	return ""
+}
+
+// decodeMarketData unmarshals an MQTT payload into pb.MarketData, filling in
+// the symbol from the topic path when the payload omits it
+func decodeMarketData(format PayloadFormat, topic string, payload []byte) (*pb.MarketData, error) {
+	data := &pb.MarketData{}
+
+	switch format {
+	case PayloadFormatProtobuf:
+		// pb.MarketData is still a hand-maintained plain struct (see pb/doc.go),
+		// not a generated protobuf message, so there's nothing to unmarshal against yet.
+		return nil, fmt.Errorf("protobuf payload format is not supported until pb contains generated types")
+	case PayloadFormatJSON, "":
+		if err := json.Unmarshal(payload, data); err != nil {
+			return nil, fmt.Errorf("json unmarshal failed: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported payload format: %s", format)
+	}
+
+	if data.Symbol == "" {
+		data.Symbol = symbolFromTopic(topic)
+	}
+	if data.Source == "" {
+		data.Source = "mqtt"
+	}
+
+	return data, nil
+}
+
+// decodeNewsItem unmarshals an MQTT payload into pb.NewsItem
+func decodeNewsItem(format PayloadFormat, payload []byte) (*pb.NewsItem, error) {
+	item := &pb.NewsItem{}
+
+	switch format {
+	case PayloadFormatProtobuf:
+		return nil, fmt.Errorf("protobuf payload format is not supported until pb contains generated types")
+	case PayloadFormatJSON, "":
+		if err := json.Unmarshal(payload, item); err != nil {
+			return nil, fmt.Errorf("json unmarshal failed: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported payload format: %s", format)
+	}
+
+	if item.Source == "" {
+		item.Source = "mqtt"
+	}
+
+	return item, nil
+}
+
+// Close disconnects from the MQTT broker
+func (b *Broker) Close() {
+	if b.client != nil && b.client.IsConnected() {
+		b.client.Disconnect(250)
+		logger.Infof("Disconnected from MQTT broker %s", b.config.BrokerURL)
+	}
+}
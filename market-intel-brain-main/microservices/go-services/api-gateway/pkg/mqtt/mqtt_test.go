@@ -0,0 +1,69 @@
+package mqtt
+
+import "testing"
+
+func TestSymbolFromTopic(t *testing.T) {
+	cases := map[string]string{
+		"market/AAPL/ticks": "AAPL",
+		"market/MSFT/ticks": "MSFT",
+		"news":              "",
+		"":                  "",
+	}
+
+	for topic, want := range cases {
+		if got := symbolFromTopic(topic); got != want {
+			t.Errorf("symbolFromTopic(%q) = %q, want %q", topic, got, want)
+		}
+	}
+}
+
+func TestDecodeMarketDataJSON(t *testing.T) {
+	payload := []byte(`{"price": 123.45, "volume": 1000}`)
+
+	data, err := decodeMarketData(PayloadFormatJSON, "market/AAPL/ticks", payload)
+	if err != nil {
+		t.Fatalf("decodeMarketData returned error: %v", err)
+	}
+
+	if data.Symbol != "AAPL" {
+		t.Errorf("expected symbol backfilled from topic, got %q", data.Symbol)
+	}
+	if data.Source != "mqtt" {
+		t.Errorf("expected source defaulted to mqtt, got %q", data.Source)
+	}
+	if data.Price != 123.45 {
+		t.Errorf("expected price 123.45, got %v", data.Price)
+	}
+}
+
+func TestDecodeMarketDataProtobufUnsupported(t *testing.T) {
+	if _, err := decodeMarketData(PayloadFormatProtobuf, "market/AAPL/ticks", []byte{}); err == nil {
+		t.Fatal("expected error for unsupported protobuf format, got nil")
+	}
+}
+
+func TestDecodeNewsItemJSON(t *testing.T) {
+	payload := []byte(`{"title": "Fed holds rates steady", "sentiment_score": 0.1}`)
+
+	item, err := decodeNewsItem(PayloadFormatJSON, payload)
+	if err != nil {
+		t.Fatalf("decodeNewsItem returned error: %v", err)
+	}
+
+	if item.Title != "Fed holds rates steady" {
+		t.Errorf("unexpected title: %q", item.Title)
+	}
+	if item.Source != "mqtt" {
+		t.Errorf("expected source defaulted to mqtt, got %q", item.Source)
+	}
+}
+
+func TestNewBrokerRejectsEmptyConfig(t *testing.T) {
+	if _, err := NewBroker(nil, nil, nil); err == nil {
+		t.Fatal("expected error for nil config, got nil")
+	}
+
+	if _, err := NewBroker(&Config{}, nil, nil); err == nil {
+		t.Fatal("expected error for empty broker URL, got nil")
+	}
+}
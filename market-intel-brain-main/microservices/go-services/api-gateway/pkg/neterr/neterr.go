@@ -0,0 +1,159 @@
+// Network Error Classification
+// Classifies errors from upstream data sources and the core-engine gRPC
+// client into a small taxonomy so callers can decide whether to retry
+// with backoff or give up and surface a permanent failure immediately.
+
+package neterr
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"math"
+	"math/rand"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Kind is a coarse classification of a network/source error
+type Kind string
+
+const (
+	// ErrTransient covers errors likely to succeed on retry with no other
+	// information available (unrecognized or generic I/O failures)
+	ErrTransient Kind = "transient"
+
+	// ErrTimeout covers deadline exceeded / request timeout errors
+	ErrTimeout Kind = "timeout"
+
+	// ErrDNS covers failures resolving the upstream host
+	ErrDNS Kind = "dns"
+
+	// ErrTLS covers certificate and handshake failures
+	ErrTLS Kind = "tls"
+
+	// ErrConnRefused covers the upstream actively refusing the connection
+	ErrConnRefused Kind = "conn_refused"
+
+	// ErrRateLimited covers upstream backpressure (gRPC ResourceExhausted)
+	ErrRateLimited Kind = "rate_limited"
+
+	// ErrAuth covers authentication/authorization failures
+	ErrAuth Kind = "auth"
+
+	// ErrPermanent covers errors that will not succeed on retry
+	ErrPermanent Kind = "permanent"
+)
+
+// Retryable reports whether a Kind is generally worth retrying with backoff
+func (k Kind) Retryable() bool {
+	switch k {
+	case ErrTransient, ErrTimeout, ErrDNS, ErrConnRefused, ErrRateLimited:
+		return true
+	default:
+		return false
+	}
+}
+
+// Classify inspects err and returns the best-matching Kind. It unwraps
+// net.OpError, url.Error, and x509.UnknownAuthorityError, and falls back to
+// the gRPC status code when err carries one.
+func Classify(err error) Kind {
+	if err == nil {
+		return ""
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrTimeout
+	}
+
+	var x509Err x509.UnknownAuthorityError
+	if errors.As(err, &x509Err) {
+		return ErrTLS
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		if urlErr.Timeout() {
+			return ErrTimeout
+		}
+		return Classify(urlErr.Err)
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		if opErr.Timeout() {
+			return ErrTimeout
+		}
+		var dnsErr *net.DNSError
+		if errors.As(opErr.Err, &dnsErr) {
+			return ErrDNS
+		}
+		if isConnRefused(opErr.Err) {
+			return ErrConnRefused
+		}
+		return ErrTransient
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return ErrDNS
+	}
+
+	if grpcStatus, ok := status.FromError(err); ok {
+		return classifyGRPCCode(grpcStatus.Code())
+	}
+
+	return ErrTransient
+}
+
+// classifyGRPCCode maps a gRPC status code to a Kind
+func classifyGRPCCode(code codes.Code) Kind {
+	switch code {
+	case codes.OK:
+		return ""
+	case codes.DeadlineExceeded:
+		return ErrTimeout
+	case codes.Unavailable:
+		return ErrConnRefused
+	case codes.ResourceExhausted:
+		return ErrRateLimited
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return ErrAuth
+	case codes.Aborted, codes.Internal, codes.DataLoss, codes.Unknown:
+		return ErrTransient
+	default:
+		return ErrPermanent
+	}
+}
+
+// isConnRefused reports whether err's message indicates a refused
+// connection. The syscall error wrapped inside net.OpError is not portable
+// across platforms, so this falls back to a substring match on the
+// underlying error text, which is what the standard library itself
+// populates consistently for ECONNREFUSED.
+func isConnRefused(err error) bool {
+	if err == nil {
+		return false
+	}
+	return strings.Contains(err.Error(), "connection refused")
+}
+
+// Backoff computes an exponential backoff delay with jitter for the given
+// retry attempt (0-indexed), bounded by maxDelay.
+func Backoff(attempt int, initialDelay, maxDelay time.Duration) time.Duration {
+	delay := float64(initialDelay) * math.Pow(2, float64(attempt))
+	if delay > float64(maxDelay) {
+		delay = float64(maxDelay)
+	}
+
+	jitter := delay * 0.25 * (rand.Float64() - 0.5)
+	delay += jitter
+
+	return time.Duration(delay)
+}
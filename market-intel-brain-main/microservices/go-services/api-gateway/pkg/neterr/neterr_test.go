@@ -0,0 +1,96 @@
+package neterr
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/url"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestClassify(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want Kind
+	}{
+		{
+			name: "deadline exceeded",
+			err:  context.DeadlineExceeded,
+			want: ErrTimeout,
+		},
+		{
+			name: "wrapped deadline exceeded",
+			err:  &url.Error{Op: "Get", URL: "https://example.com", Err: context.DeadlineExceeded},
+			want: ErrTimeout,
+		},
+		{
+			name: "unknown authority",
+			err:  x509.UnknownAuthorityError{},
+			want: ErrTLS,
+		},
+		{
+			name: "dns error",
+			err:  &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "example.invalid"}},
+			want: ErrDNS,
+		},
+		{
+			name: "connection refused",
+			err:  &net.OpError{Op: "dial", Err: errors.New("connect: connection refused")},
+			want: ErrConnRefused,
+		},
+		{
+			name: "grpc unavailable",
+			err:  status.Error(codes.Unavailable, "upstream down"),
+			want: ErrConnRefused,
+		},
+		{
+			name: "grpc resource exhausted",
+			err:  status.Error(codes.ResourceExhausted, "rate limited"),
+			want: ErrRateLimited,
+		},
+		{
+			name: "grpc unauthenticated",
+			err:  status.Error(codes.Unauthenticated, "bad token"),
+			want: ErrAuth,
+		},
+		{
+			name: "grpc invalid argument",
+			err:  status.Error(codes.InvalidArgument, "bad request"),
+			want: ErrPermanent,
+		},
+		{
+			name: "unrecognized error",
+			err:  errors.New("something went wrong"),
+			want: ErrTransient,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Classify(tc.err); got != tc.want {
+				t.Errorf("Classify(%v) = %q, want %q", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestKindRetryable(t *testing.T) {
+	retryable := []Kind{ErrTransient, ErrTimeout, ErrDNS, ErrConnRefused, ErrRateLimited}
+	for _, k := range retryable {
+		if !k.Retryable() {
+			t.Errorf("expected %q to be retryable", k)
+		}
+	}
+
+	permanent := []Kind{ErrAuth, ErrPermanent, ErrTLS}
+	for _, k := range permanent {
+		if k.Retryable() {
+			t.Errorf("expected %q to not be retryable", k)
+		}
+	}
+}
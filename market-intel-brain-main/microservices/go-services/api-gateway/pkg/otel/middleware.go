@@ -1,16 +1,20 @@
 package otel
 
 import (
-	"context"
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
-	"github.com/market-intel/api-gateway/pkg/otel"
 )
 
 // OtelMiddleware provides OpenTelemetry instrumentation for Gin
@@ -28,173 +32,178 @@ func NewOtelMiddleware(serviceName string) *OtelMiddleware {
 // Middleware returns the Gin middleware function
 func (m *OtelMiddleware) Middleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-	// Start time for request duration measurement
 		start := time.Now()
-		
-	// Extract trace context from headers if present
-	spanCtx := otel.TraceContextFromContext(c.Request.Context())
-		ctx := otel.ContextWithSpan(spanCtx, "http-request")
-		
-	// Create span for the request
-		spanName := fmt.Sprintf("%s %s %s", m.serviceName, c.Request.Method, c.Request.URL.Path)
-		ctx, span := otel.Start(ctx, spanName)
+
+		// Extract traceparent/tracestate/baggage from the inbound request so
+		// this span joins the caller's trace instead of starting a new root.
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+		spanName := fmt.Sprintf("%s %s", c.Request.Method, c.FullPath())
+		ctx, span := otel.Tracer(m.serviceName).Start(ctx, spanName,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.method", c.Request.Method),
+				attribute.String("http.route", c.FullPath()),
+				attribute.String("net.peer.ip", c.ClientIP()),
+				attribute.String("user_agent.original", c.Request.UserAgent()),
+			),
+		)
 		defer span.End()
-		
-	// Store span in context for later use
+
+		// Store span in context for later use
 		c.Request = c.Request.WithContext(ctx)
-		
-	// Process request
+
+		// Process request
 		c.Next()
-		
-	// Calculate duration
+
+		// Calculate duration
 		duration := time.Since(start)
-		
-	// Record request metrics
-		otel.RecordRequest(span, c.Request.Method, c.Request.URL.Path, c.Writer.Status())
-		
-	// Add trace ID to response headers
-		if traceID := otel.GetTraceID(c.Request.Context()); traceID != "" {
+
+		statusCode := c.Writer.Status()
+		span.SetAttributes(attribute.Int("http.status_code", statusCode))
+		if statusCode >= 500 {
+			span.SetStatus(codes.Error, fmt.Sprintf("HTTP %d", statusCode))
+		}
+
+		// Add trace ID to response headers
+		traceID := GetTraceID(ctx)
+		if traceID != "" {
 			c.Header("X-Trace-ID", traceID)
 		}
-		
-	// Add duration to response headers
+
+		// Add duration to response headers
 		c.Header("X-Duration", duration.String())
-		
-	// Log request with trace ID
-		traceID := otel.GetTraceID(c.Request.Context())
+
+		// Log request with trace ID
 		logrus.WithFields(logrus.Fields{
-			"method":    c.Request.Method,
-			"path":      c.Request.URL.Path,
-			"status":    c.Writer.Status(),
-			"trace_id":  traceID,
-			"duration":  duration.String(),
+			"method":   c.Request.Method,
+			"path":     c.Request.URL.Path,
+			"status":   statusCode,
+			"trace_id": traceID,
+			"duration": duration.String(),
 		}).Info("Request completed")
 	}
 }
 
-// MetricsMiddleware provides Prometheus metrics for the API Gateway
+// InjectTransport wraps base so outbound requests carry the active span's
+// traceparent/tracestate/baggage headers, letting the gateway's backend
+// clients participate in the same distributed trace as the inbound request.
+func InjectTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &tracingRoundTripper{base: base}
+}
+
+type tracingRoundTripper struct {
+	base http.RoundTripper
+}
+
+func (rt *tracingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	otel.GetTextMapPropagator().Inject(req.Context(), propagation.HeaderCarrier(req.Header))
+	return rt.base.RoundTrip(req)
+}
+
+// MetricsMiddleware provides Prometheus metrics for the API Gateway. All
+// collectors are constructed once, here, and registered a single time --
+// the hot path only ever calls WithLabelValues(...).Inc/Observe on them.
 type MetricsMiddleware struct {
 	registry *prometheus.Registry
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	errors   *prometheus.CounterVec
+	active   prometheus.Gauge
 }
 
 // NewMetricsMiddleware creates a new metrics middleware
 func NewMetricsMiddleware() *MetricsMiddleware {
 	registry := prometheus.NewRegistry()
-	
-	// Create metrics
-	registry.MustRegister(prometheus.NewCounter(
-		"http_requests_total",
-		"Total number of HTTP requests",
-		[]string{"method", "path", "status"},
-	))
-	
-	registry.MustRegister(prometheus.NewHistogram(
-		"http_request_duration_seconds",
-		"HTTP request duration in seconds",
-		[]string{"method", "path", "status"},
-		prometheus.ExponentialBuckets(0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1.0, 2.5, 5.0, 10.0),
-	))
-	
-	registry.MustRegister(prometheus.NewCounter(
-		"http_errors_total",
-		"Total number of HTTP errors",
-		[]string{"method", "path", "status"},
-	))
-	
-	registry.MustRegister(prometheus.NewGauge(
-		"http_connections_active",
-		"Number of active HTTP connections",
-	))
-	
-	return &MetricsMiddleware{
+
+	m := &MetricsMiddleware{
 		registry: registry,
+		requests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "http_requests_total",
+				Help: "Total number of HTTP requests",
+			},
+			[]string{"method", "path", "status"},
+		),
+		duration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "http_request_duration_seconds",
+				Help:    "HTTP request duration in seconds",
+				Buckets: []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1.0, 2.5, 5.0, 10.0},
+			},
+			[]string{"method", "path", "status"},
+		),
+		errors: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "http_errors_total",
+				Help: "Total number of HTTP errors",
+			},
+			[]string{"method", "path", "status"},
+		),
+		active: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "http_connections_active",
+				Help: "Number of active HTTP connections",
+			},
+		),
 	}
+
+	registry.MustRegister(m.requests, m.duration, m.errors, m.active)
+
+	return m
 }
 
 // Middleware returns the Gin middleware function for metrics
 func (m *MetricsMiddleware) Middleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-	// Start time for request duration measurement
+		m.active.Inc()
+		defer m.active.Dec()
+
 		start := time.Now()
-		
-	// Process request
+
 		c.Next()
-		
-	// Calculate duration
+
 		duration := time.Since(start).Seconds()
-		
-	// Record metrics
+
 		method := c.Request.Method
 		path := c.Request.URL.Path
 		status := strconv.Itoa(c.Writer.Status())
-		
-	// Increment request counter
-		m.registry.MustRegister(
-			prometheus.NewCounterVec(
-				prometheus.CounterOpts{
-					Name: "http_requests_total",
-					Help: "Total number of HTTP requests",
-				},
-			),
-		).WithLabelValues(
-			prometheus.Labels{
-				"method": method,
-				"path":   path,
-				"status": status,
-			},
-	).Inc()
-		
-	// Record request duration
-		m.registry.MustRegister(
-			prometheus.NewHistogramVec(
-				prometheus.HistogramOpts{
-					Name:    "http_request_duration_seconds",
-					Help:    "HTTP request duration in seconds",
-					Buckets: []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1.0, 2.5, 5.0, 10.0},
-				},
-			),
-		).WithLabelValues(
-			prometheus.Labels{
-				"method": method,
-				"path":   path,
-				"status": status,
-			},
-	).Observe(duration)
-		
-	// Record errors
+
+		m.requests.WithLabelValues(method, path, status).Inc()
+
+		// ObserveWithExemplar attaches the active span's trace ID to this
+		// bucketed sample so Grafana can jump straight from a latency-spike
+		// bucket to the trace that produced it.
+		histogramObserver := m.duration.WithLabelValues(method, path, status)
+		exemplarObserver, hasExemplars := histogramObserver.(prometheus.ExemplarObserver)
+		spanCtx := trace.SpanContextFromContext(c.Request.Context())
+		if hasExemplars && spanCtx.IsValid() {
+			exemplarObserver.ObserveWithExemplar(duration, prometheus.Labels{
+				"trace_id": spanCtx.TraceID().String(),
+			})
+		} else {
+			histogramObserver.Observe(duration)
+		}
+
 		if c.Writer.Status() >= 400 {
-			m.registry.MustRegister(
-				prometheus.NewCounterVec(
-					prometheus.CounterOpts{
-						Name: "http_errors_total",
-						Help: "Total number of HTTP errors",
-					},
-				),
-			).WithLabelValues(
-				prometheus.Labels{
-					"method": method,
-					"path":   path,
-					"status": status,
-				},
-			).Inc()
+			m.errors.WithLabelValues(method, path, status).Inc()
 		}
-		
-		// Update active connections gauge
-		m.registry.MustRegister(
-			prometheus.NewGauge(
-				prometheus.GaugeOpts{
-					Name: "http_connections_active",
-					Help: "Number of active HTTP connections",
-				},
-			).Set(float64(c.Writer.Size()))
-		)
 	}
 }
 
 // MetricsHandler returns Prometheus metrics for scraping
 func (m *MetricsMiddleware) MetricsHandler() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// Enable Prometheus metrics endpoint
-		promhttp.Handler()
-	}
+	handler := promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{EnableOpenMetrics: true})
+	return gin.WrapH(handler)
+}
+
+// Registry returns the Prometheus registry collectors are registered
+// against, so other subsystems (e.g. internal/cache's response cache
+// counters) can register into the same /metrics output instead of each
+// standing up its own registry.
+func (m *MetricsMiddleware) Registry() *prometheus.Registry {
+	return m.registry
 }
@@ -4,20 +4,26 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/exporters/jaeger"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
-	"go.opentelemetry.io/otel/sdk"
-	"go.opentelemetry.io/otel/sdk/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
-	"go.opentelemetry.io/otel/sdk/trace"
-	"go.opentelemetry.io/semconv/v1.13.1/semconv"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -26,27 +32,153 @@ const (
 	environment    = "development"
 )
 
-// InitOpenTelemetry initializes OpenTelemetry with appropriate exporters
-func InitOpenTelemetry() error {
-	// Set up Jaeger exporter for tracing
-	jaegerEndpoint := os.Getenv("JAEGER_ENDPOINT")
-	if jaegerEndpoint == "" {
-		jaegerEndpoint = "http://localhost:14268/api/traces"
+// TraceExporterKind selects which trace exporter InitOpenTelemetry wires up.
+type TraceExporterKind string
+
+const (
+	TraceExporterJaeger   TraceExporterKind = "jaeger"
+	TraceExporterOTLPHTTP TraceExporterKind = "otlphttp"
+	TraceExporterOTLPGRPC TraceExporterKind = "otlpgrpc"
+)
+
+// RetryConfig mirrors the OTLP exporter's built-in retry knobs (see
+// otlptracehttp.RetryConfig / otlptracegrpc.RetryConfig, which share this
+// struct's fields) so transient collector unavailability backs off and
+// retries instead of dropping the batch on the floor.
+type RetryConfig struct {
+	Enabled         bool
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	MaxElapsedTime  time.Duration
+}
+
+// BatchConfig controls the BatchSpanProcessor that sits in front of the
+// trace exporter.
+type BatchConfig struct {
+	MaxQueueSize       int
+	MaxExportBatchSize int
+	BatchTimeout       time.Duration
+	ExportTimeout      time.Duration
+}
+
+// OTelConfig selects and configures the exporters InitOpenTelemetry wires
+// up. LoadOTelConfigFromEnv populates one from the standard
+// OTEL_EXPORTER_OTLP_* variables plus the gateway's own JAEGER_ENDPOINT.
+type OTelConfig struct {
+	TraceExporter TraceExporterKind
+
+	// OTLPEndpoint is used by the otlphttp/otlpgrpc trace exporter and by
+	// the OTLP log/metric exporters alike, mirroring
+	// OTEL_EXPORTER_OTLP_ENDPOINT's role as the shared endpoint; no
+	// per-signal endpoint override is read.
+	OTLPEndpoint string
+	OTLPInsecure bool
+
+	JaegerEndpoint string
+
+	Batch BatchConfig
+	Retry RetryConfig
+
+	// EnableOTLPMetrics additionally pushes metrics to the OTLP endpoint
+	// alongside the existing Prometheus pull reader.
+	EnableOTLPMetrics bool
+}
+
+// LoadOTelConfigFromEnv builds an OTelConfig from the standard
+// OTEL_EXPORTER_OTLP_* environment variables, defaulting to the gateway's
+// historical Jaeger-over-HTTP behavior when none are set.
+func LoadOTelConfigFromEnv() *OTelConfig {
+	return &OTelConfig{
+		TraceExporter:  TraceExporterKind(getEnv("OTEL_TRACES_EXPORTER", string(TraceExporterJaeger))),
+		OTLPEndpoint:   getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317"),
+		OTLPInsecure:   getEnvBool("OTEL_EXPORTER_OTLP_INSECURE", true),
+		JaegerEndpoint: getEnv("JAEGER_ENDPOINT", "http://localhost:14268/api/traces"),
+
+		Batch: BatchConfig{
+			MaxQueueSize:       getEnvInt("OTEL_BSP_MAX_QUEUE_SIZE", 2048),
+			MaxExportBatchSize: getEnvInt("OTEL_BSP_MAX_EXPORT_BATCH_SIZE", 512),
+			BatchTimeout:       getEnvDuration("OTEL_BSP_SCHEDULE_DELAY", 5*time.Second),
+			ExportTimeout:      getEnvDuration("OTEL_BSP_EXPORT_TIMEOUT", 30*time.Second),
+		},
+		Retry: RetryConfig{
+			Enabled:         getEnvBool("OTEL_EXPORTER_OTLP_RETRY_ENABLED", true),
+			InitialInterval: getEnvDuration("OTEL_EXPORTER_OTLP_RETRY_INITIAL_INTERVAL", 1*time.Second),
+			MaxInterval:     getEnvDuration("OTEL_EXPORTER_OTLP_RETRY_MAX_INTERVAL", 30*time.Second),
+			MaxElapsedTime:  getEnvDuration("OTEL_EXPORTER_OTLP_RETRY_MAX_ELAPSED_TIME", 1*time.Minute),
+		},
+		EnableOTLPMetrics: getEnvBool("OTEL_METRICS_EXPORTER_OTLP_ENABLED", false),
 	}
+}
+
+// providers holds the SDK providers InitOpenTelemetry installs, so Shutdown
+// can flush the ones actually constructed instead of type-asserting the
+// global otel.GetTracerProvider()/otel.GetMeterProvider() (which return the
+// no-op implementation once replaced by a later Init call in tests).
+var providers struct {
+	tracerProvider *sdktrace.TracerProvider
+	meterProvider  *sdkmetric.MeterProvider
+	loggerProvider *sdklog.LoggerProvider
+}
 
-	jaegerExp, err := jaeger.New(jaeger.WithCollectorEndpoint(jaegerEndpoint))
+// newTraceExporter builds the trace exporter selected by config.TraceExporter.
+func newTraceExporter(ctx context.Context, config *OTelConfig) (sdktrace.SpanExporter, error) {
+	switch config.TraceExporter {
+	case TraceExporterOTLPHTTP:
+		opts := []otlptracehttp.Option{
+			otlptracehttp.WithEndpoint(config.OTLPEndpoint),
+			otlptracehttp.WithRetry(otlptracehttp.RetryConfig(config.Retry)),
+		}
+		if config.OTLPInsecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, opts...)
+
+	case TraceExporterOTLPGRPC:
+		opts := []otlptracegrpc.Option{
+			otlptracegrpc.WithEndpoint(config.OTLPEndpoint),
+			otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig(config.Retry)),
+		}
+		if config.OTLPInsecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		return otlptracegrpc.New(ctx, opts...)
+
+	case TraceExporterJaeger, "":
+		return jaeger.New(jaeger.WithCollectorEndpoint(jaeger.WithEndpoint(config.JaegerEndpoint)))
+
+	default:
+		return nil, fmt.Errorf("unsupported trace exporter %q", config.TraceExporter)
+	}
+}
+
+// InitOpenTelemetry initializes OpenTelemetry with the exporters selected by
+// config. Pass LoadOTelConfigFromEnv() to pick exporters the way the
+// standard OTEL_EXPORTER_OTLP_* variables describe.
+func InitOpenTelemetry(config *OTelConfig) error {
+	ctx := context.Background()
+
+	traceExp, err := newTraceExporter(ctx, config)
 	if err != nil {
-		return fmt.Errorf("failed to create Jaeger exporter: %w", err)
+		return fmt.Errorf("failed to create trace exporter: %w", err)
 	}
 
-	// Set up Prometheus exporter for metrics
+	logExp, err := otlploggrpc.New(ctx,
+		otlploggrpc.WithEndpoint(config.OTLPEndpoint),
+		otlploggrpc.WithInsecure(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create OTLP log exporter: %w", err)
+	}
+
+	// Set up Prometheus exporter for metrics, kept as the default pull
+	// reader regardless of the trace exporter choice
 	prometheusExp, err := prometheus.New()
 	if err != nil {
 		return fmt.Errorf("failed to create Prometheus exporter: %w", err)
 	}
 
 	// Create resource
-	res, err := resource.New(
+	res, err := resource.New(ctx,
 		resource.WithAttributes(
 			semconv.ServiceVersionKey.String(serviceVersion),
 			semconv.ServiceNameKey.String(serviceName),
@@ -58,47 +190,78 @@ func InitOpenTelemetry() error {
 		return fmt.Errorf("failed to create resource: %w", err)
 	}
 
-	// Create trace provider
+	// Create trace provider, batching spans through the configured queue
+	// size/batch size/timeouts instead of exporting one at a time
 	traceProvider := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(otel.NewBatchSpanProcessor(jaegerExp)),
+		sdktrace.WithBatcher(traceExp,
+			sdktrace.WithMaxQueueSize(config.Batch.MaxQueueSize),
+			sdktrace.WithMaxExportBatchSize(config.Batch.MaxExportBatchSize),
+			sdktrace.WithBatchTimeout(config.Batch.BatchTimeout),
+			sdktrace.WithExportTimeout(config.Batch.ExportTimeout),
+		),
 		sdktrace.WithResource(res),
 	)
 
-	// Create meter provider
-	meterProvider := sdkmetric.NewMeterProvider(
+	// Create meter provider, with an OTLP push reader alongside the
+	// existing Prometheus pull reader when EnableOTLPMetrics is set
+	meterOpts := []sdkmetric.Option{
 		sdkmetric.WithReader(prometheusExp),
 		sdkmetric.WithResource(res),
+	}
+	if config.EnableOTLPMetrics {
+		metricExp, err := otlpmetricgrpc.New(ctx,
+			otlpmetricgrpc.WithEndpoint(config.OTLPEndpoint),
+			otlpmetricgrpc.WithInsecure(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create OTLP metric exporter: %w", err)
+		}
+		meterOpts = append(meterOpts, sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExp)))
+	}
+	meterProvider := sdkmetric.NewMeterProvider(meterOpts...)
+
+	loggerProvider := sdklog.NewLoggerProvider(
+		sdklog.WithProcessor(sdklog.NewBatchProcessor(logExp)),
+		sdklog.WithResource(res),
 	)
 
-	// Create OTel provider
+	providers.tracerProvider = traceProvider
+	providers.meterProvider = meterProvider
+	providers.loggerProvider = loggerProvider
+
 	otel.SetTracerProvider(traceProvider)
 	otel.SetMeterProvider(meterProvider)
 
-	// Register trace propagator
-	otel.SetTextMapPropagator(propagation.TraceContext{})
-	otel.SetTextMapPropagator(propagation.Baggage{})
-
 	// Create metrics
 	meter := otel.Meter("market_intel_api_gateway")
-	
+
 	// Create counters
-	requestCounter := meter.Int64Counter(
+	requestCounter, err := meter.Int64Counter(
 		"requests_total",
-		"Total number of requests",
+		metric.WithDescription("Total number of requests"),
 	)
-	
-	errorCounter := meter.Int64Counter(
+	if err != nil {
+		return fmt.Errorf("failed to create requests_total counter: %w", err)
+	}
+
+	errorCounter, err := meter.Int64Counter(
 		"errors_total",
-		"Total number of errors",
+		metric.WithDescription("Total number of errors"),
 	)
-	
+	if err != nil {
+		return fmt.Errorf("failed to create errors_total counter: %w", err)
+	}
+
 	// Create histogram
-	requestDuration := meter.Float64Histogram(
+	requestDuration, err := meter.Float64Histogram(
 		"request_duration_seconds",
-		"Request duration in seconds",
+		metric.WithDescription("Request duration in seconds"),
 		metric.WithUnit("s"),
-		metric.WithExplicitBucketBoundaries([]float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1.0, 2.5, 5.0, 10.0}),
+		metric.WithExplicitBucketBoundaries(0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1.0, 2.5, 5.0, 10.0),
 	)
+	if err != nil {
+		return fmt.Errorf("failed to create request_duration_seconds histogram: %w", err)
+	}
 
 	// Store metrics for later use
 	RequestCounter = requestCounter
@@ -110,11 +273,23 @@ func InitOpenTelemetry() error {
 
 // Global metrics
 var (
-	RequestCounter    otel.Int64Counter
-	ErrorCounter      otel.Int64Counter
-	RequestDuration    otel.Float64Histogram
+	RequestCounter  metric.Int64Counter
+	ErrorCounter    metric.Int64Counter
+	RequestDuration metric.Float64Histogram
 )
 
+// InitPropagators wires the global propagator as a composite of W3C
+// TraceContext (traceparent/tracestate) and Baggage, so both inbound
+// extraction and outbound injection understand the same header set.
+// SetTextMapPropagator replaces rather than merges, which is why this must
+// be a single composite call instead of two separate Set calls.
+func InitPropagators() {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+}
+
 // GetTraceID extracts trace ID from context
 func GetTraceID(ctx context.Context) string {
 	spanCtx := trace.SpanContextFromContext(ctx)
@@ -124,6 +299,17 @@ func GetTraceID(ctx context.Context) string {
 	return spanCtx.TraceID().String()
 }
 
+// GetSpanID extracts the active span's ID from context, the same way
+// GetTraceID extracts its trace ID, so callers that join logs to a span
+// (e.g. pkg/logger's structured façade) can tag both.
+func GetSpanID(ctx context.Context) string {
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
+		return ""
+	}
+	return spanCtx.SpanID().String()
+}
+
 // InjectTraceID injects trace ID into gRPC metadata
 func InjectTraceID(ctx context.Context, metadata map[string]string) {
 	if traceID := GetTraceID(ctx); traceID != "" {
@@ -134,7 +320,7 @@ func InjectTraceID(ctx context.Context, metadata map[string]string) {
 // CreateSpan creates a new span with the given name
 func CreateSpan(ctx context.Context, name string, operation string) (context.Context, trace.Span) {
 	tracer := otel.Tracer(serviceName)
-	
+
 	ctx, span := tracer.Start(
 		ctx,
 		name,
@@ -144,7 +330,7 @@ func CreateSpan(ctx context.Context, name string, operation string) (context.Con
 			attribute.String("version", serviceVersion),
 		),
 	)
-	
+
 	return ctx, span
 }
 
@@ -155,44 +341,61 @@ func RecordError(ctx context.Context, span trace.Span, err error) {
 	ErrorCounter.Add(ctx, 1)
 }
 
-// Shutdown gracefully shuts down OpenTelemetry
+// Shutdown flushes and stops the tracer, meter, and logger providers
+// InitOpenTelemetry installed, bounding the whole flush to ctx's deadline so
+// a wedged collector connection can't hang process shutdown indefinitely.
 func Shutdown(ctx context.Context) error {
-	// Shutdown trace provider
-	if tp := otel.GetTracerProvider(); tp != nil {
-		if err := tp.(interface{ Shutdown(context.Context) error }).Shutdown(ctx); err != nil {
+	if providers.tracerProvider != nil {
+		if err := providers.tracerProvider.Shutdown(ctx); err != nil {
 			return fmt.Errorf("failed to shutdown tracer provider: %w", err)
 		}
 	}
-	
-	// Shutdown meter provider
-	if mp := otel.GetMeterProvider(); mp != nil {
-		if err := mp.(interface{ Shutdown(context.Context) error }).Shutdown(ctx); err != nil {
+
+	if providers.meterProvider != nil {
+		if err := providers.meterProvider.Shutdown(ctx); err != nil {
 			return fmt.Errorf("failed to shutdown meter provider: %w", err)
 		}
 	}
-	
+
+	if providers.loggerProvider != nil {
+		if err := providers.loggerProvider.Shutdown(ctx); err != nil {
+			return fmt.Errorf("failed to shutdown logger provider: %w", err)
+		}
+	}
+
 	return nil
 }
 
-// RecordRequest records a request in the current span
-func RecordRequest(ctx context.Context, span trace.Span, method, path string, statusCode int) {
-	span.SetAttributes(
-		attribute.String("http.method", method),
-		attribute.String("http.path", path),
-		attribute.Int("http.status_code", statusCode),
-	)
-	
-	// Record status code as metric
-	RequestCounter.Add(ctx, 1)
-	
-	// Record request duration
-	duration := float64(time.Since(span.StartTime().UnixNano()) / 1e9)
-	RequestDuration.Record(ctx, duration)
-	
-	// Set span status based on status code
-	if statusCode >= 400 {
-		span.SetStatus(codes.Error, fmt.Sprintf("HTTP %d", statusCode))
-	} else {
-		span.SetStatus(codes.Ok, "OK")
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if intValue, err := strconv.Atoi(value); err == nil {
+			return intValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if duration, err := time.ParseDuration(value); err == nil {
+			return duration
+		}
 	}
+	return defaultValue
 }
@@ -5,6 +5,7 @@ package resilience
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"math/rand"
@@ -13,11 +14,16 @@ import (
 	"time"
 
 	"github.com/market-intel/api-gateway/pkg/logger"
-	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// ErrConcurrencyLimit is returned by CircuitBreaker.Execute when the
+// adaptive concurrency limiter is saturated; distinct from the "circuit
+// breaker is open" error so callers can tell rate-shedding apart from an
+// unhealthy-upstream trip.
+var ErrConcurrencyLimit = errors.New("circuit breaker: concurrency limit reached")
+
 // Circuit breaker states
 type CircuitState int32
 
@@ -31,46 +37,252 @@ const (
 type CircuitBreakerConfig struct {
 	// Maximum number of failures before opening circuit
 	MaxFailures int `json:"max_failures" yaml:"max_failures"`
-	
+
 	// Timeout for half-open state
 	Timeout time.Duration `json:"timeout" yaml:"timeout"`
-	
+
 	// Reset timeout for open state
 	ResetTimeout time.Duration `json:"reset_timeout" yaml:"reset_timeout"`
-	
+
 	// Whether to enable metrics
 	EnableMetrics bool `json:"enable_metrics" yaml:"enable_metrics"`
+
+	// Rolling window failure/slow-call rate tripping (resilience4j-style),
+	// evaluated in addition to the raw consecutive MaxFailures counter
+	// above. WindowBuckets <= 0 disables it.
+	WindowBuckets             int           `json:"window_buckets" yaml:"window_buckets"`
+	BucketDuration            time.Duration `json:"bucket_duration" yaml:"bucket_duration"`
+	MinimumRequests           int           `json:"minimum_requests" yaml:"minimum_requests"`
+	FailureRateThreshold      float64       `json:"failure_rate_threshold" yaml:"failure_rate_threshold"`
+	SlowCallRateThreshold     float64       `json:"slow_call_rate_threshold" yaml:"slow_call_rate_threshold"`
+	SlowCallDurationThreshold time.Duration `json:"slow_call_duration_threshold" yaml:"slow_call_duration_threshold"`
+
+	// Adaptive (AIMD) concurrency limit: the in-flight limit grows by
+	// ConcurrencyAdditiveIncrease on every success and shrinks by
+	// ConcurrencyMultiplicativeDecrease on every failure/timeout. Execute
+	// rejects with ErrConcurrencyLimit once in-flight requests reach the
+	// current limit.
+	ConcurrencyLimitEnabled           bool    `json:"concurrency_limit_enabled" yaml:"concurrency_limit_enabled"`
+	InitialConcurrencyLimit           int64   `json:"initial_concurrency_limit" yaml:"initial_concurrency_limit"`
+	MinConcurrencyLimit               int64   `json:"min_concurrency_limit" yaml:"min_concurrency_limit"`
+	MaxConcurrencyLimit               int64   `json:"max_concurrency_limit" yaml:"max_concurrency_limit"`
+	ConcurrencyAdditiveIncrease       int64   `json:"concurrency_additive_increase" yaml:"concurrency_additive_increase"`
+	ConcurrencyMultiplicativeDecrease float64 `json:"concurrency_multiplicative_decrease" yaml:"concurrency_multiplicative_decrease"`
 }
 
 // Default circuit breaker configuration
 func DefaultCircuitBreakerConfig() *CircuitBreakerConfig {
 	return &CircuitBreakerConfig{
 		MaxFailures:   5,
-		Timeout:        30 * time.Second,
-		ResetTimeout:   60 * time.Second,
+		Timeout:       30 * time.Second,
+		ResetTimeout:  60 * time.Second,
 		EnableMetrics: true,
+
+		WindowBuckets:             10,
+		BucketDuration:            time.Second,
+		MinimumRequests:           20,
+		FailureRateThreshold:      0.5,
+		SlowCallRateThreshold:     0.5,
+		SlowCallDurationThreshold: time.Second,
+
+		ConcurrencyLimitEnabled:           true,
+		InitialConcurrencyLimit:           50,
+		MinConcurrencyLimit:               5,
+		MaxConcurrencyLimit:               500,
+		ConcurrencyAdditiveIncrease:       5,
+		ConcurrencyMultiplicativeDecrease: 0.5,
 	}
 }
 
+// windowBucket tallies outcomes recorded during one BucketDuration slice of
+// the rolling window
+type windowBucket struct {
+	successes int64
+	failures  int64
+	timeouts  int64
+	slowCalls int64
+}
+
+// failureWindow is a ring of windowBuckets covering the last
+// len(buckets)*bucketDuration of calls, used to compute resilience4j-style
+// failure/slow-call rates instead of a single consecutive-failure counter
+type failureWindow struct {
+	mu             sync.Mutex
+	buckets        []windowBucket
+	bucketDuration time.Duration
+	index          int
+	boundary       time.Time
+}
+
+func newFailureWindow(bucketCount int, bucketDuration time.Duration) *failureWindow {
+	return &failureWindow{
+		buckets:        make([]windowBucket, bucketCount),
+		bucketDuration: bucketDuration,
+		boundary:       time.Now().Add(bucketDuration),
+	}
+}
+
+// advance rotates the ring forward to the current time, clearing any
+// buckets that have aged out, and returns the index to record into. Callers
+// must hold w.mu.
+func (w *failureWindow) advance() int {
+	now := time.Now()
+	for !now.Before(w.boundary) {
+		w.index = (w.index + 1) % len(w.buckets)
+		w.buckets[w.index] = windowBucket{}
+		w.boundary = w.boundary.Add(w.bucketDuration)
+	}
+	return w.index
+}
+
+// record tallies one completed call's outcome into the live bucket
+func (w *failureWindow) record(success, timeout, slow bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	idx := w.advance()
+	if success {
+		w.buckets[idx].successes++
+	} else {
+		w.buckets[idx].failures++
+		if timeout {
+			w.buckets[idx].timeouts++
+		}
+	}
+	if slow {
+		w.buckets[idx].slowCalls++
+	}
+}
+
+// snapshot sums every live bucket and returns the request volume plus the
+// failure and slow-call rates over that volume
+func (w *failureWindow) snapshot() (total int64, failureRate, slowCallRate float64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.advance()
+
+	var successes, failures, slow int64
+	for _, b := range w.buckets {
+		successes += b.successes
+		failures += b.failures
+		slow += b.slowCalls
+	}
+
+	total = successes + failures
+	if total == 0 {
+		return 0, 0, 0
+	}
+	return total, float64(failures) / float64(total), float64(slow) / float64(total)
+}
+
+// concurrencyLimiter is an AIMD in-flight request limiter modeled on gRPC's
+// adaptive throttling / outlier detection: the limit grows additively on
+// every success and shrinks multiplicatively on every failure, so it
+// settles near the upstream's actual sustainable concurrency instead of a
+// fixed guess.
+type concurrencyLimiter struct {
+	mu             sync.Mutex
+	limit          int64
+	inFlight       int64
+	min            int64
+	max            int64
+	additive       int64
+	multiplicative float64
+	rejections     int64
+}
+
+func newConcurrencyLimiter(config *CircuitBreakerConfig) *concurrencyLimiter {
+	return &concurrencyLimiter{
+		limit:          config.InitialConcurrencyLimit,
+		min:            config.MinConcurrencyLimit,
+		max:            config.MaxConcurrencyLimit,
+		additive:       config.ConcurrencyAdditiveIncrease,
+		multiplicative: config.ConcurrencyMultiplicativeDecrease,
+	}
+}
+
+// tryAcquire reserves one in-flight slot, or counts a rejection and returns
+// false if the current limit is already saturated
+func (l *concurrencyLimiter) tryAcquire() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.inFlight >= l.limit {
+		l.rejections++
+		return false
+	}
+	l.inFlight++
+	return true
+}
+
+// release frees the in-flight slot and adjusts the limit: additively up on
+// success, multiplicatively down on failure, clamped to [min, max]
+func (l *concurrencyLimiter) release(success bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inFlight--
+
+	if success {
+		l.limit += l.additive
+		if l.limit > l.max {
+			l.limit = l.max
+		}
+		return
+	}
+
+	l.limit = int64(float64(l.limit) * l.multiplicative)
+	if l.limit < l.min {
+		l.limit = l.min
+	}
+}
+
+// snapshot returns the current limit, in-flight count, and cumulative
+// rejection count
+func (l *concurrencyLimiter) snapshot() (limit, inFlight, rejections int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limit, l.inFlight, l.rejections
+}
+
 // Circuit breaker implementation
 type CircuitBreaker struct {
-	config           *CircuitBreakerConfig
-	state            int32
-	failures         int64
-	lastFailureTime  int64
-	generation       int64
+	config          *CircuitBreakerConfig
+	state           int32
+	failures        int64
+	lastFailureTime int64
+	generation      int64
 	mu              sync.RWMutex
-	metrics          *CircuitBreakerMetrics
+	metrics         *CircuitBreakerMetrics
+	window          *failureWindow
+	limiter         *concurrencyLimiter
+
+	// halfOpenProbe gates half-open admission to a single in-flight trial
+	// request: 0 means a probe may be admitted, 1 means one already is.
+	// open()/close() reset it to 0 when the probe's outcome resolves the
+	// trial one way or the other.
+	halfOpenProbe int32
 }
 
 // Circuit breaker metrics
 type CircuitBreakerMetrics struct {
 	RequestsTotal      int64
-	SuccessesTotal    int64
-	FailuresTotal     int64
-	CircuitOpensTotal int64
+	SuccessesTotal     int64
+	FailuresTotal      int64
+	CircuitOpensTotal  int64
 	CircuitClosesTotal int64
-	TimeoutsTotal     int64
+	TimeoutsTotal      int64
+
+	// Window-derived rates, zero if the rolling window is disabled
+	WindowRequestsTotal int64
+	WindowFailureRate   float64
+	WindowSlowCallRate  float64
+
+	// Adaptive concurrency limiter state, zero if disabled
+	ConcurrencyLimit           int64
+	ConcurrencyInFlight        int64
+	ConcurrencyRejectionsTotal int64
 }
 
 // Create new circuit breaker
@@ -78,74 +290,123 @@ func NewCircuitBreaker(config *CircuitBreakerConfig) *CircuitBreaker {
 	if config == nil {
 		config = DefaultCircuitBreakerConfig()
 	}
-	
+
 	cb := &CircuitBreaker{
 		config:  config,
 		state:   int32(StateClosed),
 		metrics: &CircuitBreakerMetrics{},
 	}
-	
+
+	if config.WindowBuckets > 0 {
+		cb.window = newFailureWindow(config.WindowBuckets, config.BucketDuration)
+	}
+	if config.ConcurrencyLimitEnabled {
+		cb.limiter = newConcurrencyLimiter(config)
+	}
+
 	if config.EnableMetrics {
 		logger.Infof("Circuit breaker initialized with metrics enabled")
 	}
-	
+
 	return cb
 }
 
 // Execute function with circuit breaker protection
 func (cb *CircuitBreaker) Execute(ctx context.Context, fn func() error) error {
-	// Check if circuit is open
-	if cb.isOpen() {
+	if cb.limiter != nil && !cb.limiter.tryAcquire() {
+		return ErrConcurrencyLimit
+	}
+
+	var err error
+
+	switch {
+	case cb.isOpen():
 		cb.recordRequest()
 		cb.recordFailure()
-		return fmt.Errorf("circuit breaker is open")
-	}
-	
-	// Check if circuit is half-open
-	if cb.isHalfOpen() {
+		err = fmt.Errorf("circuit breaker is open")
+
+	case cb.isHalfOpen():
 		cb.recordRequest()
-		// Allow single request through in half-open state
-		err := fn()
-		if err != nil {
+		if !cb.tryAcquireProbe() {
+			// A trial request is already in flight; admitting more would be
+			// exactly the full-traffic flood half-open is meant to prevent,
+			// so treat this one like the circuit is still open.
+			cb.recordFailure()
+			err = fmt.Errorf("circuit breaker is half-open: probe already in flight")
+			break
+		}
+
+		err = cb.runFn(fn)
+		if err != nil && isCountableFailure(ctx, err) {
 			cb.recordFailure()
 			cb.open()
 		} else {
+			// A non-countable "failure" (see isCountableFailure) still means
+			// the probe reached the upstream, so it closes the circuit the
+			// same as an outright success would.
 			cb.recordSuccess()
 			cb.close()
 		}
-		return err
+
+	default:
+		// Circuit is closed, allow request through
+		cb.recordRequest()
+		err = cb.runFn(fn)
+		if err != nil && isCountableFailure(ctx, err) {
+			cb.recordFailure()
+		} else {
+			cb.recordSuccess()
+		}
+		// checkThresholds runs on every call, not just failures, because the
+		// rolling window can trip on a rate even when the call that just
+		// completed the minimum-request volume happened to succeed.
+		cb.checkThresholds()
+	}
+
+	if cb.limiter != nil {
+		cb.limiter.release(err == nil)
 	}
-	
-	// Circuit is closed, allow request through
-	cb.recordRequest()
+
+	return err
+}
+
+// runFn executes fn, timing the call and recording its outcome into the
+// rolling window (if enabled) so checkThresholds can compute rate-based
+// trip conditions alongside the raw consecutive-failure counter
+func (cb *CircuitBreaker) runFn(fn func() error) error {
+	start := time.Now()
 	err := fn()
-	if err != nil {
-		cb.recordFailure()
-		cb.checkThresholds()
-	} else {
-		cb.recordSuccess()
+
+	if cb.window != nil {
+		duration := time.Since(start)
+		timeout := errors.Is(err, context.DeadlineExceeded)
+		slow := cb.config.SlowCallDurationThreshold > 0 && duration >= cb.config.SlowCallDurationThreshold
+		cb.window.record(err == nil, timeout, slow)
 	}
-	
+
 	return err
 }
 
-// Check if circuit is open
+// Check if circuit is open. If the reset timeout has passed, this trips the
+// circuit into half-open instead of closing it outright -- recovery is
+// proven with a single trial request (see isHalfOpen/tryAcquireProbe)
+// rather than by reopening the floodgates to full traffic.
 func (cb *CircuitBreaker) isOpen() bool {
 	cb.mu.RLock()
-	defer cb.mu.RUnlock()
-	
-	if cb.state == int32(StateOpen) {
-		// Check if reset timeout has passed
-		lastFailure := time.Unix(atomic.LoadInt64(&cb.lastFailureTime), 0)
-		if time.Since(lastFailure) > cb.config.ResetTimeout {
-			logger.Infof("Circuit breaker reset timeout reached, closing circuit")
-			cb.close()
-			return false
-		}
-		return true
+	state := cb.state
+	cb.mu.RUnlock()
+
+	if state != int32(StateOpen) {
+		return false
 	}
-	
-	return false
+
+	lastFailure := time.Unix(atomic.LoadInt64(&cb.lastFailureTime), 0)
+	if time.Since(lastFailure) > cb.config.ResetTimeout {
+		logger.Infof("Circuit breaker reset timeout reached, probing via half-open")
+		cb.halfOpen()
+		return false
+	}
+	return true
 }
 
 // Check if circuit is half-open
@@ -166,44 +427,79 @@ func (cb *CircuitBreaker) isClosed() bool {
 func (cb *CircuitBreaker) open() {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
-	
+
 	if cb.state != int32(StateOpen) {
 		atomic.StoreInt32(&cb.state, int32(StateOpen))
 		atomic.StoreInt64(&cb.lastFailureTime, time.Now().Unix())
 		atomic.AddInt64(&cb.metrics.CircuitOpensTotal, 1)
 		logger.Warnf("Circuit breaker opened due to failure threshold")
 	}
+	atomic.StoreInt32(&cb.halfOpenProbe, 0)
 }
 
 // Close circuit
 func (cb *CircuitBreaker) close() {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
-	
+
 	if cb.state != int32(StateClosed) {
 		atomic.StoreInt32(&cb.state, int32(StateClosed))
 		atomic.StoreInt64(&cb.failures, 0)
 		atomic.AddInt64(&cb.metrics.CircuitClosesTotal, 1)
 		logger.Infof("Circuit breaker closed")
 	}
+	atomic.StoreInt32(&cb.halfOpenProbe, 0)
 }
 
 // Set circuit to half-open
 func (cb *CircuitBreaker) halfOpen() {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
-	
+
 	if cb.state != int32(StateHalfOpen) {
 		atomic.StoreInt32(&cb.state, int32(StateHalfOpen))
 		logger.Infof("Circuit breaker set to half-open state")
 	}
+	atomic.StoreInt32(&cb.halfOpenProbe, 0)
+}
+
+// tryAcquireProbe admits the single trial request half-open allows through,
+// reporting false if one is already in flight. The admitted probe's outcome
+// (open() on failure, close() on success) resets this so the next half-open
+// cycle can admit one of its own.
+func (cb *CircuitBreaker) tryAcquireProbe() bool {
+	return atomic.CompareAndSwapInt32(&cb.halfOpenProbe, 0, 1)
 }
 
-// Check thresholds and potentially open circuit
+// Check thresholds and potentially open circuit. Trips on either the raw
+// consecutive-failure counter or, if the rolling window is enabled and has
+// seen at least MinimumRequests calls, a failure or slow-call rate at or
+// above the configured thresholds.
 func (cb *CircuitBreaker) checkThresholds() {
 	failures := atomic.LoadInt64(&cb.failures)
 	if failures >= int64(cb.config.MaxFailures) {
 		cb.open()
+		return
+	}
+
+	if cb.window == nil {
+		return
+	}
+
+	total, failureRate, slowCallRate := cb.window.snapshot()
+	if total < int64(cb.config.MinimumRequests) {
+		return
+	}
+
+	if cb.config.FailureRateThreshold > 0 && failureRate >= cb.config.FailureRateThreshold {
+		logger.Warnf("Circuit breaker opening: window failure rate %.2f >= threshold %.2f over %d requests", failureRate, cb.config.FailureRateThreshold, total)
+		cb.open()
+		return
+	}
+
+	if cb.config.SlowCallRateThreshold > 0 && slowCallRate >= cb.config.SlowCallRateThreshold {
+		logger.Warnf("Circuit breaker opening: window slow-call rate %.2f >= threshold %.2f over %d requests", slowCallRate, cb.config.SlowCallRateThreshold, total)
+		cb.open()
 	}
 }
 
@@ -219,7 +515,7 @@ func (cb *CircuitBreaker) recordSuccess() {
 	if cb.config.EnableMetrics {
 		atomic.AddInt64(&cb.metrics.SuccessesTotal, 1)
 	}
-	
+
 	// Reset failure count on success
 	atomic.StoreInt64(&cb.failures, 0)
 }
@@ -229,7 +525,7 @@ func (cb *CircuitBreaker) recordFailure() {
 	if cb.config.EnableMetrics {
 		atomic.AddInt64(&cb.metrics.FailuresTotal, 1)
 	}
-	
+
 	atomic.AddInt64(&cb.failures, 1)
 	atomic.StoreInt64(&cb.lastFailureTime, time.Now().Unix())
 }
@@ -239,7 +535,7 @@ func (cb *CircuitBreaker) recordTimeout() {
 	if cb.config.EnableMetrics {
 		atomic.AddInt64(&cb.metrics.TimeoutsTotal, 1)
 	}
-	
+
 	atomic.AddInt64(&cb.failures, 1)
 	atomic.StoreInt64(&cb.lastFailureTime, time.Now().Unix())
 }
@@ -251,26 +547,42 @@ func (cb *CircuitBreaker) GetState() CircuitState {
 
 // Get metrics
 func (cb *CircuitBreaker) GetMetrics() CircuitBreakerMetrics {
-	return CircuitBreakerMetrics{
+	metrics := CircuitBreakerMetrics{
 		RequestsTotal:      atomic.LoadInt64(&cb.metrics.RequestsTotal),
-		SuccessesTotal:    atomic.LoadInt64(&cb.metrics.SuccessesTotal),
-		FailuresTotal:     atomic.LoadInt64(&cb.metrics.FailuresTotal),
-		CircuitOpensTotal: atomic.LoadInt64(&cb.metrics.CircuitOpensTotal),
+		SuccessesTotal:     atomic.LoadInt64(&cb.metrics.SuccessesTotal),
+		FailuresTotal:      atomic.LoadInt64(&cb.metrics.FailuresTotal),
+		CircuitOpensTotal:  atomic.LoadInt64(&cb.metrics.CircuitOpensTotal),
 		CircuitClosesTotal: atomic.LoadInt64(&cb.metrics.CircuitClosesTotal),
-		TimeoutsTotal:     atomic.LoadInt64(&cb.metrics.TimeoutsTotal),
+		TimeoutsTotal:      atomic.LoadInt64(&cb.metrics.TimeoutsTotal),
+	}
+
+	if cb.window != nil {
+		total, failureRate, slowCallRate := cb.window.snapshot()
+		metrics.WindowRequestsTotal = total
+		metrics.WindowFailureRate = failureRate
+		metrics.WindowSlowCallRate = slowCallRate
 	}
+
+	if cb.limiter != nil {
+		limit, inFlight, rejections := cb.limiter.snapshot()
+		metrics.ConcurrencyLimit = limit
+		metrics.ConcurrencyInFlight = inFlight
+		metrics.ConcurrencyRejectionsTotal = rejections
+	}
+
+	return metrics
 }
 
 // Reset circuit breaker
 func (cb *CircuitBreaker) Reset() {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
-	
+
 	atomic.StoreInt32(&cb.state, int32(StateClosed))
 	atomic.StoreInt64(&cb.failures, 0)
 	atomic.StoreInt64(&cb.lastFailureTime, 0)
 	atomic.StoreInt64(&cb.generation, atomic.LoadInt64(&cb.generation)+1)
-	
+
 	logger.Infof("Circuit breaker reset")
 }
 
@@ -297,25 +609,137 @@ func (s CircuitState) String() string {
 type CircuitBreakerWithRetry struct {
 	*CircuitBreaker
 	retryConfig *RetryConfig
+
+	// hedging, if set via WithHedging, switches Execute from serial retry
+	// to gRPC-style hedging: additional attempts fire on a timer while an
+	// earlier one is still in flight, and the first to succeed wins.
+	hedging *HedgingConfig
+
+	// budget, if set via WithRetryBudget, is consumed on every retry
+	// attempt (not the first) and refunded on success, so a sustained high
+	// failure ratio throttles retries instead of amplifying an outage.
+	budget *RetryBudget
+
+	hedgedWinsTotal        int64
+	deadlineTruncatedTotal int64
+}
+
+// HedgingConfig enables gRPC A6-style hedging: instead of waiting for one
+// attempt to fail before retrying, additional attempts are fired after
+// HedgeDelay while earlier ones are still in flight, and Execute returns
+// whichever attempt completes successfully first, cancelling the rest.
+type HedgingConfig struct {
+	// MaxHedges is how many extra attempts may run alongside the original;
+	// 0 disables hedging
+	MaxHedges int `json:"max_hedges" yaml:"max_hedges"`
+
+	// HedgeDelay is how long to wait after an attempt starts before firing
+	// the next one
+	HedgeDelay time.Duration `json:"hedge_delay" yaml:"hedge_delay"`
+}
+
+// DefaultHedgingConfig returns hedging disabled (MaxHedges: 0); callers opt
+// in via WithHedging
+func DefaultHedgingConfig() *HedgingConfig {
+	return &HedgingConfig{
+		MaxHedges:  0,
+		HedgeDelay: 200 * time.Millisecond,
+	}
+}
+
+// RetryBudget is a token bucket shared, by construction, across every
+// CircuitBreakerWithRetry call made through a client. Tokens refill at
+// FillRatePerSecond up to Burst; every retry attempt spends one token and
+// every first-attempt success refunds SuccessRefund tokens, so retries
+// throttle themselves once the failure ratio climbs instead of amplifying
+// an outage (the retry-storm failure mode).
+type RetryBudget struct {
+	mu              sync.Mutex
+	tokens          float64
+	burst           float64
+	fillRate        float64
+	successRefund   float64
+	lastRefill      time.Time
+	rejectionsTotal int64
+}
+
+// NewRetryBudget creates a RetryBudget starting full, refilling at
+// fillRatePerSecond up to burst tokens, and refunding successRefund tokens
+// per successful first attempt
+func NewRetryBudget(fillRatePerSecond, burst, successRefund float64) *RetryBudget {
+	return &RetryBudget{
+		tokens:        burst,
+		burst:         burst,
+		fillRate:      fillRatePerSecond,
+		successRefund: successRefund,
+		lastRefill:    time.Now(),
+	}
+}
+
+// refill adds tokens for the time elapsed since the last refill. Callers
+// must hold b.mu.
+func (b *RetryBudget) refill() {
+	now := time.Now()
+	elapsed := now.Sub(b.lastRefill).Seconds()
+	b.lastRefill = now
+
+	b.tokens += elapsed * b.fillRate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+// TryConsume spends one token for a retry attempt, returning false (and
+// counting a rejection) if the budget is empty
+func (b *RetryBudget) TryConsume() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill()
+	if b.tokens < 1 {
+		b.rejectionsTotal++
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// Refund credits the budget after a successful call
+func (b *RetryBudget) Refund() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill()
+	b.tokens += b.successRefund
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+// RejectionsTotal returns how many TryConsume calls found an empty budget
+func (b *RetryBudget) RejectionsTotal() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.rejectionsTotal
 }
 
 // Retry configuration
 type RetryConfig struct {
 	// Maximum number of retry attempts
 	MaxRetries int `json:"max_retries" yaml:"max_retries"`
-	
+
 	// Initial backoff delay
 	InitialDelay time.Duration `json:"initial_delay" yaml:"initial_delay"`
-	
+
 	// Maximum backoff delay
 	MaxDelay time.Duration `json:"max_delay" yaml:"max_delay"`
-	
+
 	// Backoff multiplier
 	Multiplier float64 `json:"multiplier" yaml:"multiplier"`
-	
+
 	// Whether to use jitter
 	Jitter bool `json:"jitter" yaml:"jitter"`
-	
+
 	// Retryable error codes
 	RetryableCodes []codes.Code `json:"retryable_codes" yaml:"retryable_codes"`
 }
@@ -323,11 +747,11 @@ type RetryConfig struct {
 // Default retry configuration
 func DefaultRetryConfig() *RetryConfig {
 	return &RetryConfig{
-		MaxRetries:     3,
-		InitialDelay:    100 * time.Millisecond,
-		MaxDelay:        5 * time.Second,
-		Multiplier:      2.0,
-		Jitter:          true,
+		MaxRetries:   3,
+		InitialDelay: 100 * time.Millisecond,
+		MaxDelay:     5 * time.Second,
+		Multiplier:   2.0,
+		Jitter:       true,
 		RetryableCodes: []codes.Code{
 			codes.Unavailable,
 			codes.DeadlineExceeded,
@@ -347,50 +771,215 @@ func NewCircuitBreakerWithRetry(cbConfig *CircuitBreakerConfig, retryConfig *Ret
 	if retryConfig == nil {
 		retryConfig = DefaultRetryConfig()
 	}
-	
+
 	return &CircuitBreakerWithRetry{
 		CircuitBreaker: NewCircuitBreaker(cbConfig),
 		retryConfig:    retryConfig,
 	}
 }
 
-// Execute with circuit breaker and retry
+// WithHedging enables gRPC-style hedging on this client; pass nil or
+// MaxHedges: 0 to disable it again
+func (cbr *CircuitBreakerWithRetry) WithHedging(config *HedgingConfig) *CircuitBreakerWithRetry {
+	cbr.hedging = config
+	return cbr
+}
+
+// WithRetryBudget attaches a shared RetryBudget that every retry attempt
+// made through this client draws from
+func (cbr *CircuitBreakerWithRetry) WithRetryBudget(budget *RetryBudget) *CircuitBreakerWithRetry {
+	cbr.budget = budget
+	return cbr
+}
+
+// failureClassifierKey is the context key WithFailureClassifier stores its
+// callback under; unexported so only this package's Execute path reads it.
+type failureClassifierKey struct{}
+
+// WithFailureClassifier returns ctx carrying fn, which CircuitBreaker.Execute
+// consults before recording a failure: fn reports whether err should count
+// against the breaker's failure rate at all. This lets a caller mark
+// expected business rejections -- e.g. a gRPC NotFound for a symbol that
+// doesn't exist -- as not evidence the upstream is unhealthy, the same way
+// WithBackoffOverride lets a caller honor a server's retry hint without
+// CircuitBreakerWithRetry needing to know anything about gRPC. Without a
+// classifier in ctx, every non-nil error counts, matching prior behavior.
+func WithFailureClassifier(ctx context.Context, fn func(err error) bool) context.Context {
+	return context.WithValue(ctx, failureClassifierKey{}, fn)
+}
+
+// isCountableFailure reports whether err should count as a circuit-breaker
+// failure, consulting ctx's classifier (see WithFailureClassifier) if one
+// is present.
+func isCountableFailure(ctx context.Context, err error) bool {
+	if classifier, ok := ctx.Value(failureClassifierKey{}).(func(error) bool); ok {
+		return classifier(err)
+	}
+	return true
+}
+
+// RetryClass classifies how safe a method is to retry automatically, the
+// same distinction gRPC-go itself draws between a "transparent" retry (safe
+// regardless of idempotency, because the runtime knows the previous attempt
+// never put a byte on the wire) and an application-level retry (safe only
+// because the method itself is idempotent).
+type RetryClass string
+
+const (
+	// RetryClassIdempotent methods (reads: Fetch*/Get*/HealthCheck/GetStatus)
+	// may be retried on any RetryableCodes failure the normal bounded,
+	// backed-off way, since repeating them has no side effect to duplicate.
+	RetryClassIdempotent RetryClass = "idempotent"
+
+	// RetryClassNonIdempotent methods (writes: ConnectDataSource) have a
+	// side effect that repeating could duplicate, so they're retried only
+	// transparently: a single extra attempt, and only when the failure is
+	// codes.Unavailable on the very first attempt -- the one case where the
+	// request plausibly never reached the server at all (e.g. the
+	// connection wasn't even established yet). Any failure after that is
+	// returned to the caller rather than retried.
+	RetryClassNonIdempotent RetryClass = "non_idempotent"
+)
+
+// RetryPolicy governs how CircuitBreakerWithRetry.Execute retries a
+// particular method, overriding the class-based default behavior described
+// by RetryClassIdempotent/RetryClassNonIdempotent above.
+type RetryPolicy struct {
+	Class RetryClass
+
+	// MaxRetries overrides RetryConfig.MaxRetries for this method if
+	// non-zero. RetryClassNonIdempotent ignores this -- it always allows at
+	// most one transparent retry regardless.
+	MaxRetries int
+}
+
+// retryPolicyKey is the context key WithRetryPolicy stores its RetryPolicy
+// under; unexported so only this package's Execute path reads it.
+type retryPolicyKey struct{}
+
+// WithRetryPolicy returns ctx carrying policy, which executeSerial consults
+// in place of the default "retry on any RetryableCodes failure, bounded by
+// RetryConfig.MaxRetries" behavior. This is how a caller that knows which
+// gRPC method it's calling -- e.g. CoreEngineClient's per-method policy
+// table -- tells a method-agnostic CircuitBreakerWithRetry that this
+// particular call is a non-idempotent write and must not be retried after
+// it's actually been attempted.
+func WithRetryPolicy(ctx context.Context, policy RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey{}, policy)
+}
+
+// retryPolicyFromContext returns ctx's RetryPolicy, defaulting to
+// RetryClassIdempotent (prior behavior: every RetryableCodes failure is
+// retried the same way regardless of method) if none was attached.
+func retryPolicyFromContext(ctx context.Context) RetryPolicy {
+	if policy, ok := ctx.Value(retryPolicyKey{}).(RetryPolicy); ok {
+		return policy
+	}
+	return RetryPolicy{Class: RetryClassIdempotent}
+}
+
+// backoffOverrideKey is the context key WithBackoffOverride stores its
+// callback under; unexported so only this package's Execute path reads it.
+type backoffOverrideKey struct{}
+
+// WithBackoffOverride returns ctx carrying fn, which executeSerial consults
+// before each backoff sleep in place of the computed exponential delay.
+// fn receives the attempt's error and returns (delay, true) to override, or
+// (_, false) to leave the computed backoff alone; a negative delay means
+// stop retrying entirely. This is how a gRPC client interceptor honors a
+// server's grpc-retry-pushback-ms hint (per the gRPC A6 retry design)
+// without CircuitBreakerWithRetry needing to know anything about gRPC.
+func WithBackoffOverride(ctx context.Context, fn func(err error) (time.Duration, bool)) context.Context {
+	return context.WithValue(ctx, backoffOverrideKey{}, fn)
+}
+
+// Execute with circuit breaker and retry. When hedging is enabled (via
+// WithHedging) it dispatches to executeHedged instead of the default
+// serial backoff loop -- the two policies are mutually exclusive, same as
+// gRPC only ever applies one retry policy per method.
 func (cbr *CircuitBreakerWithRetry) Execute(ctx context.Context, fn func() error) error {
+	if cbr.hedging != nil && cbr.hedging.MaxHedges > 0 {
+		return cbr.executeHedged(ctx, fn)
+	}
+	return cbr.executeSerial(ctx, fn)
+}
+
+// executeSerial is the default exponential-backoff retry loop, extended to
+// respect a shared RetryBudget and to clamp (or skip) backoff sleeps that
+// would run past the context deadline instead of sleeping through it.
+func (cbr *CircuitBreakerWithRetry) executeSerial(ctx context.Context, fn func() error) error {
 	var lastErr error
-	
-	for attempt := 0; attempt <= cbr.retryConfig.MaxRetries; attempt++ {
+
+	policy := retryPolicyFromContext(ctx)
+	maxRetries := cbr.retryConfig.MaxRetries
+	if policy.Class == RetryClassNonIdempotent {
+		maxRetries = 1
+	} else if policy.MaxRetries > 0 {
+		maxRetries = policy.MaxRetries
+	}
+
+	for attempt := 0; attempt <= maxRetries; attempt++ {
 		// Check if context is cancelled
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
-		
+
 		// Execute with circuit breaker
 		err := cbr.CircuitBreaker.Execute(ctx, fn)
 		if err == nil {
 			if attempt > 0 {
 				logger.Infof("Request succeeded after %d attempts", attempt+1)
+				if cbr.budget != nil {
+					cbr.budget.Refund()
+				}
 			}
 			return nil
 		}
-		
+
 		lastErr = err
-		
+
 		// Check if error is retryable
-		if !cbr.isRetryableError(err) {
+		if !cbr.isRetryableError(policy, attempt, err) {
 			logger.Warnf("Non-retryable error: %v", err)
 			return err
 		}
-		
+
 		// Check if this is the last attempt
-		if attempt == cbr.retryConfig.MaxRetries {
-			logger.Errorf("Max retries (%d) exceeded, last error: %v", cbr.retryConfig.MaxRetries, err)
+		if attempt == maxRetries {
+			logger.Errorf("Max retries (%d) exceeded, last error: %v", maxRetries, err)
+			return err
+		}
+
+		if cbr.budget != nil && !cbr.budget.TryConsume() {
+			logger.Warnf("Retry budget exhausted after attempt %d, giving up: %v", attempt+1, err)
 			return err
 		}
-		
-		// Calculate backoff delay
+
+		// Calculate backoff delay, letting a caller-supplied override (see
+		// WithBackoffOverride) replace the computed value -- e.g. a gRPC
+		// client honoring a server's grpc-retry-pushback-ms hint. A negative
+		// override delay means the override source asked for no further
+		// retries at all.
 		delay := cbr.calculateBackoff(attempt)
+		if override, ok := ctx.Value(backoffOverrideKey{}).(func(error) (time.Duration, bool)); ok {
+			if overrideDelay, overridden := override(err); overridden {
+				if overrideDelay < 0 {
+					logger.Warnf("Backoff override requested no further retries after attempt %d: %v", attempt+1, err)
+					return err
+				}
+				delay = overrideDelay
+			}
+		}
+		if deadline, ok := ctx.Deadline(); ok {
+			if remaining := time.Until(deadline); delay > remaining {
+				atomic.AddInt64(&cbr.deadlineTruncatedTotal, 1)
+				logger.Warnf("Backoff %v would exceed remaining deadline %v, giving up after attempt %d: %v", delay, remaining, attempt+1, err)
+				return err
+			}
+		}
+
 		logger.Warnf("Request failed (attempt %d/%d): %v, retrying in %v", attempt+1, cbr.retryConfig.MaxRetries, err, delay)
-		
+
 		// Wait before retry
 		select {
 		case <-ctx.Done():
@@ -399,44 +988,111 @@ func (cbr *CircuitBreakerWithRetry) Execute(ctx context.Context, fn func() error
 			// Continue to next attempt
 		}
 	}
-	
+
+	return lastErr
+}
+
+// executeHedged fires up to hedging.MaxHedges extra attempts, each
+// hedging.HedgeDelay after the previous one started, while earlier attempts
+// are still running, and returns as soon as any attempt succeeds,
+// cancelling the rest via ctx
+func (cbr *CircuitBreakerWithRetry) executeHedged(ctx context.Context, fn func() error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan error, cbr.hedging.MaxHedges+1)
+	launch := func(attempt int) {
+		go func() {
+			err := cbr.CircuitBreaker.Execute(ctx, fn)
+			if err == nil && attempt > 0 {
+				atomic.AddInt64(&cbr.hedgedWinsTotal, 1)
+				logger.Infof("Hedged attempt %d won", attempt+1)
+			}
+			select {
+			case results <- err:
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	launch(0)
+
+	go func() {
+		timer := time.NewTimer(cbr.hedging.HedgeDelay)
+		defer timer.Stop()
+		for attempt := 1; attempt <= cbr.hedging.MaxHedges; attempt++ {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+				launch(attempt)
+				timer.Reset(cbr.hedging.HedgeDelay)
+			}
+		}
+	}()
+
+	var lastErr error
+	for i := 0; i <= cbr.hedging.MaxHedges; i++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-results:
+			if err == nil {
+				return nil
+			}
+			lastErr = err
+		}
+	}
+
 	return lastErr
 }
 
-// Check if error is retryable
-func (cbr *CircuitBreakerWithRetry) isRetryableError(err error) bool {
+// isRetryableError reports whether err is retryable for the given policy at
+// the given (zero-based) attempt number. RetryClassNonIdempotent only ever
+// allows the transparent case -- the first attempt failing with
+// codes.Unavailable, the one code plausible before the request reached the
+// server -- since anything else risks duplicating a side effect the first
+// attempt may have already caused. RetryClassIdempotent (the default) keeps
+// the prior behavior of retrying any of RetryConfig.RetryableCodes.
+func (cbr *CircuitBreakerWithRetry) isRetryableError(policy RetryPolicy, attempt int, err error) bool {
 	if err == nil {
 		return false
 	}
-	
-	// Check gRPC status code
-	if grpcErr, ok := err.(interface{ GRPCStatus() (codes.Code) }); ok {
-		for _, retryableCode := range cbr.retryConfig.RetryableCodes {
-			if grpcErr.GRPCStatus() == retryableCode {
-				return true
-			}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+
+	if policy.Class == RetryClassNonIdempotent {
+		return attempt == 0 && st.Code() == codes.Unavailable
+	}
+
+	for _, retryableCode := range cbr.retryConfig.RetryableCodes {
+		if st.Code() == retryableCode {
+			return true
 		}
 	}
-	
+
 	return false
 }
 
 // Calculate exponential backoff delay
 func (cbr *CircuitBreakerWithRetry) calculateBackoff(attempt int) time.Duration {
 	delay := float64(cbr.retryConfig.InitialDelay) * math.Pow(cbr.retryConfig.Multiplier, float64(attempt))
-	
+
 	// Apply maximum delay
 	if delay > float64(cbr.retryConfig.MaxDelay) {
 		delay = float64(cbr.retryConfig.MaxDelay)
 	}
-	
+
 	// Add jitter if enabled
 	if cbr.retryConfig.Jitter {
 		// Add random jitter up to 25% of delay
 		jitter := delay * 0.25 * (rand.Float64() - 0.5)
 		delay += jitter
 	}
-	
+
 	return time.Duration(delay)
 }
 
@@ -448,25 +1104,39 @@ func (cbr *CircuitBreakerWithRetry) GetRetryConfig() *RetryConfig {
 // Get combined metrics
 func (cbr *CircuitBreakerWithRetry) GetMetrics() CircuitBreakerWithRetryMetrics {
 	cbMetrics := cbr.CircuitBreaker.GetMetrics()
-	
-	return CircuitBreakerWithRetryMetrics{
-		CircuitBreakerMetrics: cbMetrics,
-		MaxRetries:             cbr.retryConfig.MaxRetries,
-		CurrentRetry:           0, // This would need to be tracked during execution
-		InitialDelay:           cbr.retryConfig.InitialDelay,
-		MaxDelay:               cbr.retryConfig.MaxDelay,
-		Multiplier:             cbr.retryConfig.Multiplier,
-		Jitter:                 cbr.retryConfig.Jitter,
+
+	metrics := CircuitBreakerWithRetryMetrics{
+		CircuitBreakerMetrics:         cbMetrics,
+		MaxRetries:                    cbr.retryConfig.MaxRetries,
+		CurrentRetry:                  0, // This would need to be tracked during execution
+		InitialDelay:                  cbr.retryConfig.InitialDelay,
+		MaxDelay:                      cbr.retryConfig.MaxDelay,
+		Multiplier:                    cbr.retryConfig.Multiplier,
+		Jitter:                        cbr.retryConfig.Jitter,
+		HedgedWinsTotal:               atomic.LoadInt64(&cbr.hedgedWinsTotal),
+		DeadlineTruncatedRetriesTotal: atomic.LoadInt64(&cbr.deadlineTruncatedTotal),
 	}
+
+	if cbr.budget != nil {
+		metrics.BudgetRejectionsTotal = cbr.budget.RejectionsTotal()
+	}
+
+	return metrics
 }
 
 // Combined metrics
 type CircuitBreakerWithRetryMetrics struct {
 	CircuitBreakerMetrics
-	MaxRetries     int
-	CurrentRetry   int
-	InitialDelay   time.Duration
-	MaxDelay       time.Duration
-	Multiplier     float64
-	Jitter         bool
+	MaxRetries   int
+	CurrentRetry int
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+	Multiplier   float64
+	Jitter       bool
+
+	// Hedging/retry-budget/deadline counters, zero unless the respective
+	// feature is in use
+	HedgedWinsTotal               int64
+	BudgetRejectionsTotal         int64
+	DeadlineTruncatedRetriesTotal int64
 }
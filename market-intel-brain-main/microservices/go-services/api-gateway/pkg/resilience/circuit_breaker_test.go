@@ -0,0 +1,210 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFailureWindowSnapshotBelowMinimumRequests(t *testing.T) {
+	w := newFailureWindow(5, time.Minute)
+	w.record(false, false, false)
+
+	total, failureRate, _ := w.snapshot()
+	if total != 1 {
+		t.Fatalf("expected total=1, got %d", total)
+	}
+	if failureRate != 1.0 {
+		t.Fatalf("expected failureRate=1.0, got %f", failureRate)
+	}
+}
+
+func TestFailureWindowAgesOutOldBuckets(t *testing.T) {
+	w := newFailureWindow(2, 10*time.Millisecond)
+	w.record(false, false, false)
+
+	time.Sleep(30 * time.Millisecond)
+	w.record(true, false, false)
+
+	total, failureRate, _ := w.snapshot()
+	if total != 1 {
+		t.Fatalf("expected aged-out failure to drop from the window, total=%d", total)
+	}
+	if failureRate != 0 {
+		t.Fatalf("expected failureRate=0 after the failing bucket aged out, got %f", failureRate)
+	}
+}
+
+func TestCircuitBreakerOpensOnWindowFailureRate(t *testing.T) {
+	config := DefaultCircuitBreakerConfig()
+	config.MaxFailures = 1000 // disable the consecutive-failure path for this test
+	config.WindowBuckets = 1
+	config.BucketDuration = time.Minute
+	config.MinimumRequests = 4
+	config.FailureRateThreshold = 0.5
+	config.ConcurrencyLimitEnabled = false
+
+	cb := NewCircuitBreaker(config)
+
+	for i := 0; i < 4; i++ {
+		_ = cb.Execute(context.Background(), func() error {
+			if i%2 == 0 {
+				return errors.New("boom")
+			}
+			return nil
+		})
+	}
+
+	if cb.GetState() != StateOpen {
+		t.Fatalf("expected circuit to open once the window failure rate hit the threshold, state=%v", cb.GetState())
+	}
+}
+
+func TestConcurrencyLimiterRejectsAtLimit(t *testing.T) {
+	config := DefaultCircuitBreakerConfig()
+	config.WindowBuckets = 0
+	config.ConcurrencyLimitEnabled = true
+	config.InitialConcurrencyLimit = 1
+	config.MinConcurrencyLimit = 1
+	config.MaxConcurrencyLimit = 1
+
+	cb := NewCircuitBreaker(config)
+
+	release := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- cb.Execute(context.Background(), func() error {
+			<-release
+			return nil
+		})
+	}()
+
+	// Give the in-flight call a moment to acquire its slot before the
+	// second call races it for the single available slot.
+	time.Sleep(20 * time.Millisecond)
+
+	err := cb.Execute(context.Background(), func() error { return nil })
+	if !errors.Is(err, ErrConcurrencyLimit) {
+		t.Fatalf("expected ErrConcurrencyLimit while the slot is held, got %v", err)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error from held call: %v", err)
+	}
+}
+
+func TestConcurrencyLimiterGrowsOnSuccessAndShrinksOnFailure(t *testing.T) {
+	config := DefaultCircuitBreakerConfig()
+	config.WindowBuckets = 0
+	config.MaxFailures = 1000
+	config.ConcurrencyLimitEnabled = true
+	config.InitialConcurrencyLimit = 10
+	config.MinConcurrencyLimit = 1
+	config.MaxConcurrencyLimit = 100
+	config.ConcurrencyAdditiveIncrease = 5
+	config.ConcurrencyMultiplicativeDecrease = 0.5
+
+	cb := NewCircuitBreaker(config)
+
+	_ = cb.Execute(context.Background(), func() error { return nil })
+	if limit, _, _ := cb.limiter.snapshot(); limit != 15 {
+		t.Fatalf("expected limit to grow additively to 15, got %d", limit)
+	}
+
+	_ = cb.Execute(context.Background(), func() error { return errors.New("boom") })
+	if limit, _, _ := cb.limiter.snapshot(); limit != 7 {
+		t.Fatalf("expected limit to shrink multiplicatively to 7, got %d", limit)
+	}
+}
+
+func noWindowNoLimiterConfig() *CircuitBreakerConfig {
+	config := DefaultCircuitBreakerConfig()
+	config.WindowBuckets = 0
+	config.ConcurrencyLimitEnabled = false
+	config.MaxFailures = 1000
+	return config
+}
+
+func TestCircuitBreakerWithRetryHedgingReturnsFirstSuccess(t *testing.T) {
+	cbr := NewCircuitBreakerWithRetry(noWindowNoLimiterConfig(), DefaultRetryConfig())
+	cbr.WithHedging(&HedgingConfig{MaxHedges: 2, HedgeDelay: 10 * time.Millisecond})
+
+	var calls int64
+	err := cbr.Execute(context.Background(), func() error {
+		n := atomic.AddInt64(&calls, 1)
+		if n == 1 {
+			// the original attempt stalls long enough for a hedge to win
+			time.Sleep(200 * time.Millisecond)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected a hedged attempt to succeed, got %v", err)
+	}
+	if atomic.LoadInt64(&cbr.hedgedWinsTotal) != 1 {
+		t.Fatalf("expected exactly one hedged win, got %d", cbr.hedgedWinsTotal)
+	}
+}
+
+func TestCircuitBreakerWithRetryBudgetExhaustion(t *testing.T) {
+	cbr := NewCircuitBreakerWithRetry(noWindowNoLimiterConfig(), DefaultRetryConfig())
+	budget := NewRetryBudget(0, 1, 0) // one retry token, no refill, no refund
+	cbr.WithRetryBudget(budget)
+
+	var calls int64
+	failing := func() error {
+		atomic.AddInt64(&calls, 1)
+		return status.Error(codes.Unavailable, "down")
+	}
+
+	_ = cbr.Execute(context.Background(), failing)
+	firstCalls := atomic.LoadInt64(&calls)
+	if firstCalls < 2 {
+		t.Fatalf("expected the first request to retry at least once using its budget token, got %d calls", firstCalls)
+	}
+
+	atomic.StoreInt64(&calls, 0)
+	_ = cbr.Execute(context.Background(), failing)
+	if calls != 1 {
+		t.Fatalf("expected the exhausted budget to block all retries on the second request, got %d calls", calls)
+	}
+	if budget.RejectionsTotal() == 0 {
+		t.Fatalf("expected RejectionsTotal to record the budget exhaustion")
+	}
+}
+
+func TestCircuitBreakerWithRetrySkipsBackoffPastDeadline(t *testing.T) {
+	retryConfig := DefaultRetryConfig()
+	retryConfig.InitialDelay = time.Hour // any backoff will exceed the deadline below
+	cbr := NewCircuitBreakerWithRetry(noWindowNoLimiterConfig(), retryConfig)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	var calls int64
+	start := time.Now()
+	err := cbr.Execute(ctx, func() error {
+		atomic.AddInt64(&calls, 1)
+		return status.Error(codes.Unavailable, "down")
+	})
+	elapsed := time.Since(start)
+
+	if calls != 1 {
+		t.Fatalf("expected exactly one attempt before giving up on the deadline, got %d", calls)
+	}
+	if elapsed > time.Second {
+		t.Fatalf("expected to give up quickly instead of sleeping through the backoff, took %v", elapsed)
+	}
+	if err == nil {
+		t.Fatalf("expected the last failure to be returned")
+	}
+	if cbr.deadlineTruncatedTotal == 0 {
+		t.Fatalf("expected deadlineTruncatedTotal to record the skipped retry")
+	}
+}
@@ -4,12 +4,15 @@
 package resilience
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/market-intel/api-gateway/pkg/logger"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Standard error response structure
@@ -22,6 +25,11 @@ type ErrorResponse struct {
 	RequestID string `json:"request_id,omitempty"`
 	Service   string `json:"service,omitempty"`
 	Retryable bool   `json:"retryable,omitempty"`
+
+	// Details carries any google.rpc error details (RetryInfo, ErrorInfo,
+	// BadRequest, ...) the upstream gRPC service attached to its status, so
+	// callers don't lose that signal by collapsing it into Message.
+	Details []any `json:"details,omitempty"`
 }
 
 // Error response codes
@@ -36,6 +44,8 @@ const (
 	ErrCodeInternalError    = 500
 	ErrCodeBadGateway       = 502
 	ErrCodeGatewayTimeout   = 504
+	ErrCodeConflict         = 409
+	ErrCodeNotImplemented   = 501
 )
 
 // Error response types
@@ -50,6 +60,8 @@ const (
 	ErrTypeInternalError    = "INTERNAL_ERROR"
 	ErrTypeBadGateway       = "BAD_GATEWAY"
 	ErrTypeGatewayTimeout   = "GATEWAY_TIMEOUT"
+	ErrTypeConflict         = "CONFLICT"
+	ErrTypeNotImplemented   = "NOT_IMPLEMENTED"
 )
 
 // Create service unavailable error response
@@ -187,6 +199,35 @@ func GatewayTimeoutError(service string, requestID string) *ErrorResponse {
 	}
 }
 
+// Create conflict error response, for upstream FailedPrecondition/Aborted
+// statuses (e.g. a concurrent modification or an unmet precondition).
+func ConflictError(message string, requestID string) *ErrorResponse {
+	return &ErrorResponse{
+		Success:   false,
+		Error:     ErrTypeConflict,
+		Message:   message,
+		Code:      ErrCodeConflict,
+		Timestamp: getCurrentTimestamp(),
+		RequestID: requestID,
+		Retryable: false,
+	}
+}
+
+// Create not implemented error response, for upstream Unimplemented
+// statuses.
+func NotImplementedError(service string, requestID string) *ErrorResponse {
+	return &ErrorResponse{
+		Success:   false,
+		Error:     ErrTypeNotImplemented,
+		Message:   fmt.Sprintf("Service %s does not implement this method.", service),
+		Code:      ErrCodeNotImplemented,
+		Timestamp: getCurrentTimestamp(),
+		RequestID: requestID,
+		Service:   service,
+		Retryable: false,
+	}
+}
+
 // Get current timestamp in RFC3339 format
 func getCurrentTimestamp() string {
 	return time.Now().UTC().Format(time.RFC3339)
@@ -222,8 +263,10 @@ func getHTTPStatusFromErrorCode(errorCode int) int {
 		return http.StatusInternalServerError
 	case ErrCodeBadGateway:
 		return http.StatusBadGateway
-	case ErrCodeGatewayTimeout:
-		return http.StatusGatewayTimeout
+	case ErrCodeConflict:
+		return http.StatusConflict
+	case ErrCodeNotImplemented:
+		return http.StatusNotImplemented
 	default:
 		return http.StatusInternalServerError
 	}
@@ -233,70 +276,71 @@ func getHTTPStatusFromErrorCode(errorCode int) int {
 func GRPCErrorsMiddleware(serviceName string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Next()
-		
+
 		// Check if there's an error from the context
 		if len(c.Errors) > 0 {
 			lastErr := c.Errors.Last()
-			
-			// Check if it's a gRPC error
-			if isGRPCTimeoutError(lastErr) {
-				errResp := TimeoutError(serviceName, getRequestID(c))
-				SendErrorResponse(c, errResp)
-				c.Abort()
-				return
-			}
-			
-			if isGRPCUnavailableError(lastErr) {
-				errResp := ServiceUnavailable(serviceName, getRequestID(c))
-				SendErrorResponse(c, errResp)
-				c.Abort()
-				return
-			}
-			
-			if isGRPCDeadlineExceededError(lastErr) {
-				errResp := TimeoutError(serviceName, getRequestID(c))
+
+			errResp, retryAfter, ok := grpcErrorResponse(lastErr, serviceName, getRequestID(c))
+			if ok {
+				if retryAfter > 0 {
+					c.Header("Retry-After", fmt.Sprintf("%d", int(retryAfter.Seconds())))
+				}
 				SendErrorResponse(c, errResp)
 				c.Abort()
-				return
 			}
 		}
 	}
 }
 
-// Check if error is gRPC timeout
-func isGRPCTimeoutError(err error) bool {
-	if err == nil {
-		return false
+// grpcErrorResponse decodes err as a gRPC status (via status.FromError)
+// and maps its codes.Code to the matching ErrorResponse constructor,
+// carrying over any google.rpc error details the upstream service
+// attached. It replaces substring-matching on err.Error(), which is
+// unsound (it can match text that happens to appear in a user payload)
+// and throws away the retryability signal codes.Code already encodes.
+// The second return value is the RetryInfo.RetryDelay duration, if the
+// status carried one, so the caller can set a Retry-After header. ok is
+// false if err doesn't wrap a gRPC status, in which case the caller
+// should fall back to its own error handling.
+func grpcErrorResponse(err error, service, requestID string) (errResp *ErrorResponse, retryAfter time.Duration, ok bool) {
+	st, isStatus := status.FromError(err)
+	if !isStatus {
+		return nil, 0, false
 	}
-	
-	errStr := err.Error()
-	return contains(errStr, "context deadline exceeded") ||
-		   contains(errStr, "timeout") ||
-		   contains(errStr, "connection timed out")
-}
 
-// Check if error is gRPC unavailable
-func isGRPCUnavailableError(err error) bool {
-	if err == nil {
-		return false
+	switch st.Code() {
+	case codes.Unavailable:
+		errResp = ServiceUnavailable(service, requestID)
+	case codes.DeadlineExceeded:
+		errResp = TimeoutError(service, requestID)
+	case codes.ResourceExhausted:
+		errResp = RateLimitedError(service, requestID)
+	case codes.Unauthenticated:
+		errResp = UnauthorizedError(st.Message(), requestID)
+	case codes.PermissionDenied:
+		errResp = ForbiddenError(st.Message(), requestID)
+	case codes.NotFound:
+		errResp = NotFoundError(st.Message(), requestID)
+	case codes.FailedPrecondition, codes.Aborted:
+		errResp = ConflictError(st.Message(), requestID)
+	case codes.Internal:
+		errResp = InternalError(st.Message(), requestID)
+	case codes.Unimplemented:
+		errResp = NotImplementedError(service, requestID)
+	default:
+		return nil, 0, false
 	}
-	
-	errStr := err.Error()
-	return contains(errStr, "connection refused") ||
-		   contains(errStr, "no such host") ||
-		   contains(errStr, "network is unreachable") ||
-		   contains(errStr, "service unavailable")
-}
+	errResp.Service = service
 
-// Check if error is gRPC deadline exceeded
-func isGRPCDeadlineExceededError(err error) bool {
-	if err == nil {
-		return false
+	for _, d := range st.Details() {
+		errResp.Details = append(errResp.Details, d)
+		if ri, ok := d.(*errdetails.RetryInfo); ok && ri.GetRetryDelay() != nil {
+			retryAfter = ri.GetRetryDelay().AsDuration()
+		}
 	}
-	
-	errStr := err.Error()
-	return contains(errStr, "context deadline exceeded") ||
-		   contains(errStr, "deadline exceeded")
+
+	return errResp, retryAfter, true
 }
 
 // Get request ID from context
@@ -309,12 +353,6 @@ func getRequestID(c *gin.Context) string {
 	return ""
 }
 
-// Helper function to check if string contains substring
-func contains(s, substr string) bool {
-	return len(s) >= len(substr) && (s == substr || 
-		(len(s) > len(substr) && (s[:len(substr)] == substr || s[len(s)-len(substr):] == substr)))
-}
-
 // Recovery middleware for panic recovery
 func RecoveryMiddleware(serviceName string) gin.HandlerFunc {
 	return gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
@@ -0,0 +1,362 @@
+// gRPC Client Interceptors for Circuit Breaker
+// Wraps outbound unary and streaming RPCs with CircuitBreakerWithRetry so
+// callers get circuit breaking, retry/hedging, and budget enforcement
+// without hand-wrapping every call site.
+
+package resilience
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/market-intel/api-gateway/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// grpcRetryPushbackMetadataKey is the trailer a server sets per the gRPC A6
+// retry design (https://github.com/grpc/proposal/blob/master/A6-client-retries.md#pushback)
+// to override the client's computed backoff: a non-negative value is a
+// suggested delay in milliseconds, a negative value means "do not retry".
+const grpcRetryPushbackMetadataKey = "grpc-retry-pushback-ms"
+
+// RetryPushbackMetadataKey exports grpcRetryPushbackMetadataKey for
+// packages outside resilience that need to set or read the same trailer --
+// a server-side circuit breaker (pkg/resilience/server) rejecting with it,
+// or a hand-wrapped client (internal/services.CoreEngineClient) honoring it
+// without going through UnaryClientInterceptor.
+const RetryPushbackMetadataKey = grpcRetryPushbackMetadataKey
+
+// ParseRetryPushback exports grpcRetryPushback for the same callers.
+func ParseRetryPushback(trailer metadata.MD) (time.Duration, bool) {
+	return grpcRetryPushback(trailer)
+}
+
+// InterceptorMetrics receives one observation per circuit-breaker-wrapped
+// gRPC attempt, keyed by the RPC's FullMethod so per-method dashboards don't
+// need to aggregate across every method a client calls.
+type InterceptorMetrics interface {
+	ObserveAttempt(fullMethod string, attempt int, code codes.Code, duration time.Duration)
+}
+
+// PrometheusInterceptorMetrics is the default InterceptorMetrics
+// implementation, recording attempt counts and latencies per method and
+// status code the same way pkg/otel's MetricsMiddleware does for HTTP.
+type PrometheusInterceptorMetrics struct {
+	attempts *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// NewPrometheusInterceptorMetrics builds the attempt counter and duration
+// histogram and registers them against registry.
+func NewPrometheusInterceptorMetrics(registry *prometheus.Registry) *PrometheusInterceptorMetrics {
+	m := &PrometheusInterceptorMetrics{
+		attempts: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "grpc_client_circuit_breaker_attempts_total",
+				Help: "Total number of circuit-breaker-wrapped gRPC client attempts.",
+			},
+			[]string{"method", "code"},
+		),
+		duration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "grpc_client_circuit_breaker_attempt_duration_seconds",
+				Help:    "Duration of circuit-breaker-wrapped gRPC client attempts.",
+				Buckets: prometheus.DefBuckets,
+			},
+			[]string{"method", "code"},
+		),
+	}
+	registry.MustRegister(m.attempts, m.duration)
+	return m
+}
+
+// ObserveAttempt implements InterceptorMetrics.
+func (m *PrometheusInterceptorMetrics) ObserveAttempt(fullMethod string, attempt int, code codes.Code, duration time.Duration) {
+	m.attempts.WithLabelValues(fullMethod, code.String()).Inc()
+	m.duration.WithLabelValues(fullMethod, code.String()).Observe(duration.Seconds())
+}
+
+// interceptorOptions configures UnaryClientInterceptor and
+// StreamClientInterceptor.
+type interceptorOptions struct {
+	tracerName string
+	metrics    InterceptorMetrics
+}
+
+func defaultInterceptorOptions() *interceptorOptions {
+	return &interceptorOptions{
+		tracerName: "github.com/market-intel/api-gateway/pkg/resilience",
+	}
+}
+
+// Option configures UnaryClientInterceptor / StreamClientInterceptor.
+type Option func(*interceptorOptions)
+
+// WithTracerName overrides the otel tracer name spans are started under;
+// defaults to this package's import path.
+func WithTracerName(name string) Option {
+	return func(o *interceptorOptions) { o.tracerName = name }
+}
+
+// WithInterceptorMetrics routes per-attempt circuit breaker outcomes to
+// metrics instead of discarding them.
+func WithInterceptorMetrics(metrics InterceptorMetrics) Option {
+	return func(o *interceptorOptions) { o.metrics = metrics }
+}
+
+// UnaryClientInterceptor returns a grpc.UnaryClientInterceptor that runs the
+// RPC through cb: attempts are circuit-broken, retried, and (if configured)
+// hedged exactly as CircuitBreakerWithRetry.Execute does for any other call,
+// with the gRPC-specific pieces Execute itself knows nothing about layered
+// on top -- the active span gets a grpc.attempt attribute per attempt, a
+// grpc-retry-pushback-ms trailer from the server overrides the computed
+// backoff (or stops retries on a negative value), and outcomes are recorded
+// to metrics keyed by method.
+func UnaryClientInterceptor(cb *CircuitBreakerWithRetry, opts ...Option) grpc.UnaryClientInterceptor {
+	o := defaultInterceptorOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		ctx, span := otel.Tracer(o.tracerName).Start(ctx, method, trace.WithSpanKind(trace.SpanKindClient))
+		defer span.End()
+
+		var lastTrailer metadata.MD
+		ctx = WithBackoffOverride(ctx, func(error) (time.Duration, bool) {
+			return grpcRetryPushback(lastTrailer)
+		})
+
+		attempt := 0
+		err := cb.Execute(ctx, func() error {
+			attempt++
+			span.SetAttributes(attribute.Int("grpc.attempt", attempt))
+
+			var trailer metadata.MD
+			start := time.Now()
+			callErr := invoker(ctx, method, req, reply, cc, append(callOpts, grpc.Trailer(&trailer))...)
+			duration := time.Since(start)
+			lastTrailer = trailer
+
+			if o.metrics != nil {
+				o.metrics.ObserveAttempt(method, attempt, status.Code(callErr), duration)
+			}
+			if callErr != nil {
+				span.RecordError(callErr)
+			}
+			return callErr
+		})
+
+		if err != nil {
+			span.SetStatus(otelcodes.Error, status.Convert(err).Message())
+		}
+		return err
+	}
+}
+
+// StreamClientInterceptor mirrors UnaryClientInterceptor for streaming RPCs.
+// Only stream establishment goes through cb -- once streamer has handed back
+// a ClientStream, messages already sent or received on it can't be safely
+// replayed, so (as with gRPC's own retry policy) retries stop there. The
+// returned stream's span ends when the stream itself finishes, not when
+// establishment succeeds.
+func StreamClientInterceptor(cb *CircuitBreakerWithRetry, opts ...Option) grpc.StreamClientInterceptor {
+	o := defaultInterceptorOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, span := otel.Tracer(o.tracerName).Start(ctx, method, trace.WithSpanKind(trace.SpanKindClient))
+
+		var lastTrailer metadata.MD
+		ctx = WithBackoffOverride(ctx, func(error) (time.Duration, bool) {
+			return grpcRetryPushback(lastTrailer)
+		})
+
+		attempt := 0
+		var stream grpc.ClientStream
+		err := cb.Execute(ctx, func() error {
+			attempt++
+			span.SetAttributes(attribute.Int("grpc.attempt", attempt))
+
+			var trailer metadata.MD
+			start := time.Now()
+			s, callErr := streamer(ctx, desc, cc, method, append(callOpts, grpc.Trailer(&trailer))...)
+			duration := time.Since(start)
+			lastTrailer = trailer
+
+			if o.metrics != nil {
+				o.metrics.ObserveAttempt(method, attempt, status.Code(callErr), duration)
+			}
+			if callErr != nil {
+				span.RecordError(callErr)
+				return callErr
+			}
+			stream = s
+			return nil
+		})
+
+		if err != nil {
+			span.SetStatus(otelcodes.Error, status.Convert(err).Message())
+			span.End()
+			return nil, err
+		}
+
+		return &tracedClientStream{ClientStream: stream, span: span}, nil
+	}
+}
+
+// tracedClientStream wraps a grpc.ClientStream so its span ends when the
+// stream actually finishes -- on the first RecvMsg error, including the
+// io.EOF that signals a clean end -- rather than when
+// StreamClientInterceptor returns, which is as soon as the stream is
+// established.
+type tracedClientStream struct {
+	grpc.ClientStream
+	span trace.Span
+	once sync.Once
+}
+
+func (s *tracedClientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		s.once.Do(func() {
+			if err != io.EOF {
+				s.span.RecordError(err)
+				s.span.SetStatus(otelcodes.Error, err.Error())
+			}
+			s.span.End()
+		})
+	}
+	return err
+}
+
+// grpcRetryPushback parses trailer for a grpc-retry-pushback-ms hint,
+// returning ok=false if the trailer is absent or not a valid integer.
+func grpcRetryPushback(trailer metadata.MD) (time.Duration, bool) {
+	if trailer == nil {
+		return 0, false
+	}
+
+	values := trailer.Get(grpcRetryPushbackMetadataKey)
+	if len(values) == 0 {
+		return 0, false
+	}
+
+	ms, err := strconv.Atoi(values[0])
+	if err != nil {
+		logger.Warnf("Ignoring malformed %s trailer %q: %v", grpcRetryPushbackMetadataKey, values[0], err)
+		return 0, false
+	}
+	if ms < 0 {
+		return -1, true
+	}
+	return time.Duration(ms) * time.Millisecond, true
+}
+
+// PerMethodCircuitBreakerRegistry lazily creates and caches one
+// CircuitBreakerWithRetry per gRPC FullMethod, so a client that calls
+// several RPCs maintains independent circuits instead of one global circuit
+// tripping every method together when only one of them is unhealthy.
+type PerMethodCircuitBreakerRegistry struct {
+	cbConfig    *CircuitBreakerConfig
+	retryConfig *RetryConfig
+	configure   func(method string, cbr *CircuitBreakerWithRetry)
+
+	mu       sync.RWMutex
+	circuits map[string]*CircuitBreakerWithRetry
+}
+
+// NewPerMethodCircuitBreakerRegistry builds a registry whose circuits are
+// each created from a clone of cbConfig/retryConfig (nil for the package
+// defaults). If configure is non-nil it runs once against every
+// newly-created circuit, e.g. to call WithHedging or WithRetryBudget.
+func NewPerMethodCircuitBreakerRegistry(cbConfig *CircuitBreakerConfig, retryConfig *RetryConfig, configure func(method string, cbr *CircuitBreakerWithRetry)) *PerMethodCircuitBreakerRegistry {
+	return &PerMethodCircuitBreakerRegistry{
+		cbConfig:    cbConfig,
+		retryConfig: retryConfig,
+		configure:   configure,
+		circuits:    make(map[string]*CircuitBreakerWithRetry),
+	}
+}
+
+// Get returns the circuit for method, creating it (and running configure,
+// if set) on first use.
+func (r *PerMethodCircuitBreakerRegistry) Get(method string) *CircuitBreakerWithRetry {
+	r.mu.RLock()
+	cbr, ok := r.circuits[method]
+	r.mu.RUnlock()
+	if ok {
+		return cbr
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cbr, ok := r.circuits[method]; ok {
+		return cbr
+	}
+
+	cbr = NewCircuitBreakerWithRetry(cloneCircuitBreakerConfig(r.cbConfig), cloneRetryConfig(r.retryConfig))
+	if r.configure != nil {
+		r.configure(method, cbr)
+	}
+	r.circuits[method] = cbr
+	return cbr
+}
+
+// Metrics returns a snapshot of every per-method circuit's combined
+// metrics, keyed by FullMethod.
+func (r *PerMethodCircuitBreakerRegistry) Metrics() map[string]CircuitBreakerWithRetryMetrics {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make(map[string]CircuitBreakerWithRetryMetrics, len(r.circuits))
+	for method, cbr := range r.circuits {
+		out[method] = cbr.GetMetrics()
+	}
+	return out
+}
+
+// UnaryClientInterceptor returns a grpc.UnaryClientInterceptor that runs
+// each call through its method's circuit, per Get.
+func (r *PerMethodCircuitBreakerRegistry) UnaryClientInterceptor(opts ...Option) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		return UnaryClientInterceptor(r.Get(method), opts...)(ctx, method, req, reply, cc, invoker, callOpts...)
+	}
+}
+
+// StreamClientInterceptor mirrors UnaryClientInterceptor for streaming RPCs.
+func (r *PerMethodCircuitBreakerRegistry) StreamClientInterceptor(opts ...Option) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return StreamClientInterceptor(r.Get(method), opts...)(ctx, desc, cc, method, streamer, callOpts...)
+	}
+}
+
+func cloneCircuitBreakerConfig(cfg *CircuitBreakerConfig) *CircuitBreakerConfig {
+	if cfg == nil {
+		return nil
+	}
+	clone := *cfg
+	return &clone
+}
+
+func cloneRetryConfig(cfg *RetryConfig) *RetryConfig {
+	if cfg == nil {
+		return nil
+	}
+	clone := *cfg
+	clone.RetryableCodes = append([]codes.Code(nil), cfg.RetryableCodes...)
+	return &clone
+}
@@ -0,0 +1,91 @@
+package resilience
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCRetryPushbackParsesDelay(t *testing.T) {
+	trailer := metadata.Pairs(grpcRetryPushbackMetadataKey, "250")
+
+	delay, ok := grpcRetryPushback(trailer)
+	if !ok {
+		t.Fatalf("expected a pushback delay to be found")
+	}
+	if delay != 250*time.Millisecond {
+		t.Fatalf("expected 250ms, got %v", delay)
+	}
+}
+
+func TestGRPCRetryPushbackNegativeMeansStop(t *testing.T) {
+	trailer := metadata.Pairs(grpcRetryPushbackMetadataKey, "-1")
+
+	delay, ok := grpcRetryPushback(trailer)
+	if !ok {
+		t.Fatalf("expected the negative pushback to still be recognized")
+	}
+	if delay >= 0 {
+		t.Fatalf("expected a negative sentinel delay, got %v", delay)
+	}
+}
+
+func TestGRPCRetryPushbackAbsentOrMalformed(t *testing.T) {
+	if _, ok := grpcRetryPushback(nil); ok {
+		t.Fatalf("expected no pushback from a nil trailer")
+	}
+
+	trailer := metadata.Pairs(grpcRetryPushbackMetadataKey, "not-a-number")
+	if _, ok := grpcRetryPushback(trailer); ok {
+		t.Fatalf("expected a malformed pushback value to be ignored")
+	}
+}
+
+func TestPerMethodCircuitBreakerRegistryCachesPerMethod(t *testing.T) {
+	var configured []string
+	registry := NewPerMethodCircuitBreakerRegistry(nil, nil, func(method string, cbr *CircuitBreakerWithRetry) {
+		configured = append(configured, method)
+	})
+
+	first := registry.Get("/market.CoreEngine/GetMarketData")
+	again := registry.Get("/market.CoreEngine/GetMarketData")
+	if first != again {
+		t.Fatalf("expected the same circuit to be returned for the same method")
+	}
+
+	other := registry.Get("/market.CoreEngine/StreamMarketData")
+	if first == other {
+		t.Fatalf("expected distinct circuits for distinct methods")
+	}
+
+	if len(configured) != 2 {
+		t.Fatalf("expected configure to run once per distinct method, ran %d times", len(configured))
+	}
+}
+
+func TestWithBackoffOverrideStopsRetries(t *testing.T) {
+	retryConfig := DefaultRetryConfig()
+	retryConfig.InitialDelay = time.Millisecond
+	cbr := NewCircuitBreakerWithRetry(noWindowNoLimiterConfig(), retryConfig)
+
+	ctx := WithBackoffOverride(context.Background(), func(error) (time.Duration, bool) {
+		return -1, true
+	})
+
+	var calls int
+	err := cbr.Execute(ctx, func() error {
+		calls++
+		return status.Error(codes.Unavailable, "down")
+	})
+
+	if err == nil {
+		t.Fatalf("expected the final failure to be returned")
+	}
+	if calls != 1 {
+		t.Fatalf("expected the override to stop retries after the first attempt, got %d calls", calls)
+	}
+}
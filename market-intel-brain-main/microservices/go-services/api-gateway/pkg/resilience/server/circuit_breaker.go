@@ -0,0 +1,361 @@
+// Server-Side Circuit Breaker for gRPC
+// Complements pkg/resilience's client-side CircuitBreakerWithRetry with a
+// per-method breaker a gRPC server installs via UnaryServerInterceptor,
+// following the Mimir ingester pattern: trip on a sliding-window failure or
+// slow-call rate, reject instantly while open, and use a small number of
+// half-open probes to decide whether to close again.
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/market-intel/api-gateway/pkg/logger"
+	"github.com/market-intel/api-gateway/pkg/resilience"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// CircuitBreakerConfig configures every per-method breaker a
+// PerMethodCircuitBreakerRegistry creates.
+type CircuitBreakerConfig struct {
+	// FailureRateThreshold/SlowCallRateThreshold are the ratios (0-1) of
+	// failed/slow calls over SamplingWindow that trip the breaker open.
+	FailureRateThreshold  float64       `json:"failure_rate_threshold" yaml:"failure_rate_threshold"`
+	SlowCallRateThreshold float64       `json:"slow_call_rate_threshold" yaml:"slow_call_rate_threshold"`
+	SlowCallDuration      time.Duration `json:"slow_call_duration" yaml:"slow_call_duration"`
+
+	// MinimumRequestVolume is how many calls SamplingWindow must have seen
+	// before the rate thresholds above are evaluated at all, so a handful of
+	// early failures can't trip a method nobody has really exercised yet.
+	MinimumRequestVolume int `json:"minimum_request_volume" yaml:"minimum_request_volume"`
+
+	// SamplingWindow is subdivided into WindowBuckets equal slices that age
+	// out independently, the same rolling-window approach
+	// resilience.CircuitBreaker uses client-side.
+	SamplingWindow time.Duration `json:"sampling_window" yaml:"sampling_window"`
+	WindowBuckets  int           `json:"window_buckets" yaml:"window_buckets"`
+
+	// CooldownPeriod is how long the breaker stays open before it lets a
+	// half-open probe through; reported to the caller via the
+	// grpc-retry-pushback-ms trailer as the remaining time to wait.
+	CooldownPeriod time.Duration `json:"cooldown_period" yaml:"cooldown_period"`
+
+	// HalfOpenProbes is how many concurrent trial requests a half-open
+	// breaker admits; the breaker closes once that many probes in a row
+	// succeed, or reopens on the first probe failure.
+	HalfOpenProbes int `json:"half_open_probes" yaml:"half_open_probes"`
+}
+
+// DefaultCircuitBreakerConfig returns the Mimir-ingester-style defaults: trip
+// at a 50% failure or slow-call rate over a 10s window once at least 20
+// requests have been seen, cool down for 30s, then probe with 5 requests.
+func DefaultCircuitBreakerConfig() *CircuitBreakerConfig {
+	return &CircuitBreakerConfig{
+		FailureRateThreshold:  0.5,
+		SlowCallRateThreshold: 0.5,
+		SlowCallDuration:      time.Second,
+		MinimumRequestVolume:  20,
+		SamplingWindow:        10 * time.Second,
+		WindowBuckets:         10,
+		CooldownPeriod:        30 * time.Second,
+		HalfOpenProbes:        5,
+	}
+}
+
+// windowBucket tallies outcomes recorded during one bucket-duration slice of
+// the rolling window.
+type windowBucket struct {
+	successes int64
+	failures  int64
+	slow      int64
+}
+
+// slidingWindow is a ring of windowBuckets covering SamplingWindow, used to
+// compute the failure/slow-call rates checkThresholds trips on.
+type slidingWindow struct {
+	mu             sync.Mutex
+	buckets        []windowBucket
+	bucketDuration time.Duration
+	index          int
+	boundary       time.Time
+}
+
+func newSlidingWindow(bucketCount int, bucketDuration time.Duration) *slidingWindow {
+	return &slidingWindow{
+		buckets:        make([]windowBucket, bucketCount),
+		bucketDuration: bucketDuration,
+		boundary:       time.Now().Add(bucketDuration),
+	}
+}
+
+// advance rotates the ring forward to the current time, clearing any
+// buckets that have aged out. Callers must hold w.mu.
+func (w *slidingWindow) advance() int {
+	now := time.Now()
+	for !now.Before(w.boundary) {
+		w.index = (w.index + 1) % len(w.buckets)
+		w.buckets[w.index] = windowBucket{}
+		w.boundary = w.boundary.Add(w.bucketDuration)
+	}
+	return w.index
+}
+
+func (w *slidingWindow) record(success, slow bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	idx := w.advance()
+	if success {
+		w.buckets[idx].successes++
+	} else {
+		w.buckets[idx].failures++
+	}
+	if slow {
+		w.buckets[idx].slow++
+	}
+}
+
+func (w *slidingWindow) reset() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for i := range w.buckets {
+		w.buckets[i] = windowBucket{}
+	}
+}
+
+func (w *slidingWindow) snapshot() (total int64, failureRate, slowCallRate float64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.advance()
+
+	var successes, failures, slow int64
+	for _, b := range w.buckets {
+		successes += b.successes
+		failures += b.failures
+		slow += b.slow
+	}
+
+	total = successes + failures
+	if total == 0 {
+		return 0, 0, 0
+	}
+	return total, float64(failures) / float64(total), float64(slow) / float64(total)
+}
+
+// circuitBreaker is one method's breaker: closed while the failure/slow-call
+// rate stays under threshold, open (rejecting every call) for CooldownPeriod
+// once it trips, then half-open for up to HalfOpenProbes trial calls before
+// deciding whether to close again.
+type circuitBreaker struct {
+	method  string
+	config  *CircuitBreakerConfig
+	metrics *Metrics
+
+	mu                sync.Mutex
+	state             resilience.CircuitState
+	window            *slidingWindow
+	openedAt          time.Time
+	halfOpenInFlight  int
+	halfOpenSucceeded int
+	halfOpenFailed    bool
+}
+
+func newCircuitBreaker(method string, config *CircuitBreakerConfig, metrics *Metrics) *circuitBreaker {
+	cb := &circuitBreaker{
+		method:  method,
+		config:  config,
+		metrics: metrics,
+		state:   resilience.StateClosed,
+		window:  newSlidingWindow(config.WindowBuckets, config.SamplingWindow/time.Duration(config.WindowBuckets)),
+	}
+	cb.metrics.setState(method, resilience.StateClosed)
+	return cb
+}
+
+// allow decides whether to let a call through. It returns ok=false and the
+// remaining cooldown if the breaker is open, or ok=false if a half-open
+// breaker's probe budget for this period is already spent.
+func (cb *circuitBreaker) allow() (ok bool, retryAfter time.Duration) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case resilience.StateOpen:
+		remaining := cb.config.CooldownPeriod - time.Since(cb.openedAt)
+		if remaining > 0 {
+			return false, remaining
+		}
+		cb.transitionLocked(resilience.StateHalfOpen)
+		fallthrough
+
+	case resilience.StateHalfOpen:
+		if cb.halfOpenInFlight >= cb.config.HalfOpenProbes {
+			return false, cb.config.CooldownPeriod
+		}
+		cb.halfOpenInFlight++
+		return true, 0
+
+	default: // StateClosed
+		return true, 0
+	}
+}
+
+// record folds a completed call's outcome back into the breaker, closing or
+// reopening it as appropriate.
+func (cb *circuitBreaker) record(success bool, duration time.Duration) {
+	slow := cb.config.SlowCallDuration > 0 && duration >= cb.config.SlowCallDuration
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case resilience.StateHalfOpen:
+		cb.halfOpenInFlight--
+		if !success || slow {
+			cb.halfOpenFailed = true
+		} else {
+			cb.halfOpenSucceeded++
+		}
+		// Decide once every admitted probe has completed.
+		if cb.halfOpenInFlight == 0 {
+			if cb.halfOpenFailed {
+				cb.transitionLocked(resilience.StateOpen)
+			} else if cb.halfOpenSucceeded >= cb.config.HalfOpenProbes {
+				cb.window.reset()
+				cb.transitionLocked(resilience.StateClosed)
+			}
+		}
+
+	case resilience.StateClosed:
+		cb.window.record(success, slow)
+		cb.checkThresholdsLocked()
+
+	case resilience.StateOpen:
+		// A call that raced the open->half-open transition; nothing to do.
+	}
+}
+
+// checkThresholdsLocked trips the breaker once SamplingWindow has seen at
+// least MinimumRequestVolume calls and the failure or slow-call rate over it
+// is at or above the configured threshold. Callers must hold cb.mu.
+func (cb *circuitBreaker) checkThresholdsLocked() {
+	total, failureRate, slowCallRate := cb.window.snapshot()
+	if total < int64(cb.config.MinimumRequestVolume) {
+		return
+	}
+
+	if cb.config.FailureRateThreshold > 0 && failureRate >= cb.config.FailureRateThreshold {
+		logger.Warnf("Server circuit breaker for %s opening: failure rate %.2f >= threshold %.2f over %d requests", cb.method, failureRate, cb.config.FailureRateThreshold, total)
+		cb.transitionLocked(resilience.StateOpen)
+		return
+	}
+
+	if cb.config.SlowCallRateThreshold > 0 && slowCallRate >= cb.config.SlowCallRateThreshold {
+		logger.Warnf("Server circuit breaker for %s opening: slow-call rate %.2f >= threshold %.2f over %d requests", cb.method, slowCallRate, cb.config.SlowCallRateThreshold, total)
+		cb.transitionLocked(resilience.StateOpen)
+	}
+}
+
+// transitionLocked moves the breaker to state, resetting half-open probe
+// bookkeeping and recording the transition to metrics. Callers must hold
+// cb.mu.
+func (cb *circuitBreaker) transitionLocked(state resilience.CircuitState) {
+	if cb.state == state {
+		return
+	}
+
+	from := cb.state
+	cb.state = state
+	cb.halfOpenInFlight = 0
+	cb.halfOpenSucceeded = 0
+	cb.halfOpenFailed = false
+	if state == resilience.StateOpen {
+		cb.openedAt = time.Now()
+	}
+
+	cb.metrics.setState(cb.method, state)
+	cb.metrics.observeTransition(cb.method, from, state)
+	logger.Infof("Server circuit breaker for %s transitioned %s -> %s", cb.method, from, state)
+}
+
+// GetState returns the breaker's current state, mainly for tests.
+func (cb *circuitBreaker) GetState() resilience.CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.state
+}
+
+// PerMethodCircuitBreakerRegistry lazily creates and caches one circuit
+// breaker per gRPC FullMethod, so one method tripping doesn't reject calls
+// to every other method a server exposes.
+type PerMethodCircuitBreakerRegistry struct {
+	config  *CircuitBreakerConfig
+	metrics *Metrics
+
+	mu       sync.RWMutex
+	breakers map[string]*circuitBreaker
+}
+
+// NewPerMethodCircuitBreakerRegistry builds a registry whose breakers all
+// share config (nil for DefaultCircuitBreakerConfig) and report to metrics
+// (nil to disable metrics entirely).
+func NewPerMethodCircuitBreakerRegistry(config *CircuitBreakerConfig, metrics *Metrics) *PerMethodCircuitBreakerRegistry {
+	if config == nil {
+		config = DefaultCircuitBreakerConfig()
+	}
+	if metrics == nil {
+		metrics = noopMetrics()
+	}
+	return &PerMethodCircuitBreakerRegistry{
+		config:   config,
+		metrics:  metrics,
+		breakers: make(map[string]*circuitBreaker),
+	}
+}
+
+func (r *PerMethodCircuitBreakerRegistry) get(method string) *circuitBreaker {
+	r.mu.RLock()
+	cb, ok := r.breakers[method]
+	r.mu.RUnlock()
+	if ok {
+		return cb
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cb, ok := r.breakers[method]; ok {
+		return cb
+	}
+
+	cb = newCircuitBreaker(method, r.config, r.metrics)
+	r.breakers[method] = cb
+	return cb
+}
+
+// UnaryServerInterceptor returns a grpc.UnaryServerInterceptor that rejects
+// calls to a tripped method with codes.Unavailable and a
+// grpc-retry-pushback-ms trailer carrying the remaining cool-down, per the
+// gRPC A6 retry design, instead of letting the handler run at all.
+func (r *PerMethodCircuitBreakerRegistry) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		cb := r.get(info.FullMethod)
+
+		ok, retryAfter := cb.allow()
+		if !ok {
+			pushback := metadata.Pairs(resilience.RetryPushbackMetadataKey, fmt.Sprintf("%d", retryAfter.Milliseconds()))
+			_ = grpc.SetTrailer(ctx, pushback)
+			return nil, status.Errorf(codes.Unavailable, "circuit breaker open for %s, retry after %v", info.FullMethod, retryAfter)
+		}
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		cb.record(err == nil, time.Since(start))
+		return resp, err
+	}
+}
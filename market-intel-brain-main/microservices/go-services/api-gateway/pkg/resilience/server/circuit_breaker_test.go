@@ -0,0 +1,147 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/market-intel/api-gateway/pkg/resilience"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func testConfig() *CircuitBreakerConfig {
+	return &CircuitBreakerConfig{
+		FailureRateThreshold: 0.5,
+		MinimumRequestVolume: 4,
+		SamplingWindow:       time.Minute,
+		WindowBuckets:        1,
+		CooldownPeriod:       20 * time.Millisecond,
+		HalfOpenProbes:       2,
+	}
+}
+
+func TestCircuitBreakerOpensOnFailureRate(t *testing.T) {
+	cb := newCircuitBreaker("Test", testConfig(), noopMetrics())
+
+	for i := 0; i < 4; i++ {
+		ok, _ := cb.allow()
+		if !ok {
+			t.Fatalf("expected closed breaker to allow call %d", i)
+		}
+		cb.record(i%2 == 1, 0) // 2 successes, 2 failures => 50% failure rate
+	}
+
+	if cb.GetState() != resilience.StateOpen {
+		t.Fatalf("expected breaker to open at the failure rate threshold, state=%v", cb.GetState())
+	}
+}
+
+func TestCircuitBreakerRejectsWhileOpen(t *testing.T) {
+	cb := newCircuitBreaker("Test", testConfig(), noopMetrics())
+
+	cb.mu.Lock()
+	cb.transitionLocked(resilience.StateOpen)
+	cb.mu.Unlock()
+
+	ok, retryAfter := cb.allow()
+	if ok {
+		t.Fatalf("expected open breaker to reject the call")
+	}
+	if retryAfter <= 0 {
+		t.Fatalf("expected a positive retry-after while open, got %v", retryAfter)
+	}
+}
+
+func TestCircuitBreakerHalfOpenClosesAfterSuccessfulProbes(t *testing.T) {
+	config := testConfig()
+	cb := newCircuitBreaker("Test", config, noopMetrics())
+
+	cb.mu.Lock()
+	cb.transitionLocked(resilience.StateOpen)
+	cb.openedAt = time.Now().Add(-config.CooldownPeriod - time.Millisecond)
+	cb.mu.Unlock()
+
+	for i := 0; i < config.HalfOpenProbes; i++ {
+		ok, _ := cb.allow()
+		if !ok {
+			t.Fatalf("expected half-open breaker to admit probe %d", i)
+		}
+	}
+	if cb.GetState() != resilience.StateHalfOpen {
+		t.Fatalf("expected breaker to move to half-open once the cooldown elapsed, state=%v", cb.GetState())
+	}
+
+	for i := 0; i < config.HalfOpenProbes; i++ {
+		cb.record(true, 0)
+	}
+
+	if cb.GetState() != resilience.StateClosed {
+		t.Fatalf("expected breaker to close once every half-open probe succeeded, state=%v", cb.GetState())
+	}
+}
+
+func TestCircuitBreakerHalfOpenReopensOnProbeFailure(t *testing.T) {
+	config := testConfig()
+	cb := newCircuitBreaker("Test", config, noopMetrics())
+
+	cb.mu.Lock()
+	cb.transitionLocked(resilience.StateOpen)
+	cb.openedAt = time.Now().Add(-config.CooldownPeriod - time.Millisecond)
+	cb.mu.Unlock()
+
+	ok, _ := cb.allow()
+	if !ok {
+		t.Fatalf("expected half-open breaker to admit the first probe")
+	}
+	cb.record(false, 0)
+
+	if cb.GetState() != resilience.StateOpen {
+		t.Fatalf("expected breaker to reopen after a failed probe, state=%v", cb.GetState())
+	}
+}
+
+func TestUnaryServerInterceptorRejectsWithPushbackTrailer(t *testing.T) {
+	config := testConfig()
+	registry := NewPerMethodCircuitBreakerRegistry(config, nil)
+	cb := registry.get("/test.Service/Method")
+	cb.mu.Lock()
+	cb.transitionLocked(resilience.StateOpen)
+	cb.mu.Unlock()
+
+	interceptor := registry.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	handlerCalled := false
+
+	_, err := interceptor(context.Background(), struct{}{}, info, func(ctx context.Context, req any) (any, error) {
+		handlerCalled = true
+		return nil, nil
+	})
+
+	if handlerCalled {
+		t.Fatalf("expected the handler not to run while the breaker is open")
+	}
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected codes.Unavailable, got %v", err)
+	}
+}
+
+func TestUnaryServerInterceptorRecordsHandlerOutcome(t *testing.T) {
+	registry := NewPerMethodCircuitBreakerRegistry(testConfig(), nil)
+	interceptor := registry.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+
+	wantErr := errors.New("boom")
+	_, err := interceptor(context.Background(), struct{}{}, info, func(ctx context.Context, req any) (any, error) {
+		return nil, wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("expected the handler's error to pass through, got %v", err)
+	}
+
+	if registry.get(info.FullMethod).GetState() != resilience.StateClosed {
+		t.Fatalf("expected a single failure to stay below MinimumRequestVolume and leave the breaker closed")
+	}
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"github.com/market-intel/api-gateway/pkg/resilience"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// circuitStateValue maps a resilience.CircuitState to the numeric value
+// circuit_breaker_state reports for it, so dashboards can graph state over
+// time the same way they would any other gauge.
+func circuitStateValue(state resilience.CircuitState) float64 {
+	switch state {
+	case resilience.StateOpen:
+		return 1
+	case resilience.StateHalfOpen:
+		return 2
+	default: // resilience.StateClosed
+		return 0
+	}
+}
+
+// Metrics records per-method breaker state and transitions to Prometheus.
+// A nil *Metrics (see noopMetrics) is safe to call and simply discards
+// observations, so a registry can be built without metrics wired up yet.
+type Metrics struct {
+	state       *prometheus.GaugeVec
+	transitions *prometheus.CounterVec
+}
+
+// NewMetrics builds the circuit_breaker_state gauge and
+// circuit_breaker_transitions_total counter and registers them against
+// registry.
+func NewMetrics(registry *prometheus.Registry) *Metrics {
+	m := &Metrics{
+		state: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "circuit_breaker_state",
+				Help: "Current server-side circuit breaker state per method (0=closed, 1=open, 2=half_open).",
+			},
+			[]string{"method"},
+		),
+		transitions: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "circuit_breaker_transitions_total",
+				Help: "Total number of server-side circuit breaker state transitions per method.",
+			},
+			[]string{"method", "from", "to"},
+		),
+	}
+	registry.MustRegister(m.state, m.transitions)
+	return m
+}
+
+// noopMetrics returns a *Metrics whose methods discard every observation,
+// used when a registry is built without a Prometheus registry to report to.
+func noopMetrics() *Metrics {
+	return &Metrics{}
+}
+
+func (m *Metrics) setState(method string, state resilience.CircuitState) {
+	if m == nil || m.state == nil {
+		return
+	}
+	m.state.WithLabelValues(method).Set(circuitStateValue(state))
+}
+
+func (m *Metrics) observeTransition(method string, from, to resilience.CircuitState) {
+	if m == nil || m.transitions == nil {
+		return
+	}
+	m.transitions.WithLabelValues(method, from.String(), to.String()).Inc()
+}
@@ -0,0 +1,144 @@
+// Unified Shutdown Handler
+// Coordinates graceful process teardown across independently registered
+// components (servers, clients, exporters) so a panic or slow close in one
+// can't skip the rest, the way a chain of deferred calls in main() would.
+
+package shutdown
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/market-intel/api-gateway/pkg/logger"
+)
+
+// CloseFunc releases a component's resources. It should stop accepting new
+// work and wait for what's already in flight to finish, within ctx's
+// deadline.
+type CloseFunc func(ctx context.Context) error
+
+// component is a registered CloseFunc together with the metadata Handler
+// needs to run it in order and log its result.
+type component struct {
+	name     string
+	fn       CloseFunc
+	priority int
+	timeout  time.Duration
+}
+
+// Handler runs a process's registered components' CloseFuncs in priority
+// order (lowest first) when asked to Shutdown, each bounded by its own
+// timeout in addition to the overall deadline passed to Shutdown. A
+// recovered panic in one component's CloseFunc is logged and treated as
+// that component's error, rather than aborting the components still
+// waiting to close.
+//
+// Priority is meant to mirror a dependency order: listeners that accept new
+// work (HTTP, gRPC) close first so no new requests arrive, then the clients
+// and connections those requests were using, then cross-cutting
+// infrastructure like the OTel exporter last, so it can still flush spans
+// recorded during the components above's teardown.
+type Handler struct {
+	mu         sync.Mutex
+	components []component
+}
+
+// New returns an empty Handler ready to have components registered with Add.
+func New() *Handler {
+	return &Handler{}
+}
+
+// Add registers a component's CloseFunc under name, to run during Shutdown
+// at the given priority (lower runs first; components sharing a priority
+// close concurrently) bounded by timeout.
+func (h *Handler) Add(name string, fn CloseFunc, priority int, timeout time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.components = append(h.components, component{name: name, fn: fn, priority: priority, timeout: timeout})
+}
+
+// Result is one component's outcome from a Shutdown call.
+type Result struct {
+	Name     string
+	Err      error
+	Duration time.Duration
+}
+
+// Shutdown runs every registered component's CloseFunc in priority order,
+// stopping no later than ctx's deadline regardless of how many components
+// are still outstanding, and returns one Result per component in the order
+// they were run. Components sharing a priority run concurrently, each
+// racing its own per-component timeout in addition to ctx.
+func (h *Handler) Shutdown(ctx context.Context) []Result {
+	h.mu.Lock()
+	ordered := make([]component, len(h.components))
+	copy(ordered, h.components)
+	h.mu.Unlock()
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].priority < ordered[j].priority
+	})
+
+	var results []Result
+	for i := 0; i < len(ordered); {
+		j := i
+		for j < len(ordered) && ordered[j].priority == ordered[i].priority {
+			j++
+		}
+		results = append(results, h.closeBatch(ctx, ordered[i:j])...)
+		i = j
+	}
+	return results
+}
+
+// closeBatch runs a priority batch's components concurrently and waits for
+// all of them before returning, so a slow component in one batch doesn't
+// delay the start of the next batch's results beyond the batch itself.
+func (h *Handler) closeBatch(ctx context.Context, batch []component) []Result {
+	results := make([]Result, len(batch))
+	var wg sync.WaitGroup
+	for i, c := range batch {
+		wg.Add(1)
+		go func(i int, c component) {
+			defer wg.Done()
+			results[i] = h.closeOne(ctx, c)
+		}(i, c)
+	}
+	wg.Wait()
+	return results
+}
+
+func (h *Handler) closeOne(ctx context.Context, c component) Result {
+	closeCtx := ctx
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		closeCtx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	err := runRecovered(closeCtx, c.fn)
+	duration := time.Since(start)
+
+	if err != nil {
+		logger.Errorf("shutdown: %s failed after %s: %v", c.name, duration, err)
+	} else {
+		logger.Infof("shutdown: %s closed in %s", c.name, duration)
+	}
+
+	return Result{Name: c.name, Err: err, Duration: duration}
+}
+
+// runRecovered calls fn, converting a panic into an error so one
+// component's bug can't take down the rest of the shutdown sequence.
+func runRecovered(ctx context.Context, fn CloseFunc) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn(ctx)
+}
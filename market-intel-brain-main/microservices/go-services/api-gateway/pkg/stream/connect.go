@@ -0,0 +1,174 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/market-intel/api-gateway/pb"
+	"github.com/market-intel/api-gateway/pkg/logger"
+)
+
+// run is the connect/reconnect loop: dial, authenticate, replay
+// subscriptions, backfill the gap, then read until the connection drops
+func (c *Client) run(ctx context.Context) {
+	defer close(c.done)
+
+	attempt := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		reconnectTime := time.Now()
+
+		cn, err := c.dial(ctx, c.config.URL, c.config.AuthToken)
+		if err != nil {
+			attempt++
+			delay := c.backoff(attempt)
+			logger.Warnf("stream: connect to %s failed, retrying in %s: %v", c.config.URL, delay, err)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+			continue
+		}
+		attempt = 0
+
+		c.mu.Lock()
+		c.connection = cn
+		c.mu.Unlock()
+
+		// readLoop's ReadJSON blocks synchronously with no context awareness,
+		// so Close (which only cancels ctx) would hang forever once a
+		// connection is up. This watcher closes the live connection on
+		// cancellation, which unblocks ReadJSON with an error and lets
+		// readLoop return. connDone stops the watcher once this connection's
+		// iteration ends on its own (a normal disconnect), so it doesn't leak
+		// or race the next attempt's connection.
+		connDone := make(chan struct{})
+		go func() {
+			select {
+			case <-ctx.Done():
+				cn.Close()
+			case <-connDone:
+			}
+		}()
+
+		if err := cn.WriteJSON(wireMessage{Type: msgTypeAuth, AuthToken: c.config.AuthToken}); err != nil {
+			logger.Warnf("stream: failed to authenticate: %v", err)
+			cn.Close()
+			close(connDone)
+			continue
+		}
+
+		symbols := c.activeSymbols()
+		keywords := c.activeKeywords()
+		if len(symbols) > 0 || len(keywords) > 0 {
+			if err := cn.WriteJSON(wireMessage{Type: msgTypeSubscribe, Symbols: symbols, Keywords: keywords}); err != nil {
+				logger.Warnf("stream: failed to replay subscriptions: %v", err)
+			}
+		}
+
+		c.backfill(ctx, reconnectTime, symbols)
+
+		c.readLoop(cn)
+		close(connDone)
+
+		c.mu.Lock()
+		c.connection = nil
+		c.mu.Unlock()
+	}
+}
+
+// backoff computes the delay before the Nth reconnect attempt
+func (c *Client) backoff(attempt int) time.Duration {
+	delay := float64(c.config.InitialDelay) * math.Pow(c.config.BackoffFactor, float64(attempt-1))
+	if delay > float64(c.config.MaxDelay) {
+		delay = float64(c.config.MaxDelay)
+	}
+	return time.Duration(delay)
+}
+
+// backfill fetches the gap between the last tick received before the drop
+// and the reconnect time, so subscribers see no missing bars. It is a no-op
+// until at least one live tick has been seen, since there is no gap to fill.
+func (c *Client) backfill(ctx context.Context, reconnectTime time.Time, symbols []string) {
+	if c.fetcher == nil || len(symbols) == 0 {
+		return
+	}
+
+	c.mu.Lock()
+	since := c.lastTimestamp
+	c.mu.Unlock()
+	if since == 0 {
+		return
+	}
+
+	bctx, cancel := context.WithTimeout(ctx, c.config.BackfillTimeout)
+	defer cancel()
+
+	resp, err := c.fetcher.FetchMarketData(bctx, &pb.FetchMarketDataRequest{
+		Symbols:  symbols,
+		SourceId: c.config.SourceID,
+	})
+	if err != nil {
+		logger.Warnf("stream: backfill fetch failed: %v", err)
+		return
+	}
+
+	for _, data := range resp.MarketData {
+		if data.Timestamp > since && data.Timestamp <= reconnectTime.Unix() {
+			c.dispatchMarketData(data)
+		}
+	}
+}
+
+// readLoop consumes messages off the connection until it errors or closes
+func (c *Client) readLoop(cn conn) {
+	for {
+		var msg wireMessage
+		if err := cn.ReadJSON(&msg); err != nil {
+			logger.Infof("stream: connection to %s closed: %v", c.config.URL, err)
+			cn.Close()
+			return
+		}
+
+		switch msg.Type {
+		case msgTypeMarket:
+			if msg.MarketData != nil {
+				c.dispatchMarketData(*msg.MarketData)
+			}
+		case msgTypeNews:
+			if msg.NewsItem != nil {
+				c.dispatchNewsItem(*msg.NewsItem)
+			}
+		}
+	}
+}
+
+// dialWebsocket is the production dialFunc, opening a real WebSocket
+// connection to the core engine's stream endpoint
+func dialWebsocket(ctx context.Context, url, authToken string) (conn, error) {
+	dialer := websocket.Dialer{HandshakeTimeout: 10 * time.Second}
+
+	header := http.Header{}
+	if authToken != "" {
+		header.Set("Authorization", "Bearer "+authToken)
+	}
+
+	wsConn, _, err := dialer.DialContext(ctx, url, header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial stream endpoint %s: %w", url, err)
+	}
+
+	return wsConn, nil
+}
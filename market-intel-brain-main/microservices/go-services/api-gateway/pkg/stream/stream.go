@@ -0,0 +1,306 @@
+// Resilient Streaming Client
+// Maintains a single upstream WebSocket connection to the core engine's
+// market-data stream and multiplexes many downstream symbol/keyword
+// subscriptions over it. On disconnect it reconnects with exponential
+// backoff, re-authenticates, replays the union of active subscriptions, and
+// backfills the gap between the last-received tick and reconnect time so
+// consumers never see missing bars.
+
+package stream
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/market-intel/api-gateway/pb"
+	"github.com/market-intel/api-gateway/pkg/logger"
+)
+
+// MarketDataFetcher is the subset of the core-engine client used to backfill
+// the gap between a stream disconnect and the next successful reconnect
+type MarketDataFetcher interface {
+	FetchMarketData(ctx context.Context, req *pb.FetchMarketDataRequest) (*pb.FetchMarketDataResponse, error)
+}
+
+// conn is the subset of *websocket.Conn the Client depends on, so tests can
+// substitute a fake connection that can be killed mid-stream
+type conn interface {
+	WriteJSON(v interface{}) error
+	ReadJSON(v interface{}) error
+	Close() error
+}
+
+// dialFunc opens a new upstream connection
+type dialFunc func(ctx context.Context, url, authToken string) (conn, error)
+
+// wireMessage is the envelope exchanged with the core engine's stream
+// endpoint. Exactly one of MarketData/NewsItem is set per message.
+type wireMessage struct {
+	Type       string        `json:"type"`
+	Symbols    []string      `json:"symbols,omitempty"`
+	Keywords   []string      `json:"keywords,omitempty"`
+	AuthToken  string        `json:"auth_token,omitempty"`
+	MarketData *pb.MarketData `json:"market_data,omitempty"`
+	NewsItem   *pb.NewsItem   `json:"news_item,omitempty"`
+}
+
+const (
+	msgTypeAuth      = "auth"
+	msgTypeSubscribe = "subscribe"
+	msgTypeMarket    = "market_data"
+	msgTypeNews      = "news_item"
+)
+
+// Config configures reconnect backoff and backfill behavior
+type Config struct {
+	URL             string
+	AuthToken       string
+	SourceID        string
+	InitialDelay    time.Duration
+	MaxDelay        time.Duration
+	BackoffFactor   float64
+	BackfillTimeout time.Duration
+}
+
+// DefaultConfig returns sane defaults for reconnect backoff and backfill
+func DefaultConfig(url string) *Config {
+	return &Config{
+		URL:             url,
+		SourceID:        "core_engine",
+		InitialDelay:    200 * time.Millisecond,
+		MaxDelay:        30 * time.Second,
+		BackoffFactor:   2.0,
+		BackfillTimeout: 10 * time.Second,
+	}
+}
+
+type marketSub struct {
+	symbols map[string]bool
+	ch      chan pb.MarketData
+}
+
+type newsSub struct {
+	keywords map[string]bool
+	ch       chan pb.NewsItem
+}
+
+// Client multiplexes many downstream subscriptions over a single upstream
+// WebSocket connection to the core engine's market-data stream
+type Client struct {
+	config  *Config
+	fetcher MarketDataFetcher
+	dial    dialFunc
+
+	mu            sync.Mutex
+	connection    conn
+	marketSubs    map[int]*marketSub
+	newsSubs      map[int]*newsSub
+	nextSubID     int
+	lastTimestamp int64
+	seen          map[string]struct{}
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewClient creates a Client and starts its connect/reconnect loop in the
+// background. fetcher is used to backfill gaps after a reconnect.
+func NewClient(config *Config, fetcher MarketDataFetcher) *Client {
+	return newClient(config, fetcher, dialWebsocket)
+}
+
+func newClient(config *Config, fetcher MarketDataFetcher, dial dialFunc) *Client {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	c := &Client{
+		config:     config,
+		fetcher:    fetcher,
+		dial:       dial,
+		marketSubs: make(map[int]*marketSub),
+		newsSubs:   make(map[int]*newsSub),
+		seen:       make(map[string]struct{}),
+		cancel:     cancel,
+		done:       make(chan struct{}),
+	}
+
+	go c.run(ctx)
+
+	return c
+}
+
+// Close stops the reconnect loop and the underlying connection
+func (c *Client) Close() {
+	c.cancel()
+	<-c.done
+}
+
+// Subscribe registers interest in symbols and returns a channel of
+// deduplicated, in-order market data ticks along with a cancel func that
+// unregisters the subscription and closes the channel
+func (c *Client) Subscribe(symbols []string) (<-chan pb.MarketData, func()) {
+	set := make(map[string]bool, len(symbols))
+	for _, s := range symbols {
+		set[s] = true
+	}
+
+	c.mu.Lock()
+	id := c.nextSubID
+	c.nextSubID++
+	sub := &marketSub{symbols: set, ch: make(chan pb.MarketData, 256)}
+	c.marketSubs[id] = sub
+	c.mu.Unlock()
+
+	c.resubscribe()
+
+	return sub.ch, func() {
+		c.mu.Lock()
+		delete(c.marketSubs, id)
+		c.mu.Unlock()
+		close(sub.ch)
+	}
+}
+
+// SubscribeNews registers interest in news keywords and returns a channel of
+// matching news items along with a cancel func
+func (c *Client) SubscribeNews(keywords []string) (<-chan pb.NewsItem, func()) {
+	set := make(map[string]bool, len(keywords))
+	for _, k := range keywords {
+		set[k] = true
+	}
+
+	c.mu.Lock()
+	id := c.nextSubID
+	c.nextSubID++
+	sub := &newsSub{keywords: set, ch: make(chan pb.NewsItem, 256)}
+	c.newsSubs[id] = sub
+	c.mu.Unlock()
+
+	c.resubscribe()
+
+	return sub.ch, func() {
+		c.mu.Lock()
+		delete(c.newsSubs, id)
+		c.mu.Unlock()
+		close(sub.ch)
+	}
+}
+
+// activeSymbols returns the union of symbols across all live subscriptions
+func (c *Client) activeSymbols() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	union := make(map[string]bool)
+	for _, sub := range c.marketSubs {
+		for s := range sub.symbols {
+			union[s] = true
+		}
+	}
+
+	symbols := make([]string, 0, len(union))
+	for s := range union {
+		symbols = append(symbols, s)
+	}
+	return symbols
+}
+
+// activeKeywords returns the union of keywords across all live news subs
+func (c *Client) activeKeywords() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	union := make(map[string]bool)
+	for _, sub := range c.newsSubs {
+		for k := range sub.keywords {
+			union[k] = true
+		}
+	}
+
+	keywords := make([]string, 0, len(union))
+	for k := range union {
+		keywords = append(keywords, k)
+	}
+	return keywords
+}
+
+// resubscribe pushes the current union of subscriptions to the
+// upstream connection, if one is live. It is a best-effort nudge: a dropped
+// write is recovered by the normal reconnect-and-replay path.
+func (c *Client) resubscribe() {
+	c.mu.Lock()
+	current := c.connection
+	c.mu.Unlock()
+
+	if current == nil {
+		return
+	}
+
+	_ = current.WriteJSON(wireMessage{
+		Type:     msgTypeSubscribe,
+		Symbols:  c.activeSymbols(),
+		Keywords: c.activeKeywords(),
+	})
+}
+
+// dispatchMarketData fans a deduplicated tick out to every subscriber whose
+// symbol set matches (or is empty, meaning "all symbols")
+func (c *Client) dispatchMarketData(data pb.MarketData) {
+	key := fmt.Sprintf("%s|%d", data.Symbol, data.Timestamp)
+
+	c.mu.Lock()
+	if _, ok := c.seen[key]; ok {
+		c.mu.Unlock()
+		return
+	}
+	c.seen[key] = struct{}{}
+	if data.Timestamp > c.lastTimestamp {
+		c.lastTimestamp = data.Timestamp
+	}
+	subs := make([]*marketSub, 0, len(c.marketSubs))
+	for _, sub := range c.marketSubs {
+		if len(sub.symbols) == 0 || sub.symbols[data.Symbol] {
+			subs = append(subs, sub)
+		}
+	}
+	c.mu.Unlock()
+
+	for _, sub := range subs {
+		select {
+		case sub.ch <- data:
+		default:
+			logger.Warnf("stream: dropping market data tick for %s, subscriber channel full", data.Symbol)
+		}
+	}
+}
+
+// dispatchNewsItem fans a news item out to every subscriber interested in
+// one of its keywords (or all items, for a subscriber with no keywords)
+func (c *Client) dispatchNewsItem(item pb.NewsItem) {
+	c.mu.Lock()
+	subs := make([]*newsSub, 0, len(c.newsSubs))
+	for _, sub := range c.newsSubs {
+		if len(sub.keywords) == 0 || matchesAny(sub.keywords, item) {
+			subs = append(subs, sub)
+		}
+	}
+	c.mu.Unlock()
+
+	for _, sub := range subs {
+		select {
+		case sub.ch <- item:
+		default:
+			logger.Warn("stream: dropping news item, subscriber channel full")
+		}
+	}
+}
+
+func matchesAny(keywords map[string]bool, item pb.NewsItem) bool {
+	for k := range keywords {
+		if containsFold(item.Title, k) || containsFold(item.Content, k) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,313 @@
+// Package acme obtains and renews the gateway's own mTLS client identity
+// from an internal ACME server (e.g. step-ca), instead of requiring
+// pre-provisioned PEM files on every pod. It wraps golang.org/x/crypto/acme
+// with the account-registration, RFC 8555 order flow, and renewal loop
+// needed so a fresh pod can go from nothing to a valid client certificate
+// on its own.
+package acme
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/market-intel/api-gateway/pkg/logger"
+	"golang.org/x/crypto/acme"
+)
+
+// ChallengeType selects which ACME challenge the Manager solves to prove
+// control of its identifiers.
+type ChallengeType string
+
+const (
+	// ChallengeHTTP01 serves the key authorization over plain HTTP on
+	// ChallengeAddr (":80" by default).
+	ChallengeHTTP01 ChallengeType = "http-01"
+
+	// ChallengeTLSALPN01 proves control via a self-signed certificate
+	// presented over TLS on ChallengeAddr (":443" by default), per RFC 8737.
+	ChallengeTLSALPN01 ChallengeType = "tls-alpn-01"
+)
+
+// ACMEConfig configures a Manager.
+type ACMEConfig struct {
+	// DirectoryURL is the ACME server's directory endpoint, e.g.
+	// "https://step-ca.market-intel-brain.svc.cluster.local/acme/acme/directory".
+	DirectoryURL string
+
+	// AccountKeyPath is where the ACME account's private key is read from
+	// (PEM-encoded EC PRIVATE KEY), or created on first run if missing.
+	AccountKeyPath string
+
+	// Identifiers are the DNS names the issued certificate should cover.
+	Identifiers []string
+
+	// ChallengeType selects how the Manager proves control of Identifiers.
+	ChallengeType ChallengeType
+
+	// ChallengeAddr overrides the address the Manager briefly listens on
+	// while solving a challenge. Defaults to ":80" for http-01 and ":443"
+	// for tls-alpn-01.
+	ChallengeAddr string
+
+	// RenewBefore is how long before NotAfter the Manager renews the
+	// certificate. Defaults to 30 days if zero.
+	RenewBefore time.Duration
+}
+
+// Validate reports whether cfg is complete enough to start a Manager.
+func (cfg ACMEConfig) Validate() error {
+	if cfg.DirectoryURL == "" {
+		return errors.New("acme: directory_url is required")
+	}
+	if cfg.AccountKeyPath == "" {
+		return errors.New("acme: account_key_path is required")
+	}
+	if len(cfg.Identifiers) == 0 {
+		return errors.New("acme: at least one identifier is required")
+	}
+	switch cfg.ChallengeType {
+	case ChallengeHTTP01, ChallengeTLSALPN01:
+	default:
+		return fmt.Errorf("acme: unsupported challenge type %q", cfg.ChallengeType)
+	}
+	return nil
+}
+
+// Manager obtains the gateway's client certificate from an ACME server on
+// startup, caches it via Cache, and renews it in the background as it
+// approaches expiry. It is safe for concurrent use.
+type Manager struct {
+	cfg    ACMEConfig
+	cache  Cache
+	client *acme.Client
+
+	// current holds the live *tls.Certificate, swapped atomically by
+	// obtain/renew so Certificate() never hands out a half-issued cert.
+	current atomic.Pointer[tls.Certificate]
+}
+
+// NewManager loads (or creates) the ACME account key at
+// cfg.AccountKeyPath and returns a Manager ready to Start.
+func NewManager(cfg ACMEConfig, cache Cache) (*Manager, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if cfg.RenewBefore == 0 {
+		cfg.RenewBefore = 30 * 24 * time.Hour
+	}
+
+	accountKey, err := loadOrCreateAccountKey(cfg.AccountKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ACME account key: %w", err)
+	}
+
+	return &Manager{
+		cfg:   cfg,
+		cache: cache,
+		client: &acme.Client{
+			Key:          accountKey,
+			DirectoryURL: cfg.DirectoryURL,
+		},
+	}, nil
+}
+
+// Start registers the ACME account, loads a cached certificate if one is
+// still valid for longer than RenewBefore (obtaining a fresh one
+// otherwise), and launches the background renewal loop. It blocks until
+// the Manager has a usable certificate, so a fresh pod's first
+// CreateGRPCredentials call doesn't race the initial issuance.
+func (m *Manager) Start(ctx context.Context) error {
+	if _, err := m.client.Register(ctx, &acme.Account{}, acme.AcceptTOS); err != nil && !errors.Is(err, acme.ErrAccountAlreadyExists) {
+		return fmt.Errorf("failed to register ACME account: %w", err)
+	}
+
+	if certPEM, keyPEM, err := m.cache.Get(ctx); err == nil {
+		cert, parseErr := tls.X509KeyPair(certPEM, keyPEM)
+		if parseErr == nil && !certNeedsRenewal(&cert, m.cfg.RenewBefore) {
+			logger.Infof("loaded cached ACME certificate for %v", m.cfg.Identifiers)
+			m.current.Store(&cert)
+		}
+	} else if !errors.Is(err, ErrCacheMiss) {
+		logger.Warnf("failed to read ACME cache, will obtain a fresh certificate: %v", err)
+	}
+
+	if m.current.Load() == nil {
+		if err := m.renew(ctx); err != nil {
+			return fmt.Errorf("failed to obtain initial ACME certificate: %w", err)
+		}
+	}
+
+	go m.renewLoop(ctx)
+	return nil
+}
+
+// Certificate returns the Manager's current client certificate, suitable
+// for use as tls.Config.GetClientCertificate.
+func (m *Manager) Certificate() (*tls.Certificate, error) {
+	cert := m.current.Load()
+	if cert == nil {
+		return nil, errors.New("acme: no certificate available yet")
+	}
+	return cert, nil
+}
+
+// renewLoop sleeps until the current certificate is within RenewBefore of
+// expiring, renews it, and repeats. A failed renewal is retried after an
+// hour rather than crash-looping the pod over a transient CA outage.
+func (m *Manager) renewLoop(ctx context.Context) {
+	for {
+		cert := m.current.Load()
+		sleep := time.Hour
+		if cert != nil {
+			if leaf, err := x509.ParseCertificate(cert.Certificate[0]); err == nil {
+				sleep = time.Until(leaf.NotAfter.Add(-m.cfg.RenewBefore))
+				if sleep < 0 {
+					sleep = 0
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(sleep):
+		}
+
+		if err := m.renew(ctx); err != nil {
+			logger.Errorf("ACME certificate renewal failed, retrying in 1h: %v", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Hour):
+			}
+		}
+	}
+}
+
+// renew runs the full RFC 8555 order flow for cfg.Identifiers, stores the
+// result via Cache, and swaps it in as the current certificate.
+func (m *Manager) renew(ctx context.Context) error {
+	logger.Infof("requesting ACME certificate for %v via %s", m.cfg.Identifiers, m.cfg.ChallengeType)
+
+	order, err := m.client.AuthorizeOrder(ctx, acme.DomainIDs(m.cfg.Identifiers...))
+	if err != nil {
+		return fmt.Errorf("failed to create order: %w", err)
+	}
+
+	for _, authzURL := range order.AuthzURLs {
+		authz, err := m.client.GetAuthorization(ctx, authzURL)
+		if err != nil {
+			return fmt.Errorf("failed to get authorization %s: %w", authzURL, err)
+		}
+		if err := m.solve(ctx, authz); err != nil {
+			return fmt.Errorf("failed to solve authorization for %s: %w", authz.Identifier.Value, err)
+		}
+	}
+
+	order, err = m.client.WaitOrder(ctx, order.URI)
+	if err != nil {
+		return fmt.Errorf("order did not become ready: %w", err)
+	}
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return fmt.Errorf("failed to generate certificate key: %w", err)
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: m.cfg.Identifiers[0]},
+		DNSNames: m.cfg.Identifiers,
+	}, leafKey)
+	if err != nil {
+		return fmt.Errorf("failed to create CSR: %w", err)
+	}
+
+	der, _, err := m.client.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return fmt.Errorf("failed to finalize order: %w", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(leafKey)
+	if err != nil {
+		return fmt.Errorf("failed to marshal certificate key: %w", err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	var certPEM []byte
+	for _, block := range der {
+		certPEM = append(certPEM, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: block})...)
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return fmt.Errorf("failed to parse issued certificate: %w", err)
+	}
+
+	if err := m.cache.Put(ctx, certPEM, keyPEM); err != nil {
+		logger.Warnf("failed to cache ACME certificate: %v", err)
+	}
+
+	m.current.Store(&cert)
+	logger.Infof("ACME certificate issued for %v", m.cfg.Identifiers)
+	return nil
+}
+
+// certNeedsRenewal reports whether cert is already within renewBefore of
+// its expiry (or already expired), in which case it shouldn't be loaded
+// from cache as-is.
+func certNeedsRenewal(cert *tls.Certificate, renewBefore time.Duration) bool {
+	if len(cert.Certificate) == 0 {
+		return true
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return true
+	}
+	return time.Until(leaf.NotAfter) < renewBefore
+}
+
+// loadOrCreateAccountKey reads a PEM-encoded EC private key from path, or
+// generates and persists a new one if nothing exists there yet.
+func loadOrCreateAccountKey(path string) (*ecdsa.PrivateKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err == nil {
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("no PEM data found in %s", path)
+		}
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate account key: %w", err)
+	}
+
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal account key: %w", err)
+	}
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+
+	if err := ioutil.WriteFile(path, pemBytes, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to persist account key: %w", err)
+	}
+	logger.Infof("generated new ACME account key at %s", path)
+
+	return key, nil
+}
@@ -0,0 +1,135 @@
+package acme
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/market-intel/api-gateway/pkg/logger"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Cache persists the cert/key pair a Manager obtains from the ACME server,
+// so a pod restart doesn't have to re-issue on every boot.
+type Cache interface {
+	// Get returns the previously stored cert/key PEM pair. It must return
+	// ErrCacheMiss (wrapped or not) if nothing has been stored yet.
+	Get(ctx context.Context) (certPEM, keyPEM []byte, err error)
+
+	// Put stores certPEM/keyPEM, overwriting any previous pair.
+	Put(ctx context.Context, certPEM, keyPEM []byte) error
+}
+
+// ErrCacheMiss indicates the Cache has no stored cert/key pair yet, so the
+// Manager should fall through to obtaining a fresh certificate.
+var ErrCacheMiss = fmt.Errorf("acme: cache miss")
+
+// FileCache stores the cert/key pair as two files on disk, mirroring the
+// ClientCertPath/ClientKeyPath layout used elsewhere in pkg/tls.
+type FileCache struct {
+	CertPath string
+	KeyPath  string
+}
+
+// Get implements Cache.
+func (f *FileCache) Get(ctx context.Context) ([]byte, []byte, error) {
+	certPEM, err := ioutil.ReadFile(f.CertPath)
+	if os.IsNotExist(err) {
+		return nil, nil, ErrCacheMiss
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read cached certificate: %w", err)
+	}
+
+	keyPEM, err := ioutil.ReadFile(f.KeyPath)
+	if os.IsNotExist(err) {
+		return nil, nil, ErrCacheMiss
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read cached key: %w", err)
+	}
+
+	return certPEM, keyPEM, nil
+}
+
+// Put implements Cache.
+func (f *FileCache) Put(ctx context.Context, certPEM, keyPEM []byte) error {
+	if err := os.MkdirAll(filepath.Dir(f.CertPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create certificate directory: %w", err)
+	}
+	if err := ioutil.WriteFile(f.CertPath, certPEM, 0o644); err != nil {
+		return fmt.Errorf("failed to write certificate: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(f.KeyPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create key directory: %w", err)
+	}
+	if err := ioutil.WriteFile(f.KeyPath, keyPEM, 0o600); err != nil {
+		return fmt.Errorf("failed to write key: %w", err)
+	}
+
+	logger.Infof("ACME certificate cached to %s", f.CertPath)
+	return nil
+}
+
+// K8sSecretCache stores the cert/key pair in a Kubernetes Secret of type
+// kubernetes.io/tls, so every replica in a Deployment shares one
+// ACME-issued identity instead of each pod issuing (and rate-limiting
+// against the ACME server for) its own.
+type K8sSecretCache struct {
+	Clientset  kubernetes.Interface
+	Namespace  string
+	SecretName string
+}
+
+// Get implements Cache.
+func (k *K8sSecretCache) Get(ctx context.Context) ([]byte, []byte, error) {
+	secret, err := k.Clientset.CoreV1().Secrets(k.Namespace).Get(ctx, k.SecretName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil, ErrCacheMiss
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get secret %s/%s: %w", k.Namespace, k.SecretName, err)
+	}
+
+	certPEM, keyPEM := secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]
+	if len(certPEM) == 0 || len(keyPEM) == 0 {
+		return nil, nil, ErrCacheMiss
+	}
+
+	return certPEM, keyPEM, nil
+}
+
+// Put implements Cache. It creates the Secret if it doesn't exist yet and
+// updates it in place otherwise, so every replica picks up the renewed
+// material the next time it reloads from the cache.
+func (k *K8sSecretCache) Put(ctx context.Context, certPEM, keyPEM []byte) error {
+	secrets := k.Clientset.CoreV1().Secrets(k.Namespace)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      k.SecretName,
+			Namespace: k.Namespace,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+		},
+	}
+
+	if _, err := secrets.Update(ctx, secret, metav1.UpdateOptions{}); apierrors.IsNotFound(err) {
+		if _, err := secrets.Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create secret %s/%s: %w", k.Namespace, k.SecretName, err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("failed to update secret %s/%s: %w", k.Namespace, k.SecretName, err)
+	}
+
+	logger.Infof("ACME certificate cached to secret %s/%s", k.Namespace, k.SecretName)
+	return nil
+}
@@ -0,0 +1,127 @@
+package acme
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"golang.org/x/crypto/acme"
+)
+
+// solve picks a challenge of m.cfg.ChallengeType from authz, proves control
+// of the identifier by briefly standing up the matching listener, then
+// tells the ACME server to validate it and waits for the result.
+func (m *Manager) solve(ctx context.Context, authz *acme.Authorization) error {
+	if authz.Status == acme.StatusValid {
+		return nil
+	}
+
+	var chal *acme.Challenge
+	for _, c := range authz.Challenges {
+		if c.Type == string(m.cfg.ChallengeType) {
+			chal = c
+			break
+		}
+	}
+	if chal == nil {
+		return fmt.Errorf("CA offered no %s challenge for %s", m.cfg.ChallengeType, authz.Identifier.Value)
+	}
+
+	switch m.cfg.ChallengeType {
+	case ChallengeHTTP01:
+		return m.solveHTTP01(ctx, chal)
+	case ChallengeTLSALPN01:
+		return m.solveTLSALPN01(ctx, chal, authz.Identifier.Value)
+	default:
+		return fmt.Errorf("unsupported challenge type %q", m.cfg.ChallengeType)
+	}
+}
+
+// solveHTTP01 serves the key authorization at the well-known http-01 path
+// on cfg.ChallengeAddr just long enough for the CA to fetch it.
+func (m *Manager) solveHTTP01(ctx context.Context, chal *acme.Challenge) error {
+	response, err := m.client.HTTP01ChallengeResponse(chal.Token)
+	if err != nil {
+		return fmt.Errorf("failed to compute http-01 response: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(m.client.HTTP01ChallengePath(chal.Token), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(response))
+	})
+
+	addr := m.cfg.ChallengeAddr
+	if addr == "" {
+		addr = ":80"
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen for http-01 challenge on %s: %w", addr, err)
+	}
+	srv := &http.Server{Handler: mux}
+	go srv.Serve(ln)
+	defer srv.Close()
+
+	return m.accept(ctx, chal)
+}
+
+// solveTLSALPN01 stands up a short-lived TLS listener presenting the
+// challenge certificate via ALPN, as required by RFC 8737.
+func (m *Manager) solveTLSALPN01(ctx context.Context, chal *acme.Challenge, domain string) error {
+	cert, err := m.client.TLSALPN01ChallengeCert(chal.Token, domain)
+	if err != nil {
+		return fmt.Errorf("failed to build tls-alpn-01 challenge certificate: %w", err)
+	}
+
+	addr := m.cfg.ChallengeAddr
+	if addr == "" {
+		addr = ":443"
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		NextProtos:   []string{acme.ALPNProto},
+	}
+
+	ln, err := tls.Listen("tcp", addr, tlsConfig)
+	if err != nil {
+		return fmt.Errorf("failed to listen for tls-alpn-01 challenge on %s: %w", addr, err)
+	}
+	go serveAndDiscard(ln)
+	defer ln.Close()
+
+	return m.accept(ctx, chal)
+}
+
+// accept tells the CA the challenge can now be validated. The caller's
+// subsequent WaitOrder call is what actually confirms every authorization
+// went valid, so this just kicks off validation for this one challenge.
+func (m *Manager) accept(ctx context.Context, chal *acme.Challenge) error {
+	if _, err := m.client.Accept(ctx, chal); err != nil {
+		return fmt.Errorf("failed to accept %s challenge: %w", chal.Type, err)
+	}
+	return nil
+}
+
+// serveAndDiscard accepts and immediately closes connections on ln, just
+// enough to let the TLS handshake used by tls-alpn-01 validation complete.
+func serveAndDiscard(ln net.Listener) {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		go func(c net.Conn) {
+			defer c.Close()
+			c.SetDeadline(time.Now().Add(10 * time.Second))
+			if tc, ok := c.(*tls.Conn); ok {
+				tc.HandshakeContext(context.Background())
+			}
+		}(conn)
+	}
+}
@@ -0,0 +1,134 @@
+// Package revocation checks peer certificates against OCSP stapled
+// responses and, failing that, CA-published CRLs, so a compromised but
+// not-yet-expired certificate can still be rejected mid-handshake.
+package revocation
+
+import (
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/market-intel/api-gateway/pkg/logger"
+	"golang.org/x/crypto/ocsp"
+)
+
+// ErrNoOCSPStaple indicates the handshake carried no stapled OCSP response,
+// so the caller should fall back to a CRL check instead of treating this as
+// a revocation failure.
+var ErrNoOCSPStaple = fmt.Errorf("no OCSP staple presented")
+
+// defaultHTTPClient is used for both CRL downloads and OCSP fallback
+// lookups. A short timeout keeps a slow or unreachable CA from stalling
+// every handshake.
+var defaultHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// CheckOCSPStaple validates a stapled OCSP response for leaf against
+// issuer. It returns ErrNoOCSPStaple if no response was stapled, and a
+// non-nil error if the response is stale, doesn't match leaf, or reports
+// the certificate as revoked.
+func CheckOCSPStaple(leaf, issuer *x509.Certificate, staple []byte) error {
+	if len(staple) == 0 {
+		return ErrNoOCSPStaple
+	}
+
+	resp, err := ocsp.ParseResponseForCert(staple, leaf, issuer)
+	if err != nil {
+		return fmt.Errorf("failed to parse stapled OCSP response: %w", err)
+	}
+
+	if !resp.NextUpdate.IsZero() && time.Now().After(resp.NextUpdate) {
+		return fmt.Errorf("stapled OCSP response expired at %s", resp.NextUpdate)
+	}
+
+	switch resp.Status {
+	case ocsp.Good:
+		return nil
+	case ocsp.Revoked:
+		return fmt.Errorf("certificate %s revoked via OCSP at %s (reason %d)", leaf.SerialNumber, resp.RevokedAt, resp.RevocationReason)
+	default:
+		return fmt.Errorf("OCSP responder returned unknown status for certificate %s", leaf.SerialNumber)
+	}
+}
+
+// crlCacheEntry holds a previously fetched CRL along with when it was
+// fetched, so CheckCRL can avoid refetching a distribution point on every
+// handshake.
+type crlCacheEntry struct {
+	list      *x509.RevocationList
+	fetchedAt time.Time
+}
+
+// CRLChecker fetches and caches CA-published CRLs, checking a leaf
+// certificate's serial number against them. It is safe for concurrent use.
+type CRLChecker struct {
+	ttl   time.Duration
+	mu    sync.Mutex
+	cache map[string]*crlCacheEntry
+}
+
+// NewCRLChecker returns a CRLChecker that refetches a distribution point's
+// CRL once every ttl.
+func NewCRLChecker(ttl time.Duration) *CRLChecker {
+	return &CRLChecker{
+		ttl:   ttl,
+		cache: make(map[string]*crlCacheEntry),
+	}
+}
+
+// Check fetches (or reuses a cached copy of) the CRL from each of leaf's
+// CRL distribution points and reports an error if leaf's serial number
+// appears among the revoked entries. It succeeds if leaf has no
+// distribution points, since not every CA publishes CRLs.
+func (c *CRLChecker) Check(leaf *x509.Certificate) error {
+	for _, dp := range leaf.CRLDistributionPoints {
+		list, err := c.fetch(dp)
+		if err != nil {
+			logger.Warnf("failed to fetch CRL from %s: %v", dp, err)
+			continue
+		}
+
+		for _, revoked := range list.RevokedCertificateEntries {
+			if revoked.SerialNumber.Cmp(leaf.SerialNumber) == 0 {
+				return fmt.Errorf("certificate %s revoked via CRL %s at %s", leaf.SerialNumber, dp, revoked.RevocationTime)
+			}
+		}
+	}
+
+	return nil
+}
+
+// fetch returns the cached CRL for dp if it's younger than the checker's
+// TTL, otherwise downloads and parses a fresh copy.
+func (c *CRLChecker) fetch(dp string) (*x509.RevocationList, error) {
+	c.mu.Lock()
+	entry, ok := c.cache[dp]
+	c.mu.Unlock()
+	if ok && time.Since(entry.fetchedAt) < c.ttl {
+		return entry.list, nil
+	}
+
+	resp, err := defaultHTTPClient.Get(dp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download CRL: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CRL response: %w", err)
+	}
+
+	list, err := x509.ParseRevocationList(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CRL: %w", err)
+	}
+
+	c.mu.Lock()
+	c.cache[dp] = &crlCacheEntry{list: list, fetchedAt: time.Now()}
+	c.mu.Unlock()
+
+	return list, nil
+}
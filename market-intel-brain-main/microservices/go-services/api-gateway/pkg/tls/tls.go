@@ -4,37 +4,179 @@
 package tls
 
 import (
+	"context"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"strings"
+	"sync/atomic"
+	"syscall"
 	"time"
 
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
+	"github.com/fsnotify/fsnotify"
 	"github.com/market-intel/api-gateway/pkg/logger"
+	"github.com/market-intel/api-gateway/pkg/tls/acme"
+	"github.com/market-intel/api-gateway/pkg/tls/revocation"
+	"github.com/market-intel/api-gateway/pkg/tls/spiffe"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/credentials"
+)
+
+// Source selects where TLSConfig sources the client's identity material
+// from.
+type Source string
+
+const (
+	// SourceFile reads the client cert/key/CA from the paths below (the
+	// original behavior, hot-reloaded via Watch).
+	SourceFile Source = "file"
+
+	// SourceSPIFFE sources the client's X509-SVID and trust bundle from a
+	// local SPIFFE Workload API socket instead, auto-rotating both via the
+	// streaming X.509-SVID API.
+	SourceSPIFFE Source = "spiffe"
+
+	// SourceEnv reads the client cert/key/CA as PEM content from
+	// environment variables rather than files on disk.
+	SourceEnv Source = "env"
+
+	// SourceACME sources the client certificate from an ACMEManager, which
+	// issues and renews it against an internal ACME server (e.g. step-ca)
+	// instead of requiring a pre-provisioned cert/key on disk.
+	SourceACME Source = "acme"
+)
+
+// CASource is one CA root bundle contributing to a TLSConfig's merged
+// trust pool, optionally labeled with the trust domain it belongs to.
+type CASource struct {
+	Path        string `json:"path" yaml:"path"`
+	TrustDomain string `json:"trust_domain,omitempty" yaml:"trust_domain,omitempty"`
+}
+
+// PinnedPeer pins the expected SPKI hash of a peer certificate for a given
+// server name, evaluated against every certificate in the verified chain.
+type PinnedPeer struct {
+	ServerName     string `json:"server_name" yaml:"server_name"`
+	SPKIHashSHA256 string `json:"spki_hash_sha256" yaml:"spki_hash_sha256"`
+}
+
+// tlsReloadTotal and tlsCertNotAfter let operators alert on rotation
+// failures and track time-to-expiry without shelling out to openssl.
+var (
+	tlsReloadTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "tls_material_reload_total",
+		Help: "Count of TLS certificate/key/CA reload attempts, by result",
+	}, []string{"result"})
+
+	tlsCertNotAfter = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "tls_cert_not_after_seconds",
+		Help: "Unix timestamp of the currently loaded certificate's NotAfter",
+	}, []string{"cert"})
 )
 
+func init() {
+	prometheus.MustRegister(tlsReloadTotal, tlsCertNotAfter)
+}
+
 // TLS configuration for gRPC client
 type TLSConfig struct {
 	// Path to the client certificate file
 	ClientCertPath string `json:"client_cert_path" yaml:"client_cert_path"`
-	
+
 	// Path to the client private key file
 	ClientKeyPath string `json:"client_key_path" yaml:"client_key_path"`
-	
+
 	// Path to the CA certificate file for server verification
 	CACertPath string `json:"ca_cert_path" yaml:"ca_cert_path"`
-	
+
+	// CACertPaths lists additional CA root bundles merged into the same
+	// trust pool as CACertPath, each optionally labeled with the trust
+	// domain it belongs to. This lets the gateway trust services signed
+	// by more than one internal CA at once -- e.g. during a migration
+	// between CAs, or across federated clusters -- instead of requiring
+	// a single CACertPath like LoadCACertificate used to.
+	CACertPaths []CASource `json:"ca_cert_paths" yaml:"ca_cert_paths"`
+
+	// PinnedPeers restricts which leaf/intermediate certificates are
+	// accepted for a given server name, evaluated in verifyConnection.
+	// It keeps a broad federated root pool from implicitly trusting every
+	// certificate those CAs could ever issue.
+	PinnedPeers []PinnedPeer `json:"pinned_peers" yaml:"pinned_peers"`
+
 	// Whether to verify server certificate
 	VerifyServerCert bool `json:"verify_server_cert" yaml:"verify_server_cert"`
-	
+
 	// Server name for certificate verification
 	ServerName string `json:"server_name" yaml:"server_name"`
-	
+
 	// Skip TLS verification (for testing only)
 	SkipVerify bool `json:"skip_verify" yaml:"skip_verify"`
+
+	// Source selects where the client identity material comes from. Empty
+	// defaults to SourceFile for backward compatibility.
+	Source Source `json:"source" yaml:"source"`
+
+	// ClientCertPEM, ClientKeyPEM and CACertPEM hold the client cert/key/CA
+	// as inline PEM content, used when Source is SourceEnv.
+	ClientCertPEM string `json:"-" yaml:"-"`
+	ClientKeyPEM  string `json:"-" yaml:"-"`
+	CACertPEM     string `json:"-" yaml:"-"`
+
+	// AuthorizedSPIFFEIDs lists the SPIFFE ID patterns (trust domain plus
+	// an optional "/*"-suffixed path glob) the peer's X509-SVID must match.
+	// Only used when Source is SourceSPIFFE, in which case it replaces
+	// ServerName-based DNS verification entirely.
+	AuthorizedSPIFFEIDs []string `json:"authorized_spiffe_ids" yaml:"authorized_spiffe_ids"`
+
+	// ACMEManager issues and renews the client certificate when Source is
+	// SourceACME. The caller is responsible for constructing it (it needs
+	// a Cache implementation and, typically, a blocking Start call) and
+	// assigning it here before CreateTLSConfig/Validate/GetCertificateInfo
+	// are called.
+	ACMEManager *acme.Manager `json:"-" yaml:"-"`
+
+	// EnableOCSPStapling checks the server's stapled OCSP response (if any)
+	// on every handshake and rejects the connection if it reports the peer
+	// certificate as revoked.
+	EnableOCSPStapling bool `json:"enable_ocsp_stapling" yaml:"enable_ocsp_stapling"`
+
+	// EnableCRLCheck fetches the peer certificate's CRL distribution
+	// points and rejects the connection if its serial number has been
+	// revoked. It is used as a fallback when no OCSP staple is presented.
+	EnableCRLCheck bool `json:"enable_crl_check" yaml:"enable_crl_check"`
+
+	// CRLCacheTTL controls how long a fetched CRL is reused before being
+	// re-downloaded. Defaults to one hour if zero.
+	CRLCacheTTL time.Duration `json:"crl_cache_ttl" yaml:"crl_cache_ttl"`
+
+	// current holds the live *tlsMaterial, swapped atomically by reload()
+	// so in-flight handshakes never observe a half-updated cert/pool pair.
+	// Unused when Source is SourceSPIFFE, since rotation there is handled
+	// by spiffeSource instead.
+	current atomic.Pointer[tlsMaterial]
+
+	// spiffeSource is the live connection to the Workload API, established
+	// lazily by CreateTLSConfig when Source is SourceSPIFFE.
+	spiffeSource *spiffe.Source
+
+	// crlChecker lazily holds the CRL fetcher/cache used by verifyConnection
+	// when EnableCRLCheck is set.
+	crlChecker *revocation.CRLChecker
+}
+
+// tlsMaterial is the cert/CA pair backing a single point-in-time TLS
+// configuration. Reload produces a new one and swaps it in atomically
+// rather than mutating fields in place.
+type tlsMaterial struct {
+	cert *tls.Certificate
+	pool *x509.CertPool
 }
 
 // Default TLS configuration
@@ -46,6 +188,7 @@ func DefaultTLSConfig() *TLSConfig {
 		VerifyServerCert:   true,
 		ServerName:         "core-engine.market-intel-brain.svc.cluster.local",
 		SkipVerify:        false,
+		Source:            SourceFile,
 	}
 }
 
@@ -76,7 +219,35 @@ func NewTLSConfigFromEnv() *TLSConfig {
 	if skip := os.Getenv("SKIP_TLS_VERIFY"); skip != "" {
 		config.SkipVerify = strings.ToLower(skip) == "true"
 	}
-	
+
+	if source := os.Getenv("TLS_SOURCE"); source != "" {
+		config.Source = Source(strings.ToLower(source))
+	}
+
+	config.ClientCertPEM = os.Getenv("CLIENT_CERT_PEM")
+	config.ClientKeyPEM = os.Getenv("CLIENT_KEY_PEM")
+	config.CACertPEM = os.Getenv("CA_CERT_PEM")
+
+	if ids := os.Getenv("AUTHORIZED_SPIFFE_IDS"); ids != "" {
+		config.AuthorizedSPIFFEIDs = strings.Split(ids, ",")
+	}
+
+	if ocsp := os.Getenv("ENABLE_OCSP_STAPLING"); ocsp != "" {
+		config.EnableOCSPStapling = strings.ToLower(ocsp) == "true"
+	}
+
+	if crl := os.Getenv("ENABLE_CRL_CHECK"); crl != "" {
+		config.EnableCRLCheck = strings.ToLower(crl) == "true"
+	}
+
+	if ttl := os.Getenv("CRL_CACHE_TTL"); ttl != "" {
+		if parsed, err := time.ParseDuration(ttl); err == nil {
+			config.CRLCacheTTL = parsed
+		} else {
+			logger.Warnf("invalid CRL_CACHE_TTL %q, ignoring: %v", ttl, err)
+		}
+	}
+
 	return config
 }
 
@@ -107,18 +278,355 @@ func (c *TLSConfig) LoadCACertificate() (*x509.CertPool, error) {
 	if err != nil {
 		return nil, logger.Errorf("failed to read CA certificate: %w", err)
 	}
-	
+
 	certPool := x509.NewCertPool()
 	if !certPool.AppendCertsFromPEM(caCert) {
 		return nil, logger.Errorf("failed to parse CA certificate")
 	}
-	
-	logger.Infof("CA certificate loaded successfully from %s", c.CACertPath)
+
+	if err := c.mergeFederatedRoots(certPool); err != nil {
+		return nil, err
+	}
+
+	logger.Infof("CA certificate loaded successfully from %s (+%d federated roots)", c.CACertPath, len(c.CACertPaths))
 	return certPool, nil
 }
 
+// mergeFederatedRoots appends every root in c.CACertPaths to pool, so
+// callers building a trust pool from any Source pick up the same
+// federated roots CACertPath alone would miss.
+func (c *TLSConfig) mergeFederatedRoots(pool *x509.CertPool) error {
+	for _, source := range c.CACertPaths {
+		pem, err := ioutil.ReadFile(source.Path)
+		if err != nil {
+			return logger.Errorf("failed to read federated CA certificate %s: %w", source.Path, err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return logger.Errorf("failed to parse federated CA certificate %s", source.Path)
+		}
+		logger.Infof("merged federated CA root from %s (trust domain %q)", source.Path, source.TrustDomain)
+	}
+	return nil
+}
+
+// loadClientCertificateFromEnv parses the client cert/key from the inline
+// PEM content in ClientCertPEM/ClientKeyPEM, used when Source is SourceEnv.
+func (c *TLSConfig) loadClientCertificateFromEnv() (tls.Certificate, error) {
+	clientCert, err := tls.X509KeyPair([]byte(c.ClientCertPEM), []byte(c.ClientKeyPEM))
+	if err != nil {
+		return tls.Certificate{}, logger.Errorf("failed to parse client certificate from CLIENT_CERT_PEM/CLIENT_KEY_PEM: %w", err)
+	}
+
+	logger.Infof("Client certificate loaded successfully from environment")
+	return clientCert, nil
+}
+
+// loadCACertificateFromEnv parses the CA bundle from the inline PEM content
+// in CACertPEM, used when Source is SourceEnv.
+func (c *TLSConfig) loadCACertificateFromEnv() (*x509.CertPool, error) {
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM([]byte(c.CACertPEM)) {
+		return nil, logger.Errorf("failed to parse CA certificate from CA_CERT_PEM")
+	}
+
+	if err := c.mergeFederatedRoots(certPool); err != nil {
+		return nil, err
+	}
+
+	logger.Infof("CA certificate loaded successfully from environment")
+	return certPool, nil
+}
+
+// loadClientCertificateFromACME returns ACMEManager's current certificate,
+// used when Source is SourceACME. Unlike the file/env loaders, renewal
+// happens in ACMEManager's own background loop, not here; reload() just
+// picks up whatever ACMEManager is currently holding.
+func (c *TLSConfig) loadClientCertificateFromACME() (tls.Certificate, error) {
+	if c.ACMEManager == nil {
+		return tls.Certificate{}, logger.Errorf("acme_manager must be set when source is %q", SourceACME)
+	}
+	cert, err := c.ACMEManager.Certificate()
+	if err != nil {
+		return tls.Certificate{}, logger.Errorf("failed to get client certificate from ACME manager: %w", err)
+	}
+	return *cert, nil
+}
+
+// reload re-reads the client cert/key and (if verification is enabled) the
+// CA bundle from disk (or from the environment, when Source is SourceEnv)
+// and atomically swaps them in, so any *tls.Config previously handed out
+// via CreateTLSConfig picks up the new material on its very next handshake
+// through GetClientCertificate/verifyPeerCertificate without the gateway
+// needing to redial or restart. It is a no-op for SourceSPIFFE, since
+// rotation there is driven by the Workload API stream instead.
+func (c *TLSConfig) reload() error {
+	if c.Source == SourceSPIFFE {
+		return nil
+	}
+
+	loadClientCertificate := c.LoadClientCertificate
+	loadCACertificate := c.LoadCACertificate
+	if c.Source == SourceEnv {
+		loadClientCertificate = c.loadClientCertificateFromEnv
+		loadCACertificate = c.loadCACertificateFromEnv
+	}
+	if c.Source == SourceACME {
+		loadClientCertificate = c.loadClientCertificateFromACME
+	}
+
+	clientCert, err := loadClientCertificate()
+	if err != nil {
+		tlsReloadTotal.WithLabelValues("failure").Inc()
+		return fmt.Errorf("failed to load client certificate: %w", err)
+	}
+
+	var certPool *x509.CertPool
+	if c.VerifyServerCert && !c.SkipVerify {
+		certPool, err = loadCACertificate()
+		if err != nil {
+			tlsReloadTotal.WithLabelValues("failure").Inc()
+			return fmt.Errorf("failed to load CA certificate: %w", err)
+		}
+	}
+
+	c.current.Store(&tlsMaterial{cert: &clientCert, pool: certPool})
+	tlsReloadTotal.WithLabelValues("success").Inc()
+
+	if len(clientCert.Certificate) > 0 {
+		if x509Cert, err := x509.ParseCertificate(clientCert.Certificate[0]); err == nil {
+			tlsCertNotAfter.WithLabelValues("client").Set(float64(x509Cert.NotAfter.Unix()))
+		}
+	}
+
+	if c.Source == SourceEnv {
+		logger.Infof("TLS material reloaded from environment")
+	} else {
+		logger.Infof("TLS material reloaded from %s, %s, %s", c.ClientCertPath, c.ClientKeyPath, c.CACertPath)
+	}
+	return nil
+}
+
+// GetClientCertificate backs tls.Config.GetClientCertificate. It is called
+// on every handshake, so rotating c.current via reload() takes effect on
+// the very next connection without tearing down existing ones.
+func (c *TLSConfig) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	material := c.current.Load()
+	if material == nil {
+		return nil, fmt.Errorf("tls material not loaded")
+	}
+	return material.cert, nil
+}
+
+// verifyPeerCertificate backs tls.Config.VerifyPeerCertificate. It exists
+// because tls.Config.RootCAs is read once per handshake from a plain field,
+// not a hook -- and libraries like grpc-go clone the *tls.Config before
+// dialing, so mutating RootCAs in place after the fact is invisible to
+// already-established clients. Verifying manually against the atomically
+// loaded CA pool gives RootCAs the same hot-reload behavior GetClientCertificate
+// gets for free.
+func (c *TLSConfig) verifyPeerCertificate(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	material := c.current.Load()
+	if material == nil || material.pool == nil {
+		return fmt.Errorf("tls material not loaded")
+	}
+
+	if len(rawCerts) == 0 {
+		return fmt.Errorf("no peer certificate presented")
+	}
+
+	certs := make([]*x509.Certificate, len(rawCerts))
+	for i, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return fmt.Errorf("failed to parse peer certificate %d: %w", i, err)
+		}
+		certs[i] = cert
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         material.pool,
+		Intermediates: intermediates,
+		DNSName:       c.ServerName,
+		CurrentTime:   time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("peer certificate verification failed: %w", err)
+	}
+	return nil
+}
+
+// verifyConnection backs tls.Config.VerifyConnection. It runs after
+// verifyPeerCertificate has already established trust in the chain, and
+// additionally rejects the connection if the leaf certificate has been
+// revoked: first by checking a stapled OCSP response, falling back to a
+// CRL lookup if no staple was presented.
+func (c *TLSConfig) verifyConnection(cs tls.ConnectionState) error {
+	if len(cs.PeerCertificates) == 0 {
+		return fmt.Errorf("no peer certificate presented")
+	}
+
+	leaf := cs.PeerCertificates[0]
+	issuer := leaf
+	if len(cs.PeerCertificates) > 1 {
+		issuer = cs.PeerCertificates[1]
+	}
+
+	if c.EnableOCSPStapling {
+		err := revocation.CheckOCSPStaple(leaf, issuer, cs.OCSPResponse)
+		switch {
+		case err == nil:
+			return c.verifyPin(cs.PeerCertificates)
+		case errors.Is(err, revocation.ErrNoOCSPStaple):
+			logger.Warnf("no OCSP staple presented for %s, falling back to CRL", leaf.Subject)
+		default:
+			return err
+		}
+	}
+
+	if c.EnableCRLCheck {
+		if c.crlChecker == nil {
+			ttl := c.CRLCacheTTL
+			if ttl == 0 {
+				ttl = time.Hour
+			}
+			c.crlChecker = revocation.NewCRLChecker(ttl)
+		}
+		if err := c.crlChecker.Check(leaf); err != nil {
+			return err
+		}
+	}
+
+	return c.verifyPin(cs.PeerCertificates)
+}
+
+// verifyPin enforces PinnedPeers: if any entries are configured for
+// c.ServerName, at least one certificate in the verified chain must match
+// one of their SPKI hashes. It is a no-op if no pin is configured for the
+// server name, so PinnedPeers only needs to list the servers an operator
+// actually wants pinned.
+func (c *TLSConfig) verifyPin(chain []*x509.Certificate) error {
+	var want []string
+	for _, pin := range c.PinnedPeers {
+		if pin.ServerName == c.ServerName {
+			want = append(want, pin.SPKIHashSHA256)
+		}
+	}
+	if len(want) == 0 {
+		return nil
+	}
+
+	for _, cert := range chain {
+		hash := spkiHashSHA256(cert)
+		for _, w := range want {
+			if hash == w {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("no certificate in chain for %s matched a pinned SPKI hash", c.ServerName)
+}
+
+// spkiHashSHA256 returns the base64-encoded SHA-256 hash of cert's
+// SubjectPublicKeyInfo, matching the pin format used by HPKP and most
+// certificate-pinning tooling.
+func spkiHashSHA256(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// Watch reloads the client cert/key and CA bundle whenever their files
+// change on disk (fsnotify) or the process receives SIGHUP, so the gateway
+// never needs a restart to pick up a renewed certificate. It watches the
+// containing directories rather than the files themselves, since rotation
+// tools like cert-manager and Vault Agent replace files via atomic rename
+// rather than writing in place. The watcher runs until ctx is cancelled.
+// It is a no-op for SourceSPIFFE (rotation comes from the Workload API
+// stream), SourceEnv (there are no files to watch), and SourceACME
+// (renewal is driven by ACMEManager's own background loop).
+func (c *TLSConfig) Watch(ctx context.Context) error {
+	if c.Source != SourceFile {
+		return nil
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create fsnotify watcher: %w", err)
+	}
+
+	watchedDirs := map[string]struct{}{}
+	paths := []string{c.ClientCertPath, c.ClientKeyPath}
+	if c.VerifyServerCert && !c.SkipVerify {
+		paths = append(paths, c.CACertPath)
+		for _, source := range c.CACertPaths {
+			paths = append(paths, source.Path)
+		}
+	}
+	for _, p := range paths {
+		dir := filepath.Dir(p)
+		if _, ok := watchedDirs[dir]; ok {
+			continue
+		}
+		if err := watcher.Add(dir); err != nil {
+			watcher.Close()
+			return fmt.Errorf("failed to watch %s: %w", dir, err)
+		}
+		watchedDirs[dir] = struct{}{}
+	}
+
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+
+	go func() {
+		defer watcher.Close()
+		defer signal.Stop(sighup)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-sighup:
+				logger.Infof("received SIGHUP, reloading TLS material")
+				if err := c.reload(); err != nil {
+					logger.Errorf("TLS material reload failed: %v", err)
+				}
+
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+					continue
+				}
+				logger.Infof("detected change to %s, reloading TLS material", event.Name)
+				if err := c.reload(); err != nil {
+					logger.Errorf("TLS material reload failed: %v", err)
+				}
+
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logger.Errorf("TLS file watcher error: %v", err)
+			}
+		}
+	}()
+
+	return nil
+}
+
 // Create TLS configuration for gRPC client
 func (c *TLSConfig) CreateTLSConfig() (*tls.Config, error) {
+	if c.Source == SourceSPIFFE {
+		return c.createSPIFFETLSConfig()
+	}
+
 	logger.Infof("Creating TLS configuration for gRPC client")
 	logger.Infof("Client certificate path: %s", c.ClientCertPath)
 	logger.Infof("Client key path: %s", c.ClientKeyPath)
@@ -127,29 +635,17 @@ func (c *TLSConfig) CreateTLSConfig() (*tls.Config, error) {
 	logger.Infof("Verify server cert: %t", c.VerifyServerCert)
 	logger.Infof("Skip verify: %t", c.SkipVerify)
 
-	// Load client certificate
-	clientCert, err := c.LoadClientCertificate()
-	if err != nil {
-		return nil, logger.Errorf("failed to load client certificate: %w", err)
-	}
-
-	// Load CA certificate
-	var certPool *x509.CertPool
-	if c.VerifyServerCert && !c.SkipVerify {
-		certPool, err = c.LoadCACertificate()
-		if err != nil {
-			return nil, logger.Errorf("failed to load CA certificate: %w", err)
-		}
+	if err := c.reload(); err != nil {
+		return nil, fmt.Errorf("failed to load initial TLS material: %w", err)
 	}
 
 	// Create TLS configuration
 	tlsConfig := &tls.Config{
-		Certificates: []tls.Certificate{clientCert},
-		RootCAs:    certPool,
-		ServerName:  c.ServerName,
-		MinVersion: tls.VersionTLS12,
-		MaxVersion: tls.VersionTLS13,
-		
+		GetClientCertificate: c.GetClientCertificate,
+		ServerName:           c.ServerName,
+		MinVersion:           tls.VersionTLS12,
+		MaxVersion:           tls.VersionTLS13,
+
 		// Cipher suites for security
 		CipherSuites: []uint16{
 			tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
@@ -159,7 +655,7 @@ func (c *TLSConfig) CreateTLSConfig() (*tls.Config, error) {
 			tls.TLS_AES_256_GCM_SHA384,
 			tls.TLS_CHACHA20_POLY1305_SHA256,
 		},
-		
+
 		// Curve preferences
 		CurvePreferences: []tls.CurveID{
 			tls.X25519,
@@ -167,21 +663,59 @@ func (c *TLSConfig) CreateTLSConfig() (*tls.Config, error) {
 			tls.CurveP384,
 			tls.CurveP521,
 		},
-		
+
 		// Renegotiation
 		Renegotiation: tls.RenegotiateNever,
-		
+
 		// Client authentication
 		ClientAuth: tls.RequireAndVerifyClientCert,
-		
+
 		// InsecureSkipVerify for testing only
 		InsecureSkipVerify: c.SkipVerify,
 	}
 
+	// RootCAs can't be hot-reloaded as a plain field (see
+	// verifyPeerCertificate's doc comment), so server verification is done
+	// manually against the atomically-swappable CA pool instead.
+	if c.VerifyServerCert && !c.SkipVerify {
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyPeerCertificate = c.verifyPeerCertificate
+	}
+
+	if c.EnableOCSPStapling || c.EnableCRLCheck || len(c.PinnedPeers) > 0 {
+		tlsConfig.VerifyConnection = c.verifyConnection
+	}
+
 	logger.Infof("TLS configuration created successfully")
 	return tlsConfig, nil
 }
 
+// createSPIFFETLSConfig connects to the SPIFFE Workload API (establishing
+// the connection once and reusing it across calls) and builds a *tls.Config
+// that presents the resulting X509-SVID and authorizes the peer against
+// AuthorizedSPIFFEIDs instead of DNS SANs. The X509Source keeps both the
+// SVID and trust bundle current for the lifetime of the process, so unlike
+// CreateTLSConfig's file/env paths, no reload() or Watch() is needed.
+func (c *TLSConfig) createSPIFFETLSConfig() (*tls.Config, error) {
+	logger.Infof("Creating SPIFFE-sourced TLS configuration for gRPC client")
+
+	if c.spiffeSource == nil {
+		source, err := spiffe.NewSource(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to SPIFFE Workload API: %w", err)
+		}
+		c.spiffeSource = source
+	}
+
+	authorizer, err := spiffe.ParseAuthorizedIDs(c.AuthorizedSPIFFEIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse authorized SPIFFE IDs: %w", err)
+	}
+
+	logger.Infof("SPIFFE TLS configuration created successfully")
+	return c.spiffeSource.TLSConfig(authorizer), nil
+}
+
 // Create gRPC credentials with TLS
 func (c *TLSConfig) CreateGRPCredentials() (credentials.TransportCredentials, error) {
 	tlsConfig, err := c.CreateTLSConfig()
@@ -202,6 +736,26 @@ func (c *TLSConfig) CreateGRPCredentials() (credentials.TransportCredentials, er
 func (c *TLSConfig) Validate() error {
 	logger.Infof("Validating TLS configuration")
 
+	if c.Source == SourceSPIFFE {
+		if len(c.AuthorizedSPIFFEIDs) == 0 {
+			return logger.Errorf("authorized_spiffe_ids must be set when source is %q", SourceSPIFFE)
+		}
+		return nil
+	}
+
+	if c.Source == SourceEnv {
+		if c.ClientCertPEM == "" || c.ClientKeyPEM == "" {
+			return logger.Errorf("client_cert_pem/client_key_pem must be set when source is %q", SourceEnv)
+		}
+		_, err := c.loadClientCertificateFromEnv()
+		return err
+	}
+
+	if c.Source == SourceACME {
+		_, err := c.loadClientCertificateFromACME()
+		return err
+	}
+
 	// Check if certificate files exist
 	if _, err := os.Stat(c.ClientCertPath); os.IsNotExist(err) {
 		return logger.Errorf("client certificate file not found: %s", c.ClientCertPath)
@@ -215,6 +769,11 @@ func (c *TLSConfig) Validate() error {
 		if _, err := os.Stat(c.CACertPath); os.IsNotExist(err) {
 			return logger.Errorf("CA certificate file not found: %s", c.CACertPath)
 		}
+		for _, source := range c.CACertPaths {
+			if _, err := os.Stat(source.Path); os.IsNotExist(err) {
+				return logger.Errorf("federated CA certificate file not found: %s", source.Path)
+			}
+		}
 	}
 
 	// Validate certificate format
@@ -230,11 +789,14 @@ func (c *TLSConfig) Validate() error {
 
 	// Validate certificate expiration
 	if len(clientCert.Certificate) > 0 {
-		cert := clientCert.Certificate[0]
+		cert, err := x509.ParseCertificate(clientCert.Certificate[0])
+		if err != nil {
+			return logger.Errorf("failed to parse client certificate: %w", err)
+		}
 		if time.Now().After(cert.NotAfter) {
 			return logger.Errorf("client certificate has expired on %s", cert.NotAfter.Format(time.RFC3339))
 		}
-		
+
 		// Warn if certificate expires soon
 		if time.Until(cert.NotAfter).Hours() < 24*30 { // 30 days
 			logger.Warnf("client certificate expires in %.1f days", time.Until(cert.NotAfter).Hours()/24)
@@ -255,7 +817,19 @@ func (c *TLSConfig) Validate() error {
 
 // Get certificate information
 func (c *TLSConfig) GetCertificateInfo() (*CertificateInfo, error) {
-	clientCert, err := c.LoadClientCertificate()
+	if c.Source == SourceSPIFFE {
+		return nil, logger.Errorf("GetCertificateInfo is not supported for source %q; inspect the SVID via spiffe.Source.ID instead", SourceSPIFFE)
+	}
+
+	loadClientCertificate := c.LoadClientCertificate
+	if c.Source == SourceEnv {
+		loadClientCertificate = c.loadClientCertificateFromEnv
+	}
+	if c.Source == SourceACME {
+		loadClientCertificate = c.loadClientCertificateFromACME
+	}
+
+	clientCert, err := loadClientCertificate()
 	if err != nil {
 		return nil, logger.Errorf("failed to load client certificate: %w", err)
 	}
@@ -270,6 +844,11 @@ func (c *TLSConfig) GetCertificateInfo() (*CertificateInfo, error) {
 		return nil, logger.Errorf("failed to parse certificate: %w", err)
 	}
 
+	ipAddresses := make([]string, len(x509Cert.IPAddresses))
+	for i, ip := range x509Cert.IPAddresses {
+		ipAddresses[i] = ip.String()
+	}
+
 	info := &CertificateInfo{
 		Subject:           x509Cert.Subject.CommonName,
 		Issuer:            x509Cert.Issuer.CommonName,
@@ -280,7 +859,7 @@ func (c *TLSConfig) GetCertificateInfo() (*CertificateInfo, error) {
 		SignatureAlgorithm: x509Cert.SignatureAlgorithm.String(),
 		DNSNames:          x509Cert.DNSNames,
 		EmailAddresses:     x509Cert.EmailAddresses,
-		IPAddresses:        x509Cert.IPAddresses,
+		IPAddresses:        ipAddresses,
 	}
 
 	return info, nil
@@ -315,99 +894,3 @@ func (info *CertificateInfo) DaysUntilExpiration() int64 {
 	}
 	return 0
 }
-
-// TLS utilities
-package utils
-
-import (
-	"crypto/x509"
-	"encoding/pem"
-	"fmt"
-	"time"
-
-	"github.com/market-intel/api-gateway/pkg/logger"
-)
-
-// Load certificate chain from PEM file
-func LoadCertificateChain(certPath string) ([]*x509.Certificate, error) {
-	data, err := ioutil.ReadFile(certPath)
-	if err != nil {
-		return nil, logger.Errorf("failed to read certificate file: %w", err)
-	}
-
-	var certs []*x509.Certificate
-	var block *pem.Block
-	var rest = data
-
-	for {
-		block, rest = pem.Decode(rest)
-		if block == nil {
-			break
-		}
-
-		if block.Type == "CERTIFICATE" {
-			cert, err := x509.ParseCertificate(block.Bytes)
-			if err != nil {
-				return nil, logger.Errorf("failed to parse certificate: %w", err)
-			}
-			certs = append(certs, cert)
-		}
-	}
-
-	if len(certs) == 0 {
-		return nil, fmt.Errorf("no certificates found in %s", certPath)
-	}
-
-	logger.Infof("Loaded %d certificates from %s", len(certs), certPath)
-	return certs, nil
-}
-
-// Validate certificate chain
-func ValidateCertificateChain(certs []*x509.Certificate, caCertPath string) error {
-	if len(certs) == 0 {
-		return fmt.Errorf("no certificates to validate")
-	}
-
-	caData, err := ioutil.ReadFile(caCertPath)
-	if err != nil {
-		return logger.Errorf("failed to read CA certificate: %w", err)
-	}
-
-	caCert, err := x509.ParseCertificate(caData)
-	if err != nil {
-		return logger.Errorf("failed to parse CA certificate: %w", err)
-	}
-
-	// Create certificate pool
-	roots := x509.NewCertPool()
-	roots.AddCert(caCert)
-
-	// Verify each certificate in the chain
-	for i, cert := range certs {
-		opts := x509.VerifyOptions{
-			Roots:     roots,
-			CurrentTime: time.Now(),
-			KeyUsages:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
-		}
-
-		if _, err := cert.Verify(opts); err != nil {
-			return logger.Errorf("certificate %d validation failed: %w", i+1, err)
-		}
-	}
-
-	logger.Infof("Certificate chain validation successful for %d certificates", len(certs))
-	return nil
-}
-
-// Generate certificate fingerprint
-func GenerateFingerprint(cert *x509.Certificate) string {
-	hash := sha256.Sum256(cert.Raw)
-	return fmt.Sprintf("%x", hash)
-}
-
-// Check certificate revocation (placeholder)
-func CheckRevocation(cert *x509.Certificate) (bool, error) {
-	// In production, implement CRL or OCSP checking
-	logger.Warn("Certificate revocation checking not implemented - always returns false")
-	return false, nil
-}
@@ -18,6 +18,8 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"gopkg.in/yaml.v3"
+
+	"github.com/market-intel/ops-cli/pkg/kube"
 )
 
 // Configuration structure
@@ -28,28 +30,38 @@ type Config struct {
 	BackupDir     string `yaml:"backup_dir"`
 	ReportsDir    string `yaml:"reports_dir"`
 	ScriptsDir    string `yaml:"scripts_dir"`
+	Kubeconfig    string `yaml:"kubeconfig"`
+	VaultPKIMount string `yaml:"vault_pki_mount"`
+	RedisPodLabel string `yaml:"redis_pod_label"`
+}
+
+// kubeClient lazily builds the shared kube.Client, falling back to
+// in-cluster/default kubeconfig discovery when Kubeconfig is unset (see
+// kube.NewClient).
+func kubeClient() (*kube.Client, error) {
+	return kube.NewClient(config.Kubeconfig, config.Namespace)
 }
 
 // Certificate rotation configuration
 type CertConfig struct {
-	Name         string `yaml:"name"`
-	SecretName   string `yaml:"secret_name"`
-	CertManager  bool   `yaml:"cert_manager"`
-	Vault        bool   `yaml:"vault"`
-	Backup       bool   `yaml:"backup"`
+	Name        string `yaml:"name"`
+	SecretName  string `yaml:"secret_name"`
+	CertManager bool   `yaml:"cert_manager"`
+	Vault       bool   `yaml:"vault"`
+	Backup      bool   `yaml:"backup"`
 }
 
 // Redis cache configuration
 type RedisConfig struct {
-	Name     string `yaml:"name"`
-	Pattern  string `yaml:"pattern"`
-	Backup   bool   `yaml:"backup"`
+	Name    string `yaml:"name"`
+	Pattern string `yaml:"pattern"`
+	Backup  bool   `yaml:"backup"`
 }
 
 // Global variables
 var (
-	config      Config
-	certConfigs []CertConfig
+	config       Config
+	certConfigs  []CertConfig
 	redisConfigs []RedisConfig
 )
 
@@ -82,7 +94,7 @@ var certListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all certificates",
 	Long:  `List all configured certificates with their status`,
-	RunE: runCertList,
+	RunE:  runCertList,
 }
 
 var certValidateCmd = &cobra.Command{
@@ -90,7 +102,7 @@ var certValidateCmd = &cobra.Command{
 	Short: "Validate a certificate",
 	Long:  `Validate certificate expiration and configuration`,
 	Args:  cobra.ExactArgs(1),
-	RunE: runCertValidate,
+	RunE:  runCertValidate,
 }
 
 // Redis operations command
@@ -105,14 +117,14 @@ var redisFlushCmd = &cobra.Command{
 	Short: "Flush Redis cache",
 	Long:  `Safely flush specific Redis cache keys with backup`,
 	Args:  cobra.ExactArgs(1),
-	RunE: runRedisFlush,
+	RunE:  runRedisFlush,
 }
 
 var redisStatsCmd = &cobra.Command{
 	Use:   "stats",
 	Short: "Show Redis statistics",
 	Long:  `Display Redis cache statistics and key counts`,
-	RunE: runRedisStats,
+	RunE:  runRedisStats,
 }
 
 var redisBackupCmd = &cobra.Command{
@@ -120,7 +132,7 @@ var redisBackupCmd = &cobra.Command{
 	Short: "Backup Redis data",
 	Long:  `Backup Redis data matching a pattern`,
 	Args:  cobra.ExactArgs(1),
-	RunE: runRedisBackup,
+	RunE:  runRedisBackup,
 }
 
 // Debug operations command
@@ -135,7 +147,7 @@ var debugCreateCmd = &cobra.Command{
 	Short: "Create debug pod",
 	Long:  `Create ephemeral debug container attached to target pod`,
 	Args:  cobra.ExactArgs(1),
-	RunE: runDebugCreate,
+	RunE:  runDebugCreate,
 }
 
 var debugInteractiveCmd = &cobra.Command{
@@ -143,14 +155,14 @@ var debugInteractiveCmd = &cobra.Command{
 	Short: "Start interactive debug session",
 	Long:  `Start interactive shell in debug pod`,
 	Args:  cobra.ExactArgs(1),
-	RunE: runDebugInteractive,
+	RunE:  runDebugInteractive,
 }
 
 var debugCleanupCmd = &cobra.Command{
 	Use:   "cleanup",
 	Short: "Clean up debug pods",
 	Long:  `Remove all debug pods`,
-	RunE: runDebugCleanup,
+	RunE:  runDebugCleanup,
 }
 
 // Initialize CLI
@@ -162,6 +174,9 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&config.BackupDir, "backup-dir", "./backups", "Backup directory")
 	rootCmd.PersistentFlags().StringVar(&config.ReportsDir, "reports-dir", "./reports", "Reports directory")
 	rootCmd.PersistentFlags().StringVar(&config.ScriptsDir, "scripts-dir", "./scripts", "Scripts directory")
+	rootCmd.PersistentFlags().StringVar(&config.Kubeconfig, "kubeconfig", "", "Path to kubeconfig (defaults to in-cluster config, then ~/.kube/config)")
+	rootCmd.PersistentFlags().StringVar(&config.VaultPKIMount, "vault-pki-mount", "pki", "Vault PKI secrets engine mount path")
+	rootCmd.PersistentFlags().StringVar(&config.RedisPodLabel, "redis-pod-label", "app=redis", "Label selector for the Redis pod to exec into")
 
 	// Certificate flags
 	certRotateCmd.Flags().String("method", "auto", "Rotation method (auto, cert-manager, vault)")
@@ -198,6 +213,9 @@ func init() {
 	viper.BindPFlag(rootCmd.PersistentFlags().Lookup("backup-dir"))
 	viper.BindPFlag(rootCmd.PersistentFlags().Lookup("reports-dir"))
 	viper.BindPFlag(rootCmd.PersistentFlags().Lookup("scripts-dir"))
+	viper.BindPFlag(rootCmd.PersistentFlags().Lookup("kubeconfig"))
+	viper.BindPFlag(rootCmd.PersistentFlags().Lookup("vault-pki-mount"))
+	viper.BindPFlag(rootCmd.PersistentFlags().Lookup("redis-pod-label"))
 }
 
 // Load configuration
@@ -266,6 +284,15 @@ func loadConfig() {
 	if config.ScriptsDir == "" {
 		config.ScriptsDir = "./scripts"
 	}
+	if config.VaultPKIMount == "" {
+		config.VaultPKIMount = "pki"
+	}
+	if config.RedisPodLabel == "" {
+		config.RedisPodLabel = "app=redis"
+	}
+	// Kubeconfig is intentionally left empty by default: kube.NewClient
+	// falls back to in-cluster config (when ops-cli runs as a Job/Pod) and
+	// then to the standard kubeconfig loading rules.
 }
 
 // Run certificate rotation
@@ -291,23 +318,57 @@ func runCertRotate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("certificate not found: %s", certName)
 	}
 
-	// Execute rotation script
-	scriptPath := filepath.Join(config.ScriptsDir, "rotate-certs.sh")
-	cmd := exec.Command(scriptPath, "rotate", certName, method)
+	if !force {
+		confirm, err := readInputWithTimeout(fmt.Sprintf("Rotate certificate %s? [y/N]: ", certName), 30*time.Second)
+		if err != nil || strings.ToLower(confirm) != "y" {
+			fmt.Println("Rotation cancelled")
+			return nil
+		}
+	}
+
+	useVault := method == "vault" || (method == "auto" && certConfig.Vault)
+	useCertManager := method == "cert-manager" || (method == "auto" && certConfig.CertManager)
 
-	if force {
-		cmd.Env = append(os.Environ(), "FORCE_FLUSH=true")
+	if useCertManager {
+		kc, err := kubeClient()
+		if err != nil {
+			return fmt.Errorf("failed to build kubernetes client: %w", err)
+		}
+		cm, err := kube.NewCertManagerClient(kc)
+		if err != nil {
+			return fmt.Errorf("failed to build cert-manager client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		if err := cm.RotateCertificate(ctx, certName); err != nil {
+			return fmt.Errorf("cert-manager rotation failed: %w", err)
+		}
+
+		fmt.Printf("Certificate %s marked for reissuance by cert-manager\n", certName)
+		return nil
 	}
 
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		fmt.Printf("Error rotating certificate: %v\n", err)
-		fmt.Printf("Output: %s\n", string(output))
-		return err
+	if useVault {
+		vc, err := kube.NewVaultPKIClient(config.VaultPKIMount)
+		if err != nil {
+			return fmt.Errorf("failed to build vault pki client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		issued, err := vc.RotateCertificate(ctx, certName, certName, "")
+		if err != nil {
+			return fmt.Errorf("vault pki rotation failed: %w", err)
+		}
+
+		fmt.Printf("Certificate %s rotated via Vault PKI (serial: %s)\n", certName, issued.SerialNumber)
+		return nil
 	}
 
-	fmt.Printf("Certificate rotation completed:\n%s\n", string(output))
-	return nil
+	return fmt.Errorf("certificate %s has neither cert_manager nor vault configured", certName)
 }
 
 // Run certificate list
@@ -354,9 +415,7 @@ func runRedisFlush(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Batch size: %d\n", batchSize)
 
 	// Check if it's a custom pattern or predefined type
-	var pattern string
-	isCustom := false
-
+	pattern := cacheType
 	for _, redisConfig := range redisConfigs {
 		if redisConfig.Name == cacheType {
 			pattern = redisConfig.Pattern
@@ -364,36 +423,43 @@ func runRedisFlush(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	if pattern == "" {
-		// Assume it's a custom pattern
-		pattern = cacheType
-		isCustom = true
+	if !force {
+		confirm, err := readInputWithTimeout(fmt.Sprintf("Flush Redis keys matching %q? [y/N]: ", pattern), 30*time.Second)
+		if err != nil || strings.ToLower(confirm) != "y" {
+			fmt.Println("Flush cancelled")
+			return nil
+		}
 	}
 
-	// Execute flush script
-	scriptPath := filepath.Join(config.ScriptsDir, "flush-redis.sh")
-	var cmd *exec.Cmd
-
-	if isCustom {
-		cmd = exec.Command(scriptPath, "flush", "custom", pattern)
-	} else {
-		cmd = exec.Command(scriptPath, "flush", cacheType)
+	kc, err := kubeClient()
+	if err != nil {
+		return fmt.Errorf("failed to build kubernetes client: %w", err)
 	}
 
-	if force {
-		cmd.Env = append(os.Environ(), "FORCE_FLUSH=true")
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	podName, err := kc.FirstPodByLabel(ctx, config.RedisPodLabel)
+	if err != nil {
+		return fmt.Errorf("failed to locate redis pod: %w", err)
 	}
 
-	cmd.Env = append(cmd.Env, fmt.Sprintf("BATCH_SIZE=%d", batchSize))
+	scanCmd := fmt.Sprintf("redis-cli --scan --pattern '%s' --count %d | xargs -r redis-cli del", pattern, batchSize)
 
-	output, err := cmd.CombinedOutput()
+	var stdout, stderr strings.Builder
+	err = kc.Exec(ctx, kube.ExecOptions{
+		PodName:       podName,
+		ContainerName: "redis",
+		Command:       []string{"sh", "-c", scanCmd},
+		Stdout:        &stdout,
+		Stderr:        &stderr,
+	})
 	if err != nil {
-		fmt.Printf("Error flushing Redis: %v\n", err)
-		fmt.Printf("Output: %s\n", string(output))
-		return err
+		fmt.Printf("Output: %s\n", stderr.String())
+		return fmt.Errorf("error flushing redis: %w", err)
 	}
 
-	fmt.Printf("Redis flush completed:\n%s\n", string(output))
+	fmt.Printf("Redis flush completed:\n%s\n", stdout.String())
 	return nil
 }
 
@@ -443,55 +509,70 @@ func runDebugCreate(cmd *cobra.Command, args []string) error {
 	image := viper.GetString("image")
 	ttl := viper.GetInt("ttl")
 
-	fmt.Printf("Creating debug pod for: %s\n", podName)
-	if image != "" {
-		fmt.Printf("Image: %s\n", image)
+	if image == "" {
+		image = config.DebugImage
 	}
-	if ttl > 0 {
-		fmt.Printf("TTL: %d seconds\n", ttl)
+	if ttl <= 0 {
+		ttl = config.DebugTTL
 	}
 
-	// Execute debug script
-	scriptPath := filepath.Join(config.ScriptsDir, "debug-pod.sh")
-	cmdArgs := []string{"create", podName}
-
-	if image != "" {
-		cmdArgs = append(cmdArgs, image)
-	}
+	fmt.Printf("Creating debug pod for: %s\n", podName)
+	fmt.Printf("Image: %s\n", image)
+	fmt.Printf("TTL: %d seconds\n", ttl)
 
-	if ttl > 0 {
-		cmdArgs = append(cmdArgs, fmt.Sprintf("--ttl=%d", ttl))
+	kc, err := kubeClient()
+	if err != nil {
+		return fmt.Errorf("failed to build kubernetes client: %w", err)
 	}
 
-	cmd := exec.Command(scriptPath, cmdArgs...)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
 
-	output, err := cmd.CombinedOutput()
+	debugContainer, err := kc.CreateDebugContainer(ctx, podName, image, "", time.Duration(ttl)*time.Second)
 	if err != nil {
-		fmt.Printf("Error creating debug pod: %v\n", err)
-		fmt.Printf("Output: %s\n", string(output))
-		return err
+		return fmt.Errorf("error creating debug pod: %w", err)
 	}
 
-	fmt.Printf("Debug pod created:\n%s\n", string(output))
+	fmt.Printf("Debug container %s attached to pod %s (expires %s)\n",
+		debugContainer.ContainerName, debugContainer.PodName, debugContainer.ExpiresAt.Format(time.RFC3339))
 	return nil
 }
 
 // Run debug interactive
 func runDebugInteractive(cmd *cobra.Command, args []string) error {
 	podName := args[0]
+	image := viper.GetString("image")
+	ttl := viper.GetInt("ttl")
+
+	if image == "" {
+		image = config.DebugImage
+	}
+	if ttl <= 0 {
+		ttl = config.DebugTTL
+	}
 
 	fmt.Printf("Starting interactive debug session for: %s\n", podName)
 
-	// Execute debug script
-	scriptPath := filepath.Join(config.ScriptsDir, "debug-pod.sh")
-	cmd := exec.Command(scriptPath, "interactive", podName)
+	kc, err := kubeClient()
+	if err != nil {
+		return fmt.Errorf("failed to build kubernetes client: %w", err)
+	}
+
+	ctx := context.Background()
 
-	// Run interactive session
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	debugContainer, err := kc.CreateDebugContainer(ctx, podName, image, "", time.Duration(ttl)*time.Second)
+	if err != nil {
+		return fmt.Errorf("error creating debug container: %w", err)
+	}
 
-	err := cmd.Run()
+	err = kc.Attach(ctx, kube.AttachOptions{
+		PodName:       debugContainer.PodName,
+		ContainerName: debugContainer.ContainerName,
+		Stdin:         os.Stdin,
+		Stdout:        os.Stdout,
+		Stderr:        os.Stderr,
+		TTY:           true,
+	})
 	if err != nil {
 		fmt.Printf("Error in debug session: %v\n", err)
 		return err
@@ -504,34 +585,44 @@ func runDebugInteractive(cmd *cobra.Command, args []string) error {
 func runDebugCleanup(cmd *cobra.Command, args []string) error {
 	fmt.Println("Cleaning up debug pods...")
 
-	// Execute debug script
-	scriptPath := filepath.Join(config.ScriptsDir, "debug-pod.sh")
-	cmd := exec.Command(scriptPath, "cleanup")
+	kc, err := kubeClient()
+	if err != nil {
+		return fmt.Errorf("failed to build kubernetes client: %w", err)
+	}
 
-	output, err := cmd.CombinedOutput()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cleaned, err := kc.CleanupDebugPods(ctx)
 	if err != nil {
-		fmt.Printf("Error cleaning up debug pods: %v\n", err)
-		fmt.Printf("Output: %s\n", string(output))
-		return err
+		return fmt.Errorf("error cleaning up debug pods: %w", err)
 	}
 
-	fmt.Printf("Debug cleanup completed:\n%s\n", string(output))
+	if len(cleaned) == 0 {
+		fmt.Println("No expired debug pods to clean up")
+		return nil
+	}
+
+	fmt.Printf("Debug cleanup completed, untracked %d pod(s):\n", len(cleaned))
+	for _, podName := range cleaned {
+		fmt.Printf("  - %s\n", podName)
+	}
 	return nil
 }
 
 // Execute shell command with timeout
 func executeCommand(ctx context.Context, command string, args ...string) (string, error) {
 	cmd := exec.CommandContext(ctx, command, args...)
-	
+
 	var stdout, stderr strings.Builder
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
-	
+
 	err := cmd.Run()
 	if err != nil {
 		return "", fmt.Errorf("command failed: %v, stderr: %s", err, stderr.String())
 	}
-	
+
 	return stdout.String(), nil
 }
 
@@ -539,16 +630,16 @@ func executeCommand(ctx context.Context, command string, args ...string) (string
 func readInputWithTimeout(prompt string, timeout time.Duration) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
-	
+
 	fmt.Print(prompt)
-	
+
 	done := make(chan string)
 	go func() {
 		reader := bufio.NewReader(os.Stdin)
 		input, _ := reader.ReadString('\n')
 		done <- strings.TrimSpace(input)
 	}()
-	
+
 	select {
 	case input := <-done:
 		return input, nil
@@ -563,18 +654,18 @@ func generateReport(reportType string, data interface{}) error {
 	if err := os.MkdirAll(reportDir, 0755); err != nil {
 		return fmt.Errorf("failed to create report directory: %v", err)
 	}
-	
+
 	reportFile := filepath.Join(reportDir, fmt.Sprintf("%s-report.json", reportType))
-	
+
 	jsonData, err := json.MarshalIndent(data, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal report data: %v", err)
 	}
-	
+
 	if err := os.WriteFile(reportFile, jsonData, 0644); err != nil {
 		return fmt.Errorf("failed to write report file: %v", err)
 	}
-	
+
 	fmt.Printf("Report generated: %s\n", reportFile)
 	return nil
 }
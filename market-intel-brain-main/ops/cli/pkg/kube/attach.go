@@ -0,0 +1,100 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// AttachOptions configures an interactive attach/exec stream.
+type AttachOptions struct {
+	PodName       string
+	ContainerName string
+	Stdin         io.Reader
+	Stdout        io.Writer
+	Stderr        io.Writer
+	TTY           bool
+}
+
+// Attach opens an interactive stream to an already-running container (an
+// ephemeral debug container, typically) over SPDY, wiring the given
+// stdin/stdout/stderr straight through -- this is what replaces shelling
+// out to `kubectl debug`/`kubectl attach` from debug-pod.sh.
+func (c *Client) Attach(ctx context.Context, opts AttachOptions) error {
+	req := c.Clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(opts.PodName).
+		Namespace(c.Namespace).
+		SubResource("attach")
+
+	req.VersionedParams(&corev1.PodAttachOptions{
+		Container: opts.ContainerName,
+		Stdin:     opts.Stdin != nil,
+		Stdout:    opts.Stdout != nil,
+		Stderr:    opts.Stderr != nil,
+		TTY:       opts.TTY,
+	}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(c.RESTConfig, "POST", req.URL())
+	if err != nil {
+		return fmt.Errorf("failed to create SPDY executor: %w", err)
+	}
+
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdin:  opts.Stdin,
+		Stdout: opts.Stdout,
+		Stderr: opts.Stderr,
+		Tty:    opts.TTY,
+	})
+	if err != nil {
+		return fmt.Errorf("attach session to %s/%s failed: %w", opts.PodName, opts.ContainerName, err)
+	}
+
+	return nil
+}
+
+// ExecOptions configures a one-shot, non-interactive command execution.
+type ExecOptions struct {
+	PodName       string
+	ContainerName string
+	Command       []string
+	Stdout        io.Writer
+	Stderr        io.Writer
+}
+
+// Exec runs Command inside an already-running container and streams its
+// output back over SPDY, replacing the handful of ops scripts that used to
+// shell out to `kubectl exec` (e.g. running `redis-cli` commands in-pod).
+func (c *Client) Exec(ctx context.Context, opts ExecOptions) error {
+	req := c.Clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(opts.PodName).
+		Namespace(c.Namespace).
+		SubResource("exec")
+
+	req.VersionedParams(&corev1.PodExecOptions{
+		Container: opts.ContainerName,
+		Command:   opts.Command,
+		Stdout:    opts.Stdout != nil,
+		Stderr:    opts.Stderr != nil,
+	}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(c.RESTConfig, "POST", req.URL())
+	if err != nil {
+		return fmt.Errorf("failed to create SPDY executor: %w", err)
+	}
+
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdout: opts.Stdout,
+		Stderr: opts.Stderr,
+	})
+	if err != nil {
+		return fmt.Errorf("exec %v in %s/%s failed: %w", opts.Command, opts.PodName, opts.ContainerName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CertManagerClient drives cert-manager Certificate resources directly
+// instead of shelling out to rotate-certs.sh.
+type CertManagerClient struct {
+	clientset cmclient.Interface
+	namespace string
+}
+
+// NewCertManagerClient builds a CertManagerClient sharing restConfig with
+// the rest of the kube package.
+func NewCertManagerClient(c *Client) (*CertManagerClient, error) {
+	clientset, err := cmclient.NewForConfig(c.RESTConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cert-manager client: %w", err)
+	}
+	return &CertManagerClient{clientset: clientset, namespace: c.Namespace}, nil
+}
+
+// RotateCertificate forces cert-manager to reissue certName. This mirrors
+// what `cmctl renew` does under the hood: it flips the Certificate's
+// Issuing condition to True, which cert-manager's certificate-requests
+// controller treats as a trigger to start a fresh issuance regardless of
+// how much of the current certificate's lifetime remains.
+func (cm *CertManagerClient) RotateCertificate(ctx context.Context, certName string) error {
+	certs := cm.clientset.CertmanagerV1().Certificates(cm.namespace)
+
+	cert, err := certs.Get(ctx, certName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get certificate %s: %w", certName, err)
+	}
+
+	now := metav1.NewTime(time.Now())
+	setCondition(cert, cmapi.CertificateConditionIssuing, cmmeta.ConditionTrue, "ManuallyTriggered", "Rotation requested via ops-cli", now)
+
+	if _, err := certs.UpdateStatus(ctx, cert, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to trigger reissuance for certificate %s: %w", certName, err)
+	}
+
+	return nil
+}
+
+// Validate reports the certificate's current Ready condition and expiry,
+// replacing the shell script's `rotate-certs.sh validate` subcommand.
+func (cm *CertManagerClient) Validate(ctx context.Context, certName string) (*cmapi.Certificate, error) {
+	cert, err := cm.clientset.CertmanagerV1().Certificates(cm.namespace).Get(ctx, certName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get certificate %s: %w", certName, err)
+	}
+	return cert, nil
+}
+
+// setCondition updates (or appends) a condition of the given type on cert,
+// matching the shape cert-manager's controllers expect.
+func setCondition(cert *cmapi.Certificate, condType cmapi.CertificateConditionType, status cmmeta.ConditionStatus, reason, message string, t metav1.Time) {
+	for i := range cert.Status.Conditions {
+		if cert.Status.Conditions[i].Type == condType {
+			cert.Status.Conditions[i].Status = status
+			cert.Status.Conditions[i].Reason = reason
+			cert.Status.Conditions[i].Message = message
+			cert.Status.Conditions[i].LastTransitionTime = &t
+			return
+		}
+	}
+
+	cert.Status.Conditions = append(cert.Status.Conditions, cmapi.CertificateCondition{
+		Type:               condType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: &t,
+	})
+}
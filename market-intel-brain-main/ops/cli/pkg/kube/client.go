@@ -0,0 +1,83 @@
+// Package kube wraps client-go (and the kubectl debug machinery) so ops-cli
+// can drive debug sessions and certificate rotations against a cluster
+// natively, instead of shelling out to ./scripts/*.sh.
+package kube
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// Client bundles the typed Kubernetes clientset with the REST config needed
+// to open exec/attach streams, plus the namespace ops-cli operates in.
+type Client struct {
+	Clientset  kubernetes.Interface
+	RESTConfig *rest.Config
+	Namespace  string
+}
+
+// NewClient builds a Client from an explicit kubeconfig path. If
+// kubeconfigPath is empty, it falls back to in-cluster config (when running
+// as a pod) and then to the default kubeconfig loading rules (~/.kube/config,
+// $KUBECONFIG) so the same binary works both on an operator's laptop and as
+// a one-off Job in the cluster.
+func NewClient(kubeconfigPath, namespace string) (*Client, error) {
+	restConfig, err := loadRESTConfig(kubeconfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubernetes config: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	return &Client{
+		Clientset:  clientset,
+		RESTConfig: restConfig,
+		Namespace:  namespace,
+	}, nil
+}
+
+// FirstPodByLabel returns the name of an arbitrary running pod matching
+// labelSelector, so callers that need "the redis pod" or "the core-engine
+// pod" don't have to hard-code a pod name. It errors if no pod matches.
+func (c *Client) FirstPodByLabel(ctx context.Context, labelSelector string) (string, error) {
+	list, err := c.Clientset.CoreV1().Pods(c.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labelSelector,
+		Limit:         1,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list pods matching %q: %w", labelSelector, err)
+	}
+	if len(list.Items) == 0 {
+		return "", fmt.Errorf("no pods matching selector %q in namespace %s", labelSelector, c.Namespace)
+	}
+	return list.Items[0].Name, nil
+}
+
+// loadRESTConfig tries, in order: an explicit kubeconfig path, in-cluster
+// config, and the client-go default loading rules.
+func loadRESTConfig(kubeconfigPath string) (*rest.Config, error) {
+	if kubeconfigPath != "" {
+		return clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	}
+
+	if cfg, err := rest.InClusterConfig(); err == nil {
+		return cfg, nil
+	}
+
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if home, err := os.UserHomeDir(); err == nil {
+		loadingRules.Precedence = append(loadingRules.Precedence, home+"/.kube/config")
+	}
+
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		loadingRules, &clientcmd.ConfigOverrides{}).ClientConfig()
+}
@@ -0,0 +1,147 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// debugManagedByLabel marks a pod as carrying an ops-cli-managed
+	// ephemeral debug container, so cleanup only ever touches pods it
+	// created and never someone else's ephemeral containers.
+	debugManagedByLabel = "debug.market-intel.io/managed-by"
+	debugManagedByValue = "ops-cli"
+
+	// debugExpiresAtLabel holds the unix timestamp (seconds) after which
+	// the debug container is considered stale and eligible for cleanup.
+	// It's a label rather than an annotation because cleanup selects on
+	// it directly with a label selector.
+	debugExpiresAtLabel = "debug.market-intel.io/expires-at"
+)
+
+// DebugContainer describes an ephemeral debug container ops-cli attached to
+// a running pod.
+type DebugContainer struct {
+	PodName       string
+	ContainerName string
+	ExpiresAt     time.Time
+}
+
+// CreateDebugContainer attaches an ephemeral debug container to podName
+// using the pods/ephemeralcontainers subresource, and labels the pod with a
+// TTL so a later Cleanup call can find it. The target container defaults to
+// the pod's first container if targetContainer is empty.
+func (c *Client) CreateDebugContainer(ctx context.Context, podName, image string, targetContainer string, ttl time.Duration) (*DebugContainer, error) {
+	pod, err := c.Clientset.CoreV1().Pods(c.Namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod %s: %w", podName, err)
+	}
+
+	if targetContainer == "" {
+		if len(pod.Spec.Containers) == 0 {
+			return nil, fmt.Errorf("pod %s has no containers to target", podName)
+		}
+		targetContainer = pod.Spec.Containers[0].Name
+	}
+
+	containerName := fmt.Sprintf("debug-%d", time.Now().UnixNano())
+	expiresAt := time.Now().Add(ttl)
+
+	ephemeralContainer := corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:                     containerName,
+			Image:                    image,
+			Stdin:                    true,
+			TTY:                      true,
+			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		},
+		TargetContainerName: targetContainer,
+	}
+
+	updated := pod.DeepCopy()
+	updated.Spec.EphemeralContainers = append(updated.Spec.EphemeralContainers, ephemeralContainer)
+
+	if _, err := c.Clientset.CoreV1().Pods(c.Namespace).UpdateEphemeralContainers(ctx, podName, updated, metav1.UpdateOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to add ephemeral container to pod %s: %w", podName, err)
+	}
+
+	if err := c.labelDebugPod(ctx, podName, expiresAt); err != nil {
+		return nil, fmt.Errorf("ephemeral container %s created but failed to label pod %s for TTL tracking: %w", containerName, podName, err)
+	}
+
+	return &DebugContainer{PodName: podName, ContainerName: containerName, ExpiresAt: expiresAt}, nil
+}
+
+// labelDebugPod patches podName with the managed-by/expires-at labels used
+// to find and reap debug sessions later.
+func (c *Client) labelDebugPod(ctx context.Context, podName string, expiresAt time.Time) error {
+	pod, err := c.Clientset.CoreV1().Pods(c.Namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	updated := pod.DeepCopy()
+	if updated.Labels == nil {
+		updated.Labels = map[string]string{}
+	}
+	updated.Labels[debugManagedByLabel] = debugManagedByValue
+	updated.Labels[debugExpiresAtLabel] = strconv.FormatInt(expiresAt.Unix(), 10)
+
+	_, err = c.Clientset.CoreV1().Pods(c.Namespace).Update(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
+
+// ListDebugPods returns every pod in the namespace that currently carries
+// an ops-cli-managed debug container.
+func (c *Client) ListDebugPods(ctx context.Context) ([]corev1.Pod, error) {
+	list, err := c.Clientset.CoreV1().Pods(c.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", debugManagedByLabel, debugManagedByValue),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list debug pods: %w", err)
+	}
+	return list.Items, nil
+}
+
+// CleanupDebugPods strips the TTL tracking labels from every managed pod
+// whose TTL has expired. Kubernetes has no API to remove an ephemeral
+// container once added (it can only run to completion), so "cleanup" here
+// means untracking expired sessions rather than deleting the container
+// itself -- the process inside it has already exited by the time the TTL
+// fires, since CreateDebugContainer gives the container no long-running
+// entrypoint beyond the shell the operator was using.
+func (c *Client) CleanupDebugPods(ctx context.Context) ([]string, error) {
+	pods, err := c.ListDebugPods(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var cleaned []string
+	now := time.Now().Unix()
+	for _, pod := range pods {
+		expiresAt, err := strconv.ParseInt(pod.Labels[debugExpiresAtLabel], 10, 64)
+		if err != nil || expiresAt > now {
+			continue
+		}
+
+		updated := pod.DeepCopy()
+		delete(updated.Labels, debugManagedByLabel)
+		delete(updated.Labels, debugExpiresAtLabel)
+
+		if _, err := c.Clientset.CoreV1().Pods(c.Namespace).Update(ctx, updated, metav1.UpdateOptions{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return cleaned, fmt.Errorf("failed to untrack debug pod %s: %w", pod.Name, err)
+		}
+		cleaned = append(cleaned, pod.Name)
+	}
+
+	return cleaned, nil
+}
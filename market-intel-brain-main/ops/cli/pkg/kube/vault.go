@@ -0,0 +1,87 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+
+	vaultapi "github.com/hashicorp/vault/api"
+)
+
+// VaultPKIClient issues fresh leaf certificates from a Vault PKI secrets
+// engine, replacing the Vault branch of rotate-certs.sh.
+type VaultPKIClient struct {
+	client *vaultapi.Client
+	mount  string
+}
+
+// NewVaultPKIClient builds a VaultPKIClient from the standard Vault
+// environment (VAULT_ADDR, VAULT_TOKEN, ...). mount is the PKI secrets
+// engine's mount path, e.g. "pki" or "pki_int".
+func NewVaultPKIClient(mount string) (*VaultPKIClient, error) {
+	client, err := vaultapi.NewClient(vaultapi.DefaultConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vault client: %w", err)
+	}
+	return &VaultPKIClient{client: client, mount: mount}, nil
+}
+
+// VaultCertificate is an issued leaf certificate and its key material, as
+// returned by Vault's pki/issue/<role> endpoint.
+type VaultCertificate struct {
+	Certificate  string
+	PrivateKey   string
+	CAChain      []string
+	SerialNumber string
+}
+
+// RotateCertificate issues a new certificate for commonName under role,
+// which is the Vault-native equivalent of the cert-manager reissuance path
+// for certificates backed by Vault PKI instead.
+func (v *VaultPKIClient) RotateCertificate(ctx context.Context, role, commonName string, ttl string) (*VaultCertificate, error) {
+	data := map[string]interface{}{
+		"common_name": commonName,
+	}
+	if ttl != "" {
+		data["ttl"] = ttl
+	}
+
+	secret, err := v.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/issue/%s", v.mount, role), data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue certificate from vault pki: %w", err)
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("vault pki issue returned no data for role %s", role)
+	}
+
+	cert, _ := secret.Data["certificate"].(string)
+	key, _ := secret.Data["private_key"].(string)
+	serial, _ := secret.Data["serial_number"].(string)
+
+	var chain []string
+	if rawChain, ok := secret.Data["ca_chain"].([]interface{}); ok {
+		for _, c := range rawChain {
+			if s, ok := c.(string); ok {
+				chain = append(chain, s)
+			}
+		}
+	}
+
+	return &VaultCertificate{
+		Certificate:  cert,
+		PrivateKey:   key,
+		CAChain:      chain,
+		SerialNumber: serial,
+	}, nil
+}
+
+// RevokeCertificate revokes a previously issued certificate by serial
+// number, so a rotated-out cert can't keep being trusted.
+func (v *VaultPKIClient) RevokeCertificate(ctx context.Context, serialNumber string) error {
+	_, err := v.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/revoke", v.mount), map[string]interface{}{
+		"serial_number": serialNumber,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke vault certificate %s: %w", serialNumber, err)
+	}
+	return nil
+}